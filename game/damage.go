@@ -1,9 +1,62 @@
 package game
 
-// ApplyDamageWithShields applies damage to shields first, then hull.
-// Returns the total amount of damage actually applied.
+import "math"
+
+// DamageType identifies what dealt a hit, for rules that treat weapons
+// differently.
+type DamageType int
+
+const (
+	DamageOther  DamageType = iota // Planet fire, ship explosions
+	DamageTorp                     // Torpedoes
+	DamagePhaser                   // Phasers
+	DamagePlasma                   // Plasma torpedoes
+)
+
+// ShieldAbsorption is the fraction of each weapon's damage that raised
+// shields soak up before the rest bleeds through to the hull. Other damage
+// is always fully absorbed.
+type ShieldAbsorption struct {
+	Torp   float64
+	Phaser float64
+	Plasma float64
+}
+
+// FullShieldAbsorption lets shields absorb every damage type completely,
+// matching the original game.
+var FullShieldAbsorption = ShieldAbsorption{Torp: 1, Phaser: 1, Plasma: 1}
+
+// fraction returns the share of kind damage that shields absorb.
+func (a ShieldAbsorption) fraction(kind DamageType) float64 {
+	switch kind {
+	case DamageTorp:
+		return a.Torp
+	case DamagePhaser:
+		return a.Phaser
+	case DamagePlasma:
+		return a.Plasma
+	}
+	return 1
+}
+
+// Facing returns the absorption for a weapon hit on p arriving from
+// (srcX, srcY) under directional shields: unchanged on the bow, falling off
+// smoothly to rear times as much from dead astern. A rear of 1 leaves shields
+// omnidirectional.
+func (a ShieldAbsorption) Facing(p *Player, srcX, srcY, rear float64) ShieldAbsorption {
+	if rear >= 1 || (srcX == p.X && srcY == p.Y) {
+		return a
+	}
+	cosAngle := math.Cos(math.Atan2(srcY-p.Y, srcX-p.X) - p.Dir)
+	f := rear + (1-rear)*(1+cosAngle)/2
+	return ShieldAbsorption{Torp: a.Torp * f, Phaser: a.Phaser * f, Plasma: a.Plasma * f}
+}
+
+// ApplyDamageWithShields applies damage to shields first, then hull. Shields
+// only take absorb's share for the damage kind; the rest goes straight to the
+// hull. Returns the total amount of damage actually applied.
 // This ensures consistent damage handling across all weapon types.
-func ApplyDamageWithShields(p *Player, damage int) int {
+func ApplyDamageWithShields(p *Player, damage int, kind DamageType, absorb ShieldAbsorption) int {
 	if p == nil || damage <= 0 {
 		return 0
 	}
@@ -12,7 +65,11 @@ func ApplyDamageWithShields(p *Player, damage int) int {
 
 	// Apply damage to shields first if they're up and have capacity
 	if p.Shields_up && p.Shields > 0 {
-		shieldDamage := min(damage, p.Shields)
+		absorbable := damage
+		if f := absorb.fraction(kind); f < 1 {
+			absorbable = int(math.Round(float64(damage) * max(f, 0)))
+		}
+		shieldDamage := min(absorbable, p.Shields)
 		p.Shields -= shieldDamage
 		damage -= shieldDamage
 		totalApplied += shieldDamage
@@ -0,0 +1,207 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PlanetFlagAssignment sets the special flags of one planet in a custom map.
+type PlanetFlagAssignment struct {
+	Planet string   `json:"planet"` // Planet name or label, case-insensitive (e.g. "Rigel" or "RIG")
+	Flags  []string `json:"flags"`  // Any of "repair", "fuel", "agri", "core"
+}
+
+// TeamConfig overrides one team's display name and home location in a
+// custom map. Zero values keep the defaults.
+type TeamConfig struct {
+	Name  string `json:"name"`
+	HomeX int    `json:"homeX"`
+	HomeY int    `json:"homeY"`
+}
+
+// MinHomeSeparation is the minimum distance between two team homes, so the
+// random spawn areas around them (up to 5000 units per axis) never overlap.
+const MinHomeSeparation = 10000
+
+// MapConfig holds custom map settings loaded from a JSON file.
+type MapConfig struct {
+	// PlanetFlags assigns planet flags explicitly. When set, the randomized
+	// INL flag distribution is skipped: planets keep the flags of the
+	// standard layout unless listed here, in which case the listed flags
+	// replace their repair/fuel/agri/core flags.
+	PlanetFlags []PlanetFlagAssignment `json:"planetFlags"`
+
+	// Teams overrides team names and home locations, keyed by
+	// "fed", "rom", "kli" or "ori".
+	Teams map[string]TeamConfig `json:"teams"`
+}
+
+// teamKeys maps map-config team keys to team flags.
+var teamKeys = map[string]int{
+	"fed": TeamFed,
+	"rom": TeamRom,
+	"kli": TeamKli,
+	"ori": TeamOri,
+}
+
+// teamConfig returns the override for team, if any. Safe on a nil config.
+func (m *MapConfig) teamConfig(team int) (TeamConfig, bool) {
+	if m == nil {
+		return TeamConfig{}, false
+	}
+	for key, tc := range m.Teams {
+		if teamKeys[strings.ToLower(key)] == team {
+			return tc, true
+		}
+	}
+	return TeamConfig{}, false
+}
+
+// TeamName returns the display name of team, honoring any override.
+func (m *MapConfig) TeamName(team int) string {
+	if tc, ok := m.teamConfig(team); ok && tc.Name != "" {
+		return tc.Name
+	}
+	return TeamNames[team]
+}
+
+// TeamHome returns the home location of team, honoring any override.
+func (m *MapConfig) TeamHome(team int) (x, y int) {
+	if tc, ok := m.teamConfig(team); ok && (tc.HomeX != 0 || tc.HomeY != 0) {
+		return tc.HomeX, tc.HomeY
+	}
+	return TeamHomeX[team], TeamHomeY[team]
+}
+
+// validateTeams checks that team overrides name real teams, each at most
+// once, and that every team's home lies inside the galaxy, clear of every
+// other home.
+func (m *MapConfig) validateTeams() error {
+	seen := make(map[int]string)
+	for key := range m.Teams {
+		team, ok := teamKeys[strings.ToLower(key)]
+		if !ok {
+			return fmt.Errorf("unknown team %q", key)
+		}
+		if other, dup := seen[team]; dup {
+			return fmt.Errorf("team %q is configured twice (also as %q)", key, other)
+		}
+		seen[team] = key
+	}
+
+	teams := []int{TeamFed, TeamRom, TeamKli, TeamOri}
+	for i, team := range teams {
+		x, y := m.TeamHome(team)
+		if x <= 0 || x >= GalaxyWidth || y <= 0 || y >= GalaxyHeight {
+			return fmt.Errorf("team %s home (%d, %d) is outside the galaxy", m.TeamName(team), x, y)
+		}
+		for _, other := range teams[i+1:] {
+			ox, oy := m.TeamHome(other)
+			if Distance(float64(x), float64(y), float64(ox), float64(oy)) < MinHomeSeparation {
+				return fmt.Errorf("team %s and %s homes are closer than %d", m.TeamName(team), m.TeamName(other), MinHomeSeparation)
+			}
+		}
+	}
+	return nil
+}
+
+// planetFlagNames maps map-config flag names to planet flag bits.
+var planetFlagNames = map[string]int{
+	"repair": PlanetRepair,
+	"fuel":   PlanetFuel,
+	"agri":   PlanetAgri,
+	"core":   PlanetCore,
+}
+
+// assignableFlags are the planet flags a map config may set or clear.
+const assignableFlags = PlanetRepair | PlanetFuel | PlanetAgri | PlanetCore
+
+// LoadMapConfig reads a map config from a JSON file and checks that its
+// planet flag assignments are valid against the standard planet layout.
+func LoadMapConfig(path string) (*MapConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading map config: %w", err)
+	}
+	var cfg MapConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing map config %s: %w", path, err)
+	}
+
+	// Validate against a scratch galaxy so errors surface at startup
+	gs := &GameState{}
+	InitPlanets(gs)
+	if err := ApplyPlanetFlags(gs, cfg.PlanetFlags); err != nil {
+		return nil, fmt.Errorf("map config %s: %w", path, err)
+	}
+	if err := cfg.validateTeams(); err != nil {
+		return nil, fmt.Errorf("map config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// ApplyPlanetFlags applies explicit flag assignments to the planets in gs.
+// The result is validated so that every team starts with at least one fuel
+// and one repair planet; on error the planets are left unchanged.
+func ApplyPlanetFlags(gs *GameState, assignments []PlanetFlagAssignment) error {
+	flags := make([]int, MaxPlanets)
+	for i, planet := range gs.Planets {
+		if planet != nil {
+			flags[i] = planet.Flags
+		}
+	}
+
+	for _, a := range assignments {
+		idx := findPlanetByName(gs, a.Planet)
+		if idx < 0 {
+			return fmt.Errorf("unknown planet %q", a.Planet)
+		}
+		newFlags := 0
+		for _, name := range a.Flags {
+			bit, ok := planetFlagNames[strings.ToLower(name)]
+			if !ok {
+				return fmt.Errorf("planet %s: unknown flag %q", a.Planet, name)
+			}
+			newFlags |= bit
+		}
+		flags[idx] = flags[idx]&^assignableFlags | newFlags
+	}
+
+	// Every team needs somewhere to refuel and repair
+	for _, team := range []int{TeamFed, TeamRom, TeamKli, TeamOri} {
+		hasFuel, hasRepair := false, false
+		for i, planet := range gs.Planets {
+			if planet == nil || planet.Owner != team {
+				continue
+			}
+			hasFuel = hasFuel || flags[i]&PlanetFuel != 0
+			hasRepair = hasRepair || flags[i]&PlanetRepair != 0
+		}
+		if !hasFuel {
+			return fmt.Errorf("team %d has no fuel planet", team)
+		}
+		if !hasRepair {
+			return fmt.Errorf("team %d has no repair planet", team)
+		}
+	}
+
+	for i, planet := range gs.Planets {
+		if planet != nil {
+			planet.Flags = flags[i]
+		}
+	}
+	return nil
+}
+
+// findPlanetByName returns the index of the planet whose name or label
+// matches (case-insensitively), or -1 if there is none.
+func findPlanetByName(gs *GameState, name string) int {
+	for i, planet := range gs.Planets {
+		if planet != nil && (strings.EqualFold(planet.Name, name) || strings.EqualFold(planet.Label, name)) {
+			return i
+		}
+	}
+	return -1
+}
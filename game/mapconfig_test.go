@@ -0,0 +1,95 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadMapConfigAppliesPlanetFlags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "map.json")
+	mapJSON := `{"planetFlags": [
+		{"planet": "Rigel", "flags": ["fuel", "agri"]},
+		{"planet": "CAN", "flags": ["repair"]},
+		{"planet": "Altair", "flags": []}
+	]}`
+	if err := os.WriteFile(path, []byte(mapJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadMapConfig(path)
+	if err != nil {
+		t.Fatalf("LoadMapConfig: %v", err)
+	}
+
+	gs := &GameState{}
+	InitPlanets(gs)
+	if err := ApplyPlanetFlags(gs, cfg.PlanetFlags); err != nil {
+		t.Fatalf("ApplyPlanetFlags: %v", err)
+	}
+
+	tests := []struct {
+		planet int
+		want   int
+	}{
+		{1, PlanetFuel | PlanetAgri}, // Rigel
+		{2, PlanetRepair},            // Canopus, matched by label
+		{7, 0},                       // Altair loses its core flag
+		{0, PlanetHome | PlanetCore | PlanetRepair | PlanetFuel | PlanetAgri}, // Earth untouched
+	}
+	for _, tt := range tests {
+		if got := gs.Planets[tt.planet].Flags; got != tt.want {
+			t.Errorf("%s flags = %#x, want %#x", gs.Planets[tt.planet].Name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyPlanetFlagsRejectsTeamWithoutFuel(t *testing.T) {
+	gs := &GameState{}
+	InitPlanets(gs)
+	before := gs.Planets[10].Flags
+
+	// Romulus is the only Romulan fuel planet in the standard layout
+	err := ApplyPlanetFlags(gs, []PlanetFlagAssignment{
+		{Planet: "Romulus", Flags: []string{"repair", "agri"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "no fuel planet") {
+		t.Fatalf("expected a missing fuel planet error, got %v", err)
+	}
+	if gs.Planets[10].Flags != before {
+		t.Error("planets must be left unchanged when validation fails")
+	}
+
+	if err := ApplyPlanetFlags(gs, []PlanetFlagAssignment{{Planet: "Nowhere"}}); err == nil {
+		t.Error("expected an error for an unknown planet")
+	}
+	if err := ApplyPlanetFlags(gs, []PlanetFlagAssignment{{Planet: "Rigel", Flags: []string{"shipyard"}}}); err == nil {
+		t.Error("expected an error for an unknown flag")
+	}
+}
+
+func TestLoadMapConfigRejectsBadTeamHomes(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapJSON string
+		wantErr string
+	}{
+		{"outside galaxy", `{"teams": {"fed": {"homeX": 120000, "homeY": 50000}}}`, "outside the galaxy"},
+		{"overlapping", `{"teams": {"fed": {"homeX": 78000, "homeY": 22000}}}`, "closer than"},
+		{"unknown team", `{"teams": {"borg": {"name": "Borg"}}}`, "unknown team"},
+		{"duplicate team", `{"teams": {"fed": {"name": "Terrans"}, "FED": {"name": "Humans"}}}`, "configured twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "map.json")
+			if err := os.WriteFile(path, []byte(tt.mapJSON), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadMapConfig(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadMapConfig error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
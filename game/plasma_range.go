@@ -1,5 +1,7 @@
 package game
 
+import "math"
+
 // maxPlasmaRange calculates the maximum distance a plasma torpedo can travel
 // before its fuse expires, given the fuse time (in ticks) and speed (units per tick)
 func maxPlasmaRange(fuseTicks int, speedUnitsPerTick float64) float64 {
@@ -11,20 +13,43 @@ func maxPlasmaRange(fuseTicks int, speedUnitsPerTick float64) float64 {
 
 // MaxPlasmaRangeForShip calculates the maximum plasma range for a specific ship type
 func MaxPlasmaRangeForShip(ship ShipType) float64 {
+	return ScaledPlasmaRangeForShip(ship, 1, 1)
+}
+
+// PlasmaFlight returns a ship's plasma speed (units per tick) and fuse (ticks)
+// with speedScale applied to the speed and rangeScale to the fuse. The fuse is
+// rounded to whole ticks and never drops below one.
+func PlasmaFlight(ship ShipType, speedScale, rangeScale float64) (speed float64, fuse int) {
 	stats := ShipData[ship]
-	if !stats.HasPlasma {
-		return 0.0
+	// Speed is converted to units per tick: PlasmaSpeed * 20
+	speed = float64(stats.PlasmaSpeed*20) * speedScale
+	fuse = stats.PlasmaFuse
+	if rangeScale != 1 {
+		fuse = max(1, int(math.Round(float64(fuse)*rangeScale)))
 	}
+	return speed, fuse
+}
 
-	// Speed is converted to units per tick: PlasmaSpeed * 20
-	speedUnitsPerTick := float64(stats.PlasmaSpeed * 20)
-	return maxPlasmaRange(stats.PlasmaFuse, speedUnitsPerTick)
+// ScaledPlasmaRangeForShip is MaxPlasmaRangeForShip for plasmas whose speed
+// and fuse are scaled as in PlasmaFlight
+func ScaledPlasmaRangeForShip(ship ShipType, speedScale, rangeScale float64) float64 {
+	if !ShipData[ship].HasPlasma {
+		return 0.0
+	}
+	speed, fuse := PlasmaFlight(ship, speedScale, rangeScale)
+	return maxPlasmaRange(fuse, speed)
 }
 
 // EffectivePlasmaRange returns a conservative estimate of plasma range
 // accounting for target movement and tactical considerations
 func EffectivePlasmaRange(ship ShipType, safetyFactor float64) float64 {
-	maxRange := MaxPlasmaRangeForShip(ship)
+	return ScaledEffectivePlasmaRange(ship, safetyFactor, 1, 1)
+}
+
+// ScaledEffectivePlasmaRange is EffectivePlasmaRange for plasmas whose speed
+// and fuse are scaled as in PlasmaFlight
+func ScaledEffectivePlasmaRange(ship ShipType, safetyFactor, speedScale, rangeScale float64) float64 {
+	maxRange := ScaledPlasmaRangeForShip(ship, speedScale, rangeScale)
 	if maxRange == 0.0 {
 		return 0.0
 	}
@@ -0,0 +1,18 @@
+package game
+
+import "math"
+
+// Elo-style rating constants
+const (
+	InitialRating = 1500.0 // Rating assigned to a human player on login
+	BotRating     = 1500.0 // Fixed rating for bots (all bots play at hard difficulty)
+	RatingK       = 32.0   // Maximum rating change from a single kill
+)
+
+// RatingGain returns the rating points a killer earns (and the victim loses)
+// for a kill. Beating a higher-rated opponent is worth more than beating a
+// lower-rated one; the gain always lies between 0 and RatingK.
+func RatingGain(killerRating, victimRating float64) float64 {
+	expected := 1 / (1 + math.Pow(10, (victimRating-killerRating)/400))
+	return RatingK * (1 - expected)
+}
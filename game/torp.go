@@ -59,7 +59,31 @@ func EffectiveTorpRangeForShip(shipType ShipType, shipStats ShipStats) int {
 }
 
 // PhaserRange returns the effective phaser range for a ship.
-// Formula from original Netrek: PHASEDIST * phaserdamage / 100
+// Formula from original Netrek: PHASEDIST * phaserdamage / 100, unless the
+// ship sets PhaserRangeMult to tune range apart from damage.
 func PhaserRange(stats ShipStats) float64 {
+	if stats.PhaserRangeMult > 0 {
+		return float64(PhaserDist) * stats.PhaserRangeMult
+	}
+	return phaserFalloffRange(stats)
+}
+
+// phaserGlancingFrac is the smallest share of PhaserDamage a ship with
+// PhaserRangeMult set lands, so hits past its falloff range still count.
+const phaserGlancingFrac = 0.1
+
+// phaserFalloffRange is the original PHASEDIST * phaserdamage / 100 range.
+// Damage always falls off over it; PhaserRangeMult only stretches reach.
+func phaserFalloffRange(stats ShipStats) float64 {
 	return float64(PhaserDist) * float64(stats.PhaserDamage) / 100.0
 }
+
+// PhaserDamageAt returns the phaser damage a ship deals at dist, before
+// handicaps. Callers gate hits with PhaserRange.
+func PhaserDamageAt(stats ShipStats, dist float64) float64 {
+	frac := 1.0 - dist/phaserFalloffRange(stats)
+	if stats.PhaserRangeMult > 0 && frac < phaserGlancingFrac {
+		frac = phaserGlancingFrac
+	}
+	return float64(stats.PhaserDamage) * frac
+}
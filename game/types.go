@@ -11,7 +11,7 @@ const (
 	MaxPlayers = 64
 	MaxPlanets = 40
 	MaxTorps   = 8
-	MaxPlasma  = 1
+	MaxPlasma  = 1 // Default per-ship plasma limit (see ShipStats.MaxPlasma)
 
 	// Galaxy dimensions
 	GalaxyWidth  = 100000
@@ -30,7 +30,6 @@ const (
 	// Ship explosion constants (from original Netrek)
 	ShipExplosionDist    = 350  // Ships within this distance take full damage
 	ShipExplosionMaxDist = 3000 // Maximum explosion damage radius
-	ShipExplosionRange   = 2650 // Used in damage falloff calculation (MaxDist - ExplosionDist)
 
 	// Plasma explosion constant
 	PlasmaExplosionDist = 1500 // Plasma has larger explosion radius than torpedoes
@@ -39,13 +38,19 @@ const (
 	ZAPPLAYERDIST = 390 // Phaser will hit player if line is this close
 	ZAPPLASMADIST = 270 // Phaser will hit plasma if line is this close
 
-	// Game timing
+	// Game timing (game frames; a server may tick faster between frames, see
+	// the server's Config.FPS)
 	FPS            = 10
 	UpdateInterval = time.Millisecond * 100 // 10 FPS (10 ticks per second)
 
 	// Physics constants
 	FractionScale = 1000 // Scale factor for fractional accumulators (turn rate, acceleration)
 
+	// Warp-up stall for heavy ships (optional, see server Config.WarpUp)
+	WarpUpSpeed        = 4    // Heavy ships below this warp are still spooling up their engines
+	WarpUpAccelPerMass = 0.04 // Ships with AccInt/Mass below this are heavy (battleship, starbase)
+	WarpUpPenalty      = 2    // Acceleration divisor while spooling up
+
 	// Tractor/Pressor beam constants (from original Netrek daemon.c)
 	TractorForceWarp = 20 // Tractor force = TractorForceWarp * TractorStr (WARP1 in original)
 	TractorFuelCost  = 20 // Fuel cost per tick when using tractor/pressor
@@ -74,6 +79,14 @@ const (
 	TeamOri  = 1 << 3
 )
 
+// TeamNames are the default team display names
+var TeamNames = map[int]string{
+	TeamFed: "Federation",
+	TeamRom: "Romulan",
+	TeamKli: "Klingon",
+	TeamOri: "Orion",
+}
+
 // Team home positions for respawn
 var TeamHomeX = map[int]int{
 	TeamFed: 20000,
@@ -117,6 +130,7 @@ const (
 	KillQuit      = 5 // Player quit
 	KillDaemon    = 6 // Server killed player
 	KillPlasma    = 7 // Killed by plasma torpedo
+	KillEdge      = 8 // Flew out of the galaxy under the kill edge rule
 )
 
 // Planet combat constants
@@ -124,6 +138,16 @@ const (
 	PlanetFireDist = 1500 // Distance at which planets fire at enemy ships
 )
 
+// Electronic countermeasures
+const (
+	ECMFuelCost = 15 // Fuel burned per tick while ECM is jamming
+)
+
+// Cloaking
+const (
+	RecloakDelayFrames = 20 // Frames a ship that fired its way out of cloak must wait to cloak again (server Config.DecloakOnFire)
+)
+
 // Starbase combat constants
 const (
 	StarbaseEnemyDetectRange = 12000      // Distance to start combat logic
@@ -158,6 +182,7 @@ type ShipStats struct {
 	MaxShields   int
 	MaxDamage    int
 	MaxArmies    int
+	BombBonus    int // Extra armies killed by each successful bomb (assault ships)
 	TorpDamage   int
 	TorpSpeed    int
 	TorpFuse     int
@@ -165,6 +190,7 @@ type ShipStats struct {
 	PlasmaDamage int
 	PlasmaSpeed  int
 	PlasmaFuse   int // Plasma torpedo fuse time (in ticks)
+	MaxPlasma    int // Maximum plasma torpedoes in flight at once
 	TurnRate     int // Turn rate in original units (higher = faster turning)
 	Mass         int
 	TractorStr   int
@@ -178,6 +204,12 @@ type ShipStats struct {
 	PlasmaFuelMult int // Multiplier for plasma fuel cost (damage * mult)
 	// Tractor/Pressor range multiplier
 	TractorRange float64 // Multiplier for tractor/pressor range
+	// Phaser range multiplier on PhaserDist; 0 ties range to PhaserDamage/100
+	// as in the original game
+	PhaserRangeMult float64
+	// Multiplier on the frames between bombing runs; below 1 bombs more
+	// often, and 0 keeps the configured interval
+	BombIntervalMult float64
 	// Movement physics
 	AccInt int // Acceleration integer (higher = faster acceleration)
 	DecInt int // Deceleration integer (higher = faster deceleration)
@@ -189,204 +221,245 @@ type ShipStats struct {
 	CloakCost      int // Fuel cost per tick when cloaked
 	ShieldFuelCost int // Fuel cost per tick when shields are up
 	DetCost        int // Fuel cost for detonating enemy torpedoes
+	// Explosion
+	ExplosionDamage int // Damage dealt to ships close by when this ship explodes
+	// Special ability
+	Special         int // Ability triggered by the "special" command (SpecialNone if the ship has none)
+	SpecialCooldown int // Frames before the ability can be used again
+	SpecialFuel     int // Fuel cost of using the ability
+}
+
+// Ship special abilities (ShipStats.Special)
+const (
+	SpecialNone        = iota
+	SpecialShieldBoost // Recharge shields to full and raise them
+)
+
+// SpecialNames gives the display name of each special ability.
+var SpecialNames = map[int]string{
+	SpecialShieldBoost: "Shield overcharge",
 }
 
 var ShipData = map[ShipType]ShipStats{
 	ShipScout: {
-		Name:           "Scout",
-		MaxSpeed:       12,
-		MaxFuel:        5000,
-		MaxShields:     75,
-		MaxDamage:      75,
-		MaxArmies:      2,
-		TorpDamage:     25,
-		TorpSpeed:      16,
-		TorpFuse:       16,
-		PhaserDamage:   75,
-		TurnRate:       570000, // Original Netrek turn rate
-		Mass:           1500,
-		TractorStr:     2000,
-		HasPlasma:      false,
-		MaxWpnTemp:     1000,
-		MaxEngTemp:     1000,
-		TorpFuelMult:   7,
-		PhaserFuelMult: 7,
-		TractorRange:   0.7,
-		AccInt:         200,
-		DecInt:         270,
-		RepairRate:     80,
-		FuelRecharge:   8,
-		WpnCool:        2,
-		EngCool:        5,
-		CloakCost:      17,
-		ShieldFuelCost: 2,
-		DetCost:        100,
+		Name:            "Scout",
+		MaxSpeed:        12,
+		MaxFuel:         5000,
+		MaxShields:      75,
+		MaxDamage:       75,
+		MaxArmies:       2,
+		BombBonus:       0,
+		TorpDamage:      25,
+		TorpSpeed:       16,
+		TorpFuse:        16,
+		PhaserDamage:    75,
+		TurnRate:        570000, // Original Netrek turn rate
+		Mass:            1500,
+		TractorStr:      2000,
+		HasPlasma:       false,
+		MaxPlasma:       MaxPlasma,
+		MaxWpnTemp:      1000,
+		MaxEngTemp:      1000,
+		TorpFuelMult:    7,
+		PhaserFuelMult:  7,
+		TractorRange:    0.7,
+		AccInt:          200,
+		DecInt:          270,
+		RepairRate:      80,
+		FuelRecharge:    8,
+		WpnCool:         2,
+		EngCool:         5,
+		CloakCost:       17,
+		ShieldFuelCost:  2,
+		DetCost:         100,
+		ExplosionDamage: 75,
 	},
 	ShipDestroyer: {
-		Name:           "Destroyer",
-		MaxSpeed:       10,
-		MaxFuel:        7000,
-		MaxShields:     85,
-		MaxDamage:      85,
-		MaxArmies:      5,
-		TorpDamage:     30,
-		TorpSpeed:      14,
-		TorpFuse:       30,
-		PhaserDamage:   85,
-		PlasmaDamage:   75,
-		PlasmaSpeed:    15,
-		PlasmaFuse:     30,     // Original Netrek value
-		TurnRate:       310000, // Original Netrek turn rate
-		Mass:           1800,
-		TractorStr:     2500,
-		HasPlasma:      true,
-		MaxWpnTemp:     1000,
-		MaxEngTemp:     1000,
-		TorpFuelMult:   7,
-		PhaserFuelMult: 7,
-		PlasmaFuelMult: 30,
-		TractorRange:   0.9,
-		AccInt:         200,
-		DecInt:         300,
-		RepairRate:     100,
-		FuelRecharge:   11,
-		WpnCool:        2,
-		EngCool:        5,
-		CloakCost:      21,
-		ShieldFuelCost: 3,
-		DetCost:        100,
+		Name:            "Destroyer",
+		MaxSpeed:        10,
+		MaxFuel:         7000,
+		MaxShields:      85,
+		MaxDamage:       85,
+		MaxArmies:       5,
+		BombBonus:       0,
+		TorpDamage:      30,
+		TorpSpeed:       14,
+		TorpFuse:        30,
+		PhaserDamage:    85,
+		PlasmaDamage:    75,
+		PlasmaSpeed:     15,
+		PlasmaFuse:      30,     // Original Netrek value
+		TurnRate:        310000, // Original Netrek turn rate
+		Mass:            1800,
+		TractorStr:      2500,
+		HasPlasma:       true,
+		MaxPlasma:       MaxPlasma,
+		MaxWpnTemp:      1000,
+		MaxEngTemp:      1000,
+		TorpFuelMult:    7,
+		PhaserFuelMult:  7,
+		PlasmaFuelMult:  30,
+		TractorRange:    0.9,
+		AccInt:          200,
+		DecInt:          300,
+		RepairRate:      100,
+		FuelRecharge:    11,
+		WpnCool:         2,
+		EngCool:         5,
+		CloakCost:       21,
+		ShieldFuelCost:  3,
+		DetCost:         100,
+		ExplosionDamage: 100,
 	},
 	ShipCruiser: {
-		Name:           "Cruiser",
-		MaxSpeed:       9,
-		MaxFuel:        10000,
-		MaxShields:     100,
-		MaxDamage:      100,
-		MaxArmies:      10,
-		TorpDamage:     40,
-		TorpSpeed:      12,
-		TorpFuse:       40,
-		PhaserDamage:   100,
-		PlasmaDamage:   100,
-		PlasmaSpeed:    15,
-		PlasmaFuse:     35,     // Original Netrek value
-		TurnRate:       170000, // Original Netrek turn rate
-		Mass:           2000,
-		TractorStr:     3000,
-		HasPlasma:      true,
-		MaxWpnTemp:     1000,
-		MaxEngTemp:     1000,
-		TorpFuelMult:   7,
-		PhaserFuelMult: 7,
-		PlasmaFuelMult: 30,
-		TractorRange:   1.0,
-		AccInt:         150,
-		DecInt:         200,
-		RepairRate:     110,
-		FuelRecharge:   12,
-		WpnCool:        2,
-		EngCool:        5,
-		CloakCost:      26,
-		ShieldFuelCost: 3,
-		DetCost:        100,
+		Name:            "Cruiser",
+		MaxSpeed:        9,
+		MaxFuel:         10000,
+		MaxShields:      100,
+		MaxDamage:       100,
+		MaxArmies:       10,
+		BombBonus:       0,
+		TorpDamage:      40,
+		TorpSpeed:       12,
+		TorpFuse:        40,
+		PhaserDamage:    100,
+		PlasmaDamage:    100,
+		PlasmaSpeed:     15,
+		PlasmaFuse:      35,     // Original Netrek value
+		TurnRate:        170000, // Original Netrek turn rate
+		Mass:            2000,
+		TractorStr:      3000,
+		HasPlasma:       true,
+		MaxPlasma:       MaxPlasma,
+		MaxWpnTemp:      1000,
+		MaxEngTemp:      1000,
+		TorpFuelMult:    7,
+		PhaserFuelMult:  7,
+		PlasmaFuelMult:  30,
+		TractorRange:    1.0,
+		AccInt:          150,
+		DecInt:          200,
+		RepairRate:      110,
+		FuelRecharge:    12,
+		WpnCool:         2,
+		EngCool:         5,
+		CloakCost:       26,
+		ShieldFuelCost:  3,
+		DetCost:         100,
+		ExplosionDamage: 100,
 	},
 	ShipBattleship: {
-		Name:           "Battleship",
-		MaxSpeed:       8,
-		MaxFuel:        14000,
-		MaxShields:     130,
-		MaxDamage:      130,
-		MaxArmies:      6,
-		TorpDamage:     40,
-		TorpSpeed:      12,
-		TorpFuse:       40,
-		PhaserDamage:   105,
-		PlasmaDamage:   130,
-		PlasmaSpeed:    15,
-		PlasmaFuse:     35,    // Original Netrek value
-		TurnRate:       75000, // Original Netrek turn rate
-		Mass:           2300,
-		TractorStr:     3700,
-		HasPlasma:      true,
-		MaxWpnTemp:     1000,
-		MaxEngTemp:     1000,
-		TorpFuelMult:   9,
-		PhaserFuelMult: 10,
-		PlasmaFuelMult: 30,
-		TractorRange:   1.2,
-		AccInt:         80,
-		DecInt:         180,
-		RepairRate:     125,
-		FuelRecharge:   14,
-		WpnCool:        2,
-		EngCool:        5,
-		CloakCost:      30,
-		ShieldFuelCost: 3,
-		DetCost:        100,
+		Name:            "Battleship",
+		MaxSpeed:        8,
+		MaxFuel:         14000,
+		MaxShields:      130,
+		MaxDamage:       130,
+		MaxArmies:       6,
+		BombBonus:       0,
+		TorpDamage:      40,
+		TorpSpeed:       12,
+		TorpFuse:        40,
+		PhaserDamage:    105,
+		PlasmaDamage:    130,
+		PlasmaSpeed:     15,
+		PlasmaFuse:      35,    // Original Netrek value
+		TurnRate:        75000, // Original Netrek turn rate
+		Mass:            2300,
+		TractorStr:      3700,
+		HasPlasma:       true,
+		MaxPlasma:       MaxPlasma,
+		MaxWpnTemp:      1000,
+		MaxEngTemp:      1000,
+		TorpFuelMult:    9,
+		PhaserFuelMult:  10,
+		PlasmaFuelMult:  30,
+		TractorRange:    1.2,
+		AccInt:          80,
+		DecInt:          180,
+		RepairRate:      125,
+		FuelRecharge:    14,
+		WpnCool:         2,
+		EngCool:         5,
+		CloakCost:       30,
+		ShieldFuelCost:  3,
+		DetCost:         100,
+		ExplosionDamage: 100,
+		// Battleships can overcharge their shields once every 30 seconds
+		Special:         SpecialShieldBoost,
+		SpecialCooldown: 30 * FPS,
+		SpecialFuel:     1500,
 	},
 	ShipAssault: {
-		Name:           "Assault",
-		MaxSpeed:       8,
-		MaxFuel:        6000,
-		MaxShields:     80,
-		MaxDamage:      200,
-		MaxArmies:      20,
-		TorpDamage:     30,
-		TorpSpeed:      16,
-		TorpFuse:       30, // Fixed: Was 20, should be 30
-		PhaserDamage:   80,
-		TurnRate:       120000, // Original Netrek turn rate
-		Mass:           2300,
-		TractorStr:     2500,
-		HasPlasma:      false,
-		MaxWpnTemp:     1000,
-		MaxEngTemp:     1200, // Assault has higher engine temp limit
-		TorpFuelMult:   9,
-		PhaserFuelMult: 7,
-		TractorRange:   0.7,
-		AccInt:         100,
-		DecInt:         200,
-		RepairRate:     120,
-		FuelRecharge:   10,
-		WpnCool:        2,
-		EngCool:        7,
-		CloakCost:      17,
-		ShieldFuelCost: 3,
-		DetCost:        100,
+		Name:            "Assault",
+		MaxSpeed:        8,
+		MaxFuel:         6000,
+		MaxShields:      80,
+		MaxDamage:       200,
+		MaxArmies:       20,
+		BombBonus:       1, // Assault ships bomb one extra army per hit
+		TorpDamage:      30,
+		TorpSpeed:       16,
+		TorpFuse:        30, // Fixed: Was 20, should be 30
+		PhaserDamage:    80,
+		TurnRate:        120000, // Original Netrek turn rate
+		Mass:            2300,
+		TractorStr:      2500,
+		HasPlasma:       false,
+		MaxPlasma:       MaxPlasma,
+		MaxWpnTemp:      1000,
+		MaxEngTemp:      1200, // Assault has higher engine temp limit
+		TorpFuelMult:    9,
+		PhaserFuelMult:  7,
+		TractorRange:    0.7,
+		AccInt:          100,
+		DecInt:          200,
+		RepairRate:      120,
+		FuelRecharge:    10,
+		WpnCool:         2,
+		EngCool:         7,
+		CloakCost:       17,
+		ShieldFuelCost:  3,
+		DetCost:         100,
+		ExplosionDamage: 100,
+		// Assault ships also make bombing runs more often
+		BombIntervalMult: 0.6,
 	},
 	ShipStarbase: {
-		Name:           "Starbase",
-		MaxSpeed:       2,
-		MaxFuel:        60000,
-		MaxShields:     500,
-		MaxDamage:      600,
-		MaxArmies:      25,
-		TorpDamage:     30,
-		TorpSpeed:      14,
-		TorpFuse:       30,
-		PhaserDamage:   120,
-		PlasmaDamage:   150,
-		PlasmaSpeed:    15,
-		PlasmaFuse:     25,    // Original Netrek value
-		TurnRate:       50000, // Original Netrek turn rate
-		Mass:           5000,
-		TractorStr:     8000,
-		HasPlasma:      true,
-		MaxWpnTemp:     1300, // Starbase has higher weapon temp limit
-		MaxEngTemp:     1000,
-		TorpFuelMult:   10,
-		PhaserFuelMult: 8,
-		PlasmaFuelMult: 25,
-		TractorRange:   1.5,
-		AccInt:         100,
-		DecInt:         200,
-		RepairRate:     140,
-		FuelRecharge:   35,
-		WpnCool:        3,
-		EngCool:        5,
-		CloakCost:      75,
-		ShieldFuelCost: 6,
-		DetCost:        100,
+		Name:            "Starbase",
+		MaxSpeed:        2,
+		MaxFuel:         60000,
+		MaxShields:      500,
+		MaxDamage:       600,
+		MaxArmies:       25,
+		BombBonus:       0,
+		TorpDamage:      30,
+		TorpSpeed:       14,
+		TorpFuse:        30,
+		PhaserDamage:    120,
+		PlasmaDamage:    150,
+		PlasmaSpeed:     15,
+		PlasmaFuse:      25,    // Original Netrek value
+		TurnRate:        50000, // Original Netrek turn rate
+		Mass:            5000,
+		TractorStr:      8000,
+		HasPlasma:       true,
+		MaxPlasma:       MaxPlasma,
+		MaxWpnTemp:      1300, // Starbase has higher weapon temp limit
+		MaxEngTemp:      1000,
+		TorpFuelMult:    10,
+		PhaserFuelMult:  8,
+		PlasmaFuelMult:  25,
+		TractorRange:    1.5,
+		AccInt:          100,
+		DecInt:          200,
+		RepairRate:      140,
+		FuelRecharge:    35,
+		WpnCool:         3,
+		EngCool:         5,
+		CloakCost:       75,
+		ShieldFuelCost:  6,
+		DetCost:         100,
+		ExplosionDamage: 200,
 	},
 }
 
@@ -416,6 +489,7 @@ type Player struct {
 	Kills       float64 `json:"kills"`
 	KillsStreak float64 `json:"killsStreak"` // Second kill counter that resets on death
 	Deaths      int     `json:"deaths"`
+	Rating      float64 `json:"rating,omitempty"` // Elo-style rating (only when ratings are enabled)
 
 	// Weapons
 	WTemp     int `json:"wtemp"` // Weapon temperature
@@ -426,15 +500,24 @@ type Player struct {
 	// Flags
 	Shields_up     bool `json:"shields_up"`
 	Cloaked        bool `json:"cloaked"`
+	ECMActive      bool `json:"ecm"`           // Jamming: enemies can't lock on and bot fire control is degraded
 	Repairing      bool `json:"repairing"`     // In repair mode
 	RepairRequest  bool `json:"repairRequest"` // Slowing down to repair
 	RepairCounter  int  `json:"-"`             // Counter for repair timing (not sent to client)
 	Bombing        bool `json:"bombing"`
 	Beaming        bool `json:"beaming"`
-	BeamingUp      bool `json:"beamingUp"`      // True if beaming up, false if beaming down
-	EngineOverheat bool `json:"engineOverheat"` // Engine temp exceeded max (PFENG in original)
-	Tractoring     int  `json:"tractoring"`     // Player ID being tractored, -1 if none
-	Pressoring     int  `json:"pressoring"`     // Player ID being pressored, -1 if none
+	BeamingUp      bool `json:"beamingUp"`       // True if beaming up, false if beaming down
+	BeamCount      int  `json:"beamCount"`       // Armies left to beam before stopping (0 = continuous)
+	EngineOverheat bool `json:"engineOverheat"`  // Engine temp exceeded max (PFENG in original)
+	Tractoring     int  `json:"tractoring"`      // Player ID being tractored, -1 if none
+	Pressoring     int  `json:"pressoring"`      // Player ID being pressored, -1 if none
+	Assist         bool `json:"assist"`          // Dogfight assist: server manages shields for a human player
+	AutoRepair     int  `json:"autoRepair"`      // Damage percent that triggers an automatic repair request (0 = off)
+	AutoRepairSet  bool `json:"-"`               // Auto-repair already requested for the current damage (not sent to client)
+	OrbitRepair    bool `json:"orbitRepair"`     // Enter repair mode automatically while idle in orbit of a friendly planet
+	OrbitRepairSet bool `json:"-"`               // Orbit repair already started during the current orbit (not sent to client)
+	SpecialTimer   int  `json:"specialTimer"`    // Frames until the ship's special ability is ready again
+	Dummy          bool `json:"dummy,omitempty"` // Stationary practice target: no AI, removed when destroyed
 
 	// Lock-on
 	LockType   string `json:"lockType"`   // "none", "player", or "planet"
@@ -449,6 +532,12 @@ type Player struct {
 	WhyDead        int  `json:"whyDead"`      // Reason for death (KillTorp, KillPhaser, etc)
 	RespawnMsgSent bool `json:"-"`            // True if "cannot respawn" message was sent (not sent to client)
 
+	// Spawn protection
+	SpawnProtectUntil int64 `json:"-"` // Frame until which a respawned ship is protected inside its spawn zone
+
+	// Decloak-on-fire
+	RecloakFrame int64 `json:"-"` // Frame before which a ship that fired out of cloak can't cloak again
+
 	// Engine overheat tracking
 	OverheatTimer int `json:"-"` // Frames left in overheat state (not sent to client)
 
@@ -466,6 +555,9 @@ type Player struct {
 	BotTargetLockTime   int     `json:"-"` // Ticks remaining for target lock
 	BotTargetValue      float64 `json:"-"` // Current target's value score
 	BotPlanetApproachID int     `json:"-"` // Planet ID bot is trying to approach (-1 if none)
+	BotApproachDefense  float64 `json:"-"` // Planet defense score when the approach began
+	BotAbandonedPlanet  int     `json:"-"` // Planet ID of the last abandoned assault
+	BotAbandonedUntil   int64   `json:"-"` // Frame until which BotAbandonedPlanet is not picked again
 	BotDefenseTarget    int     `json:"-"` // Planet ID bot is actively defending (-1 if none)
 	BotGoalX            float64 `json:"-"` // Navigation goal
 	BotGoalY            float64 `json:"-"`
@@ -474,6 +566,15 @@ type Player struct {
 	BotCooldown         int     `json:"-"` // Frames until next action
 	BotPrevDamage       int     `json:"-"` // Damage at the previous bot decision (detects new hits)
 	BotHitTimer         int     `json:"-"` // Frames remaining where the bot counts as recently hit
+	BotDetonateReady    int64   `json:"-"` // Frame from which the bot may detonate enemy torpedoes again
+	BotDenialReady      int64   `json:"-"` // Frame from which the bot may fire another area denial plasma
+	BotRecharging       bool    `json:"-"` // Broken off to recharge fuel until it is nearly full
+	BotMemoryTarget     int     `json:"-"` // Player ID of the last target seen (for searching after it cloaks)
+	BotMemoryX          float64 `json:"-"` // Last known position of BotMemoryTarget
+	BotMemoryY          float64 `json:"-"`
+	BotMemoryUntil      int64   `json:"-"` // Frame at which the target memory expires (0 = no memory)
+	BotCaution          string  `json:"-"` // Caution profile name (empty uses the server default)
+	BotTakeoverOf       string  `json:"-"` // Name of the disconnected human whose ship this bot flies ("" for other bots)
 
 	// Refit system - ship type to use on next respawn (-1 means no pending refit)
 	NextShipType int `json:"-"` // Ship type to use on next respawn
@@ -518,18 +619,48 @@ const (
 	PlanetCore   = 1 << 11
 )
 
+// Timed game events that temporarily alter the rules
+const (
+	EventNone         = ""
+	EventDoubleGrowth = "double_growth" // Planet armies grow twice as fast
+	EventRapidFire    = "rapid_fire"    // Weapons cool twice as fast
+)
+
 // TournamentPlayerStats tracks player performance in tournament mode
 type TournamentPlayerStats struct {
 	Kills        int
 	Deaths       int
 	PlanetsTaken int
 	PlanetsLost  int
+	ArmiesBombed int
 	TorpsFired   int
 	PhasersFired int
 	DamageDealt  int
 	DamageTaken  int
 }
 
+// MaxKillFeed is the number of recent kills kept for the match kill feed
+const MaxKillFeed = 10
+
+// KillEvent records a ship destruction for the match kill feed
+type KillEvent struct {
+	Frame  int64 `json:"frame"`
+	Victim int   `json:"victim"` // Player ID of the destroyed ship
+	Killer int   `json:"killer"` // Player ID credited with the kill, -1 if none
+	Reason int   `json:"reason"` // KillTorp, KillPhaser, etc
+
+	X float64 `json:"x"` // Where the ship died
+	Y float64 `json:"y"`
+}
+
+// TeamOrder is a team's current objective, chosen by the server's bot
+// commander and followed by that team's bots.
+type TeamOrder struct {
+	Objective string // What the team is doing; empty when there are no orders
+	Planet    int    // Planet to defend or push (-1 if none)
+	Target    int    // Enemy player to hunt (-1 if none)
+}
+
 // GameState holds the entire game state
 type GameState struct {
 	Mu sync.RWMutex // Made public for access from server package
@@ -544,14 +675,33 @@ type GameState struct {
 	T_mode    bool  // Tournament mode
 	T_start   int64 // Tournament start time (frame)
 	T_remain  int   // Tournament time remaining (seconds)
+	T_forced  bool  // Tournament mode held on or off by an admin regardless of population
+	Ceasefire bool  // Weapons and damage suspended by an admin; movement continues
 	GameOver  bool
 	Winner    int    // Winning team (if GameOver)
 	WinType   string // "genocide" or "conquest"
 
+	// Timed game event
+	ActiveEvent   string // Currently running event (EventNone if none)
+	EventEndFrame int64  // Frame at which the active event ends
+
 	// Team statistics
 	TeamPlanets [4]int // Planet count per team
 	TeamPlayers [4]int // Active player count per team
 
+	// Bot commanders
+	TeamOrders map[int]TeamOrder // Team flag -> objective for that team's bots
+	BotSquads  map[int]string    // Bot player ID -> offense or defense squad
+
+	// Survival mode
+	SurvivalWave     int   // Attack waves launched so far
+	SurvivalBase     int   // Player ID of the defended starbase (-1 until it is launched)
+	SurvivalBaseHull int   // Hull points the defended starbase has left
+	SurvivalNextWave int64 // Frame at which the next attack wave launches
+
+	// Match kill feed
+	KillFeed []KillEvent // Most recent kills, oldest first (at most MaxKillFeed)
+
 	// Tournament statistics
 	TournamentStats map[int]*TournamentPlayerStats // Player ID -> stats
 }
@@ -562,6 +712,9 @@ func NewGameState() *GameState {
 		Torps:           make([]*Torpedo, 0),
 		Plasmas:         make([]*Plasma, 0),
 		TournamentStats: make(map[int]*TournamentPlayerStats),
+		TeamOrders:      make(map[int]TeamOrder),
+		BotSquads:       make(map[int]string),
+		SurvivalBase:    -1,
 	}
 
 	// Initialize players
@@ -611,14 +764,7 @@ func NormalizeAngle(angle float64) float64 {
 }
 
 // GetShipExplosionDamage returns the explosion damage for a ship type
-// Based on original Netrek: SB=200, SC=75, all others=100
+// (ShipStats.ExplosionDamage; original Netrek: SB=200, SC=75, all others=100)
 func GetShipExplosionDamage(shipType ShipType) int {
-	switch shipType {
-	case ShipStarbase:
-		return 200
-	case ShipScout:
-		return 75
-	default:
-		return 100
-	}
+	return ShipData[shipType].ExplosionDamage
 }
@@ -4,27 +4,280 @@ import (
 	"context"
 	"embed"
 	"flag"
+	"github.com/lab1702/netrek-web/game"
 	"github.com/lab1702/netrek-web/server"
 	"io/fs"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 )
 
+// maxFPS caps -fps; beyond it state updates cost far more bandwidth than
+// they add smoothness.
+const maxFPS = 60
+
 //go:embed static/*
 var staticFiles embed.FS
 
 func main() {
 	port := flag.String("port", "8080", "Server port")
+	mapFile := flag.String("map", "", "JSON map config file with explicit planet flag assignments")
+	botNamesFile := flag.String("bot-names", "", "Text file of bot names, one per line, to use instead of the built-in list")
+
+	cfg := server.DefaultConfig()
+	flag.Float64Var(&cfg.StarbaseEnemyDetectRange, "starbase-detect-range", cfg.StarbaseEnemyDetectRange, "Distance at which starbase bots engage enemies")
+	flag.Float64Var(&cfg.StarbaseTorpRange, "starbase-torp-range", cfg.StarbaseTorpRange, "Maximum torpedo range for starbase bots")
+	flag.Float64Var(&cfg.StarbasePhaserRange, "starbase-phaser-range", cfg.StarbasePhaserRange, "Maximum phaser range for starbase bots")
+	flag.Float64Var(&cfg.StarbasePlasmaMaxRange, "starbase-plasma-range", cfg.StarbasePlasmaMaxRange, "Maximum plasma range for starbase bots")
+	flag.BoolVar(&cfg.BotSeparation, "bot-separation", cfg.BotSeparation, "Steer bots apart from nearby allies (disable for solo bot testing)")
+	flag.Float64Var(&cfg.BotSepMinSafeDistance, "bot-sep-range", cfg.BotSepMinSafeDistance, "Distance within which bots steer away from allies")
+	flag.Float64Var(&cfg.BotSepIdealDistance, "bot-sep-ideal", cfg.BotSepIdealDistance, "Spacing bots try to keep from allies (lower for tighter formations)")
+	flag.Float64Var(&cfg.BotSepCriticalDistance, "bot-sep-critical", cfg.BotSepCriticalDistance, "Ally distance that triggers emergency separation")
+	flag.StringVar(&cfg.BotCaution, "bot-caution", cfg.BotCaution, "Default bot caution profile: conservative, balanced or aggressive")
+	flag.IntVar(&cfg.EventInterval, "event-interval", cfg.EventInterval, "Seconds between random game events such as double army growth (0 disables)")
+	flag.IntVar(&cfg.EventDuration, "event-duration", cfg.EventDuration, "Seconds each game event lasts")
+	flag.BoolVar(&cfg.BackfillOnDisconnect, "backfill-on-disconnect", cfg.BackfillOnDisconnect, "Immediately add a bot to a team that falls behind when a human disconnects")
+	flag.BoolVar(&cfg.AutoBalance, "auto-balance", cfg.AutoBalance, "Every few seconds, add or remove bots so each team matches the largest human team")
+	flag.IntVar(&cfg.AutoBalanceThreshold, "auto-balance-threshold", cfg.AutoBalanceThreshold, "Smallest team size gap, in ships, that auto-balance acts on")
+	flag.IntVar(&cfg.AutoBalanceRemoveDelay, "auto-balance-remove-delay", cfg.AutoBalanceRemoveDelay, "Seconds a team must stay over strength before auto-balance removes its surplus bots")
+	flag.IntVar(&cfg.HandicapGap, "handicap-gap", cfg.HandicapGap, "Human player gap to the largest team at which a smaller team repairs faster (0 disables handicapping)")
+	flag.Float64Var(&cfg.HandicapRepair, "handicap-repair", cfg.HandicapRepair, "Extra repair speed for a handicapped team per human it is short (0.1 = 10% each)")
+	flag.Func("team-handicap", "Per-team multipliers as team:damage=x,fuel=y,repair=z (e.g. rom:damage=1.2); repeat for each team", func(value string) error {
+		team, h, err := server.ParseTeamHandicap(value)
+		if err != nil {
+			return err
+		}
+		if cfg.TeamHandicaps == nil {
+			cfg.TeamHandicaps = make(map[int]server.TeamHandicap)
+		}
+		cfg.TeamHandicaps[team] = h
+		return nil
+	})
+	flag.BoolVar(&cfg.Survival, "survival", cfg.Survival, "Co-op survival mode: everyone joins the Federation to defend a starbase against growing waves of Klingon bots")
+	flag.IntVar(&cfg.SurvivalWaveInterval, "survival-wave-interval", cfg.SurvivalWaveInterval, "Seconds between survival mode attack waves")
+	flag.BoolVar(&cfg.ReadyCheck, "ready-check", cfg.ReadyCheck, "Scheduled matches: start tournament mode only once connected players type /ready, instead of at 4v4")
+	flag.Float64Var(&cfg.ReadyQuorum, "ready-quorum", cfg.ReadyQuorum, "Fraction of connected players that must be ready before a -ready-check match starts (1 = everyone)")
+	flag.BoolVar(&cfg.BotTakeoverOnDisconnect, "bot-takeover", cfg.BotTakeoverOnDisconnect, "Hand a disconnecting human's ship to a bot so their team keeps the ship mid-fight")
+	flag.IntVar(&cfg.MaxConnections, "max-connections", cfg.MaxConnections, "Maximum concurrent WebSocket connections; logins beyond the 64 player slots wait in a queue")
+	flag.IntVar(&cfg.FPS, "fps", cfg.FPS, "Game loop ticks and state updates per second, a multiple of 10; game rules still run at 10 frames per second, the extra ticks only smooth movement")
+	flag.IntVar(&cfg.MinProtocolVersion, "min-protocol-version", cfg.MinProtocolVersion, "Oldest client protocol version allowed to log in")
+	flag.BoolVar(&cfg.HotSeat, "hot-seat", cfg.HotSeat, "Debug: let one browser drive several ships by tagging messages with a slot index (not for public servers)")
+	flag.BoolVar(&cfg.WSCompression, "ws-compression", cfg.WSCompression, "Enable WebSocket compression by default (clients may override with ?compress=0/1)")
+	flag.StringVar(&cfg.AdminToken, "admin-token", cfg.AdminToken, "Token for admin-only API endpoints, sent as an X-Admin-Token header (empty disables them)")
+	flag.StringVar(&cfg.WebhookURL, "webhook", cfg.WebhookURL, "URL to POST a JSON match result to when a game ends, e.g. for Discord or analytics (empty disables it)")
+	flag.Float64Var(&cfg.DamageScale, "damage-scale", cfg.DamageScale, "Multiplier on all weapon damage (0.5 for casual play, 2.0 for fast brutal games)")
+	flag.Float64Var(&cfg.PlasmaSpeedScale, "plasma-speed", cfg.PlasmaSpeedScale, "Multiplier on plasma speed; faster plasmas also fly farther before their fuse runs out")
+	flag.Float64Var(&cfg.PlasmaRangeScale, "plasma-range", cfg.PlasmaRangeScale, "Multiplier on plasma fuse time, and so on plasma range at a given speed")
+	flag.BoolVar(&cfg.Ratings, "ratings", cfg.Ratings, "Track Elo-style player ratings that rise and fall with kills against stronger or weaker opponents")
+	flag.StringVar(&cfg.RatingsFile, "ratings-file", cfg.RatingsFile, "JSON file to keep -ratings in across restarts (empty keeps them in memory only)")
+	flag.StringVar(&cfg.RefitMode, "refit-mode", cfg.RefitMode, "Ship refit rules: per-life (refit on respawn), free (also refit while docked at a repair planet) or rotation (forced ship cycle)")
+	flag.Float64Var(&cfg.StartFuel, "start-fuel", cfg.StartFuel, "Fraction of max fuel ships spawn with (below 1 for a resource-scarce game)")
+	flag.Float64Var(&cfg.StartDamage, "start-damage", cfg.StartDamage, "Fraction of max damage ships spawn with, as a refit penalty (0 spawns fully repaired)")
+	flag.IntVar(&cfg.Lives, "lives", cfg.Lives, "Deaths each player may take per match before observing the rest of it (0 = unlimited respawns)")
+	flag.Float64Var(&cfg.SpawnProtectRadius, "spawn-protect-radius", cfg.SpawnProtectRadius, "Radius around each home world where freshly respawned ships take no damage (0 disables; spawns land within about 7100)")
+	flag.IntVar(&cfg.SpawnProtectSeconds, "spawn-protect-seconds", cfg.SpawnProtectSeconds, "Seconds of spawn protection, ended early by leaving the spawn zone")
+	flag.BoolVar(&cfg.WarpUp, "warp-up", cfg.WarpUp, "Make battleships and starbases stall at low warp before reaching full acceleration")
+	flag.Float64Var(&cfg.TurnRateScale, "turn-rate-scale", cfg.TurnRateScale, "Multiplier on every ship's turn rate (above 1 for twitchier ships, below 1 for more ponderous ones)")
+	flag.Float64Var(&cfg.DamageDecelScale, "damage-decel-scale", cfg.DamageDecelScale, "Multiplier on how hard a crippled ship brakes down to its damage-reduced top speed")
+	flag.BoolVar(&cfg.ShipSpecials, "ship-specials", cfg.ShipSpecials, "Enable per-ship special abilities on the E key, such as the battleship shield overcharge")
+	flag.Float64Var(&cfg.ShieldAbsorbAll, "shield-absorb", cfg.ShieldAbsorbAll, "Fraction of every weapon's damage raised shields absorb, the rest bleeding through to the hull (1 = classic full absorption; -shield-absorb-torp/-phaser/-plasma override it per weapon)")
+	flag.Float64Var(&cfg.ShieldAbsorb.Torp, "shield-absorb-torp", cfg.ShieldAbsorb.Torp, "Fraction of torpedo damage raised shields absorb before the rest hits the hull (negative uses -shield-absorb)")
+	flag.Float64Var(&cfg.ShieldAbsorb.Phaser, "shield-absorb-phaser", cfg.ShieldAbsorb.Phaser, "Fraction of phaser damage raised shields absorb before the rest hits the hull (negative uses -shield-absorb)")
+	flag.Float64Var(&cfg.ShieldAbsorb.Plasma, "shield-absorb-plasma", cfg.ShieldAbsorb.Plasma, "Fraction of plasma damage raised shields absorb before the rest hits the hull (negative uses -shield-absorb)")
+	flag.Float64Var(&cfg.ShieldRearFactor, "shield-rear-factor", cfg.ShieldRearFactor, "Share of shield absorption left against hits from directly behind, blending to full at the bow (1 keeps shields omnidirectional)")
+	flag.Float64Var(&cfg.ExplosionFullDist, "explosion-full-radius", cfg.ExplosionFullDist, "Distance within which an exploding ship deals its full explosion damage")
+	flag.Float64Var(&cfg.ExplosionMaxDist, "explosion-radius", cfg.ExplosionMaxDist, "Distance at which ship explosion damage falls off to nothing")
+	flag.Float64Var(&cfg.FireArc, "fire-arc", cfg.FireArc, "Arc in degrees, centered on the bow, into which torpedoes and phasers may be fired (0 allows any direction)")
+	flag.BoolVar(&cfg.DecloakOnFire, "decloak-on-fire", cfg.DecloakOnFire, "Let cloaked ships fire, dropping the cloak and barring recloaking for 2 seconds, instead of refusing the shot")
+	flag.Float64Var(&cfg.CloakCostScale, "cloak-cost-scale", cfg.CloakCostScale, "Multiplier on the fuel cost of cloaking")
+	flag.Float64Var(&cfg.CloakDetectRange, "cloak-detect-range", cfg.CloakDetectRange, "Range within which bots detect and engage cloaked ships")
+	flag.IntVar(&cfg.ArmyMultiplier, "army-multiplier", cfg.ArmyMultiplier, "Multiplier on starting planet armies and army growth (2 for faster double-armies games)")
+	flag.BoolVar(&cfg.NeutralStart, "neutral-start", cfg.NeutralStart, "Start every planet but the home worlds neutral, so teams expand from home")
+	flag.Float64Var(&cfg.ConquestFraction, "conquest-fraction", cfg.ConquestFraction, "Fraction of all planets a team must own to win by conquest (0.6 for quicker games; 1 = every planet)")
+	flag.BoolVar(&cfg.ShieldsDownToOrbit, "shields-down-orbit", cfg.ShieldsDownToOrbit, "Require shields down to orbit, bomb or beam armies; raising shields breaks orbit")
+	flag.BoolVar(&cfg.CaptureNeedsArmies, "capture-needs-armies", cfg.CaptureNeedsArmies, "Make armies beamed onto an independent planet fight its armies first, so capture needs more armies than it holds")
+	flag.IntVar(&cfg.BombFrames, "bomb-frames", cfg.BombFrames, "Frames between bombing runs on an orbited enemy planet (lower bombs faster)")
+	flag.IntVar(&cfg.BeamFrames, "beam-frames", cfg.BeamFrames, "Frames between single-army beam transfers (lower beams faster)")
+	flag.IntVar(&cfg.StarbaseBombFrames, "starbase-bomb-frames", cfg.StarbaseBombFrames, "Frames between bombing runs for starbases")
+	flag.IntVar(&cfg.StarbaseBeamFrames, "starbase-beam-frames", cfg.StarbaseBeamFrames, "Frames between single-army beam transfers for starbases")
+	flag.IntVar(&cfg.BeamArmies, "beam-armies", cfg.BeamArmies, "Most armies moved by each beam transfer")
+	flag.Float64Var(&cfg.PlanetFireRange, "planet-fire-range", cfg.PlanetFireRange, "Distance at which hostile planets fire on ships")
+	flag.Float64Var(&cfg.PlanetFireScale, "planet-fire-scale", cfg.PlanetFireScale, "Multiplier on planet fire damage")
+	flag.Float64Var(&cfg.RepairRateScale, "repair-rate-scale", cfg.RepairRateScale, "Multiplier on how fast ships repair (2 repairs twice as fast)")
+	flag.IntVar(&cfg.RepairFuelCost, "repair-fuel-cost", cfg.RepairFuelCost, "Fuel burned per tick while repairing")
+	flag.BoolVar(&cfg.OrbitRepair, "orbit-repair", cfg.OrbitRepair, "Start players with orbit auto-repair on: idle ships orbiting a friendly planet repair without being asked")
+	flag.Float64Var(&cfg.RepairSafeRadius, "repair-safe-radius", cfg.RepairSafeRadius, "Bar weapons fire within this distance of repair planets (0 disables)")
+	flag.BoolVar(&cfg.RepairSafeOwnerOnly, "repair-safe-owner-only", cfg.RepairSafeOwnerOnly, "Let a repair planet's owners keep firing inside its safe zone")
+	flag.IntVar(&cfg.MaxGalaxyTorps, "max-galaxy-torps", cfg.MaxGalaxyTorps, "Maximum live torpedoes in the whole galaxy; fire beyond it is refused (0 = unlimited)")
+	flag.StringVar(&cfg.GalaxyEdge, "galaxy-edge", cfg.GalaxyEdge, "Galaxy edge behavior: bounce (ships rebound, projectiles expire), wrap (cross one edge to reappear at the opposite one; weapons, sensors and bots reach across it) or kill (ships reaching the edge are destroyed)")
+	flag.BoolVar(&cfg.FreeForAll, "free-for-all", cfg.FreeForAll, "Make torpedoes and plasmas neutral so they can hit teammates too")
 	flag.Parse()
 
+	if cfg.DamageScale < server.MinDamageScale || cfg.DamageScale > server.MaxDamageScale {
+		log.Fatalf("-damage-scale must be between %.1f and %.1f", server.MinDamageScale, server.MaxDamageScale)
+	}
+
+	if cfg.BotSepCriticalDistance <= 0 || cfg.BotSepCriticalDistance >= cfg.BotSepIdealDistance || cfg.BotSepIdealDistance >= cfg.BotSepMinSafeDistance {
+		log.Fatalf("Bot separation distances must satisfy 0 < -bot-sep-critical < -bot-sep-ideal < -bot-sep-range")
+	}
+
+	if !server.ValidCaution(cfg.BotCaution) {
+		log.Fatalf("-bot-caution must be conservative, balanced or aggressive, got %q", cfg.BotCaution)
+	}
+
+	if cfg.RatingsFile != "" && !cfg.Ratings {
+		log.Fatalf("-ratings-file requires -ratings")
+	}
+
+	if cfg.ArmyMultiplier < 1 {
+		log.Fatalf("-army-multiplier must be at least 1")
+	}
+
+	if cfg.ConquestFraction < 0.5 || cfg.ConquestFraction > 1 {
+		log.Fatalf("-conquest-fraction must be between 0.5 and 1")
+	}
+
+	if cfg.BombFrames < 1 || cfg.BeamFrames < 1 || cfg.StarbaseBombFrames < 1 || cfg.StarbaseBeamFrames < 1 {
+		log.Fatalf("-bomb-frames, -beam-frames, -starbase-bomb-frames and -starbase-beam-frames must be at least 1")
+	}
+	if cfg.BeamArmies < 1 {
+		log.Fatalf("-beam-armies must be at least 1")
+	}
+
+	if cfg.PlanetFireRange < 0 || cfg.PlanetFireScale < 0 {
+		log.Fatalf("-planet-fire-range and -planet-fire-scale must not be negative")
+	}
+
+	if cfg.RepairSafeRadius < 0 {
+		log.Fatalf("-repair-safe-radius must not be negative")
+	}
+
+	if cfg.MinProtocolVersion < 1 || cfg.MinProtocolVersion > server.ProtocolVersion {
+		log.Fatalf("-min-protocol-version must be between 1 and %d", server.ProtocolVersion)
+	}
+
+	if cfg.AutoBalanceThreshold < 1 || cfg.AutoBalanceRemoveDelay < 0 {
+		log.Fatalf("-auto-balance-threshold must be at least 1 and -auto-balance-remove-delay must not be negative")
+	}
+
+	if cfg.HandicapGap < 0 || cfg.HandicapRepair < 0 {
+		log.Fatalf("-handicap-gap and -handicap-repair must not be negative")
+	}
+
+	if cfg.SurvivalWaveInterval <= 0 {
+		log.Fatalf("-survival-wave-interval must be positive")
+	}
+
+	if cfg.Survival && (cfg.AutoBalance || cfg.BackfillOnDisconnect) {
+		log.Fatalf("-survival sets its own teams and cannot be combined with -auto-balance or -backfill-on-disconnect")
+	}
+
+	if cfg.ReadyQuorum <= 0 || cfg.ReadyQuorum > 1 {
+		log.Fatalf("-ready-quorum must be greater than 0 and at most 1")
+	}
+
+	if cfg.PlasmaSpeedScale <= 0 || cfg.PlasmaRangeScale <= 0 {
+		log.Fatalf("-plasma-speed and -plasma-range must be positive")
+	}
+
+	if cfg.MaxConnections <= 0 {
+		log.Fatalf("-max-connections must be positive")
+	}
+
+	if cfg.Lives < 0 {
+		log.Fatalf("-lives must not be negative")
+	}
+
+	if cfg.MaxGalaxyTorps < 0 {
+		log.Fatalf("-max-galaxy-torps must not be negative")
+	}
+
+	if cfg.FireArc < 0 || cfg.FireArc > 360 {
+		log.Fatalf("-fire-arc must be between 0 and 360")
+	}
+
+	if cfg.TurnRateScale <= 0 {
+		log.Fatalf("-turn-rate-scale must be positive")
+	}
+	if cfg.DamageDecelScale <= 0 {
+		log.Fatalf("-damage-decel-scale must be positive")
+	}
+	if cfg.RepairRateScale <= 0 || cfg.RepairFuelCost < 0 {
+		log.Fatalf("-repair-rate-scale must be positive and -repair-fuel-cost not negative")
+	}
+
+	absorb := cfg.ShieldAbsorption()
+	for _, f := range []float64{cfg.ShieldAbsorbAll, absorb.Torp, absorb.Phaser, absorb.Plasma} {
+		if f < 0 || f > 1 {
+			log.Fatalf("-shield-absorb, -shield-absorb-torp, -shield-absorb-phaser and -shield-absorb-plasma must be between 0 and 1")
+		}
+	}
+
+	if cfg.ShieldRearFactor < 0 || cfg.ShieldRearFactor > 1 {
+		log.Fatalf("-shield-rear-factor must be between 0 and 1")
+	}
+
+	if cfg.ExplosionFullDist < 0 || cfg.ExplosionMaxDist <= cfg.ExplosionFullDist {
+		log.Fatalf("-explosion-full-radius must not be negative and must be less than -explosion-radius")
+	}
+
+	if cfg.CloakCostScale < 0 || cfg.CloakDetectRange < 0 {
+		log.Fatalf("-cloak-cost-scale and -cloak-detect-range must not be negative")
+	}
+
+	switch cfg.RefitMode {
+	case server.RefitPerLife, server.RefitFree, server.RefitRotation:
+	default:
+		log.Fatalf("-refit-mode must be per-life, free or rotation, got %q", cfg.RefitMode)
+	}
+
+	if cfg.FPS < game.FPS || cfg.FPS > maxFPS || cfg.FPS%game.FPS != 0 {
+		log.Fatalf("-fps must be a multiple of %d between %d and %d", game.FPS, game.FPS, maxFPS)
+	}
+
+	switch cfg.GalaxyEdge {
+	case server.EdgeBounce, server.EdgeWrap, server.EdgeKill:
+	default:
+		log.Fatalf("-galaxy-edge must be bounce, wrap or kill, got %q", cfg.GalaxyEdge)
+	}
+
+	if cfg.StartFuel < 0 || cfg.StartFuel > 1 {
+		log.Fatalf("-start-fuel must be between 0 and 1")
+	}
+	if cfg.StartDamage < 0 || cfg.StartDamage >= 1 {
+		log.Fatalf("-start-damage must be at least 0 and below 1")
+	}
+
+	if cfg.WebhookURL != "" {
+		if u, err := url.Parse(cfg.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			log.Fatalf("-webhook must be an http or https URL, got %q", cfg.WebhookURL)
+		}
+	}
+
+	if *mapFile != "" {
+		m, err := game.LoadMapConfig(*mapFile)
+		if err != nil {
+			log.Fatalf("Failed to load map config: %v", err)
+		}
+		cfg.Map = m
+	}
+
+	if *botNamesFile != "" {
+		names, err := server.LoadBotNames(*botNamesFile)
+		if err != nil {
+			log.Fatalf("Failed to load bot names: %v", err)
+		}
+		cfg.BotNames = names
+	}
+
 	log.Printf("Starting Netrek Web Server on port %s", *port)
 
 	// Create game server
-	gameServer := server.NewServer()
+	gameServer := server.NewServerWithConfig(cfg)
 	go gameServer.Run()
 
 	// Serve static files from the static subdirectory
@@ -40,11 +293,28 @@ func main() {
 	// Team stats endpoint
 	http.HandleFunc("/api/teams", gameServer.HandleTeamStats)
 
-	// Health check endpoint
-	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("OK"))
-	})
+	// Full match snapshot for observers and casters
+	http.HandleFunc("/api/match", gameServer.HandleMatch)
+
+	// Server settings for clients and tools
+	http.HandleFunc("/api/info", gameServer.HandleInfo)
+
+	// Best-rated players, when ratings are enabled
+	http.HandleFunc("/api/leaderboard", gameServer.HandleLeaderboard)
+
+	// Admin endpoints
+	http.HandleFunc("/api/player", gameServer.HandlePlayerDetail)
+	http.HandleFunc("/api/bots", gameServer.HandleBots)
+	http.HandleFunc("/api/tmode", gameServer.HandleTournamentMode)
+	http.HandleFunc("/api/ceasefire", gameServer.HandleCeasefire)
+	http.HandleFunc("/api/handicap", gameServer.HandleTeamHandicap)
+	http.HandleFunc("/api/possess", gameServer.HandlePossess)
+
+	// Health check endpoints: /livez (process up), /readyz (game loop
+	// advancing). /health is kept as an alias of /livez.
+	http.HandleFunc("/health", gameServer.HandleLivez)
+	http.HandleFunc("/livez", gameServer.HandleLivez)
+	http.HandleFunc("/readyz", gameServer.HandleReadyz)
 
 	// Start HTTP server
 	srv := &http.Server{
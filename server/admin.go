@@ -0,0 +1,256 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// requireAdmin checks the X-Admin-Token header against the configured admin
+// token and writes an error response if it does not match. Admin endpoints
+// are disabled entirely when no token is configured.
+func (s *Server) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	token := s.config().AdminToken
+	if token == "" {
+		http.Error(w, "Admin API disabled", http.StatusForbidden)
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Token")), []byte(token)) != 1 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// botDetail exposes the bot AI state that is never sent to game clients.
+type botDetail struct {
+	Target         int     `json:"target"`
+	TargetLockTime int     `json:"targetLockTime"`
+	TargetValue    float64 `json:"targetValue"`
+	PlanetApproach int     `json:"planetApproach"`
+	DefenseTarget  int     `json:"defenseTarget"`
+	GoalX          float64 `json:"goalX"`
+	GoalY          float64 `json:"goalY"`
+	HasGoal        bool    `json:"hasGoal"`
+	Cooldown       int     `json:"cooldown"`
+	HitTimer       int     `json:"hitTimer"`
+}
+
+// playerDetail is the full server-side view of a player returned by
+// HandlePlayerDetail: everything clients receive plus bot internals.
+type playerDetail struct {
+	game.Player
+	Bot *botDetail `json:"bot,omitempty"`
+}
+
+// HandlePlayerDetail returns the full state of one player (GET /api/player?id=N).
+// Admin-only, since it includes details such as cloaked positions and bot
+// internals that regular clients never see.
+func (s *Server) HandlePlayerDetail(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil || id < 0 || id >= game.MaxPlayers {
+		http.Error(w, "Invalid player id", http.StatusBadRequest)
+		return
+	}
+
+	s.gameState.Mu.RLock()
+	p := s.gameState.Players[id]
+	if p.Status == game.StatusFree {
+		s.gameState.Mu.RUnlock()
+		http.Error(w, "Player not found", http.StatusNotFound)
+		return
+	}
+	detail := playerDetail{Player: *p}
+	if p.IsBot {
+		detail.Bot = &botDetail{
+			Target:         p.BotTarget,
+			TargetLockTime: p.BotTargetLockTime,
+			TargetValue:    p.BotTargetValue,
+			PlanetApproach: p.BotPlanetApproachID,
+			DefenseTarget:  p.BotDefenseTarget,
+			GoalX:          p.BotGoalX,
+			GoalY:          p.BotGoalY,
+			HasGoal:        p.BotHasGoal,
+			Cooldown:       p.BotCooldown,
+			HitTimer:       p.BotHitTimer,
+		}
+	}
+	s.gameState.Mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(detail)
+}
+
+// HandleBots spawns bots for testing (POST /api/bots). The only pattern so
+// far is "range", which lines up stationary target dummies for weapon tests:
+//
+//	{"pattern": "range", "team": "rom", "count": 5}
+func (s *Server) HandleBots(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Pattern string `json:"pattern"`
+		Team    string `json:"team"`
+		Count   int    `json:"count"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Pattern != "range" {
+		http.Error(w, "Unknown pattern", http.StatusBadRequest)
+		return
+	}
+	team, ok := teamAlias[req.Team]
+	if !ok {
+		http.Error(w, "Invalid team", http.StatusBadRequest)
+		return
+	}
+	if req.Count < 1 || req.Count > MaxTargetDummies {
+		http.Error(w, "Invalid count", http.StatusBadRequest)
+		return
+	}
+
+	ids := s.AddTargetRange(team, req.Count)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"ids": ids})
+}
+
+// HandleCeasefire toggles a ceasefire (POST /api/ceasefire) for moderating or
+// staging a game: while it is on nobody can fire and no damage is dealt, but
+// ships keep moving. The new state is announced to all players.
+func (s *Server) HandleCeasefire(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.gameState.Mu.Lock()
+	s.gameState.Ceasefire = !s.gameState.Ceasefire
+	ceasefire := s.gameState.Ceasefire
+	s.gameState.Mu.Unlock()
+
+	if ceasefire {
+		s.broadcastInfo("🕊️ CEASEFIRE declared by an administrator. Weapons are offline.")
+	} else {
+		s.broadcastInfo("Ceasefire lifted. Weapons are back online.")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"ceasefire": ceasefire})
+}
+
+// HandleTournamentMode forces tournament mode on or off regardless of the
+// player count (POST /api/tmode?on=true|false), for testing t-mode rules with
+// a handful of players. on=auto hands control back to the population check.
+func (s *Server) HandleTournamentMode(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	mode := r.URL.Query().Get("on")
+	if mode != "true" && mode != "false" && mode != "auto" {
+		http.Error(w, "on must be true, false or auto", http.StatusBadRequest)
+		return
+	}
+
+	s.gameState.Mu.Lock()
+	gs := s.gameState
+	switch mode {
+	case "true":
+		gs.T_forced = true
+		if !gs.T_mode {
+			s.startTournament()
+			s.broadcastInfo("⚔️ TOURNAMENT MODE FORCED ON by an administrator. 30 minute time limit.")
+		}
+	case "false":
+		gs.T_forced = true
+		if gs.T_mode {
+			s.stopTournament("Tournament mode forced off by an administrator")
+		}
+	case "auto":
+		gs.T_forced = false
+	}
+	response := map[string]interface{}{"tMode": gs.T_mode, "forced": gs.T_forced}
+	s.gameState.Mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// HandleTeamHandicap lists (GET /api/handicap) or sets (POST) per-team
+// handicaps for mixed-skill matches, overriding -team-handicap. Multipliers
+// left out of a POST are 1, so posting just the team clears its handicap:
+//
+//	{"team": "rom", "damage": 1.25, "fuel": 1.1, "repair": 1}
+func (s *Server) HandleTeamHandicap(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		req := struct {
+			Team string `json:"team"`
+			TeamHandicap
+		}{TeamHandicap: noTeamHandicap}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		team, ok := teamAlias[req.Team]
+		if !ok {
+			http.Error(w, "Invalid team", http.StatusBadRequest)
+			return
+		}
+		if !req.TeamHandicap.Valid() {
+			http.Error(w, fmt.Sprintf("Multipliers must be between %.2f and %.0f", MinTeamHandicap, MaxTeamHandicap), http.StatusBadRequest)
+			return
+		}
+
+		s.gameState.Mu.Lock()
+		if s.teamHandicaps == nil {
+			s.teamHandicaps = make(map[int]TeamHandicap)
+		}
+		s.teamHandicaps[team] = req.TeamHandicap
+		s.gameState.Mu.Unlock()
+
+		s.broadcastInfo(fmt.Sprintf("⚖️ Handicap for %s: damage x%.2f, fuel x%.2f, repair x%.2f",
+			s.config().Map.TeamName(team), req.Damage, req.Fuel, req.Repair))
+	}
+
+	handicaps := make(map[string]TeamHandicap)
+	s.gameState.Mu.RLock()
+	for name, team := range teamAlias {
+		handicaps[name] = s.teamHandicap(team)
+	}
+	s.gameState.Mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"handicaps": handicaps})
+}
@@ -0,0 +1,321 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// TestHandlePlayerDetail verifies the admin player endpoint returns a known
+// bot's state including AI internals, 404s for a free slot, and rejects
+// requests without the admin token.
+func TestHandlePlayerDetail(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AdminToken = "secret"
+	s := &Server{gameState: game.NewGameState(), cfg: &cfg}
+
+	p := s.gameState.Players[3]
+	p.Status = game.StatusAlive
+	p.Name = "[BOT] Kirk"
+	p.Team = game.TeamKli
+	p.X = 12345
+	p.Y = 54321
+	p.Fuel = 4200
+	p.Damage = 17
+	p.Armies = 2
+	p.Cloaked = true
+	p.IsBot = true
+	p.BotTarget = 7
+	p.BotCooldown = 4
+
+	get := func(query, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/player"+query, nil)
+		if token != "" {
+			req.Header.Set("X-Admin-Token", token)
+		}
+		rec := httptest.NewRecorder()
+		s.HandlePlayerDetail(rec, req)
+		return rec
+	}
+
+	rec := get("?id=3", "secret")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var body struct {
+		ID      int     `json:"id"`
+		Name    string  `json:"name"`
+		X       float64 `json:"x"`
+		Fuel    int     `json:"fuel"`
+		Damage  int     `json:"damage"`
+		Armies  int     `json:"armies"`
+		Cloaked bool    `json:"cloaked"`
+		Bot     *struct {
+			Target   int `json:"target"`
+			Cooldown int `json:"cooldown"`
+		} `json:"bot"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.ID != 3 || body.Name != p.Name || body.X != 12345 || body.Fuel != 4200 ||
+		body.Damage != 17 || body.Armies != 2 || !body.Cloaked {
+		t.Errorf("unexpected player fields: %+v", body)
+	}
+	if body.Bot == nil || body.Bot.Target != 7 || body.Bot.Cooldown != 4 {
+		t.Errorf("unexpected bot fields: %+v", body.Bot)
+	}
+
+	if rec := get("?id=5", "secret"); rec.Code != http.StatusNotFound {
+		t.Errorf("free slot status = %d, want 404", rec.Code)
+	}
+	if rec := get("?id=3", "wrong"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("bad token status = %d, want 401", rec.Code)
+	}
+	if rec := get("?id=99", "secret"); rec.Code != http.StatusBadRequest {
+		t.Errorf("out-of-range id status = %d, want 400", rec.Code)
+	}
+}
+
+// TestHandleBotsTargetRange verifies the range pattern lines up the requested
+// number of stationary dummies at fixed spacing, outside team balancing.
+func TestHandleBotsTargetRange(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AdminToken = "secret"
+	s := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/bots", strings.NewReader(`{"pattern":"range","team":"rom","count":5}`))
+	req.Header.Set("X-Admin-Token", "secret")
+	rec := httptest.NewRecorder()
+	s.HandleBots(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+
+	var body struct {
+		IDs []int `json:"ids"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(body.IDs) != 5 {
+		t.Fatalf("spawned %d dummies, want 5", len(body.IDs))
+	}
+
+	s.UpdateBots()
+	for i, id := range body.IDs {
+		p := s.gameState.Players[id]
+		if !p.Dummy || p.Team != game.TeamRom || p.Status != game.StatusAlive {
+			t.Errorf("player %d: dummy=%v team=%d status=%d, want alive Romulan dummy", id, p.Dummy, p.Team, p.Status)
+		}
+		if p.DesSpeed != 0 {
+			t.Errorf("dummy %d should stay stationary, DesSpeed = %v", id, p.DesSpeed)
+		}
+		if i > 0 {
+			prev := s.gameState.Players[body.IDs[i-1]]
+			if p.Y != prev.Y || p.X-prev.X != TargetRangeSpacing {
+				t.Errorf("dummy %d at (%.0f,%.0f), previous at (%.0f,%.0f): want spacing %.0f on one row",
+					i, p.X, p.Y, prev.X, prev.Y, TargetRangeSpacing)
+			}
+		}
+	}
+
+	if counts, _ := s.teamMemberCounts(); counts[game.TeamRom] != 0 {
+		t.Errorf("dummies counted toward team balance: %d", counts[game.TeamRom])
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/bots", strings.NewReader(`{"pattern":"swarm","team":"rom","count":5}`))
+	req.Header.Set("X-Admin-Token", "secret")
+	rec = httptest.NewRecorder()
+	s.HandleBots(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown pattern status = %d, want 400", rec.Code)
+	}
+}
+
+// TestHandleTournamentModeForcesTMode verifies an admin can force tournament
+// mode on with too few players, that the population check does not revert
+// it, and that bots then switch to planet conquest.
+func TestHandleTournamentModeForcesTMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AdminToken = "secret"
+	s := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+	post := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/tmode"+query, nil)
+		req.Header.Set("X-Admin-Token", "secret")
+		rec := httptest.NewRecorder()
+		s.HandleTournamentMode(rec, req)
+		return rec
+	}
+
+	bot := s.gameState.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Connected = true
+	bot.IsBot = true
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.BotPlanetApproachID = -1
+
+	if rec := post("?on=true"); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	s.checkTournamentMode()
+	if !s.gameState.T_mode {
+		t.Fatal("forced tournament mode was reverted by the population check")
+	}
+
+	// A bot carrying armies next to a neutral planet lands them to take it
+	neutral := s.gameState.Planets[20]
+	neutral.Owner = game.TeamNone
+	neutral.Armies = 0
+	bot.X = neutral.X + 500
+	bot.Y = neutral.Y
+	bot.Armies = 2
+	bot.Orbiting = -1
+	s.updateBotHard(bot)
+	if bot.Orbiting != neutral.ID || !bot.Beaming || bot.BeamingUp {
+		t.Errorf("bot should orbit planet %d and beam down: orbiting=%d beaming=%v up=%v",
+			neutral.ID, bot.Orbiting, bot.Beaming, bot.BeamingUp)
+	}
+
+	if post("?on=false"); s.gameState.T_mode || s.gameState.T_remain != 0 {
+		t.Errorf("tournament mode should be forced off with its clock cleared: T_mode=%v T_remain=%d",
+			s.gameState.T_mode, s.gameState.T_remain)
+	}
+	if rec := post("?on=maybe"); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid mode status = %d, want 400", rec.Code)
+	}
+}
+
+// TestHandleCeasefireSuppressesFiring verifies that a ceasefire stops human
+// and bot weapons and damage, and that firing works again once it is lifted.
+func TestHandleCeasefireSuppressesFiring(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AdminToken = "secret"
+	s := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+	toggle := func(want bool) {
+		req := httptest.NewRequest(http.MethodPost, "/api/ceasefire", nil)
+		req.Header.Set("X-Admin-Token", "secret")
+		rec := httptest.NewRecorder()
+		s.HandleCeasefire(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", rec.Code)
+		}
+		if s.gameState.Ceasefire != want {
+			t.Fatalf("ceasefire = %v, want %v", s.gameState.Ceasefire, want)
+		}
+	}
+
+	human := s.gameState.Players[0]
+	human.Status = game.StatusAlive
+	human.Team = game.TeamFed
+	human.Ship = game.ShipCruiser
+	human.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	client := &Client{ID: 1, server: s, send: make(chan ServerMessage, 10)}
+	client.SetPlayerID(0)
+
+	bot := s.gameState.Players[1]
+	bot.Status = game.StatusAlive
+	bot.IsBot = true
+	bot.Team = game.TeamRom
+	bot.Ship = game.ShipCruiser
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.X = human.X + 3000
+
+	fire := []byte(`{"dir": 0}`)
+
+	toggle(true)
+	client.handleFire(fire)
+	s.fireBotTorpedo(bot, human)
+	if len(s.gameState.Torps) != 0 {
+		t.Errorf("%d torpedoes fired during ceasefire, want 0", len(s.gameState.Torps))
+	}
+	if applied := s.applyDamage(human, 50, game.DamageTorp); applied != 0 || human.Damage != 0 {
+		t.Errorf("ceasefire damage applied = %d, want 0", applied)
+	}
+
+	toggle(false)
+	client.handleFire(fire)
+	s.fireBotTorpedo(bot, human)
+	if len(s.gameState.Torps) != 2 {
+		t.Errorf("%d torpedoes fired after ceasefire, want 2", len(s.gameState.Torps))
+	}
+}
+
+// TestPossessBotHandsControlToAdmin verifies that an admin possessing a bot
+// takes it out of UpdateBots and steers it with their own move commands, and
+// that releasing it returns the bot to the AI and the admin to their ship.
+// A game reset ends the possession.
+func TestPossessBotHandsControlToAdmin(t *testing.T) {
+	server, client, admin := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
+	server.cfg.AdminToken = "secret"
+	server.clients[client.ID] = client
+	if !server.AddBot(game.TeamRom, game.ShipDestroyer) {
+		t.Fatal("AddBot failed")
+	}
+	var bot *game.Player
+	for _, p := range server.gameState.Players {
+		if p.IsBot {
+			bot = p
+		}
+	}
+
+	possess := func(token string) *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"player": %d, "bot": %d}`, admin.ID, bot.ID)
+		req := httptest.NewRequest(http.MethodPost, "/api/possess", strings.NewReader(body))
+		req.Header.Set("X-Admin-Token", token)
+		rec := httptest.NewRecorder()
+		server.HandlePossess(rec, req)
+		return rec
+	}
+
+	if rec := possess("wrong"); rec.Code != http.StatusUnauthorized || client.GetPlayerID() != admin.ID || !bot.IsBot {
+		t.Fatalf("a wrong admin token returned %d and must not possess the bot", rec.Code)
+	}
+
+	if rec := possess("secret"); rec.Code != http.StatusOK {
+		t.Fatalf("POST /api/possess returned %d: %s", rec.Code, rec.Body.String())
+	}
+	if client.GetPlayerID() != bot.ID || bot.IsBot || bot.OwnerClientID != client.ID {
+		t.Fatalf("after possessing, client flies %d (IsBot=%v), want bot %d under human control",
+			client.GetPlayerID(), bot.IsBot, bot.ID)
+	}
+
+	bot.DesDir, bot.DesSpeed = 0, 0
+	for i := 0; i < 20; i++ {
+		server.UpdateBots()
+	}
+	if bot.DesDir != 0 || bot.DesSpeed != 0 {
+		t.Errorf("UpdateBots steered the possessed bot to dir %.2f speed %.1f", bot.DesDir, bot.DesSpeed)
+	}
+
+	client.handleMove(json.RawMessage(`{"dir":1.5,"speed":4}`))
+	if bot.DesDir != 1.5 || bot.DesSpeed != 4 {
+		t.Errorf("possessed bot dir %.2f speed %.1f, want the admin's 1.50 and 4", bot.DesDir, bot.DesSpeed)
+	}
+	if admin.DesSpeed != 0 {
+		t.Error("the admin's own ship should not take the move command")
+	}
+
+	client.handleBotCommand("/release")
+	if !bot.IsBot || bot.OwnerClientID != -1 || client.GetPlayerID() != admin.ID {
+		t.Errorf("after /release IsBot=%v owner=%d client flies %d, want the bot back under AI and the admin on %d",
+			bot.IsBot, bot.OwnerClientID, client.GetPlayerID(), admin.ID)
+	}
+	// A game reset sends the admin back to the lobby with nothing to release
+	if rec := possess("secret"); rec.Code != http.StatusOK {
+		t.Fatalf("POST /api/possess returned %d: %s", rec.Code, rec.Body.String())
+	}
+	server.resetGame()
+	if client.possessing || client.GetPlayerID() != -1 {
+		t.Errorf("after a reset possessing=%v and client flies %d, want no possession in the lobby",
+			client.possessing, client.GetPlayerID())
+	}
+}
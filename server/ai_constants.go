@@ -37,12 +37,142 @@ const (
 	PhaserRangeFactor    = 0.8     // Shield when within 80% of enemy phaser range
 	RepairSafetyDistance = 12000.0 // Minimum enemy distance to drop shields for repair (must exceed phaser range + buffer)
 
+	// Panic Retreat Thresholds
+	// A critically damaged bot facing several enemies breaks off and runs home
+	PanicDamageRatio  = 0.75   // Damage fraction at which survival overrides all objectives
+	PanicEnemyRange   = 7000.0 // Range within which enemies count toward being outnumbered
+	PanicMinEnemies   = 2      // Number of close enemies that triggers a panic retreat
+	PanicCloakMinFuel = 1500   // Minimum fuel to cloak while retreating
+
+	// Planet Assault Re-evaluation
+	// A bot abandons its planet approach when the defense is reinforced and it has no support
+	AssaultAbandonScoreRise = 2000.0  // Defense score rise (about two extra defenders) that dooms an approach
+	AssaultSupportRange     = 15000.0 // Allies within this distance count as support
+	AssaultAbandonFrames    = 300     // Frames an abandoned planet is left alone (30 seconds at 10 FPS)
+
+	// Torpedo Detonation
+	// A bot detonates an inbound enemy spread it cannot dodge
+	BotDetonateMinTorps       = 2      // Threatening torpedoes in range before a cornered bot detonates
+	BotDetonateSwarmTorps     = 4      // Threatening torpedoes in range that are detonated even in open space
+	BotDetonateWallMargin     = 3000.0 // Distance from the galaxy edge at which a bot counts as cornered
+	BotDetonateCooldownFrames = 20     // Frames between detonations (2 seconds at 10 FPS)
+
+	// Deadly Galaxy Edge
+	// Under the kill edge rule bots keep clear of the edge rather than
+	// bouncing off it
+	DeadlyEdgeMargin        = 6000.0 // Distance from a deadly edge at which bots turn back (a scout at full speed needs about 4200 to reverse)
+	DeadlyEdgeTargetPenalty = 4000.0 // Target score penalty for enemies inside DeadlyEdgeMargin
+
+	// Contested Capture
+	// A carrier beaming down while an enemy beams at the same planet fights for it
+	ContestedBeamCooldown = 3 // Frames between decisions (and shots at the rival beamer) during a capture race
+
+	// Scout Intel
+	// A team's scout bot touches orbit at unscouted enemy planets and avoids fights
+	ScoutEvadeRange = 6000.0 // Enemies closer than this make the scout turn away
+
+	// Interceptor
+	// On teams with enough bots, one watches the border for enemy army carriers
+	InterceptorMinTeamBots  = 3       // Team bots needed before one is spared to intercept
+	InterceptorWatchRange   = 20000.0 // Carriers this close to a friendly planet and heading in are intercepted
+	InterceptorEngageRange  = 6000.0  // Distance at which the interceptor stops leading and fights
+	InterceptorPatrolRadius = 5000.0  // How far the interceptor strays from the border planet it watches
+
+	// Space Control
+	// On teams with enough bots, the toughest one holds the galaxy center
+	SpaceControlMinTeamBots = 4      // Team bots needed before one is spared to hold the center
+	SpaceControlRadius      = 8000.0 // How far from the galaxy center the space controller may stray
+	SpaceControlEngageRange = 7000.0 // Enemies this close are fired on from the zone
+	SpaceControlHoldSpeed   = 2.0    // Speed while facing an intruder
+
+	// Fuel Recharge
+	// A bot low on fuel with no enemy close breaks off to recharge before re-engaging
+	RechargeThreatRange = 8000.0 // Enemies closer than this keep the bot fighting (or end the recharge)
+	RechargeFullFuel    = 0.9    // Fraction of max fuel at which the bot re-engages
+
+	// Screen
+	// Bots near a human teammate working an enemy planet cover the assault
+	ScreenSupportRange = 10000.0 // Humans this close to the bot get a screen
+	ScreenThreatRange  = 15000.0 // Enemies this close to the planet are screened off
+	ScreenEngageRange  = 6000.0  // Distance at which the screen fights the defender
+	ScreenStandoff     = 3000.0  // Distance from the planet at which the screen takes station
+	ScreenMaxPerHuman  = 2       // Bots screening one human; the closest ones take the job
+	ScreenOrbitStep    = 0.3     // Radians the screen advances round the planet per decision when no defender is near
+
+	// Survival Assault
+	// Attacking bots in survival mode converge on the defended starbase
+	SurvivalEscortRange = 3000.0  // Defenders this close are fought before pressing on to the base
+	SurvivalEngageRange = 10000.0 // Distance at which attackers open fire on the base
+
+	// Team Commander
+	// Each team's bots share an objective the commander re-evaluates periodically
+	CommanderIntervalFrames = 50     // Frames between commander decisions (5 seconds at 10 FPS)
+	CommanderDefendThreat   = 150.0  // Planet threat score (see getPlanetThreats) that makes the team defend it
+	CommanderHuntRatio      = 0.6    // Share of the galaxy's planets above which the team hunts ships instead of pushing planets
+	CommanderHuntBonus      = 5000.0 // Target score bonus for the team's hunt target
+	CommanderOrderShare     = 0.5    // Share of the team's bots, closest to the objective first, that carry out the order
+
+	// Squads
+	// Each team's bots are split into offense and defense squads, rebalanced periodically
+	SquadRebalanceFrames = 300       // Frames between squad rebalances (30 seconds at 10 FPS)
+	SquadDefenseShare    = 0.5       // Share of the team's bots on defense in an even game
+	SquadShareStep       = 1.0 / 6.0 // Defense share lost when dominant, gained per threatened planet
+	SquadMaxDefenseShare = 0.75      // Most of the team ever held back on defense
+
+	// Torpedo Mining
+	// A planet defender lays a torpedo wall across an inbound enemy's approach
+	MineSpreadTorps = 4      // Torpedoes in the wall
+	MineMinRange    = 4000.0 // Enemies closer than this are fired on directly
+	MineApproachCos = 0.7    // Minimum cosine between an enemy's heading and the bearing to the planet (about 45 degrees)
+	MineMinFuel     = 2000   // Fuel a defender keeps in hand before spending torps on a wall
+
+	// Target Memory
+	// Bots search where a target was last seen for a short while after it
+	// cloaks or slips out of sensor range
+	TargetMemoryFrames     = 50      // Frames a sighting is remembered (5 seconds at 10 FPS)
+	TargetSearchArriveDist = 1500.0  // Distance from the last known position at which the search ends
+	TargetSensorRange      = 25000.0 // Range beyond which bots do not pick up new targets
+
+	// Beaming
+	BotBeamBatch = 10 // Armies a bot expects to beam before re-evaluating
+
+	// Planet Fire
+	PlanetFireRiskWeight = 5000.0 // Planet selection penalty per ship's worth of damage taken crossing a planet's fire zone
+
+	// Cloaking
+	BotCloakReserveFrames = 100 // Frames of cloak fuel a bot wants in hand before cloaking (10 seconds at 10 FPS)
+
+	// Jamming
+	// Against an ECM target, bots lose its velocity and fire unguided spreads
+	ECMSpreadTorps = 3   // Minimum torpedoes per volley at a jamming target
+	ECMAimError    = 0.4 // Maximum aim error in radians, either way, against a jamming target (about 23 degrees)
+
+	// Plasma/Torpedo Combo
+	// Plasma-armed bots lead with plasma, then torp the target's dodge path
+	ComboTorpCount          = 3    // Torpedoes in the follow-up spread
+	ComboDodgeSpeedFraction = 0.5  // Minimum fraction of top speed a dodging target is assumed to reach
+	ComboReactTicks         = 2.0  // Ticks before plasma impact at which the target is assumed to break
+	ComboMinFuelReserve     = 1500 // Fuel left after the combo so the bot can still shield and maneuver
+
+	// Plasma Area Denial
+	// Plasma-armed defenders guarding a frontline planet mine the lane an
+	// enemy is using to approach it
+	DenialGuardRange     = 8000.0  // Farthest a bot may be from the chokepoint planet it guards
+	DenialApproachRange  = 12000.0 // Farthest an enemy may be from the chokepoint to count as approaching
+	DenialHeadingCos     = 0.8     // Minimum cosine between the enemy's heading and the line to the chokepoint (about 37 degrees)
+	DenialMinFuel        = 3000    // Fuel a bot must hold to spend a plasma on area denial
+	DenialCooldownFrames = 50      // Frames between area denial plasmas (5 seconds at 10 FPS)
+
+	// Tractor Response
+	TractorCounterMinFuel = 1000 // Minimum fuel to counter an enemy tractor with a pressor
+
 	// Team Coordination Thresholds
 	BroadcastTargetMinValue = 15000.0 // Minimum target score to broadcast to allies
 	BroadcastTargetRange    = 15000.0 // Maximum distance to broadcast target suggestions
 
 	// Ally Separation Thresholds
-	// These control how bots maintain distance from teammates
+	// These control how bots maintain distance from teammates (defaults for
+	// the Config.BotSep* distances)
 	SepMinSafeDistance  = 4000.0 // Maximum range to consider allies for separation
 	SepIdealDistance    = 2500.0 // Ideal spacing between bots
 	SepCriticalDistance = 1200.0 // Emergency separation distance
@@ -69,7 +199,7 @@ const (
 	TargetDecloakBonus     = 2000.0  // Bonus for cloaked targets within close range
 	TargetIsolatedBonus    = 2000.0  // Bonus for targets with no nearby allies
 	TargetPersistenceBonus = 3000.0  // Bonus for keeping current target (prevents thrashing)
-	TargetCloakDetectRange = 2000.0  // Range within which cloaked ships are worth attacking
+	TargetCloakDetectRange = 2000.0  // Range within which cloaked ships are worth attacking (default for Config.CloakDetectRange)
 	IsolationRange         = 5000.0  // Range to check for nearby allies when determining isolation
 
 	// Planet Strategy Thresholds
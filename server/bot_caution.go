@@ -0,0 +1,67 @@
+package server
+
+import "github.com/lab1702/netrek-web/game"
+
+// Bot caution profiles selected by Config.BotCaution or per bot with /addbot.
+const (
+	CautionConservative = "conservative" // Shields early and heads home for repair and fuel with plenty in hand
+	CautionBalanced     = "balanced"     // The classic thresholds
+	CautionAggressive   = "aggressive"   // Fights on low fuel and heavy damage before breaking off
+)
+
+// cautionProfile holds the fuel and damage thresholds behind a bot's shield
+// and repair decisions.
+type cautionProfile struct {
+	FuelCritical int // Below this fuel shields are dropped outright
+	FuelLow      int // Fuel needed to shield against immediate and close threats
+	FuelModerate int // Fuel needed to shield against high threats and nearby enemies
+	FuelGood     int // Fuel needed to shield against medium threats
+
+	RepairDamage float64 // Fraction of max damage at which the bot goes to repair
+	RefuelFuel   float64 // Fraction of max fuel below which the bot goes to refuel
+}
+
+var cautionProfiles = map[string]cautionProfile{
+	CautionConservative: {
+		FuelCritical: 800,
+		FuelLow:      1200,
+		FuelModerate: 2000,
+		FuelGood:     3000,
+		RepairDamage: 0.35,
+		RefuelFuel:   0.5,
+	},
+	CautionBalanced: {
+		FuelCritical: FuelCritical,
+		FuelLow:      FuelLow,
+		FuelModerate: FuelModerate,
+		FuelGood:     FuelGood,
+		RepairDamage: 0.5,
+		RefuelFuel:   1.0 / 3,
+	},
+	CautionAggressive: {
+		FuelCritical: 200,
+		FuelLow:      300,
+		FuelModerate: 800,
+		FuelGood:     1200,
+		RepairDamage: 0.7,
+		RefuelFuel:   0.2,
+	},
+}
+
+// ValidCaution reports whether name is a known caution profile.
+func ValidCaution(name string) bool {
+	_, ok := cautionProfiles[name]
+	return ok
+}
+
+// botCaution returns the bot's own caution profile, falling back to the
+// server-wide Config.BotCaution and then to the balanced profile.
+func (s *Server) botCaution(p *game.Player) cautionProfile {
+	if c, ok := cautionProfiles[p.BotCaution]; ok {
+		return c
+	}
+	if c, ok := cautionProfiles[s.config().BotCaution]; ok {
+		return c
+	}
+	return cautionProfiles[CautionBalanced]
+}
@@ -10,18 +10,19 @@ func (s *Server) engageCombat(p *game.Player, target *game.Player, dist float64)
 	shipStats := game.ShipData[p.Ship]
 	targetStats := game.ShipData[target.Ship]
 
+	s.rememberTarget(p, target)
+
 	// Consider breaking orbit when entering combat
 	if p.Orbiting >= 0 {
 		// Only break orbit if the planet doesn't need bombing or threat is extreme
-		if p.Orbiting < game.MaxPlanets {
+		if p.Orbiting < game.MaxPlanets && s.gameState.Planets[p.Orbiting] != nil {
 			planet := s.gameState.Planets[p.Orbiting]
 			// Only leave if planet is friendly, has no armies, or we're in extreme danger
 			if planet.Owner == p.Team || planet.Armies == 0 ||
 				(dist < 2000 && p.Damage > game.ShipData[p.Ship].MaxDamage/2) {
 				p.Orbiting = -1
 				p.Bombing = false
-				p.Beaming = false
-				p.BeamingUp = false
+				stopBeaming(p)
 			} else if planet.Owner != p.Team && planet.Armies > 0 && dist > 4000 {
 				// Continue bombing enemy planet if threat is not immediate
 				p.Bombing = true
@@ -31,13 +32,12 @@ func (s *Server) engageCombat(p *game.Player, target *game.Player, dist float64)
 		} else {
 			p.Orbiting = -1
 			p.Bombing = false
-			p.Beaming = false
-			p.BeamingUp = false
+			stopBeaming(p)
 		}
 	}
 
 	// Check for nearby allies to avoid bunching up
-	separationVector := s.calculateSeparationVector(p)
+	separationVector := s.botSeparation(p)
 
 	// Calculate intercept course with enhanced prediction
 	interceptDir := s.calculateEnhancedInterceptCourse(p, target)
@@ -94,21 +94,22 @@ func (s *Server) engageCombat(p *game.Player, target *game.Player, dist float64)
 	// Cloaking tactics for scouts and destroyers — decide before weapons
 	// so that a cloaking bot doesn't fire and cloak in the same tick.
 	if (p.Ship == game.ShipScout || p.Ship == game.ShipDestroyer) && p.Fuel > 3000 {
-		if s.shouldUseCloaking(p, target, dist) {
+		if s.shouldUseCloaking(p, target, dist) && !s.recloakBlocked(p) {
 			p.Cloaked = true
 		} else if p.Cloaked && (p.Fuel < 1500 || dist < 1000) {
 			p.Cloaked = false
 		}
 	}
 
-	// Weapon usage — no facing restrictions needed.
+	// Weapon usage — facing only matters under Config.FireArc, where
+	// turnToFire brings the bow round before a shot.
 	// NOTE: fuel/temp/count checks below intentionally duplicate guards inside
 	// fireBotTorpedo/fireBotPhaser. The caller checks use strategic thresholds
 	// (e.g. reserve torp slots for spreads, higher fuel buffers) and control the
 	// firedTorps/firedPhaser flags that sequence weapon priority.
 
 	// Enhanced torpedo firing with prediction and spread patterns
-	// Torpedoes can be fired in any direction regardless of ship facing
+	// Torpedoes can be fired in any direction unless Config.FireArc applies
 	// Use velocity-adjusted range to prevent fuse expiry on fast targets
 	effectiveTorpRange := s.getVelocityAdjustedTorpRange(p, target)
 
@@ -120,7 +121,24 @@ func (s *Server) engageCombat(p *game.Player, target *game.Player, dist float64)
 	targetDamageRatio := float64(target.Damage) / float64(targetStats.MaxDamage)
 	burstFireMode := targetDamageRatio > 0.7 && dist < effectiveTorpRange*0.6 // Burst when target is heavily damaged and in close range
 	firedTorps := false
-	if canReachTarget && dist < effectiveTorpRange && p.NumTorps < game.MaxTorps-2 && p.Fuel > 1500 && p.WTemp < shipStats.MaxWpnTemp-100 {
+	firedCombo := false
+
+	// Plasma-armed ships chain weapons: plasma first to force a dodge, then a
+	// torpedo spread into the dodge path (skipped when finishing off a target)
+	plasmaCost := shipStats.PlasmaDamage * shipStats.PlasmaFuelMult
+	comboCost := plasmaCost + ComboTorpCount*shipStats.TorpDamage*shipStats.TorpFuelMult
+	if shipStats.HasPlasma && !burstFireMode && canReachTarget && dist < effectiveTorpRange &&
+		dist > s.effectivePlasmaRange(p.Ship, 0.30) && dist < s.maxPlasmaRange(p.Ship) &&
+		p.NumPlasma < shipStats.MaxPlasma && p.NumTorps <= game.MaxTorps-ComboTorpCount &&
+		p.Fuel >= comboCost+ComboMinFuelReserve && p.WTemp < shipStats.MaxWpnTemp-300 {
+		if s.firePlasmaTorpCombo(p, target) {
+			p.BotCooldown = 12
+			firedTorps = true
+			firedCombo = true
+		}
+	}
+
+	if !firedCombo && canReachTarget && dist < effectiveTorpRange && p.NumTorps < game.MaxTorps-2 && p.Fuel > 1500 && p.WTemp < shipStats.MaxWpnTemp-100 {
 		if burstFireMode && p.NumTorps < game.MaxTorps-6 && p.Fuel > 2500 {
 			// Burst fire mode - rapid successive torpedoes for kill securing
 			s.fireTorpedoSpread(p, target, 4) // Fire 4-torpedo burst
@@ -142,7 +160,7 @@ func (s *Server) engageCombat(p *game.Player, target *game.Player, dist float64)
 
 	// Fire when enemy is running away - only if we didn't already fire torpedoes above
 	if !firedTorps && canReachTarget && dist < effectiveTorpRange && p.NumTorps < game.MaxTorps-3 && p.Fuel > 1000 {
-		targetAngleToUs := math.Atan2(p.Y-target.Y, p.X-target.X)
+		targetAngleToUs := s.bearing(target.X, target.Y, p.X, p.Y)
 		// AngleDifference fully normalizes the wrap; a manual single-fold
 		// (abs then "if > π subtract from 2π") leaves a negative result when
 		// Dir (in [0,2π)) and atan2 (in (-π,π]) differ by more than 2π.
@@ -154,7 +172,7 @@ func (s *Server) engageCombat(p *game.Player, target *game.Player, dist float64)
 	}
 
 	// Enhanced phaser timing with kill securing — only if no torps fired this tick
-	// Phasers can be fired in any direction regardless of ship facing
+	// Phasers can be fired in any direction unless Config.FireArc applies
 	firedPhaser := false
 	if !firedTorps {
 		myPhaserRange := game.PhaserRange(shipStats)
@@ -162,7 +180,7 @@ func (s *Server) engageCombat(p *game.Player, target *game.Player, dist float64)
 			phaserCost := shipStats.PhaserDamage * shipStats.PhaserFuelMult
 			if p.Fuel >= phaserCost && p.WTemp < shipStats.MaxWpnTemp-100 { // Match human firing threshold
 				// Calculate if phaser would be a kill shot
-				phaserDamage := float64(shipStats.PhaserDamage) * (1.0 - dist/myPhaserRange)
+				phaserDamage := game.PhaserDamageAt(shipStats, dist)
 				wouldKill := target.Damage+int(phaserDamage) >= targetStats.MaxDamage
 
 				// More aggressive phaser usage
@@ -182,13 +200,12 @@ func (s *Server) engageCombat(p *game.Player, target *game.Player, dist float64)
 	// Gate on the ship's actual plasma fuel cost (not a coarse 3000 constant) so a
 	// heavy ship whose cost exceeds 3000 (e.g. Battleship = 3900) doesn't commit to
 	// the plasma branch when it cannot afford the shot.
-	plasmaCost := shipStats.PlasmaDamage * shipStats.PlasmaFuelMult
-	if !firedTorps && !firedPhaser && shipStats.HasPlasma && p.NumPlasma < 1 && p.Fuel >= plasmaCost {
+	if !firedTorps && !firedPhaser && shipStats.HasPlasma && p.NumPlasma < shipStats.MaxPlasma && p.Fuel >= plasmaCost {
 		// Use actual plasma maximum range to prevent fuse expiry
-		maxPlasmaRange := game.MaxPlasmaRangeForShip(p.Ship)
-		plasmaLongRange := game.EffectivePlasmaRange(p.Ship, 0.85)  // 85% of max for long range
-		plasmaShortRange := game.EffectivePlasmaRange(p.Ship, 0.30) // 30% of max for minimum range
-		plasmaKillRange := game.EffectivePlasmaRange(p.Ship, 0.75)  // 75% of max for kill shots
+		maxPlasmaRange := s.maxPlasmaRange(p.Ship)
+		plasmaLongRange := s.effectivePlasmaRange(p.Ship, 0.85)  // 85% of max for long range
+		plasmaShortRange := s.effectivePlasmaRange(p.Ship, 0.30) // 30% of max for minimum range
+		plasmaKillRange := s.effectivePlasmaRange(p.Ship, 0.75)  // 75% of max for kill shots
 
 		// Only fire if target is within actual plasma range
 		if dist < maxPlasmaRange &&
@@ -228,12 +245,12 @@ func (s *Server) defendWhileCarrying(p, enemy *game.Player) {
 	}
 
 	shipStats := game.ShipData[p.Ship]
-	dist := game.Distance(p.X, p.Y, enemy.X, enemy.Y)
+	dist := s.distance(p.X, p.Y, enemy.X, enemy.Y)
 
 	// Try to maintain distance while carrying
 	if dist < 3000 {
 		// Enemy too close - defensive maneuvers
-		angleAway := math.Atan2(p.Y-enemy.Y, p.X-enemy.X)
+		angleAway := s.bearing(enemy.X, enemy.Y, p.X, p.Y)
 		p.DesDir = angleAway
 		p.DesSpeed = float64(shipStats.MaxSpeed)
 
@@ -278,7 +295,10 @@ func (s *Server) assessUniversalThreats(p *game.Player) CombatThreat {
 	}
 	var nearbyPlanets []nearbyPlanet
 	for _, planet := range s.gameState.Planets {
-		if game.Distance(p.X, p.Y, planet.X, planet.Y) < 10000 {
+		if planet == nil {
+			continue
+		}
+		if s.distance(p.X, p.Y, planet.X, planet.Y) < 10000 {
 			nearbyPlanets = append(nearbyPlanets, nearbyPlanet{x: planet.X, y: planet.Y})
 		}
 	}
@@ -286,8 +306,8 @@ func (s *Server) assessUniversalThreats(p *game.Player) CombatThreat {
 	// Enhanced torpedo checking for all movement scenarios.
 	// Also computes shield-specific threat values in the same pass.
 	for _, torp := range s.gameState.Torps {
-		if torp.Owner != p.ID && torp.Team != p.Team && torp.Status == game.TorpMove {
-			dist := game.Distance(p.X, p.Y, torp.X, torp.Y)
+		if s.projectileThreatens(p, torp) {
+			dist := s.distance(p.X, p.Y, torp.X, torp.Y)
 			if dist < threat.closestTorpDist {
 				threat.closestTorpDist = dist
 			}
@@ -306,6 +326,9 @@ func (s *Server) assessUniversalThreats(p *game.Player) CombatThreat {
 			if isThreatening {
 				threat.requiresEvasion = true
 				threat.threatLevel += 4
+				if dist <= float64(game.PhaserDist) && torp.Team != p.Team {
+					threat.detonatableTorps++
+				}
 
 				// Shield scoring: trajectory-confirmed threatening torpedoes
 				if dist < TorpedoClose {
@@ -328,7 +351,7 @@ func (s *Server) assessUniversalThreats(p *game.Player) CombatThreat {
 				// dangerous in contested planet areas. Uses pre-computed
 				// nearby planets to avoid O(torps * planets) iteration.
 				for _, np := range nearbyPlanets {
-					if game.Distance(torp.X, torp.Y, np.x, np.y) < 10000 {
+					if s.distance(torp.X, torp.Y, np.x, np.y) < 10000 {
 						threat.threatLevel += baseThreatIncrease // Double the proximity bonus near planets
 						break
 					}
@@ -339,8 +362,8 @@ func (s *Server) assessUniversalThreats(p *game.Player) CombatThreat {
 
 	// Check plasma threats (skip friendly plasma)
 	for _, plasma := range s.gameState.Plasmas {
-		if plasma.Owner != p.ID && plasma.Team != p.Team && plasma.Status == game.TorpMove {
-			dist := game.Distance(p.X, p.Y, plasma.X, plasma.Y)
+		if s.projectileThreatens(p, plasma) {
+			dist := s.distance(p.X, p.Y, plasma.X, plasma.Y)
 			if dist < threat.closestPlasma {
 				threat.closestPlasma = dist
 			}
@@ -366,7 +389,7 @@ func (s *Server) assessUniversalThreats(p *game.Player) CombatThreat {
 	// handled separately via the bot hit timer.
 	for _, enemy := range s.gameState.Players {
 		if enemy.Status == game.StatusAlive && enemy.Team != p.Team && !enemy.Cloaked {
-			dist := game.Distance(p.X, p.Y, enemy.X, enemy.Y)
+			dist := s.distance(p.X, p.Y, enemy.X, enemy.Y)
 
 			// Track closest enemy (for shield decisions)
 			if dist < threat.closestEnemyDist {
@@ -379,7 +402,7 @@ func (s *Server) assessUniversalThreats(p *game.Player) CombatThreat {
 
 				// Check if enemy is facing us (potential phaser threat).
 				// Use AngleDifference for a fully-normalized wrap.
-				angleToUs := math.Atan2(p.Y-enemy.Y, p.X-enemy.X)
+				angleToUs := s.bearing(enemy.X, enemy.Y, p.X, p.Y)
 				angleDiff := AngleDifference(enemy.Dir, angleToUs)
 				if dist < 2000 && angleDiff < math.Pi/6 {
 					threat.requiresEvasion = true
@@ -415,7 +438,7 @@ func (s *Server) assessUniversalThreats(p *game.Player) CombatThreat {
 // isTorpedoThreatening checks if a torpedo poses a real threat using enhanced prediction
 func (s *Server) isTorpedoThreatening(p *game.Player, torp *game.Torpedo) bool {
 	// Distance check - only consider nearby torpedoes
-	dist := game.Distance(p.X, p.Y, torp.X, torp.Y)
+	dist := s.distance(p.X, p.Y, torp.X, torp.Y)
 	if dist > 5000 { // Increased detection range to catch more threats
 		return false
 	}
@@ -446,7 +469,7 @@ func (s *Server) isTorpedoThreatening(p *game.Player, torp *game.Torpedo) bool {
 		futPlayerY := p.Y + playerVelY*t
 
 		// Check for collision with larger safety margin
-		collisionDist := game.Distance(futPlayerX, futPlayerY, futTorpX, futTorpY)
+		collisionDist := s.distance(futPlayerX, futPlayerY, futTorpX, futTorpY)
 		if collisionDist < 800 { // Increased safety threshold from 600 to 800
 			return true
 		}
@@ -454,8 +477,7 @@ func (s *Server) isTorpedoThreatening(p *game.Player, torp *game.Torpedo) bool {
 
 	// Also check if torpedo is generally heading towards our area
 	// Vector from torpedo to player
-	dx := p.X - torpX
-	dy := p.Y - torpY
+	dx, dy := s.delta(torpX, torpY, p.X, p.Y)
 	angleToPlayer := math.Atan2(dy, dx)
 
 	// If torpedo is heading towards us, it's a threat
@@ -507,7 +529,7 @@ func (s *Server) selectCombatManeuver(p, target *game.Player, dist float64, inte
 		// Close range - use angular velocity matching for dogfight
 		if maneuverAdvantage > 0 {
 			// We turn better - circle strafe
-			perpendicularAngle := math.Atan2(target.Y-p.Y, target.X-p.X) + math.Pi/2
+			perpendicularAngle := s.bearing(p.X, p.Y, target.X, target.Y) + math.Pi/2
 			maneuver.direction = perpendicularAngle
 			maneuver.speed = float64(shipStats.MaxSpeed) * 0.7
 			maneuver.maneuver = "circle-strafe"
@@ -515,7 +537,7 @@ func (s *Server) selectCombatManeuver(p, target *game.Player, dist float64, inte
 			// They turn better - maintain distance
 			if speedAdvantage > 0 {
 				// We're faster - boom and zoom
-				angleAway := math.Atan2(p.Y-target.Y, p.X-target.X)
+				angleAway := s.bearing(target.X, target.Y, p.X, p.Y)
 				maneuver.direction = angleAway
 				maneuver.speed = float64(shipStats.MaxSpeed)
 				maneuver.maneuver = "boom-zoom"
@@ -546,9 +568,10 @@ func (s *Server) assessAndActivateShields(p *game.Player) {
 		return
 	}
 	p.BotShieldFrame = s.gameState.Frame
+	caution := s.botCaution(p)
 
 	// Don't shield if critically low on fuel (emergency threshold)
-	if p.Fuel < FuelCritical {
+	if p.Fuel < caution.FuelCritical {
 		p.Shields_up = false
 		return
 	}
@@ -560,33 +583,106 @@ func (s *Server) assessAndActivateShields(p *game.Player) {
 	shouldShield := false
 
 	// Immediate threats - shield if we have minimal fuel (much lower threshold)
-	if threat.immediateThreat && p.Fuel > FuelLow {
+	if threat.immediateThreat && p.Fuel > caution.FuelLow {
 		shouldShield = true
-	} else if threat.shieldThreatLevel >= ThreatLevelImmediate && p.Fuel > FuelModerate {
+	} else if threat.shieldThreatLevel >= ThreatLevelImmediate && p.Fuel > caution.FuelModerate {
 		// High threat level - shield up
 		shouldShield = true
-	} else if threat.shieldThreatLevel >= ThreatLevelMedium && p.Fuel > FuelGood {
+	} else if threat.shieldThreatLevel >= ThreatLevelMedium && p.Fuel > caution.FuelGood {
 		// Medium threat with good fuel reserves
 		shouldShield = true
-	} else if threat.closestTorpDist < TorpedoVeryClose && p.Fuel > FuelLow {
+	} else if threat.closestTorpDist < TorpedoVeryClose && p.Fuel > caution.FuelLow {
 		// Torpedo very close - be defensive with lower fuel requirement
 		shouldShield = true
-	} else if threat.closestEnemyDist < EnemyClose && p.Fuel > FuelModerate {
+	} else if threat.closestEnemyDist < EnemyClose && p.Fuel > caution.FuelModerate {
 		// Enemy nearby - be prepared with moderate fuel requirement
 		shouldShield = true
 	}
 
 	// Special case: always shield when carrying armies and threatened (lower fuel requirement)
-	if p.Armies > 0 && (threat.closestEnemyDist < ArmyCarryingRange || threat.closestTorpDist < TorpedoClose) && p.Fuel > FuelLow {
+	if p.Armies > 0 && (threat.closestEnemyDist < ArmyCarryingRange || threat.closestTorpDist < TorpedoClose) && p.Fuel > caution.FuelLow {
 		shouldShield = true
 	}
 
 	// Special case: shield during planet defense when enemies are close (lower fuel requirement)
-	if p.BotDefenseTarget >= 0 && (threat.closestEnemyDist < DefenseShieldRange || threat.closestTorpDist < TorpedoVeryClose) && p.Fuel > FuelLow {
+	if p.BotDefenseTarget >= 0 && (threat.closestEnemyDist < DefenseShieldRange || threat.closestTorpDist < TorpedoVeryClose) && p.Fuel > caution.FuelLow {
 		shouldShield = true
 	}
 
+	// Under the shields-down rule an orbiting bot that needs its shields
+	// leaves orbit to fight; otherwise they stay down to hold the orbit. An
+	// assisted human keeps the orbit they chose.
+	if shouldShield && p.Orbiting >= 0 && s.config().ShieldsDownToOrbit {
+		if p.IsBot {
+			p.Orbiting = -1
+			p.Bombing = false
+			stopBeaming(p)
+		} else {
+			shouldShield = false
+		}
+	}
+
 	p.Shields_up = shouldShield
+
+	// Bots overcharge failing shields when under immediate attack
+	if p.IsBot && shouldShield && threat.immediateThreat && p.SpecialTimer == 0 && s.config().ShipSpecials {
+		shipStats := game.ShipData[p.Ship]
+		if shipStats.Special == game.SpecialShieldBoost && p.Shields < shipStats.MaxShields/4 {
+			s.useSpecial(p)
+		}
+	}
+}
+
+// findTractoringEnemy returns the enemy currently holding p in a tractor
+// beam, or nil if nobody is.
+func (s *Server) findTractoringEnemy(p *game.Player) *game.Player {
+	for _, other := range s.gameState.Players {
+		if other.Status == game.StatusAlive && other.Team != p.Team && other.Tractoring == p.ID {
+			return other
+		}
+	}
+	return nil
+}
+
+// breakTractor responds to being held in an enemy tractor beam. The bot
+// abandons its current plan, pushes back with its own pressor when it can,
+// turns away at full speed, and makes the tractoring ship its priority target.
+func (s *Server) breakTractor(p, tractorer *game.Player) {
+	shipStats := game.ShipData[p.Ship]
+	dist := s.distance(p.X, p.Y, tractorer.X, tractorer.Y)
+
+	p.Orbiting = -1
+	p.Bombing = false
+	stopBeaming(p)
+	cancelRepair(p)
+
+	// Counter the tractor with a pressor if in beam range and fuel allows
+	p.Tractoring = -1
+	if !p.Cloaked && p.Fuel > TractorCounterMinFuel &&
+		dist <= float64(game.TractorDist)*shipStats.TractorRange {
+		p.Pressoring = tractorer.ID
+	}
+
+	// Turn away and run at full speed
+	p.DesDir = s.bearing(tractorer.X, tractorer.Y, p.X, p.Y)
+	p.DesSpeed = float64(shipStats.MaxSpeed)
+
+	// The tractoring ship becomes the priority target
+	if p.BotTarget != tractorer.ID {
+		p.BotTarget = tractorer.ID
+		p.BotTargetValue = 0
+	}
+	p.BotTargetLockTime = 30
+
+	if p.NumTorps < game.MaxTorps && dist < s.getVelocityAdjustedTorpRange(p, tractorer) &&
+		s.canTorpReachTarget(p, tractorer) {
+		s.fireBotTorpedo(p, tractorer)
+	}
+	if dist < game.PhaserRange(shipStats) {
+		s.fireBotPhaser(p, tractorer)
+	}
+
+	p.BotCooldown = 3
 }
 
 // shouldUseCloaking determines if bot should cloak
@@ -596,6 +692,11 @@ func (s *Server) shouldUseCloaking(p, target *game.Player, dist float64) bool {
 		return false
 	}
 
+	// Don't cloak without fuel to stay hidden for a while at the current cost
+	if p.Fuel < s.cloakCost(p)*BotCloakReserveFrames {
+		return false
+	}
+
 	shipStats := game.ShipData[p.Ship]
 	damageRatio := float64(p.Damage) / float64(shipStats.MaxDamage)
 
@@ -611,3 +712,47 @@ func (s *Server) shouldUseCloaking(p, target *game.Player, dist float64) bool {
 
 	return false
 }
+
+// rememberTarget records where a visible target was last seen so the bot can
+// search for it briefly if it cloaks.
+func (s *Server) rememberTarget(p *game.Player, target *game.Player) {
+	if target.Cloaked {
+		return
+	}
+	p.BotMemoryTarget = target.ID
+	p.BotMemoryX = target.X
+	p.BotMemoryY = target.Y
+	p.BotMemoryUntil = s.gameState.Frame + TargetMemoryFrames
+}
+
+// searchLastKnownTarget steers the bot toward the last known position of a
+// remembered target that has since cloaked or left sensor range, rather than
+// forgetting it the moment it vanishes. Returns true while the search is under
+// way; the memory is dropped once it expires or the bot reaches the spot.
+func (s *Server) searchLastKnownTarget(p *game.Player) bool {
+	if s.gameState.Frame >= p.BotMemoryUntil {
+		return false
+	}
+	if p.BotMemoryTarget < 0 || p.BotMemoryTarget >= game.MaxPlayers {
+		return false
+	}
+	target := s.gameState.Players[p.BotMemoryTarget]
+	if target.Status != game.StatusAlive || target.Team == p.Team {
+		return false
+	}
+	if !target.Cloaked && s.distance(p.X, p.Y, target.X, target.Y) <= TargetSensorRange {
+		return false
+	}
+
+	dist := s.distance(p.X, p.Y, p.BotMemoryX, p.BotMemoryY)
+	if dist < TargetSearchArriveDist {
+		p.BotMemoryUntil = 0
+		return false
+	}
+
+	p.Orbiting = -1
+	baseDir := s.bearing(p.X, p.Y, p.BotMemoryX, p.BotMemoryY)
+	s.applySafeNavigation(p, baseDir, float64(game.ShipData[p.Ship].MaxSpeed))
+	p.BotCooldown = 3
+	return true
+}
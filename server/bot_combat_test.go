@@ -1,6 +1,7 @@
 package server
 
 import (
+	"math"
 	"testing"
 
 	"github.com/lab1702/netrek-web/game"
@@ -203,3 +204,221 @@ func TestAssessThreatsIgnoresCloakedEnemies(t *testing.T) {
 		}
 	})
 }
+
+// TestAssessThreatsHandlesTeammateTorpedoes verifies bots treat a teammate's
+// torpedo as a threat exactly when it could hit them: only in free-for-all
+// mode, even if the torpedo was fired during a free-for-all spell since ended.
+func TestAssessThreatsHandlesTeammateTorpedoes(t *testing.T) {
+	setup := func(freeForAll bool) (*Server, *game.Player) {
+		cfg := DefaultConfig()
+		cfg.FreeForAll = freeForAll
+		s := &Server{gameState: game.NewGameState(), cfg: &cfg}
+		for i := range s.gameState.Players {
+			s.gameState.Players[i] = &game.Player{ID: i, Status: game.StatusFree}
+		}
+		bot := s.gameState.Players[0]
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = game.ShipCruiser
+		bot.X, bot.Y = 50000, 50000
+
+		mate := s.gameState.Players[1]
+		mate.Status = game.StatusAlive
+		mate.Team = game.TeamFed
+		mate.Ship = game.ShipCruiser
+		mate.X, mate.Y = 48000, 50000
+
+		// The teammate's torpedo passes 1000 units out heading for the bot
+		s.gameState.Torps = append(s.gameState.Torps, &game.Torpedo{
+			Owner:  mate.ID,
+			X:      49000,
+			Y:      50000,
+			Dir:    0,
+			Speed:  600,
+			Status: game.TorpMove,
+			Team:   s.projectileTeam(mate),
+		})
+		return s, bot
+	}
+
+	t.Run("FriendlyFireOffIgnoresTeammateTorp", func(t *testing.T) {
+		s, bot := setup(false)
+		threat := s.assessUniversalThreats(bot)
+		if threat.requiresEvasion || threat.immediateThreat || threat.closestTorpDist != MaxSearchDistance {
+			t.Errorf("a teammate's torpedo is harmless without friendly fire, got %+v", threat)
+		}
+	})
+
+	t.Run("FreeForAllAvoidsTeammateTorp", func(t *testing.T) {
+		s, bot := setup(true)
+		threat := s.assessUniversalThreats(bot)
+		if !threat.requiresEvasion || !threat.immediateThreat {
+			t.Errorf("a teammate's torpedo can hit in free-for-all mode, got %+v", threat)
+		}
+		if s.calculateTorpedoDanger(bot, 0) == 0 {
+			t.Error("dodging should steer clear of a teammate's torpedo in free-for-all mode")
+		}
+	})
+
+	t.Run("LeftoverNeutralTorpIsHarmless", func(t *testing.T) {
+		// Fired while free-for-all was on, so the torpedo is neutral, but
+		// with it off the collision rule spares the owner's team again
+		s, bot := setup(false)
+		s.gameState.Torps[0].Team = game.TeamNone
+		threat := s.assessUniversalThreats(bot)
+		if threat.requiresEvasion || threat.immediateThreat {
+			t.Errorf("a teammate's leftover neutral torpedo can't hit, got %+v", threat)
+		}
+		if danger := s.calculateTorpedoDanger(bot, 0); danger != 0 {
+			t.Errorf("dodging weighed a harmless torpedo, danger %.1f", danger)
+		}
+	})
+}
+
+func TestBotBreaksFreeFromTractor(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{
+		gameState: gs,
+		broadcast: make(chan ServerMessage, 100),
+	}
+	gs.Frame = 1
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.X = 50000
+	bot.Y = 50000
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+	bot.BotTarget = -1
+
+	// Enemy to the east holding the bot in a tractor beam
+	enemy := gs.Players[1]
+	enemy.Status = game.StatusAlive
+	enemy.Team = game.TeamRom
+	enemy.Ship = game.ShipCruiser
+	enemy.X = 54000
+	enemy.Y = 50000
+	enemy.Tractoring = bot.ID
+	enemy.Pressoring = -1
+
+	server.updateBotHard(bot)
+
+	if bot.BotTarget != enemy.ID {
+		t.Errorf("tractored bot should target the tractoring ship %d, got %d", enemy.ID, bot.BotTarget)
+	}
+	if math.Cos(bot.DesDir) > -0.9 {
+		t.Errorf("tractored bot should steer away from the tractorer (west), got DesDir=%.2f", bot.DesDir)
+	}
+	if bot.DesSpeed != float64(game.ShipData[game.ShipCruiser].MaxSpeed) {
+		t.Errorf("tractored bot should run at max speed, got %.1f", bot.DesSpeed)
+	}
+	if bot.Pressoring != enemy.ID {
+		t.Errorf("tractored bot in beam range should counter with a pressor, got Pressoring=%d", bot.Pressoring)
+	}
+}
+
+// TestBotSearchesWhereTargetCloaked verifies that a bot keeps heading toward
+// the spot an engaged target cloaked at for a few ticks instead of forgetting
+// it the moment it disappears.
+func TestBotSearchesWhereTargetCloaked(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+	gs.Frame = 100
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X = 50000
+	bot.Y = 50000
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+
+	enemy := gs.Players[1]
+	enemy.Status = game.StatusAlive
+	enemy.Team = game.TeamRom
+	enemy.Ship = game.ShipCruiser
+	enemy.X = 58000
+	enemy.Y = 50000
+
+	server.engageCombat(bot, enemy, game.Distance(bot.X, bot.Y, enemy.X, enemy.Y))
+
+	// The enemy cloaks and slips away to the north
+	enemy.Cloaked = true
+	enemy.X = 58000
+	enemy.Y = 30000
+	gs.Torps = nil
+
+	for tick := 0; tick < 3; tick++ {
+		gs.Frame++
+		bot.BotCooldown = 0
+		server.updateBotHard(bot)
+
+		// Heading should point east toward where the enemy was last seen
+		if math.Cos(bot.DesDir) < 0.9 {
+			t.Fatalf("tick %d: bot should search the last known position (east), got DesDir=%.2f", tick, bot.DesDir)
+		}
+		bot.X += 500
+	}
+
+	// Once the memory expires the search ends
+	gs.Frame = bot.BotMemoryUntil
+	if server.searchLastKnownTarget(bot) {
+		t.Error("search should stop once the target memory expires")
+	}
+}
+
+// TestBotSearchesWhereTargetLeftRange verifies that a bot also searches the
+// last known position of a target that slips out of sensor range uncloaked.
+func TestBotSearchesWhereTargetLeftRange(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+	gs.Frame = 100
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X = 50000
+	bot.Y = 50000
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+
+	enemy := gs.Players[1]
+	enemy.Status = game.StatusAlive
+	enemy.Team = game.TeamRom
+	enemy.Ship = game.ShipCruiser
+	enemy.X = 58000
+	enemy.Y = 50000
+
+	server.engageCombat(bot, enemy, game.Distance(bot.X, bot.Y, enemy.X, enemy.Y))
+
+	// Still in range: ordinary targeting handles it, no search
+	if server.searchLastKnownTarget(bot) {
+		t.Fatal("bot searched for a target still within sensor range")
+	}
+
+	// The enemy runs far to the north, out of sensor range
+	enemy.Y = 50000 + TargetSensorRange + 15000
+	gs.Torps = nil
+	gs.Frame++
+	bot.BotCooldown = 0
+	server.updateBotHard(bot)
+
+	if math.Cos(bot.DesDir) < 0.9 {
+		t.Errorf("bot should search the last known position (east), got DesDir=%.2f", bot.DesDir)
+	}
+}
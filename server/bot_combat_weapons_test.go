@@ -313,3 +313,92 @@ func TestPhaserRangeHelper(t *testing.T) {
 		}
 	}
 }
+
+// TestBotFiresSpreadAtJammingTarget verifies that a bot's single aimed torpedo
+// becomes an unguided spread against an ECM-active target.
+func TestBotFiresSpreadAtJammingTarget(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 10)}
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.Fuel = 10000
+	bot.X, bot.Y = 50000, 50000
+
+	target := gs.Players[1]
+	target.Status = game.StatusAlive
+	target.Team = game.TeamRom
+	target.Ship = game.ShipCruiser
+	target.X, target.Y = 54000, 50000
+	target.ECMActive = true
+
+	server.fireBotTorpedo(bot, target)
+	if len(gs.Torps) != ECMSpreadTorps {
+		t.Fatalf("bot fired %d torpedoes at a jamming target, want a %d-torpedo spread", len(gs.Torps), ECMSpreadTorps)
+	}
+}
+
+// TestCorneredBotDetonatesInboundSpread verifies that a crippled bot pinned
+// against the galaxy edge by a torpedo spread detonates the torpedoes, paying
+// the fuel cost, instead of being killed by them.
+func TestCorneredBotDetonatesInboundSpread(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X, bot.Y = 800, 50000
+	bot.Dir = math.Pi // Nose to the wall
+	bot.Damage = game.ShipData[game.ShipCruiser].MaxDamage - 5
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+
+	shooter := gs.Players[1]
+	shooter.Status = game.StatusAlive
+	shooter.Team = game.TeamRom
+	shooter.Ship = game.ShipCruiser
+	shooter.X, shooter.Y = 9000, 50000
+
+	stats := game.ShipData[game.ShipCruiser]
+	for i, dy := range []float64{-300, 0, 300} {
+		gs.Torps = append(gs.Torps, &game.Torpedo{
+			ID:     i,
+			Owner:  shooter.ID,
+			X:      3500,
+			Y:      bot.Y + dy,
+			Dir:    math.Atan2(-dy, bot.X-3500),
+			Speed:  float64(stats.TorpSpeed * 20),
+			Damage: stats.TorpDamage,
+			Fuse:   stats.TorpFuse,
+			Status: game.TorpMove,
+			Team:   shooter.Team,
+		})
+	}
+	shooter.NumTorps = 3
+
+	fuel := bot.Fuel
+	for frame := 1; frame <= 30 && len(gs.Torps) > 0; frame++ {
+		gs.Frame = int64(frame)
+		server.applySafeNavigation(bot, 0, float64(stats.MaxSpeed))
+		server.updatePlayerPhysics(bot, 0)
+		server.updateProjectiles()
+	}
+
+	if bot.Damage >= stats.MaxDamage || bot.Status != game.StatusAlive {
+		t.Fatal("cornered bot was killed by the spread instead of detonating it")
+	}
+	if len(gs.Torps) != 0 {
+		t.Fatalf("%d torpedoes still in flight", len(gs.Torps))
+	}
+	if spent := fuel - bot.Fuel; spent < 3*stats.DetCost {
+		t.Errorf("bot spent %d fuel, want at least %d for detonating three torpedoes", spent, 3*stats.DetCost)
+	}
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"math"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// updateCommanders re-evaluates each bot team's objective every
+// CommanderIntervalFrames and stores it in GameState.TeamOrders, where the
+// team's bots pick it up when choosing roles and objectives. Teams without
+// bots get no orders. Caller must hold gameState.Mu.
+func (s *Server) updateCommanders() {
+	if s.gameState.Frame%CommanderIntervalFrames != 0 {
+		return
+	}
+	for _, team := range []int{game.TeamFed, game.TeamRom, game.TeamKli, game.TeamOri} {
+		if !s.teamHasBots(team) {
+			delete(s.gameState.TeamOrders, team)
+			continue
+		}
+		s.gameState.TeamOrders[team] = s.decideTeamOrder(team)
+	}
+}
+
+// teamHasBots reports whether team has a living, non-dummy bot.
+func (s *Server) teamHasBots(team int) bool {
+	for _, p := range s.gameState.Players {
+		if p.IsBot && !p.Dummy && p.Status == game.StatusAlive && p.Team == team {
+			return true
+		}
+	}
+	return false
+}
+
+// decideTeamOrder picks team's objective: defend its most threatened planet
+// when one is under real threat, hunt enemy ships when it already holds most
+// of the galaxy, and otherwise push the weakest nearby planet it doesn't own.
+func (s *Server) decideTeamOrder(team int) game.TeamOrder {
+	order := game.TeamOrder{Planet: -1, Target: -1}
+
+	// Defend: the friendly planet with the highest threat score
+	bestThreat := CommanderDefendThreat
+	for id, pt := range s.getPlanetThreats() {
+		planet := s.gameState.Planets[id]
+		if planet != nil && planet.Owner == team && pt.threatScore >= bestThreat {
+			bestThreat = pt.threatScore
+			order.Objective = TeamOrderDefend
+			order.Planet = id
+		}
+	}
+	if order.Objective != "" {
+		return order
+	}
+
+	centerX, centerY := s.calculateTeamCenter(team)
+
+	// Hunt: a dominant team goes after the enemy carrying the most armies,
+	// or failing that the visible enemy closest to its territory
+	if float64(s.countTeamPlanets()[team])/float64(game.MaxPlanets) > CommanderHuntRatio {
+		var prey *game.Player
+		preyDist := MaxSearchDistance
+		for _, enemy := range s.gameState.Players {
+			if enemy.Status != game.StatusAlive || enemy.Team == team || enemy.Cloaked {
+				continue
+			}
+			dist := s.distance(centerX, centerY, enemy.X, enemy.Y)
+			if prey == nil || enemy.Armies > prey.Armies || (enemy.Armies == prey.Armies && dist < preyDist) {
+				prey = enemy
+				preyDist = dist
+			}
+		}
+		if prey != nil {
+			order.Objective = TeamOrderHunt
+			order.Target = prey.ID
+			return order
+		}
+	}
+
+	// Push: the planet nearest the team's territory, each defending army
+	// counting as 1000 units of extra distance
+	bestScore := MaxSearchDistance
+	for _, planet := range s.gameState.Planets {
+		if planet == nil || planet.Owner == team {
+			continue
+		}
+		score := s.distance(centerX, centerY, planet.X, planet.Y) + float64(planet.Armies)*1000
+		if score < bestScore {
+			bestScore = score
+			order.Objective = TeamOrderPush
+			order.Planet = planet.ID
+		}
+	}
+	return order
+}
+
+// teamOrder returns the commander's current order for team, and whether it
+// has one.
+func (s *Server) teamOrder(team int) (game.TeamOrder, bool) {
+	order, ok := s.gameState.TeamOrders[team]
+	return order, ok && order.Objective != ""
+}
+
+// botTeamOrder returns the commander's order for p's team when p is one of
+// the bots carrying it out, and whether it is. Only the CommanderOrderShare
+// of the team's bots closest to the objective follow the order; the rest keep
+// their own roles. The defense squad never leaves to push or hunt.
+func (s *Server) botTeamOrder(p *game.Player) (game.TeamOrder, bool) {
+	order, ok := s.teamOrder(p.Team)
+	if !ok || !s.followsTeamOrder(p, order) {
+		return game.TeamOrder{}, false
+	}
+	return order, true
+}
+
+// followsTeamOrder reports whether p is among the bots assigned to order.
+func (s *Server) followsTeamOrder(p *game.Player, order game.TeamOrder) bool {
+	eligible := func(bot *game.Player) bool {
+		if !bot.IsBot || bot.Dummy || bot.Status != game.StatusAlive || bot.Team != p.Team {
+			return false
+		}
+		return order.Objective == TeamOrderDefend || s.gameState.BotSquads[bot.ID] != SquadDefense
+	}
+	if !eligible(p) {
+		return false
+	}
+
+	x, y, ok := s.teamOrderPosition(order)
+	dist := func(bot *game.Player) float64 {
+		if !ok {
+			return 0
+		}
+		return s.distance(bot.X, bot.Y, x, y)
+	}
+
+	// p's rank among eligible bots by distance to the objective, ties going
+	// to the lower slot
+	myDist := dist(p)
+	rank, total := 0, 0
+	for _, bot := range s.gameState.Players {
+		if !eligible(bot) {
+			continue
+		}
+		total++
+		if d := dist(bot); d < myDist || (d == myDist && bot.ID < p.ID) {
+			rank++
+		}
+	}
+	return rank < int(math.Ceil(float64(total)*CommanderOrderShare))
+}
+
+// teamOrderPosition returns where order's objective is, and false when the
+// planet or ship it names no longer exists.
+func (s *Server) teamOrderPosition(order game.TeamOrder) (float64, float64, bool) {
+	if order.Planet >= 0 && order.Planet < game.MaxPlanets && s.gameState.Planets[order.Planet] != nil {
+		planet := s.gameState.Planets[order.Planet]
+		return planet.X, planet.Y, true
+	}
+	if order.Target >= 0 && order.Target < game.MaxPlayers {
+		target := s.gameState.Players[order.Target]
+		return target.X, target.Y, target.Status == game.StatusAlive
+	}
+	return 0, 0, false
+}
+
+// teamOrderPlanet returns the planet named by the order p is carrying out
+// when the order has the given objective, or nil.
+func (s *Server) teamOrderPlanet(p *game.Player, objective string) *game.Planet {
+	order, ok := s.botTeamOrder(p)
+	if !ok || order.Objective != objective || order.Planet < 0 || order.Planet >= game.MaxPlanets {
+		return nil
+	}
+	return s.gameState.Planets[order.Planet]
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 	"math/rand"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,9 +44,10 @@ func (c *Client) handleBotCommand(cmd string) {
 			return
 		}
 
-		// /addbot [team] [ship_type]
+		// /addbot [team] [ship_type] [caution]
 		team := game.TeamFed
 		ship := game.ShipDestroyer
+		caution := ""
 
 		if len(parts) > 1 {
 			switch parts[1] {
@@ -70,7 +72,7 @@ func (c *Client) handleBotCommand(cmd string) {
 				c.sendMsg(ServerMessage{
 					Type: MsgTypeMessage,
 					Data: map[string]interface{}{
-						"text": "Invalid ship type. Usage: /addbot [fed/rom/kli/ori] [SC|DD|CA|BB|AS|SB]",
+						"text": "Invalid ship type. Usage: /addbot [fed/rom/kli/ori] [SC|DD|CA|BB|AS|SB] [conservative|balanced|aggressive]",
 						"type": "warning",
 					},
 				})
@@ -78,13 +80,14 @@ func (c *Client) handleBotCommand(cmd string) {
 			}
 		}
 
-		// Level parameter is ignored - all bots are hard mode now
-		if len(parts) > 3 {
-			// Silently ignore the level parameter for backward compatibility
+		// The old difficulty level slot now picks a caution profile; unknown
+		// words (such as the retired levels) fall back to the server default
+		if len(parts) > 3 && ValidCaution(strings.ToLower(parts[3])) {
+			caution = strings.ToLower(parts[3])
 		}
 
-		// AddBot enforces the one-starbase-per-team limit atomically under its own lock
-		c.server.AddBot(team, ship)
+		// AddBotWithCaution enforces the one-starbase-per-team limit atomically under its own lock
+		c.server.AddBotWithCaution(team, ship, caution)
 
 	case "/removebot":
 		if c.botCmdThrottled() {
@@ -275,6 +278,16 @@ func (c *Client) handleBotCommand(cmd string) {
 
 	case "/refit":
 		// /refit [ship_type]
+		if c.server.config().RefitMode == RefitRotation {
+			c.sendMsg(ServerMessage{
+				Type: MsgTypeMessage,
+				Data: map[string]interface{}{
+					"text": "Refits are disabled: ships rotate automatically on each respawn.",
+					"type": "warning",
+				},
+			})
+			return
+		}
 		if len(parts) < 2 {
 			c.sendMsg(ServerMessage{
 				Type: MsgTypeMessage,
@@ -327,13 +340,28 @@ func (c *Client) handleBotCommand(cmd string) {
 			}
 		}
 
+		// Get the ship name for the confirmation message
+		shipName := game.ShipData[game.ShipType(shipTypeInt)].Name
+
+		// In free refit mode a ship docked at a friendly repair planet
+		// swaps ships on the spot
+		if c.server.config().RefitMode == RefitFree && c.server.dockedForRefit(p) {
+			c.server.refitShip(p, game.ShipType(shipTypeInt))
+			c.server.gameState.Mu.Unlock()
+			c.sendMsg(ServerMessage{
+				Type: MsgTypeMessage,
+				Data: map[string]interface{}{
+					"text": fmt.Sprintf("Refitted to %s.", shipName),
+					"type": "info",
+				},
+			})
+			return
+		}
+
 		// Set the next ship type for this player
 		p.NextShipType = shipTypeInt
 		c.server.gameState.Mu.Unlock()
 
-		// Get the ship name for the confirmation message
-		shipName := game.ShipData[game.ShipType(shipTypeInt)].Name
-
 		// Send confirmation message
 		c.sendMsg(ServerMessage{
 			Type: MsgTypeMessage,
@@ -343,12 +371,78 @@ func (c *Client) handleBotCommand(cmd string) {
 			},
 		})
 
+	case "/beam":
+		// /beam up|down [count] - move a set number of armies while orbiting
+		count := 0
+		if len(parts) > 2 {
+			n, err := strconv.Atoi(parts[2])
+			if err != nil || n <= 0 {
+				count = -1
+			} else {
+				count = n
+			}
+		}
+		if len(parts) < 2 || (parts[1] != "up" && parts[1] != "down") || count < 0 {
+			c.sendMsg(ServerMessage{
+				Type: MsgTypeMessage,
+				Data: map[string]interface{}{
+					"text": "Usage: /beam up|down [count]",
+					"type": "warning",
+				},
+			})
+			return
+		}
+		c.startBeam(parts[1] == "up", count)
+
+	case "/autorepair":
+		// /autorepair <percent>|off - request repairs automatically past a damage threshold
+		threshold := -1
+		if len(parts) == 2 {
+			if parts[1] == "off" {
+				threshold = 0
+			} else if n, err := strconv.Atoi(strings.TrimSuffix(parts[1], "%")); err == nil && n > 0 && n <= 100 {
+				threshold = n
+			}
+		}
+		if threshold < 0 {
+			c.sendMsg(ServerMessage{
+				Type: MsgTypeMessage,
+				Data: map[string]interface{}{
+					"text": "Usage: /autorepair <damage percent>|off",
+					"type": "warning",
+				},
+			})
+			return
+		}
+		if !c.setAutoRepair(threshold) {
+			return
+		}
+		text := "Auto-repair off"
+		if threshold > 0 {
+			text = fmt.Sprintf("Auto-repair at %d%% damage", threshold)
+		}
+		c.sendMsg(ServerMessage{
+			Type: MsgTypeMessage,
+			Data: map[string]interface{}{
+				"text": text,
+				"type": "info",
+			},
+		})
+
+	case "/ready", "/unready":
+		// /ready and /unready - toggle readiness for a ready-checked match
+		c.setReady(parts[0] == "/ready")
+
+	case "/release":
+		// /release - hand a bot possessed through /api/possess back to the AI
+		c.releaseBot()
+
 	case "/help":
 		// Send help message
 		c.sendMsg(ServerMessage{
 			Type: MsgTypeMessage,
 			Data: map[string]interface{}{
-				"text": "Bot commands: /addbot [fed/rom/kli/ori] [SC|DD|CA|BB|AS|SB] | /removebot | /balance | /clearbots | /fillbots | /refit SC|DD|CA|BB|AS|SB",
+				"text": "Bot commands: /addbot [fed/rom/kli/ori] [SC|DD|CA|BB|AS|SB] [conservative|balanced|aggressive] | /removebot | /balance | /clearbots | /fillbots | /refit SC|DD|CA|BB|AS|SB | /beam up|down [count] | /autorepair <percent>|off | /ready | /unready",
 				"type": "info",
 			},
 		})
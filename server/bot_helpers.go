@@ -40,14 +40,15 @@ func (s *Server) canTorpReachTarget(p *game.Player, target *game.Player) bool {
 	projSpeed := float64(shipStats.TorpSpeed * game.TorpUnitFactor)
 
 	shooterPos := Point2D{X: p.X, Y: p.Y}
-	targetPos := Point2D{X: target.X, Y: target.Y}
+	targetPos := Point2D{}
+	targetPos.X, targetPos.Y = s.nearestImage(p.X, p.Y, target.X, target.Y)
 	targetVel := s.targetVelocity(target)
 
 	solution, ok := InterceptDirection(shooterPos, targetPos, targetVel, projSpeed)
 	if !ok {
 		// No intercept solution — target is moving too fast to catch.
 		// Only allow firing at very close range for area denial.
-		dist := game.Distance(p.X, p.Y, target.X, target.Y)
+		dist := s.distance(p.X, p.Y, target.X, target.Y)
 		maxRange := float64(game.MaxTorpRange(shipStats))
 		return dist < maxRange*0.3
 	}
@@ -62,6 +63,24 @@ func (s *Server) canTorpReachTarget(p *game.Player, target *game.Player) bool {
 	return solution.TimeToIntercept <= maxFuseTicks
 }
 
+// projectileThreatens reports whether a moving torpedo or plasma can hit p,
+// following the collision rules in updateProjectiles: a ship is never hit by
+// its own fire, and teammates' fire only hits in free-for-all mode. Like the
+// collision check it goes by the owner's team rather than the projectile's, so
+// neutral torpedoes left over from a free-for-all spell are judged correctly.
+// Bots use it so they neither waste maneuvers on harmless friendly fire nor
+// ignore fire that can hurt them.
+func (s *Server) projectileThreatens(p *game.Player, t *game.Torpedo) bool {
+	if t.Owner == p.ID || t.Status != game.TorpMove {
+		return false
+	}
+	if s.config().FreeForAll || t.Owner < 0 || t.Owner >= game.MaxPlayers {
+		return true
+	}
+	owner := s.gameState.Players[t.Owner]
+	return owner == nil || owner.Team != p.Team
+}
+
 // findNearestEnemy finds the closest enemy player
 func (s *Server) findNearestEnemy(p *game.Player) *game.Player {
 	var nearest *game.Player
@@ -70,7 +89,7 @@ func (s *Server) findNearestEnemy(p *game.Player) *game.Player {
 	for i := range s.gameState.Players {
 		other := s.gameState.Players[i]
 		if other.Status == game.StatusAlive && other.Team != p.Team && i != p.ID && !other.Cloaked {
-			dist := game.Distance(p.X, p.Y, other.X, other.Y)
+			dist := s.distance(p.X, p.Y, other.X, other.Y)
 			if dist < minDist {
 				minDist = dist
 				nearest = other
@@ -90,7 +109,7 @@ func (s *Server) countTeamPlanets() map[int]int {
 	}
 	var counts [9]int // Indexed by team flag (0=None, 1=Fed, 2=Rom, 4=Kli, 8=Ori)
 	for _, planet := range s.gameState.Planets {
-		if planet.Owner >= 0 && planet.Owner < len(counts) {
+		if planet != nil && planet.Owner >= 0 && planet.Owner < len(counts) {
 			counts[planet.Owner]++
 		}
 	}
@@ -111,7 +130,7 @@ func (s *Server) calculateTeamCenter(team int) (float64, float64) {
 	count := 0
 
 	for _, planet := range s.gameState.Planets {
-		if planet.Owner == team {
+		if planet != nil && planet.Owner == team {
 			totalX += planet.X
 			totalY += planet.Y
 			count++
@@ -123,15 +142,14 @@ func (s *Server) calculateTeamCenter(team int) (float64, float64) {
 	}
 
 	// Fallback to team home if no planets owned
-	return float64(game.TeamHomeX[team]), float64(game.TeamHomeY[team])
+	return s.teamHome(team)
 }
 
 // isCorePlanet checks if a planet is a core/home planet for a team
 func (s *Server) isCorePlanet(planet *game.Planet, team int) bool {
 	// Check if planet is close to team's home coordinates
-	homeX := float64(game.TeamHomeX[team])
-	homeY := float64(game.TeamHomeY[team])
-	dist := game.Distance(planet.X, planet.Y, homeX, homeY)
+	homeX, homeY := s.teamHome(team)
+	dist := s.distance(planet.X, planet.Y, homeX, homeY)
 	return dist < CorePlanetRadius
 }
 
@@ -179,7 +197,8 @@ func (s *Server) selectBotShipType(team int) game.ShipType {
 	return combatShips[rand.Intn(len(combatShips))]
 }
 
-// selectBotBehavior determines bot behavior based on game state
+// selectBotBehavior determines bot behavior based on game state and, once
+// the team has been split into squads, the bot's squad
 func (s *Server) selectBotBehavior(p *game.Player) string {
 	// Analyze game state
 	teamPlanets := s.countTeamPlanets()
@@ -199,10 +218,58 @@ func (s *Server) selectBotBehavior(p *game.Player) string {
 		}
 	}
 
+	// One fast ship per team gathers intel while enemy planets are unscouted
+	if s.isTeamScout(p) && s.findUnscoutedEnemyPlanet(p) != nil {
+		return BotRoleScout
+	}
+
+	// One bot on a well-staffed team guards the border against carriers
+	if s.isTeamInterceptor(p) {
+		return BotRoleInterceptor
+	}
+
+	// The toughest bot on a well-staffed team denies the enemy the map center
+	if s.isTeamSpaceController(p) {
+		return BotRoleSpaceControl
+	}
+
+	// Bots near a human teammate's planet assault cover it instead of
+	// competing for the planet
+	if human, _ := s.findHumanAssault(p); human != nil {
+		return BotRoleScreen
+	}
+
+	// The bots closest to the commander's objective carry it out; the rest
+	// of the team keeps to its squads and the dynamic mix below
+	if order, ok := s.botTeamOrder(p); ok {
+		switch order.Objective {
+		case TeamOrderDefend:
+			return BotRoleDefender
+		case TeamOrderPush:
+			return BotRoleRaider
+		case TeamOrderHunt:
+			return BotRoleHunter
+		}
+	}
+
+	// Ships with a bombing bonus lean toward raiding enemy planets
+	bomber := game.ShipData[p.Ship].BombBonus > 0
+
+	// Bots assigned to a squad keep to its role between rebalances
+	switch s.gameState.BotSquads[p.ID] {
+	case SquadDefense:
+		return BotRoleDefender
+	case SquadOffense:
+		if p.KillsStreak >= game.ArmyKillRequirement || bomber {
+			return BotRoleRaider
+		}
+		return BotRoleHunter
+	}
+
 	// Dynamic role assignment
 	if controlRatio < 0.2 {
 		// Losing badly - focus on defense and raids
-		if defenders < 2 {
+		if defenders < 2 && !bomber {
 			return BotRoleDefender
 		}
 		return BotRoleRaider
@@ -213,7 +280,7 @@ func (s *Server) selectBotBehavior(p *game.Player) string {
 		// Balanced - mixed strategy
 		if hunters > defenders+1 {
 			return BotRoleDefender
-		} else if p.KillsStreak >= game.ArmyKillRequirement {
+		} else if p.KillsStreak >= game.ArmyKillRequirement || bomber {
 			return BotRoleRaider
 		}
 		return BotRoleHunter
@@ -234,7 +301,7 @@ func (s *Server) selectBestCombatTarget(p *game.Player) *game.Player {
 		if p.BotTarget < len(s.gameState.Players) {
 			currentTarget := s.gameState.Players[p.BotTarget]
 			if currentTarget.Status == game.StatusAlive && currentTarget.Team != p.Team {
-				dist := game.Distance(p.X, p.Y, currentTarget.X, currentTarget.Y)
+				dist := s.distance(p.X, p.Y, currentTarget.X, currentTarget.Y)
 				if dist < 30000 { // Extended range for target persistence
 					// Persistence bonus prevents target thrashing
 					bestScore = s.calculateTargetScore(p, currentTarget, dist) + TargetPersistenceBonus
@@ -256,8 +323,8 @@ func (s *Server) selectBestCombatTarget(p *game.Player) *game.Player {
 			continue
 		}
 
-		dist := game.Distance(p.X, p.Y, other.X, other.Y)
-		if dist > 25000 {
+		dist := s.distance(p.X, p.Y, other.X, other.Y)
+		if dist > TargetSensorRange {
 			continue // Too far
 		}
 
@@ -316,7 +383,7 @@ func (s *Server) isPlayerIsolated(playerID int) bool {
 				if j == i || other.Status != game.StatusAlive || other.Team != player.Team {
 					continue
 				}
-				if game.Distance(player.X, player.Y, other.X, other.Y) < IsolationRange {
+				if s.distance(player.X, player.Y, other.X, other.Y) < IsolationRange {
 					isolated = false
 					break
 				}
@@ -357,9 +424,14 @@ func (s *Server) calculateTargetScore(p *game.Player, target *game.Player, dist
 		score += speedDiff * TargetSpeedBonus
 	}
 
+	// Chasing an enemy along a deadly edge risks flying out after it
+	if s.nearDeadlyEdge(target.X, target.Y) {
+		score -= DeadlyEdgeTargetPenalty
+	}
+
 	// Avoid cloaked ships unless close
 	if target.Cloaked {
-		if dist > TargetCloakDetectRange {
+		if dist > s.config().CloakDetectRange {
 			score -= TargetCloakedPenalty
 		} else {
 			score += TargetDecloakBonus
@@ -371,6 +443,11 @@ func (s *Server) calculateTargetScore(p *game.Player, target *game.Player, dist
 		score += TargetIsolatedBonus
 	}
 
+	// The team commander wants this ship run down
+	if order, ok := s.teamOrder(p.Team); ok && order.Objective == TeamOrderHunt && order.Target == target.ID {
+		score += CommanderHuntBonus
+	}
+
 	return score
 }
 
@@ -422,7 +499,7 @@ func (s *Server) broadcastTargetToAllies(p *game.Player, target *game.Player, ta
 			continue
 		}
 
-		dist := game.Distance(p.X, p.Y, ally.X, ally.Y)
+		dist := s.distance(p.X, p.Y, ally.X, ally.Y)
 		if dist >= BroadcastTargetRange {
 			continue
 		}
@@ -453,3 +530,40 @@ func (s *Server) ApplyPendingTargetSuggestions() {
 	}
 	s.pendingSuggestions = s.pendingSuggestions[:0]
 }
+
+// abandonAssault gives up p's assault on planet and keeps the planet out of
+// its target selection for AssaultAbandonFrames.
+func (s *Server) abandonAssault(p *game.Player, planet *game.Planet) {
+	p.BotAbandonedPlanet = planet.ID
+	p.BotAbandonedUntil = s.gameState.Frame + AssaultAbandonFrames
+	p.BotPlanetApproachID = -1
+}
+
+// assaultAbandoned reports whether p recently abandoned an assault on planet.
+func (s *Server) assaultAbandoned(p *game.Player, planet *game.Planet) bool {
+	return planet.ID == p.BotAbandonedPlanet && s.gameState.Frame < p.BotAbandonedUntil
+}
+
+// botOrbit puts p in orbit of planet, scouting it. Under the shields-down
+// rule the bot lowers its shields to hold the orbit, and
+// assessAndActivateShields takes it back out if a threat calls for them.
+func (s *Server) botOrbit(p *game.Player, planet *game.Planet) {
+	p.Orbiting = planet.ID
+	planet.Info |= p.Team // Update planet info
+	p.DesSpeed = 0
+	if s.config().ShieldsDownToOrbit {
+		p.Shields_up = false
+	}
+}
+
+// countAlliesNear counts living teammates of p within dist of it.
+func (s *Server) countAlliesNear(p *game.Player, dist float64) int {
+	count := 0
+	for _, ally := range s.gameState.Players {
+		if ally.Status == game.StatusAlive && ally.Team == p.Team && ally.ID != p.ID &&
+			s.distance(p.X, p.Y, ally.X, ally.Y) < dist {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// isTeamInterceptor reports whether p is its team's interceptor: the
+// highest-slot living bot on a team with at least InterceptorMinTeamBots
+// bots, skipping scouts and starbases. Like isTeamScout, picking by slot keeps
+// a single interceptor per team without tracking the role on the player.
+func (s *Server) isTeamInterceptor(p *game.Player) bool {
+	if p.Ship == game.ShipScout || p.Ship == game.ShipStarbase {
+		return false
+	}
+	teamBots := 0
+	for _, other := range s.gameState.Players {
+		if !other.IsBot || other.Dummy || other.Status != game.StatusAlive || other.Team != p.Team {
+			continue
+		}
+		teamBots++
+		if other.ID > p.ID && other.Ship != game.ShipScout && other.Ship != game.ShipStarbase {
+			return false
+		}
+	}
+	return teamBots >= InterceptorMinTeamBots
+}
+
+// findIncomingCarrier returns the enemy army carrier that will soonest reach
+// one of p's team's planets: a visible enemy with armies aboard that is
+// either already over a friendly planet or within InterceptorWatchRange and
+// heading toward one. Returns nil when no carrier is inbound.
+func (s *Server) findIncomingCarrier(p *game.Player) *game.Player {
+	var best *game.Player
+	bestETA := math.MaxFloat64
+
+	for _, enemy := range s.gameState.Players {
+		if enemy.Status != game.StatusAlive || enemy.Team == p.Team || enemy.Armies == 0 || enemy.Cloaked {
+			continue
+		}
+		speed := math.Max(enemy.Speed, 1) * 20
+		for _, planet := range s.gameState.Planets {
+			if planet == nil || planet.Owner != p.Team {
+				continue
+			}
+			dist := s.distance(enemy.X, enemy.Y, planet.X, planet.Y)
+			if dist > InterceptorWatchRange {
+				continue
+			}
+			if dist > PlanetBombRange {
+				// Farther out, only carriers heading roughly toward the planet count
+				angleToPlanet := s.bearing(enemy.X, enemy.Y, planet.X, planet.Y)
+				if enemy.Speed < 1 || AngleDifference(enemy.Dir, angleToPlanet) > math.Pi/4 {
+					continue
+				}
+			}
+			if eta := dist / speed; eta < bestETA {
+				bestETA = eta
+				best = enemy
+			}
+		}
+	}
+	return best
+}
+
+// interceptCarrier runs down an inbound enemy carrier, leading it at full
+// speed until it is close enough to fight.
+func (s *Server) interceptCarrier(p, carrier *game.Player) {
+	dist := s.distance(p.X, p.Y, carrier.X, carrier.Y)
+	p.BotTarget = carrier.ID
+	if dist < InterceptorEngageRange {
+		s.engageCombat(p, carrier, dist)
+		return
+	}
+
+	p.Orbiting = -1
+	p.Bombing = false
+	stopBeaming(p)
+	s.applySafeNavigation(p, s.calculateEnhancedInterceptCourse(p, carrier), float64(game.ShipData[p.Ship].MaxSpeed))
+}
+
+// patrolBorder keeps p near the friendly frontline planet closest to it so
+// it is in position when a carrier comes across. Returns false if the team
+// has no frontline planet to watch.
+func (s *Server) patrolBorder(p *game.Player) bool {
+	planet := s.nearestPlanet(p, func(pl *game.Planet) bool {
+		return pl.Owner == p.Team && s.isPlanetOnFrontline(pl, p.Team)
+	})
+	if planet == nil {
+		return false
+	}
+
+	maxSpeed := float64(game.ShipData[p.Ship].MaxSpeed)
+	if dist := s.distance(p.X, p.Y, planet.X, planet.Y); dist > InterceptorPatrolRadius {
+		s.applySafeNavigation(p, s.bearing(p.X, p.Y, planet.X, planet.Y), maxSpeed)
+	} else {
+		s.applySafeNavigation(p, rand.Float64()*2*math.Pi, maxSpeed*0.5)
+	}
+	return true
+}
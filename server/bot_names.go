@@ -0,0 +1,98 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// LoadBotNames reads a bot name pool from path, one name per line. Blank
+// lines, lines starting with # and repeated names are skipped.
+func LoadBotNames(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name := sanitizeText(line)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%s lists no bot names", path)
+	}
+	return names, nil
+}
+
+// claimBotName picks an unused name from the bot name pool (Config.BotNames,
+// or BotNames by default) for the bot in slot id and records it as in use.
+// Once every pool name is taken, names get numbered suffixes ("Data-2").
+// Caller must hold gameState.Mu.
+func (s *Server) claimBotName(id int) string {
+	pool := s.config().BotNames
+	if len(pool) == 0 {
+		pool = BotNames
+	}
+	if s.botNames == nil {
+		s.botNames = make(map[int]string)
+	}
+
+	// Slots freed without releasing their name (a reset, say) no longer
+	// hold it. A live slot keeps its name even while an administrator flies
+	// the bot
+	inUse := make(map[string]bool)
+	for slot, name := range s.botNames {
+		if slot == id || s.gameState.Players[slot].Status == game.StatusFree {
+			delete(s.botNames, slot)
+			continue
+		}
+		inUse[name] = true
+	}
+
+	var free []string
+	for _, name := range pool {
+		if !inUse[name] {
+			free = append(free, name)
+		}
+	}
+	var name string
+	if len(free) > 0 {
+		name = free[rand.Intn(len(free))]
+	}
+	for n := 2; name == ""; n++ {
+		for _, base := range pool {
+			if candidate := fmt.Sprintf("%s-%d", base, n); !inUse[candidate] {
+				name = candidate
+				break
+			}
+		}
+	}
+
+	s.botNames[id] = name
+	return name
+}
+
+// releaseBotName returns the name held by the bot in slot id to the pool.
+// Caller must hold gameState.Mu.
+func (s *Server) releaseBotName(id int) {
+	delete(s.botNames, id)
+}
@@ -13,11 +13,11 @@ type planetPos struct{ x, y float64 }
 
 // calculateEnhancedInterceptCourse calculates intercept with acceleration prediction
 func (s *Server) calculateEnhancedInterceptCourse(p, target *game.Player) float64 {
-	dist := game.Distance(p.X, p.Y, target.X, target.Y)
+	dist := s.distance(p.X, p.Y, target.X, target.Y)
 
 	// If target is far or cloaked, use basic intercept
 	if dist > 20000 || target.Cloaked || target.Speed < 1 {
-		return math.Atan2(target.Y-p.Y, target.X-p.X)
+		return s.bearing(p.X, p.Y, target.X, target.Y)
 	}
 
 	// Track target's acceleration (speed changes)
@@ -57,7 +57,7 @@ func (s *Server) calculateEnhancedInterceptCourse(p, target *game.Player) float6
 	predictX := target.X + futureSpeed*math.Cos(target.Dir)*timeToIntercept*20
 	predictY := target.Y + futureSpeed*math.Sin(target.Dir)*timeToIntercept*20
 
-	return math.Atan2(predictY-p.Y, predictX-p.X)
+	return s.bearing(p.X, p.Y, predictX, predictY)
 }
 
 // getAdvancedDodgeDirection calculates optimal dodge considering multiple threats
@@ -69,7 +69,10 @@ func (s *Server) getAdvancedDodgeDirection(p *game.Player, wantedDir float64, th
 	// scanning all 40 planets in every calculateClearance call (~25 calls).
 	var nearbyPlanets []planetPos
 	for _, planet := range s.gameState.Planets {
-		if game.Distance(p.X, p.Y, planet.X, planet.Y) < 12000 {
+		if planet == nil {
+			continue
+		}
+		if s.distance(p.X, p.Y, planet.X, planet.Y) < 12000 {
 			nearbyPlanets = append(nearbyPlanets, planetPos{x: planet.X, y: planet.Y})
 		}
 	}
@@ -98,7 +101,7 @@ func (s *Server) getAdvancedDodgeDirection(p *game.Player, wantedDir float64, th
 			score -= AngleDifference(testDir, wantedDir) * 100
 
 			// Check wall and planet proximity using pre-filtered planets
-			clearance := calculateClearanceWithPlanets(p, testDir, nearbyPlanets)
+			clearance := calculateClearanceWithPlanets(p, testDir, nearbyPlanets, s.config().GalaxyEdge == EdgeWrap)
 			if clearance < 3000 {
 				score -= (3000 - clearance) * 2
 			}
@@ -119,7 +122,7 @@ func (s *Server) calculateTorpedoDanger(p *game.Player, dir float64) float64 {
 	speed := p.Speed * 20 // Use actual current speed, not max
 
 	for _, torp := range s.gameState.Torps {
-		if torp.Owner == p.ID || torp.Team == p.Team || torp.Status != game.TorpMove {
+		if !s.projectileThreatens(p, torp) {
 			continue
 		}
 
@@ -130,7 +133,7 @@ func (s *Server) calculateTorpedoDanger(p *game.Player, dir float64) float64 {
 			torpX := torp.X + torp.Speed*math.Cos(torp.Dir)*t
 			torpY := torp.Y + torp.Speed*math.Sin(torp.Dir)*t
 
-			dist := game.Distance(myX, myY, torpX, torpY)
+			dist := s.distance(myX, myY, torpX, torpY)
 			if dist < 700 {
 				danger += (700 - dist) / 100
 			}
@@ -146,7 +149,7 @@ func (s *Server) calculatePlasmaDanger(p *game.Player, dir float64) float64 {
 	speed := p.Speed * 20 // Use actual current speed, not max
 
 	for _, plasma := range s.gameState.Plasmas {
-		if plasma.Owner == p.ID || plasma.Team == p.Team || plasma.Status != game.TorpMove {
+		if !s.projectileThreatens(p, plasma) {
 			continue
 		}
 
@@ -156,7 +159,7 @@ func (s *Server) calculatePlasmaDanger(p *game.Player, dir float64) float64 {
 			plasmaX := plasma.X + plasma.Speed*math.Cos(plasma.Dir)*t
 			plasmaY := plasma.Y + plasma.Speed*math.Sin(plasma.Dir)*t
 
-			dist := game.Distance(myX, myY, plasmaX, plasmaY)
+			dist := s.distance(myX, myY, plasmaX, plasmaY)
 			if dist < 1000 {
 				danger += (1000 - dist) / 100
 			}
@@ -236,19 +239,28 @@ func (s *Server) getEvasionSpeed(p *game.Player, threats CombatThreat) float64 {
 
 // calculateClearanceWithPlanets calculates how much clear space in a direction,
 // using a pre-filtered list of nearby planet positions to avoid scanning all 40 planets.
-func calculateClearanceWithPlanets(p *game.Player, dir float64, nearbyPlanets []planetPos) float64 {
+// A wrapping galaxy has no walls, so only planets, measured across the edge
+// when nearer, limit the clearance there.
+func calculateClearanceWithPlanets(p *game.Player, dir float64, nearbyPlanets []planetPos, wrap bool) float64 {
 	testDist := 5000.0
 	testX := p.X + math.Cos(dir)*testDist
 	testY := p.Y + math.Sin(dir)*testDist
 
 	// Check walls
-	clearance := math.Min(testX, game.GalaxyWidth-testX)
-	clearance = math.Min(clearance, testY)
-	clearance = math.Min(clearance, game.GalaxyHeight-testY)
+	clearance := MaxSearchDistance
+	if !wrap {
+		clearance = math.Min(testX, game.GalaxyWidth-testX)
+		clearance = math.Min(clearance, testY)
+		clearance = math.Min(clearance, game.GalaxyHeight-testY)
+	}
 
 	// Check planets - treat planet surface as wall (discourage suicide dives)
 	for _, np := range nearbyPlanets {
-		planetDist := game.Distance(testX, testY, np.x, np.y)
+		dx, dy := np.x-testX, np.y-testY
+		if wrap {
+			dx, dy = wrapDelta(dx, dy)
+		}
+		planetDist := math.Sqrt(dx*dx + dy*dy)
 		// Treat anything within 2000 units of planet surface as blocked
 		planetClearance := planetDist - 2000
 		if planetClearance < 0 {
@@ -262,11 +274,58 @@ func calculateClearanceWithPlanets(p *game.Player, dir float64, nearbyPlanets []
 	return clearance
 }
 
+// nearDeadlyEdge reports whether (x, y) lies within DeadlyEdgeMargin of a
+// galaxy edge that destroys ships (EdgeKill).
+func (s *Server) nearDeadlyEdge(x, y float64) bool {
+	return s.config().GalaxyEdge == EdgeKill &&
+		(x < DeadlyEdgeMargin || x > game.GalaxyWidth-DeadlyEdgeMargin ||
+			y < DeadlyEdgeMargin || y > game.GalaxyHeight-DeadlyEdgeMargin)
+}
+
+// steerClearOfDeadlyEdge turns p back from a deadly galaxy edge, whatever
+// the bot's AI chose: within DeadlyEdgeMargin of one, the part of its desired
+// course heading into the edge is reversed, and while the ship still heads
+// into it its speed is held to a quarter so it turns tightly. Other edge
+// rules leave the course alone.
+func (s *Server) steerClearOfDeadlyEdge(p *game.Player) {
+	if !s.nearDeadlyEdge(p.X, p.Y) {
+		return
+	}
+	p.DesDir = s.awayFromDeadlyEdge(p, p.DesDir)
+	if s.awayFromDeadlyEdge(p, p.Dir) != p.Dir {
+		p.DesSpeed = math.Min(p.DesSpeed, float64(game.ShipData[p.Ship].MaxSpeed)/4)
+	}
+}
+
+// awayFromDeadlyEdge returns dir with any part of it leading into a galaxy
+// edge within DeadlyEdgeMargin of p reversed.
+func (s *Server) awayFromDeadlyEdge(p *game.Player, dir float64) float64 {
+	vx, vy := math.Cos(dir), math.Sin(dir)
+	turned := false
+	if (p.X < DeadlyEdgeMargin && vx < 0) || (p.X > game.GalaxyWidth-DeadlyEdgeMargin && vx > 0) {
+		vx = -vx
+		turned = true
+	}
+	if (p.Y < DeadlyEdgeMargin && vy < 0) || (p.Y > game.GalaxyHeight-DeadlyEdgeMargin && vy > 0) {
+		vy = -vy
+		turned = true
+	}
+	if !turned {
+		return dir
+	}
+	return math.Atan2(vy, vx)
+}
+
 // applySafeNavigation applies torpedo dodging and threat assessment to any navigation
 func (s *Server) applySafeNavigation(p *game.Player, desiredDir float64, desiredSpeed float64) {
 	// Always check for threats regardless of what the bot is doing
 	threats := s.assessUniversalThreats(p)
 
+	// Pinned by a torpedo spread: detonate it rather than try to dodge
+	if threats.requiresEvasion && s.tryDetonateEnemyTorps(p, threats) {
+		threats = s.assessUniversalThreats(p)
+	}
+
 	// If immediate torpedo evasion is required, override everything
 	if threats.requiresEvasion {
 		// Use advanced dodging but try to maintain general objective direction
@@ -280,7 +339,7 @@ func (s *Server) applySafeNavigation(p *game.Player, desiredDir float64, desired
 	}
 
 	// No immediate threat - apply desired navigation with separation
-	separationVector := s.calculateSeparationVector(p)
+	separationVector := s.botSeparation(p)
 	p.DesDir = blendWithSeparation(desiredDir, separationVector, 300.0, 0.5)
 
 	// Apply desired speed
@@ -312,9 +371,23 @@ func blendWithSeparation(baseDir float64, sep SeparationVector, divisor, maxWeig
 	return math.Atan2(navY, navX)
 }
 
+// botSeparation returns the ally separation vector for p, or a zero vector
+// when separation is disabled in the server config so bots steer straight
+// toward their objective.
+func (s *Server) botSeparation(p *game.Player) SeparationVector {
+	if !s.config().BotSeparation {
+		return SeparationVector{}
+	}
+	return s.calculateSeparationVector(p)
+}
+
 // calculateSeparationVector calculates a vector to maintain safe distance from allies
 func (s *Server) calculateSeparationVector(p *game.Player) SeparationVector {
 	separationVec := SeparationVector{x: 0, y: 0, magnitude: 0}
+	cfg := s.config()
+	minSafeDistance := cfg.BotSepMinSafeDistance
+	idealDistance := cfg.BotSepIdealDistance
+	criticalDistance := cfg.BotSepCriticalDistance
 
 	nearbyAllies := 0
 	totalRepelX := 0.0
@@ -331,15 +404,20 @@ func (s *Server) calculateSeparationVector(p *game.Player) SeparationVector {
 			continue
 		}
 
-		dist := game.Distance(p.X, p.Y, ally.X, ally.Y)
+		dist := s.distance(p.X, p.Y, ally.X, ally.Y)
+
+		// A heavily damaged ally may explode: keep clear of its whole blast
+		safeDistance := minSafeDistance
+		if ally.Damage*2 > game.ShipData[ally.Ship].MaxDamage {
+			safeDistance = math.Max(minSafeDistance, cfg.ExplosionMaxDist)
+		}
 
 		// Consider all allies within extended range for separation
-		if dist < SepMinSafeDistance && dist > 0 {
+		if dist < safeDistance && dist > 0 {
 			nearbyAllies++
 
 			// Normalized vector away from ally
-			dx := p.X - ally.X
-			dy := p.Y - ally.Y
+			dx, dy := s.delta(ally.X, ally.Y, p.X, p.Y)
 
 			// Normalize
 			norm := math.Sqrt(dx*dx + dy*dy)
@@ -350,15 +428,15 @@ func (s *Server) calculateSeparationVector(p *game.Player) SeparationVector {
 
 			// Repulsion strength based on distance zone
 			var strength float64
-			if dist < SepCriticalDistance {
+			if dist < criticalDistance {
 				// Emergency separation - extremely strong repulsion
-				strength = SepCriticalStrength * (SepCriticalDistance - dist) / SepCriticalDistance
-			} else if dist < SepIdealDistance {
+				strength = SepCriticalStrength * (criticalDistance - dist) / criticalDistance
+			} else if dist < idealDistance {
 				// Strong separation to maintain ideal distance
-				strength = SepIdealStrength * (SepIdealDistance - dist) / SepIdealDistance
+				strength = SepIdealStrength * (idealDistance - dist) / idealDistance
 			} else {
 				// Moderate separation for distances beyond ideal
-				strength = SepModerateStrength * (SepMinSafeDistance - dist) / SepMinSafeDistance
+				strength = SepModerateStrength * (safeDistance - dist) / safeDistance
 			}
 
 			// Extra repulsion if both bots are moving toward the same target
@@ -406,6 +484,55 @@ func (s *Server) calculateSeparationVector(p *game.Player) SeparationVector {
 	return separationVec
 }
 
+// shouldPanicRetreat reports whether the bot is critically damaged and
+// outnumbered by visible enemies, in which case survival overrides every
+// other objective.
+func (s *Server) shouldPanicRetreat(p *game.Player) bool {
+	shipStats := game.ShipData[p.Ship]
+	if float64(p.Damage) < float64(shipStats.MaxDamage)*PanicDamageRatio {
+		return false
+	}
+
+	closeEnemies := 0
+	for _, other := range s.gameState.Players {
+		if other.Status != game.StatusAlive || other.Team == p.Team || other.Cloaked {
+			continue
+		}
+		if s.distance(p.X, p.Y, other.X, other.Y) < PanicEnemyRange {
+			closeEnemies++
+		}
+	}
+	return closeEnemies >= PanicMinEnemies
+}
+
+// panicRetreat breaks off all activity and runs for the nearest friendly
+// planet at full speed, cloaking when there is fuel to spare. The bot holds
+// fire so it doesn't draw more attention while escaping.
+func (s *Server) panicRetreat(p *game.Player) {
+	p.Orbiting = -1
+	p.Bombing = false
+	stopBeaming(p)
+	cancelRepair(p)
+	p.Tractoring = -1
+	p.Pressoring = -1
+	p.BotPlanetApproachID = -1
+	p.BotDefenseTarget = -1
+
+	if p.Fuel > PanicCloakMinFuel && !s.recloakBlocked(p) {
+		p.Cloaked = true
+	}
+
+	// Head for the nearest friendly planet, or the team's home if none remain
+	havenX, havenY := s.teamHome(p.Team)
+	if haven := s.nearestPlanet(p, func(pl *game.Planet) bool { return pl.Owner == p.Team }); haven != nil {
+		havenX, havenY = haven.X, haven.Y
+	}
+
+	p.BotCooldown = 3
+	dir := s.bearing(p.X, p.Y, havenX, havenY)
+	s.applySafeNavigation(p, dir, float64(game.ShipData[p.Ship].MaxSpeed))
+}
+
 // moveToSafeArea moves the bot to a safe area when no neutral planets are available
 func (s *Server) moveToSafeArea(p *game.Player) {
 	// Find the center of friendly space
@@ -414,7 +541,7 @@ func (s *Server) moveToSafeArea(p *game.Player) {
 
 	for i := range s.gameState.Planets {
 		planet := s.gameState.Planets[i]
-		if planet.Owner == p.Team {
+		if planet != nil && planet.Owner == p.Team {
 			friendlyX += planet.X
 			friendlyY += planet.Y
 			friendlyCount++
@@ -433,8 +560,7 @@ func (s *Server) moveToSafeArea(p *game.Player) {
 		targetY := friendlyY + offsetDist*math.Sin(angleOffset)
 
 		// Navigate to safe position
-		dx := targetX - p.X
-		dy := targetY - p.Y
+		dx, dy := s.delta(p.X, p.Y, targetX, targetY)
 		dist := math.Sqrt(dx*dx + dy*dy)
 
 		if dist > 1000 {
@@ -443,7 +569,7 @@ func (s *Server) moveToSafeArea(p *game.Player) {
 			p.DesSpeed = float64(game.ShipData[p.Ship].MaxSpeed) * 0.5 // Move at half speed to conserve fuel
 
 			// Apply separation to avoid bunching
-			separationVector := s.calculateSeparationVector(p)
+			separationVector := s.botSeparation(p)
 			p.DesDir = blendWithSeparation(baseDir, separationVector, 300.0, 0.5)
 		} else {
 			// At safe area - orbit slowly
@@ -454,8 +580,7 @@ func (s *Server) moveToSafeArea(p *game.Player) {
 		// Clear any combat/planet actions
 		p.Orbiting = -1
 		p.Bombing = false
-		p.Beaming = false
-		p.BeamingUp = false
+		stopBeaming(p)
 		p.BotCooldown = 10
 	} else {
 		// No friendly planets - just stop and wait
@@ -476,8 +601,9 @@ func (s *Server) executePatrol(p *game.Player) {
 
 		if controlRatio < 0.3 {
 			// Defensive patrol near home
-			p.BotGoalX = float64(game.TeamHomeX[p.Team]) + float64(rand.Intn(15000)-7500)
-			p.BotGoalY = float64(game.TeamHomeY[p.Team]) + float64(rand.Intn(15000)-7500)
+			homeX, homeY := s.teamHome(p.Team)
+			p.BotGoalX = homeX + float64(rand.Intn(15000)-7500)
+			p.BotGoalY = homeY + float64(rand.Intn(15000)-7500)
 		} else {
 			// Offensive patrol in contested areas
 			// Collect all frontline planets and pick one randomly
@@ -485,7 +611,7 @@ func (s *Server) executePatrol(p *game.Player) {
 			var frontlineCandidates []*game.Planet
 			for i := range s.gameState.Planets {
 				planet := s.gameState.Planets[i]
-				if s.isPlanetOnFrontline(planet, p.Team) {
+				if planet != nil && s.isPlanetOnFrontline(planet, p.Team) {
 					frontlineCandidates = append(frontlineCandidates, planet)
 				}
 			}
@@ -507,8 +633,9 @@ func (s *Server) executePatrol(p *game.Player) {
 					}
 				}
 				enemyTeam := enemyTeams[rand.Intn(len(enemyTeams))]
-				p.BotGoalX = float64(game.TeamHomeX[enemyTeam]) + float64(rand.Intn(20000)-10000)
-				p.BotGoalY = float64(game.TeamHomeY[enemyTeam]) + float64(rand.Intn(20000)-10000)
+				enemyX, enemyY := s.teamHome(enemyTeam)
+				p.BotGoalX = enemyX + float64(rand.Intn(20000)-10000)
+				p.BotGoalY = enemyY + float64(rand.Intn(20000)-10000)
 			}
 		}
 
@@ -519,10 +646,11 @@ func (s *Server) executePatrol(p *game.Player) {
 		p.BotHasGoal = true
 	}
 
-	// Check if bot is stuck at galaxy edge and reset patrol
+	// Check if bot is stuck at galaxy edge and reset patrol (a wrapping
+	// galaxy has no edge to get stuck on)
 	edgeMargin := 2000.0
-	if p.X < edgeMargin || p.X > game.GalaxyWidth-edgeMargin ||
-		p.Y < edgeMargin || p.Y > game.GalaxyHeight-edgeMargin {
+	if s.config().GalaxyEdge != EdgeWrap && (p.X < edgeMargin || p.X > game.GalaxyWidth-edgeMargin ||
+		p.Y < edgeMargin || p.Y > game.GalaxyHeight-edgeMargin) {
 		// Bot is at edge, reset patrol destination
 		p.BotHasGoal = false
 		p.BotCooldown = 10
@@ -530,8 +658,7 @@ func (s *Server) executePatrol(p *game.Player) {
 	}
 
 	// Navigate to patrol point
-	dx := p.BotGoalX - p.X
-	dy := p.BotGoalY - p.Y
+	dx, dy := s.delta(p.X, p.Y, p.BotGoalX, p.BotGoalY)
 	dist := math.Hypot(dx, dy)
 
 	if dist < 3000 {
@@ -542,7 +669,7 @@ func (s *Server) executePatrol(p *game.Player) {
 
 		// Apply separation during patrol to spread bots across the map
 		// Stronger weight (200 divisor, 0.6 max) during patrol for better spread
-		separationVector := s.calculateSeparationVector(p)
+		separationVector := s.botSeparation(p)
 		p.DesDir = blendWithSeparation(baseDir, separationVector, 200.0, 0.6)
 		p.DesSpeed = float64(shipStats.MaxSpeed) * 0.8 // Sustainable cruise speed
 	}
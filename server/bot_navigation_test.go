@@ -66,7 +66,7 @@ func TestCalculateClearanceWithPlanets(t *testing.T) {
 	}
 
 	t.Run("No planets - clearance limited by walls", func(t *testing.T) {
-		clearance := calculateClearanceWithPlanets(p, 0, nil)
+		clearance := calculateClearanceWithPlanets(p, 0, nil, false)
 		// Test point is 5000 units east of center. Wall clearance should
 		// be min of all wall distances from that point.
 		testX := p.X + 5000
@@ -82,7 +82,7 @@ func TestCalculateClearanceWithPlanets(t *testing.T) {
 	t.Run("Planet directly ahead reduces clearance", func(t *testing.T) {
 		// Place planet 5000 units east (right where test point lands)
 		planets := []planetPos{{x: p.X + 5000, y: p.Y}}
-		clearance := calculateClearanceWithPlanets(p, 0, planets)
+		clearance := calculateClearanceWithPlanets(p, 0, planets, false)
 		// Planet is at test point -> distance = 0, clearance = max(0 - 2000, 0) = 0
 		if clearance != 0 {
 			t.Errorf("clearance = %f, want 0 (planet at test point)", clearance)
@@ -92,8 +92,8 @@ func TestCalculateClearanceWithPlanets(t *testing.T) {
 	t.Run("Planet far from test direction does not affect clearance", func(t *testing.T) {
 		// Planet far south-west, we're testing east — planet clearance exceeds wall clearance
 		planets := []planetPos{{x: p.X - 40000, y: p.Y + 40000}}
-		clearanceWithPlanet := calculateClearanceWithPlanets(p, 0, planets)
-		clearanceWithout := calculateClearanceWithPlanets(p, 0, nil)
+		clearanceWithPlanet := calculateClearanceWithPlanets(p, 0, planets, false)
+		clearanceWithout := calculateClearanceWithPlanets(p, 0, nil, false)
 		if clearanceWithPlanet != clearanceWithout {
 			t.Errorf("distant planet affected clearance: %f vs %f", clearanceWithPlanet, clearanceWithout)
 		}
@@ -209,3 +209,146 @@ func TestGetEvasionSpeed(t *testing.T) {
 		}
 	})
 }
+
+// TestBotSeparationToggle verifies that with separation disabled a bot steers
+// exactly along its objective direction even with an ally alongside, while the
+// default configuration blends in repulsion from that ally.
+func TestBotSeparationToggle(t *testing.T) {
+	for _, separation := range []bool{true, false} {
+		gs := game.NewGameState()
+		cfg := DefaultConfig()
+		cfg.BotSeparation = separation
+		server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+		bot := gs.Players[0]
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = game.ShipCruiser
+		bot.IsBot = true
+		bot.X = 50000
+		bot.Y = 50000
+		bot.Orbiting = -1
+
+		// Ally just north of the bot, well inside the critical separation distance
+		ally := gs.Players[1]
+		ally.Status = game.StatusAlive
+		ally.Team = game.TeamFed
+		ally.Ship = game.ShipCruiser
+		ally.X = 50000
+		ally.Y = 49200
+		ally.Orbiting = -1
+
+		const objective = 0.0 // due east
+		server.applySafeNavigation(bot, objective, 6)
+
+		if separation && bot.DesDir == objective {
+			t.Error("separation enabled: expected repulsion from the nearby ally to bend the heading")
+		}
+		if !separation && bot.DesDir != objective {
+			t.Errorf("separation disabled: DesDir = %.4f, want raw objective %.4f", bot.DesDir, objective)
+		}
+	}
+}
+
+// TestBotSeparationDistancesConfigurable verifies that shrinking the ideal and
+// critical separation distances lets bots fly closer together with only the
+// mild beyond-ideal repulsion instead of emergency separation.
+func TestBotSeparationDistancesConfigurable(t *testing.T) {
+	separation := func(cfg Config) SeparationVector {
+		gs := game.NewGameState()
+		server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+		bot := gs.Players[0]
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = game.ShipCruiser
+		bot.IsBot = true
+		bot.X = 50000
+		bot.Y = 50000
+		bot.Orbiting = -1
+		bot.BotTarget = -1
+
+		// Ally 1000 units away: inside the default critical distance
+		ally := gs.Players[1]
+		ally.Status = game.StatusAlive
+		ally.Team = game.TeamFed
+		ally.Ship = game.ShipCruiser
+		ally.X = 51000
+		ally.Y = 50000
+		ally.Orbiting = -1
+
+		return server.calculateSeparationVector(bot)
+	}
+
+	defaults := separation(DefaultConfig())
+
+	tight := DefaultConfig()
+	tight.BotSepIdealDistance = 800
+	tight.BotSepCriticalDistance = 400
+	tightSep := separation(tight)
+
+	if tightSep.magnitude >= defaults.magnitude {
+		t.Errorf("tight formation repulsion %.3f should be weaker than default %.3f", tightSep.magnitude, defaults.magnitude)
+	}
+	// One ally beyond the ideal distance only gets moderate repulsion
+	// (scaled by the single-ally magnitude factor of 1.3)
+	if limit := SepModerateStrength * 1.3; tightSep.magnitude > limit {
+		t.Errorf("tight formation repulsion %.3f exceeds moderate limit %.3f", tightSep.magnitude, limit)
+	}
+}
+
+// TestBotsKeepClearOfDeadlyEdge verifies that under the kill edge rule a bot
+// flying at full speed toward the edge turns back and survives, that bots
+// prefer an enemy away from the edge over one hugging it, and that the bounce
+// rule leaves a bot's course alone.
+func TestBotsKeepClearOfDeadlyEdge(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.GalaxyEdge = EdgeKill
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.IsBot = true
+	bot.Connected = true
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipScout
+	bot.Fuel = game.ShipData[bot.Ship].MaxFuel
+	bot.Orbiting = -1
+	bot.BotTarget = -1
+	bot.BotDefenseTarget = -1
+	bot.BotPlanetApproachID = -1
+	bot.X, bot.Y = DeadlyEdgeMargin+1000, 50000
+	bot.Dir, bot.DesDir = math.Pi, math.Pi // Due west, into the edge
+	bot.Speed = float64(game.ShipData[bot.Ship].MaxSpeed)
+	bot.DesSpeed = bot.Speed
+
+	for tick := 0; tick < 100; tick++ {
+		gs.Frame++
+		server.UpdateBots()
+		server.updatePlayerPhysics(bot, bot.ID)
+		if bot.Status != game.StatusAlive {
+			t.Fatalf("bot flew into the deadly edge at tick %d (x=%.0f)", tick, bot.X)
+		}
+	}
+
+	// Two enemies at the same range: one hugging the edge, one inside
+	bot.X, bot.Y = 50000, 10000
+	for i, y := range []float64{2000, 18000} {
+		enemy := gs.Players[i+1]
+		enemy.Status = game.StatusAlive
+		enemy.Team = game.TeamKli
+		enemy.Ship = game.ShipCruiser
+		enemy.X, enemy.Y = 50000, y
+	}
+	if target := server.selectBestCombatTarget(bot); target == nil || target.ID != 2 {
+		t.Errorf("bot picked %v, want the enemy away from the edge", target)
+	}
+
+	cfg.GalaxyEdge = EdgeBounce
+	bot.X, bot.DesDir = 4000, math.Pi
+	server.steerClearOfDeadlyEdge(bot)
+	if bot.DesDir != math.Pi {
+		t.Errorf("bounce rule changed the course to %.3f, want it left at pi", bot.DesDir)
+	}
+}
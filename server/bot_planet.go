@@ -26,12 +26,12 @@ func (s *Server) findBestPlanetToTake(p *game.Player) *game.Planet {
 	for i := range s.gameState.Planets {
 		planet := s.gameState.Planets[i]
 
-		// Consider enemy or neutral planets
-		if planet.Owner == p.Team {
+		// Consider enemy or neutral planets we have not just given up on
+		if planet == nil || planet.Owner == p.Team || s.assaultAbandoned(p, planet) {
 			continue
 		}
 
-		dist := game.Distance(p.X, p.Y, planet.X, planet.Y)
+		dist := s.distance(p.X, p.Y, planet.X, planet.Y)
 		if dist > 30000 {
 			continue // Too far
 		}
@@ -58,6 +58,9 @@ func (s *Server) findBestPlanetToTake(p *game.Player) *game.Planet {
 			score += 2000 - armyDifficulty*200
 		}
 
+		// Avoid planets whose defensive fire would maul us on the way in
+		score -= s.planetFireRisk(p, planet) * PlanetFireRiskWeight
+
 		// Prefer agricultural planets (they produce armies)
 		if (planet.Flags & game.PlanetAgri) != 0 {
 			score += 2000
@@ -80,7 +83,7 @@ func (s *Server) findBestPlanetToTake(p *game.Player) *game.Planet {
 		allies := 0
 		for _, other := range s.gameState.Players {
 			if other.Status == game.StatusAlive && other.Team == p.Team && other.ID != p.ID {
-				otherDist := game.Distance(planet.X, planet.Y, other.X, other.Y)
+				otherDist := s.distance(planet.X, planet.Y, other.X, other.Y)
 				if otherDist < 10000 {
 					allies++
 				}
@@ -112,6 +115,18 @@ func (s *Server) findBestPlanetToTake(p *game.Player) *game.Planet {
 	return best
 }
 
+// planetFireRisk estimates the fraction of p's shields and hull that planet's
+// defensive fire would strip while p crosses its fire zone at top speed.
+func (s *Server) planetFireRisk(p *game.Player, planet *game.Planet) float64 {
+	if planet.Owner == p.Team || planet.Owner == game.TeamNone || planet.Armies == 0 {
+		return 0
+	}
+	shipStats := game.ShipData[p.Ship]
+	ticksInRange := s.config().PlanetFireRange / (float64(shipStats.MaxSpeed) * 20)
+	volleys := ticksInRange / 5 // Planets fire every 5 frames
+	return volleys * float64(s.planetFireDamage(planet)) / float64(shipStats.MaxShields+shipStats.MaxDamage)
+}
+
 // assessPlanetStrategicValue evaluates a planet's strategic importance
 func (s *Server) assessPlanetStrategicValue(planet *game.Planet, team int) float64 {
 	value := 0.0
@@ -121,11 +136,11 @@ func (s *Server) assessPlanetStrategicValue(planet *game.Planet, team int) float
 	nearbyEnemy := 0
 
 	for _, other := range s.gameState.Planets {
-		if other.ID == planet.ID {
+		if other == nil || other.ID == planet.ID {
 			continue
 		}
 
-		dist := game.Distance(planet.X, planet.Y, other.X, other.Y)
+		dist := s.distance(planet.X, planet.Y, other.X, other.Y)
 		if dist < 15000 {
 			if other.Owner == team {
 				nearbyFriendly++
@@ -146,7 +161,7 @@ func (s *Server) assessPlanetStrategicValue(planet *game.Planet, team int) float
 	}
 
 	// Central planets are more valuable for map control
-	distFromCenter := game.Distance(planet.X, planet.Y, game.GalaxyWidth/2, game.GalaxyHeight/2)
+	distFromCenter := s.distance(planet.X, planet.Y, game.GalaxyWidth/2, game.GalaxyHeight/2)
 	if distFromCenter < 20000 {
 		value += (20000 - distFromCenter) / 5000
 	}
@@ -174,7 +189,7 @@ func (s *Server) detectPlanetDefenders(planet *game.Planet, team int) *PlanetDef
 	for i := range s.gameState.Players {
 		player := s.gameState.Players[i]
 		if player.Status == game.StatusAlive && player.Team != team && !player.Cloaked {
-			dist := game.Distance(planet.X, planet.Y, player.X, player.Y)
+			dist := s.distance(planet.X, planet.Y, player.X, player.Y)
 			if dist <= DEFENDER_RADIUS {
 				// Add to defenders list
 				info.Defenders = append(info.Defenders, player)
@@ -190,6 +205,14 @@ func (s *Server) detectPlanetDefenders(planet *game.Planet, team int) *PlanetDef
 				if player.Armies > 0 {
 					info.HasCarrierDefense = true
 				}
+
+				// An enemy beaming armies down onto a planet it does not own
+				// is racing us for it
+				if player.Orbiting == planet.ID && player.Beaming && !player.BeamingUp &&
+					planet.Owner != player.Team &&
+					(info.ClosestBeamer == nil || dist < s.distance(planet.X, planet.Y, info.ClosestBeamer.X, info.ClosestBeamer.Y)) {
+					info.ClosestBeamer = player
+				}
 			}
 		}
 	}
@@ -214,17 +237,50 @@ func (s *Server) detectPlanetDefenders(planet *game.Planet, team int) *PlanetDef
 	return info
 }
 
+// raceForPlanet handles a contested capture: when an enemy is beaming armies
+// at the planet p wants, p commits to the planet and goes after the beamer
+// before its armies settle the race. Returns false when nobody is beaming there.
+func (s *Server) raceForPlanet(p *game.Player, planet *game.Planet, info *PlanetDefenderInfo) bool {
+	beamer := info.ClosestBeamer
+	if beamer == nil {
+		return false
+	}
+	if p.BotPlanetApproachID != planet.ID {
+		p.BotApproachDefense = info.DefenseScore
+	}
+	p.BotPlanetApproachID = planet.ID
+	p.Orbiting = -1
+	p.Bombing = false
+	stopBeaming(p)
+	s.engageCombat(p, beamer, s.distance(p.X, p.Y, beamer.X, beamer.Y))
+	return true
+}
+
+// contestBeamer fires on the closest enemy beaming at the planet a carrier
+// is beaming down to, keeping the carrier in orbit so its own beam-down wins
+// the race. Returns the bot cooldown to use.
+func (s *Server) contestBeamer(p *game.Player, planet *game.Planet, cooldown int) int {
+	beamer := s.detectPlanetDefenders(planet, p.Team).ClosestBeamer
+	if beamer == nil {
+		return cooldown
+	}
+	if p.NumTorps < game.MaxTorps && p.Fuel > game.ShipData[p.Ship].MaxFuel/4 {
+		s.fireBotTorpedo(p, beamer)
+	}
+	return min(cooldown, ContestedBeamCooldown)
+}
+
 // isPlanetOnFrontline checks if a planet is on the frontline
 func (s *Server) isPlanetOnFrontline(planet *game.Planet, team int) bool {
 	hasEnemyNearby := false
 	hasFriendlyNearby := false
 
 	for _, other := range s.gameState.Planets {
-		if other.ID == planet.ID {
+		if other == nil || other.ID == planet.ID {
 			continue
 		}
 
-		dist := game.Distance(planet.X, planet.Y, other.X, other.Y)
+		dist := s.distance(planet.X, planet.Y, other.X, other.Y)
 		if dist < 12000 {
 			if other.Owner == team {
 				hasFriendlyNearby = true
@@ -248,7 +304,7 @@ func (s *Server) nearestPlanet(p *game.Player, ok func(*game.Planet) bool) *game
 		if planet == nil || !ok(planet) {
 			continue
 		}
-		if dist := game.Distance(p.X, p.Y, planet.X, planet.Y); dist < minDist {
+		if dist := s.distance(p.X, p.Y, planet.X, planet.Y); dist < minDist {
 			minDist = dist
 			nearest = planet
 		}
@@ -256,9 +312,14 @@ func (s *Server) nearestPlanet(p *game.Player, ok func(*game.Planet) bool) *game
 	return nearest
 }
 
-// findNearestNeutralPlanet finds the closest neutral planet
+// findNearestNeutralPlanet finds the closest neutral planet. When capture
+// needs armies, only planets p's armies can take (fewer armies than p
+// carries) count.
 func (s *Server) findNearestNeutralPlanet(p *game.Player) *game.Planet {
-	return s.nearestPlanet(p, func(pl *game.Planet) bool { return pl.Owner == 0 })
+	needArmies := s.config().CaptureNeedsArmies
+	return s.nearestPlanet(p, func(pl *game.Planet) bool {
+		return pl.Owner == 0 && (!needArmies || pl.Armies < p.Armies)
+	})
 }
 
 // findNearestArmyPlanet finds the closest friendly planet with armies
@@ -269,18 +330,23 @@ func (s *Server) findNearestArmyPlanet(p *game.Player) *game.Planet {
 // findNearestEnemyArmyPlanet finds the closest enemy planet with armies
 func (s *Server) findNearestEnemyArmyPlanet(p *game.Player) *game.Planet {
 	return s.nearestPlanet(p, func(pl *game.Planet) bool {
-		return pl.Owner != p.Team && pl.Owner != 0 && pl.Armies > 4
+		return pl.Owner != p.Team && pl.Owner != 0 && pl.Armies > 4 && !s.assaultAbandoned(p, pl)
 	})
 }
 
 // findPlanetToDefend finds a friendly planet that needs defense
 func (s *Server) findPlanetToDefend(p *game.Player) *game.Planet {
+	// The team commander's defense order comes first
+	if planet := s.teamOrderPlanet(p, TeamOrderDefend); planet != nil && planet.Owner == p.Team {
+		return planet
+	}
+
 	var best *game.Planet
 	bestScore := WorstScore
 
 	for i := range s.gameState.Planets {
 		planet := s.gameState.Planets[i]
-		if planet.Owner != p.Team {
+		if planet == nil || planet.Owner != p.Team {
 			continue
 		}
 
@@ -288,7 +354,7 @@ func (s *Server) findPlanetToDefend(p *game.Player) *game.Planet {
 		threatLevel := 0.0
 		for _, enemy := range s.gameState.Players {
 			if enemy.Status == game.StatusAlive && enemy.Team != p.Team {
-				dist := game.Distance(planet.X, planet.Y, enemy.X, enemy.Y)
+				dist := s.distance(planet.X, planet.Y, enemy.X, enemy.Y)
 				if dist < 10000 {
 					threatLevel += (10000 - dist) / 1000
 					if enemy.Armies > 0 {
@@ -299,7 +365,7 @@ func (s *Server) findPlanetToDefend(p *game.Player) *game.Planet {
 		}
 
 		if threatLevel > 0 {
-			dist := game.Distance(p.X, p.Y, planet.X, planet.Y)
+			dist := s.distance(p.X, p.Y, planet.X, planet.Y)
 			score := threatLevel*1000 - dist/10
 
 			// Prioritize important planets
@@ -322,16 +388,21 @@ func (s *Server) findPlanetToDefend(p *game.Player) *game.Planet {
 
 // findPlanetToRaid finds an enemy planet suitable for raiding
 func (s *Server) findPlanetToRaid(p *game.Player) *game.Planet {
+	// The team commander's push target comes first while it has armies to bomb
+	if planet := s.teamOrderPlanet(p, TeamOrderPush); planet != nil && planet.Owner != p.Team && planet.Armies > 0 {
+		return planet
+	}
+
 	var best *game.Planet
 	bestScore := WorstScore
 
 	for i := range s.gameState.Planets {
 		planet := s.gameState.Planets[i]
-		if planet.Owner == p.Team || planet.Owner == 0 {
+		if planet == nil || planet.Owner == p.Team || planet.Owner == 0 {
 			continue
 		}
 
-		dist := game.Distance(p.X, p.Y, planet.X, planet.Y)
+		dist := s.distance(p.X, p.Y, planet.X, planet.Y)
 		if dist > 20000 {
 			continue
 		}
@@ -340,7 +411,7 @@ func (s *Server) findPlanetToRaid(p *game.Player) *game.Planet {
 		defenders := 0
 		for _, enemy := range s.gameState.Players {
 			if enemy.Status == game.StatusAlive && enemy.Team == planet.Owner {
-				if game.Distance(planet.X, planet.Y, enemy.X, enemy.Y) < 5000 {
+				if s.distance(planet.X, planet.Y, enemy.X, enemy.Y) < 5000 {
 					defenders++
 				}
 			}
@@ -372,8 +443,7 @@ func (s *Server) defendPlanet(p *game.Player, planet *game.Planet, enemy *game.P
 	// Clear any other bot states that would interfere
 	p.Orbiting = -1
 	p.Bombing = false
-	p.Beaming = false
-	p.BeamingUp = false
+	stopBeaming(p)
 	p.BotPlanetApproachID = -1
 
 	// Use comprehensive shield management for planet defense
@@ -381,7 +451,7 @@ func (s *Server) defendPlanet(p *game.Player, planet *game.Planet, enemy *game.P
 
 	// Calculate intercept position between enemy and planet
 	// We want to position ourselves optimally between the enemy and planet
-	enemyToPlanetDir := math.Atan2(planet.Y-enemy.Y, planet.X-enemy.X)
+	enemyToPlanetDir := s.bearing(enemy.X, enemy.Y, planet.X, planet.Y)
 
 	// Optimal intercept distance (3-5k from enemy, between enemy and planet)
 	optimalInterceptDist := 4000.0
@@ -394,12 +464,12 @@ func (s *Server) defendPlanet(p *game.Player, planet *game.Planet, enemy *game.P
 	interceptY := enemy.Y + math.Sin(enemyToPlanetDir)*optimalInterceptDist*0.7
 
 	// Check if we're positioned well (between enemy and planet)
-	distToIntercept := game.Distance(p.X, p.Y, interceptX, interceptY)
+	distToIntercept := s.distance(p.X, p.Y, interceptX, interceptY)
 
 	// Movement logic
 	if distToIntercept > 1500 || enemyDist > 6000 {
 		// Move to intercept position or chase enemy if too far
-		navDir := math.Atan2(interceptY-p.Y, interceptX-p.X)
+		navDir := s.bearing(p.X, p.Y, interceptX, interceptY)
 
 		// If enemy is far, move at full speed to close distance
 		var desiredSpeed float64
@@ -413,7 +483,7 @@ func (s *Server) defendPlanet(p *game.Player, planet *game.Planet, enemy *game.P
 		s.applySafeNavigation(p, navDir, desiredSpeed)
 	} else {
 		// We're in position - engage with combat maneuvering
-		angleToEnemy := math.Atan2(enemy.Y-p.Y, enemy.X-p.X)
+		angleToEnemy := s.bearing(p.X, p.Y, enemy.X, enemy.Y)
 
 		// Check if we're too close - use lateral movement to maintain range
 		if enemyDist < 2000 {
@@ -428,6 +498,45 @@ func (s *Server) defendPlanet(p *game.Player, planet *game.Planet, enemy *game.P
 		}
 	}
 
+	// Mine the approach of an enemy still on its way in; fight it directly
+	// once it is close or turns away
+	if s.mineApproach(p, planet, enemy, enemyDist) {
+		p.BotCooldown = 4
+		return
+	}
+
 	// Aggressive weapon usage for planet defense
 	s.planetDefenseWeaponLogic(p, enemy, enemyDist)
 }
+
+// mineApproach lays a torpedo wall across the line an inbound enemy is flying
+// toward planet: the spread is aimed where a straight approach would carry the
+// enemy, not at the ship itself, so it has to turn off its course or fly
+// through the torps. Returns true if the wall was fired.
+func (s *Server) mineApproach(p *game.Player, planet *game.Planet, enemy *game.Player, enemyDist float64) bool {
+	// One wall at a time, and only while the enemy is still out of close range
+	if p.NumTorps > 0 || p.Fuel < MineMinFuel || enemyDist < MineMinRange || enemy.Speed < 1 || enemy.Cloaked {
+		return false
+	}
+
+	approachDir := s.bearing(enemy.X, enemy.Y, planet.X, planet.Y)
+	if math.Cos(enemy.Dir-approachDir) < MineApproachCos {
+		return false // Not heading in
+	}
+
+	// Solve against an enemy flying straight at the planet at its current speed
+	shipStats := game.ShipData[p.Ship]
+	approachVel := Vector2D{
+		X: enemy.Speed * math.Cos(approachDir) * 20,
+		Y: enemy.Speed * math.Sin(approachDir) * 20,
+	}
+	enemyPos := Point2D{}
+	enemyPos.X, enemyPos.Y = s.nearestImage(p.X, p.Y, enemy.X, enemy.Y)
+	solution, ok := InterceptDirection(Point2D{X: p.X, Y: p.Y}, enemyPos, approachVel, float64(shipStats.TorpSpeed*20))
+	if !ok || solution.TimeToIntercept > float64(shipStats.TorpFuse) {
+		return false // The torps would burn out before the enemy arrives
+	}
+
+	s.fireTorpedoSpreadDir(p, solution.Direction, MineSpreadTorps)
+	return p.NumTorps > 0
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"math"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// tryPlasmaAreaDenial fires a plasma into the lane an enemy is using to
+// approach a friendly frontline planet near the bot, aimed at the point on
+// that lane the enemy will have reached when the plasma arrives. It reports
+// whether a plasma was fired. Enemies already within phaser range are left to
+// the bot's direct combat, and a bot fires at most one denial plasma every
+// DenialCooldownFrames so it keeps plasma for the fight itself.
+func (s *Server) tryPlasmaAreaDenial(p *game.Player) bool {
+	if !s.botCanFirePlasma(p) || p.Fuel < DenialMinFuel || s.gameState.Frame < p.BotDenialReady {
+		return false
+	}
+
+	shipStats := game.ShipData[p.Ship]
+	plasmaSpeed, _ := s.plasmaFlight(p.Ship)
+	maxRange := s.maxPlasmaRange(p.Ship)
+	minRange := maxRange * 0.25 // Same standoff as planet defense plasma
+	phaserRange := game.PhaserRange(shipStats)
+
+	for _, planet := range s.gameState.Planets {
+		if planet == nil || planet.Owner != p.Team ||
+			s.distance(p.X, p.Y, planet.X, planet.Y) > DenialGuardRange ||
+			!s.isPlanetOnFrontline(planet, p.Team) {
+			continue
+		}
+
+		for _, enemy := range s.gameState.Players {
+			if enemy.Status != game.StatusAlive || enemy.Team == p.Team || enemy.Cloaked || enemy.Speed <= 0 ||
+				s.distance(p.X, p.Y, enemy.X, enemy.Y) < phaserRange {
+				continue
+			}
+			laneLen := s.distance(enemy.X, enemy.Y, planet.X, planet.Y)
+			if laneLen > DenialApproachRange || laneLen == 0 {
+				continue
+			}
+
+			// The enemy must be heading down the lane toward the planet
+			laneX, laneY := s.delta(enemy.X, enemy.Y, planet.X, planet.Y)
+			laneX /= laneLen
+			laneY /= laneLen
+			vel := s.targetVelocity(enemy)
+			speed := math.Hypot(vel.X, vel.Y)
+			closing := vel.X*laneX + vel.Y*laneY
+			if speed == 0 || closing/speed < DenialHeadingCos {
+				continue
+			}
+
+			// Lead along the lane, stopping at the planet itself
+			aimX, aimY := enemy.X, enemy.Y
+			for i := 0; i < 3; i++ {
+				travel := math.Min(closing*s.distance(p.X, p.Y, aimX, aimY)/plasmaSpeed, laneLen)
+				aimX = enemy.X + laneX*travel
+				aimY = enemy.Y + laneY*travel
+			}
+
+			aimDist := s.distance(p.X, p.Y, aimX, aimY)
+			if aimDist < minRange || aimDist > maxRange {
+				continue
+			}
+			if !s.fireBotPlasmaDir(p, s.bearing(p.X, p.Y, aimX, aimY)) {
+				return false
+			}
+			p.BotDenialReady = s.gameState.Frame + DenialCooldownFrames
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"math"
 	"testing"
 
 	"github.com/lab1702/netrek-web/game"
@@ -267,3 +268,194 @@ func TestBotPlasmaFiring95Percent(t *testing.T) {
 		}
 	}
 }
+
+// TestPlasmaTorpComboAimsAtDodgePath verifies that after leading with plasma
+// the follow-up torpedo spread is centered on the target's predicted dodge
+// position rather than its straight-line intercept, and that the prediction
+// leaves the plasma where it was.
+func TestPlasmaTorpComboAimsAtDodgePath(t *testing.T) {
+	server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 10)}
+
+	bot := server.gameState.Players[0]
+	bot.Status = game.StatusAlive
+	bot.IsBot = true
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.X, bot.Y = 50000, 50000
+
+	// Target crossing the bot's bow, so the plasma forces it off its course
+	target := server.gameState.Players[1]
+	target.Status = game.StatusAlive
+	target.Team = game.TeamRom
+	target.Ship = game.ShipCruiser
+	target.X, target.Y = 56000, 50000
+	target.Dir = math.Pi / 2
+	target.Speed = 6
+
+	if !server.firePlasmaTorpCombo(bot, target) {
+		t.Fatal("combo did not launch its plasma")
+	}
+	if len(server.gameState.Plasmas) != 1 || len(server.gameState.Torps) != ComboTorpCount {
+		t.Fatalf("fired %d plasmas and %d torps, want 1 and %d",
+			len(server.gameState.Plasmas), len(server.gameState.Torps), ComboTorpCount)
+	}
+
+	plasma := server.gameState.Plasmas[0]
+	plasmaX, plasmaY := plasma.X, plasma.Y
+	dodgeX, dodgeY := server.predictPlasmaDodgePosition(bot, target, plasma)
+	if plasma.X != plasmaX || plasma.Y != plasmaY {
+		t.Errorf("prediction moved the plasma to (%.0f, %.0f)", plasma.X, plasma.Y)
+	}
+	if math.Abs(dodgeX-target.X) < 500 {
+		t.Fatalf("predicted dodge (%.0f, %.0f) is not off the target's course", dodgeX, dodgeY)
+	}
+
+	// The middle torpedo of the spread carries no offset, only jitter
+	center := server.gameState.Torps[ComboTorpCount/2]
+	want := math.Atan2(dodgeY-bot.Y, dodgeX-bot.X)
+	if diff := AngleDifference(center.Dir, want); diff > (maxJitterDeg+0.5)*math.Pi/180 {
+		t.Errorf("center torpedo heading %.3f, want %.3f toward predicted dodge (off by %.3f rad)", center.Dir, want, diff)
+	}
+}
+
+// TestPlasmaDodgePredictionFollowsBotDodge verifies that the combo predicts
+// the break the bots' own dodge logic makes: a target closing head-on, which
+// that logic keeps on course toward the shooter, is not assumed to turn.
+func TestPlasmaDodgePredictionFollowsBotDodge(t *testing.T) {
+	server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 10)}
+
+	bot := server.gameState.Players[0]
+	bot.Status = game.StatusAlive
+	bot.IsBot = true
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.X, bot.Y = 50000, 50000
+
+	target := server.gameState.Players[1]
+	target.Status = game.StatusAlive
+	target.Team = game.TeamRom
+	target.Ship = game.ShipCruiser
+	target.X, target.Y = 56000, 50000
+	target.Dir = math.Pi
+	target.Speed = 6
+
+	if !server.fireBotPlasma(bot, target) {
+		t.Fatal("bot did not launch its plasma")
+	}
+	dodgeX, dodgeY := server.predictPlasmaDodgePosition(bot, target, server.gameState.Plasmas[0])
+	if math.Abs(dodgeY-target.Y) > 1 || dodgeX >= target.X {
+		t.Errorf("predicted dodge (%.0f, %.0f), want ahead on the target's course y=%.0f", dodgeX, dodgeY, target.Y)
+	}
+}
+
+// TestPlasmaAreaDenialLeadsEnemyDownTheLane verifies a bot fires plasma across
+// an enemy's approach to a frontline planet, ahead of the enemy and short of
+// the planet, waits out its cooldown before firing again, and holds fire once
+// the enemy turns away.
+func TestPlasmaAreaDenialLeadsEnemyDownTheLane(t *testing.T) {
+	server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 10)}
+
+	// A frontline chokepoint: a Romulan planet between a Romulan rear
+	// planet and a Federation planet, with everything else neutral
+	for _, planet := range server.gameState.Planets {
+		planet.Owner = game.TeamNone
+	}
+	choke := server.gameState.Planets[0]
+	choke.Owner = game.TeamRom
+	choke.X, choke.Y = 50000, 50000
+	enemyPlanet := server.gameState.Planets[1]
+	enemyPlanet.Owner = game.TeamFed
+	enemyPlanet.X, enemyPlanet.Y = 58000, 50000
+	rear := server.gameState.Planets[2]
+	rear.Owner = game.TeamRom
+	rear.X, rear.Y = 50000, 60000
+
+	bot := server.gameState.Players[0]
+	bot.Status = game.StatusAlive
+	bot.IsBot = true
+	bot.Team = game.TeamRom
+	bot.Ship = game.ShipCruiser
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.X, bot.Y = 50000, 45000
+
+	// Enemy running down the lane toward the chokepoint, outside phaser range
+	enemy := server.gameState.Players[1]
+	enemy.Status = game.StatusAlive
+	enemy.Team = game.TeamFed
+	enemy.Ship = game.ShipCruiser
+	enemy.X, enemy.Y = 42000, 50000
+	enemy.Dir = 0
+	enemy.Speed = 6
+
+	if !server.tryPlasmaAreaDenial(bot) {
+		t.Fatal("bot did not fire plasma into the approach lane")
+	}
+	if len(server.gameState.Plasmas) != 1 {
+		t.Fatalf("fired %d plasmas, want 1", len(server.gameState.Plasmas))
+	}
+
+	// Where the plasma crosses the lane: ahead of the enemy, short of the planet
+	dir := server.gameState.Plasmas[0].Dir
+	crossX := bot.X + (choke.Y-bot.Y)*math.Cos(dir)/math.Sin(dir)
+	if crossX < enemy.X+1000 || crossX > choke.X {
+		t.Errorf("plasma crosses the lane at x=%.0f, want ahead of the enemy (%.0f) and short of the planet (%.0f)", crossX, enemy.X, choke.X)
+	}
+
+	// A second denial plasma waits for the cooldown
+	server.gameState.Plasmas = nil
+	bot.NumPlasma = 0
+	if server.tryPlasmaAreaDenial(bot) {
+		t.Error("bot fired a second denial plasma within the cooldown")
+	}
+
+	// An enemy heading away from the chokepoint is not worth a plasma
+	server.gameState.Frame = bot.BotDenialReady
+	enemy.Dir = math.Pi
+	if server.tryPlasmaAreaDenial(bot) {
+		t.Error("bot fired plasma at an enemy leaving the lane")
+	}
+}
+
+// TestPlasmaSpeedScaleStretchesRange verifies that scaling plasma speed scales
+// a ship's maximum plasma range by the same factor, and that bots both fire
+// faster plasmas and engage out to the longer range.
+func TestPlasmaSpeedScaleStretchesRange(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PlasmaSpeedScale = 2.0
+	server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 10), cfg: &cfg}
+
+	ship := game.ShipBattleship
+	baseRange := game.MaxPlasmaRangeForShip(ship)
+	if got := server.maxPlasmaRange(ship); got != 2*baseRange {
+		t.Errorf("max plasma range at 2x speed = %.0f, want %.0f", got, 2*baseRange)
+	}
+	if got, want := server.effectivePlasmaRange(ship, 0.5), baseRange; got != want {
+		t.Errorf("half of the scaled max range = %.0f, want %.0f", got, want)
+	}
+
+	shooter := server.gameState.Players[0]
+	shooter.Status = game.StatusAlive
+	shooter.Ship = ship
+	shooter.Team = game.TeamFed
+	shooter.X, shooter.Y = 50000, 50000
+	shooter.Fuel = game.ShipData[ship].MaxFuel
+
+	// Beyond the classic plasma range but inside the doubled one
+	target := server.gameState.Players[1]
+	target.Status = game.StatusAlive
+	target.Ship = game.ShipCruiser
+	target.Team = game.TeamKli
+	target.X, target.Y = shooter.X+baseRange*1.5, shooter.Y
+
+	if !server.fireBotPlasma(shooter, target) {
+		t.Fatal("bot should fire at a target within the scaled plasma range")
+	}
+	plasma := server.gameState.Plasmas[0]
+	stats := game.ShipData[ship]
+	if plasma.Speed != float64(stats.PlasmaSpeed*20)*2 || plasma.Fuse != stats.PlasmaFuse {
+		t.Errorf("plasma speed=%.0f fuse=%d, want doubled speed %d and unchanged fuse %d",
+			plasma.Speed, plasma.Fuse, stats.PlasmaSpeed*40, stats.PlasmaFuse)
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"github.com/lab1702/netrek-web/game"
+)
+
+// tryRecharge breaks p off to recharge fuel. A bot whose fuel has dropped
+// below its caution profile's refuel level with no enemy inside
+// RechargeThreatRange drops shields and holds at the nearest friendly fuel
+// planet, or in place if the team has none, until fuel is back to
+// RechargeFullFuel. An enemy closing in or a hit from an unseen attacker
+// ends the recharge early. Reports whether p spent its turn recharging.
+func (s *Server) tryRecharge(p *game.Player, enemyDist float64, recentlyHit bool) bool {
+	shipStats := game.ShipData[p.Ship]
+	threatened := enemyDist < RechargeThreatRange || recentlyHit
+
+	if !p.BotRecharging {
+		if threatened || float64(p.Fuel) >= float64(shipStats.MaxFuel)*s.botCaution(p).RefuelFuel {
+			return false
+		}
+		p.BotRecharging = true
+	}
+	if threatened || float64(p.Fuel) >= float64(shipStats.MaxFuel)*RechargeFullFuel {
+		p.BotRecharging = false
+		return false
+	}
+
+	p.BotTarget = -1
+	p.Shields_up = false
+	p.Bombing = false
+	stopBeaming(p)
+
+	planet := s.findNearestFuelPlanet(p)
+	if planet == nil {
+		p.DesSpeed = 0
+		return true
+	}
+	if p.Orbiting == planet.ID {
+		p.DesSpeed = 0
+		return true
+	}
+
+	dist := s.distance(p.X, p.Y, planet.X, planet.Y)
+	if dist < OrbitDistance {
+		s.botOrbit(p, planet)
+		return true
+	}
+	p.Orbiting = -1
+	s.applySafeNavigation(p, s.bearing(p.X, p.Y, planet.X, planet.Y), s.getOptimalSpeed(p, dist))
+	return true
+}
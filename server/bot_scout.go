@@ -0,0 +1,68 @@
+package server
+
+import (
+	"github.com/lab1702/netrek-web/game"
+)
+
+// isTeamScout reports whether p is its team's scout: the fastest living bot
+// that is not a starbase and carries no armies, the lowest slot breaking
+// ties. A team without a scout ship still sends its next fastest ship, and
+// picking by speed and slot keeps a single scout per team without tracking
+// the role on the player.
+func (s *Server) isTeamScout(p *game.Player) bool {
+	if !canScout(p) {
+		return false
+	}
+	speed := game.ShipData[p.Ship].MaxSpeed
+	for _, other := range s.gameState.Players {
+		if other == p || !other.IsBot || other.Team != p.Team || !canScout(other) {
+			continue
+		}
+		otherSpeed := game.ShipData[other.Ship].MaxSpeed
+		if otherSpeed > speed || (otherSpeed == speed && other.ID < p.ID) {
+			return false
+		}
+	}
+	return true
+}
+
+// canScout reports whether p is free to take the scout role.
+func canScout(p *game.Player) bool {
+	return !p.Dummy && p.Status == game.StatusAlive && p.Ship != game.ShipStarbase && p.Armies == 0
+}
+
+// findUnscoutedEnemyPlanet returns the nearest enemy planet p's team has no
+// intel on, or nil once every enemy planet has been scouted.
+func (s *Server) findUnscoutedEnemyPlanet(p *game.Player) *game.Planet {
+	return s.nearestPlanet(p, func(pl *game.Planet) bool {
+		return pl.Owner != p.Team && pl.Owner != game.TeamNone && pl.Info&p.Team == 0
+	})
+}
+
+// scoutPlanet flies p to planet and orbits it to update its team's intel, the
+// same way a human scouts. The scout reports enemy carriers it sees to nearby
+// allies and turns away from enemies that get close rather than fighting them.
+func (s *Server) scoutPlanet(p *game.Player, planet *game.Planet, nearestEnemy *game.Player, enemyDist float64) {
+	if nearestEnemy != nil && nearestEnemy.Armies > 0 {
+		s.broadcastTargetToAllies(p, nearestEnemy, BroadcastTargetMinValue)
+	}
+
+	dist := s.distance(p.X, p.Y, planet.X, planet.Y)
+	if dist < OrbitDistance {
+		s.botOrbit(p, planet)
+		p.BotCooldown = 5
+		return
+	}
+
+	baseDir := s.bearing(p.X, p.Y, planet.X, planet.Y)
+	speed := s.getOptimalSpeed(p, dist)
+	if nearestEnemy != nil && enemyDist < ScoutEvadeRange {
+		baseDir = s.bearing(nearestEnemy.X, nearestEnemy.Y, p.X, p.Y)
+		speed = float64(game.ShipData[p.Ship].MaxSpeed)
+	}
+
+	p.Orbiting = -1
+	p.Bombing = false
+	stopBeaming(p)
+	s.applySafeNavigation(p, baseDir, speed)
+}
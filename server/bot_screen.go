@@ -0,0 +1,112 @@
+package server
+
+import (
+	"math"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// findHumanAssault returns the nearest human teammate within
+// ScreenSupportRange of p who is orbiting an enemy or neutral planet to bomb
+// or capture it and is not already screened by ScreenMaxPerHuman closer bots.
+// Bots in the defense squad keep to their planets. Returns nil, nil when no
+// human needs p as a screen.
+func (s *Server) findHumanAssault(p *game.Player) (*game.Player, *game.Planet) {
+	if s.gameState.BotSquads[p.ID] == SquadDefense {
+		return nil, nil
+	}
+
+	var human *game.Player
+	var planet *game.Planet
+	bestDist := ScreenSupportRange
+
+	for _, ally := range s.gameState.Players {
+		if ally.IsBot || ally.Status != game.StatusAlive || ally.Team != p.Team ||
+			ally.Orbiting < 0 || ally.Orbiting >= game.MaxPlanets {
+			continue
+		}
+		target := s.gameState.Planets[ally.Orbiting]
+		if target == nil || target.Owner == p.Team {
+			continue
+		}
+		if dist := s.distance(p.X, p.Y, ally.X, ally.Y); dist < bestDist && s.closerScreens(p, ally, dist) < ScreenMaxPerHuman {
+			bestDist = dist
+			human = ally
+			planet = target
+		}
+	}
+	return human, planet
+}
+
+// closerScreens counts p's teammate bots outside the defense squad that are
+// closer than dist to human (ties going to the lower slot), the ones that
+// screen human ahead of p.
+func (s *Server) closerScreens(p, human *game.Player, dist float64) int {
+	count := 0
+	for _, bot := range s.gameState.Players {
+		if bot == p || !bot.IsBot || bot.Status != game.StatusAlive || bot.Team != p.Team ||
+			s.gameState.BotSquads[bot.ID] == SquadDefense {
+			continue
+		}
+		if d := s.distance(bot.X, bot.Y, human.X, human.Y); d < dist || (d == dist && bot.ID < p.ID) {
+			count++
+		}
+	}
+	return count
+}
+
+// findScreenThreat returns the visible enemy closest to planet within
+// ScreenThreatRange, the defender most likely to break up the assault.
+func (s *Server) findScreenThreat(p *game.Player, planet *game.Planet) *game.Player {
+	var threat *game.Player
+	bestDist := ScreenThreatRange
+
+	for _, enemy := range s.gameState.Players {
+		if enemy.Status != game.StatusAlive || enemy.Team == p.Team || enemy.Cloaked {
+			continue
+		}
+		if dist := s.distance(planet.X, planet.Y, enemy.X, enemy.Y); dist < bestDist {
+			bestDist = dist
+			threat = enemy
+		}
+	}
+	return threat
+}
+
+// screenHuman keeps p between a human teammate's assault on planet and the
+// enemy defender closing on it, fighting the defender once it is within
+// ScreenEngageRange. With no defender in sight p circles the planet at
+// ScreenStandoff, ScreenOrbitStep further round each decision, leaving the
+// bombing and beaming to the human.
+func (s *Server) screenHuman(p *game.Player, planet *game.Planet) {
+	maxSpeed := float64(game.ShipData[p.Ship].MaxSpeed)
+
+	p.Orbiting = -1
+	p.Bombing = false
+	stopBeaming(p)
+
+	threat := s.findScreenThreat(p, planet)
+	if threat == nil {
+		if s.distance(p.X, p.Y, planet.X, planet.Y) > ScreenStandoff*1.5 {
+			s.applySafeNavigation(p, s.bearing(p.X, p.Y, planet.X, planet.Y), maxSpeed)
+			return
+		}
+		angle := s.bearing(planet.X, planet.Y, p.X, p.Y) + ScreenOrbitStep
+		stationX := planet.X + math.Cos(angle)*ScreenStandoff
+		stationY := planet.Y + math.Sin(angle)*ScreenStandoff
+		s.applySafeNavigation(p, s.bearing(p.X, p.Y, stationX, stationY), maxSpeed*0.5)
+		return
+	}
+
+	p.BotTarget = threat.ID
+	if dist := s.distance(p.X, p.Y, threat.X, threat.Y); dist < ScreenEngageRange {
+		s.engageCombat(p, threat, dist)
+		return
+	}
+
+	// Take station on the defender's line of approach
+	toThreat := s.bearing(planet.X, planet.Y, threat.X, threat.Y)
+	stationX := planet.X + math.Cos(toThreat)*ScreenStandoff
+	stationY := planet.Y + math.Sin(toThreat)*ScreenStandoff
+	s.applySafeNavigation(p, s.bearing(p.X, p.Y, stationX, stationY), maxSpeed)
+}
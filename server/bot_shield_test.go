@@ -338,3 +338,66 @@ func TestTorpedoThreatDetection(t *testing.T) {
 		})
 	}
 }
+
+// TestCautionProfileShieldFuelReserve verifies that more cautious bots keep
+// more fuel in reserve before shielding, and that bots without a profile of
+// their own follow the server default.
+func TestCautionProfileShieldFuelReserve(t *testing.T) {
+	// lowestShieldFuel finds the least fuel at which a bot of the given
+	// profile raises shields against a torpedo closing from 1800 units
+	lowestShieldFuel := func(caution string) int {
+		for fuel := 0; fuel <= 4000; fuel += 50 {
+			gs := game.NewGameState()
+			server := &Server{
+				gameState: gs,
+				broadcast: make(chan ServerMessage, 100),
+			}
+			bot := gs.Players[0]
+			bot.Status = game.StatusAlive
+			bot.Team = game.TeamFed
+			bot.Ship = game.ShipDestroyer
+			bot.IsBot = true
+			bot.BotCaution = caution
+			bot.BotDefenseTarget = -1
+			bot.X, bot.Y = 50000, 50000
+			bot.Fuel = fuel
+			gs.Torps = append(gs.Torps, &game.Torpedo{
+				Owner:  1,
+				X:      bot.X + 1800,
+				Y:      bot.Y,
+				Dir:    math.Pi,
+				Speed:  600,
+				Status: game.TorpMove,
+				Team:   game.TeamKli,
+			})
+
+			server.assessAndActivateShields(bot)
+			if bot.Shields_up {
+				return fuel
+			}
+		}
+		t.Fatalf("%s bot never shielded", caution)
+		return -1
+	}
+
+	aggressive := lowestShieldFuel(CautionAggressive)
+	balanced := lowestShieldFuel(CautionBalanced)
+	conservative := lowestShieldFuel(CautionConservative)
+	if !(aggressive < balanced && balanced < conservative) {
+		t.Errorf("expected shield fuel reserve aggressive < balanced < conservative, got %d, %d, %d",
+			aggressive, balanced, conservative)
+	}
+
+	// A bot without its own profile follows the server default
+	cfg := DefaultConfig()
+	cfg.BotCaution = CautionAggressive
+	server := &Server{gameState: game.NewGameState(), cfg: &cfg}
+	bot := server.gameState.Players[0]
+	if got := server.botCaution(bot); got != cautionProfiles[CautionAggressive] {
+		t.Errorf("expected the server default profile, got %+v", got)
+	}
+	bot.BotCaution = CautionConservative
+	if got := server.botCaution(bot); got != cautionProfiles[CautionConservative] {
+		t.Errorf("expected the bot's own profile to override the default, got %+v", got)
+	}
+}
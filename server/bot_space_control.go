@@ -0,0 +1,76 @@
+package server
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// isTeamSpaceController reports whether p holds the galaxy center for its
+// team: the toughest living bot (most hull plus shields, lowest slot on a
+// tie) on a team with at least SpaceControlMinTeamBots bots, skipping scouts,
+// starbases and the team interceptor.
+func (s *Server) isTeamSpaceController(p *game.Player) bool {
+	if p.Ship == game.ShipScout || p.Ship == game.ShipStarbase {
+		return false
+	}
+
+	teamBots := 0
+	var candidates []*game.Player
+	for _, other := range s.gameState.Players {
+		if !other.IsBot || other.Dummy || other.Status != game.StatusAlive || other.Team != p.Team {
+			continue
+		}
+		teamBots++
+		if other.Ship != game.ShipScout && other.Ship != game.ShipStarbase {
+			candidates = append(candidates, other)
+		}
+	}
+	if teamBots < SpaceControlMinTeamBots || len(candidates) == 0 {
+		return false
+	}
+	// The highest-slot candidate is the team interceptor (see isTeamInterceptor)
+	candidates = candidates[:len(candidates)-1]
+
+	toughness := func(pl *game.Player) int {
+		stats := game.ShipData[pl.Ship]
+		return stats.MaxDamage + stats.MaxShields
+	}
+	var toughest *game.Player
+	for _, c := range candidates {
+		if toughest == nil || toughness(c) > toughness(toughest) {
+			toughest = c
+		}
+	}
+	return toughest == p
+}
+
+// holdCenter keeps p within SpaceControlRadius of the galaxy center. Enemies
+// within SpaceControlEngageRange are fired on and faced, but never chased out
+// of the zone; a bot pushed or drawn outside heads straight back in.
+func (s *Server) holdCenter(p, enemy *game.Player, enemyDist float64) {
+	centerX, centerY := game.GalaxyWidth/2.0, game.GalaxyHeight/2.0
+	maxSpeed := float64(game.ShipData[p.Ship].MaxSpeed)
+
+	p.Orbiting = -1
+	p.Bombing = false
+	stopBeaming(p)
+
+	engaging := enemy != nil && enemyDist < SpaceControlEngageRange
+	if engaging {
+		p.BotTarget = enemy.ID
+		s.assessAndActivateShields(p)
+		s.planetDefenseWeaponLogic(p, enemy, enemyDist)
+	}
+
+	switch {
+	case s.distance(p.X, p.Y, centerX, centerY) > SpaceControlRadius:
+		s.applySafeNavigation(p, s.bearing(p.X, p.Y, centerX, centerY), maxSpeed)
+	case engaging:
+		// Turn to face the intruder, holding ground rather than closing in
+		s.applySafeNavigation(p, s.bearing(p.X, p.Y, enemy.X, enemy.Y), SpaceControlHoldSpeed)
+	default:
+		s.applySafeNavigation(p, rand.Float64()*2*math.Pi, maxSpeed*0.3)
+	}
+}
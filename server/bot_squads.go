@@ -0,0 +1,94 @@
+package server
+
+import (
+	"math"
+	"sort"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// updateSquads keeps GameState.BotSquads current. Every SquadRebalanceFrames
+// each team's bots are split into offense and defense squads; between
+// rebalances a team is only re-split when a new bot joins it, and bots that
+// leave are dropped. Caller must hold gameState.Mu.
+func (s *Server) updateSquads() {
+	if s.gameState.BotSquads == nil {
+		s.gameState.BotSquads = make(map[int]string)
+	}
+	for id := range s.gameState.BotSquads {
+		if !s.squadEligible(s.gameState.Players[id]) {
+			delete(s.gameState.BotSquads, id)
+		}
+	}
+
+	rebalance := s.gameState.Frame%SquadRebalanceFrames == 0
+	for _, team := range []int{game.TeamFed, game.TeamRom, game.TeamKli, game.TeamOri} {
+		var bots []*game.Player
+		unassigned := false
+		for _, p := range s.gameState.Players {
+			if p.Team == team && s.squadEligible(p) {
+				bots = append(bots, p)
+				if _, ok := s.gameState.BotSquads[p.ID]; !ok {
+					unassigned = true
+				}
+			}
+		}
+		if len(bots) > 0 && (rebalance || unassigned) {
+			s.assignSquads(team, bots)
+		}
+	}
+}
+
+// squadEligible reports whether p is a bot that belongs in a squad: any
+// non-dummy bot in the match, dead or alive, except starbases, which run
+// their own AI.
+func (s *Server) squadEligible(p *game.Player) bool {
+	return p.IsBot && !p.Dummy && p.Ship != game.ShipStarbase &&
+		(p.Status == game.StatusAlive || p.Status == game.StatusExplode || p.Status == game.StatusDead)
+}
+
+// assignSquads splits bots, all on team, between offense and defense. Bots
+// already on defense keep their place first, then ships without a bombing
+// bonus are preferred, so assignments only change as far as the new split
+// requires.
+func (s *Server) assignSquads(team int, bots []*game.Player) {
+	defenders := int(float64(len(bots)) * s.squadDefenseShare(team))
+
+	sort.SliceStable(bots, func(i, j int) bool {
+		di := s.gameState.BotSquads[bots[i].ID] == SquadDefense
+		dj := s.gameState.BotSquads[bots[j].ID] == SquadDefense
+		if di != dj {
+			return di
+		}
+		bi := game.ShipData[bots[i].Ship].BombBonus > 0
+		bj := game.ShipData[bots[j].Ship].BombBonus > 0
+		if bi != bj {
+			return bj
+		}
+		return bots[i].ID < bots[j].ID
+	})
+	for i, p := range bots {
+		if i < defenders {
+			s.gameState.BotSquads[p.ID] = SquadDefense
+		} else {
+			s.gameState.BotSquads[p.ID] = SquadOffense
+		}
+	}
+}
+
+// squadDefenseShare returns the share of team's bots to hold back on
+// defense: half in an even game, less once the team dominates the galaxy and
+// more for each friendly planet under real threat.
+func (s *Server) squadDefenseShare(team int) float64 {
+	share := SquadDefenseShare
+	if float64(s.countTeamPlanets()[team])/float64(game.MaxPlanets) > CommanderHuntRatio {
+		share -= SquadShareStep
+	}
+	for id, pt := range s.getPlanetThreats() {
+		planet := s.gameState.Planets[id]
+		if planet != nil && planet.Owner == team && pt.threatScore >= CommanderDefendThreat {
+			share += SquadShareStep
+		}
+	}
+	return math.Min(share, SquadMaxDefenseShare)
+}
@@ -24,9 +24,26 @@ const (
 
 // Bot behavior roles returned by selectBotBehavior
 const (
-	BotRoleHunter   = "hunter"
-	BotRoleDefender = "defender"
-	BotRoleRaider   = "raider"
+	BotRoleHunter       = "hunter"
+	BotRoleDefender     = "defender"
+	BotRoleRaider       = "raider"
+	BotRoleScout        = "scout"
+	BotRoleInterceptor  = "interceptor"
+	BotRoleSpaceControl = "space-control"
+	BotRoleScreen       = "screen"
+)
+
+// Team objectives chosen by the bot commander (see updateCommanders)
+const (
+	TeamOrderDefend = "defend" // Hold a threatened friendly planet
+	TeamOrderPush   = "push"   // Converge on one enemy or neutral planet
+	TeamOrderHunt   = "hunt"   // Run down one enemy ship
+)
+
+// Squads a team's bots are split into (see updateSquads)
+const (
+	SquadOffense = "offense" // Raids planets and hunts enemy ships
+	SquadDefense = "defense" // Guards friendly planets
 )
 
 // BotNames for generating random bot names
@@ -46,6 +63,7 @@ type CombatThreat struct {
 	closestEnemyDist float64
 	nearbyEnemies    int
 	requiresEvasion  bool
+	detonatableTorps int // Threatening enemy torpedoes within detonation range
 	threatLevel      int
 
 	// Shield-specific fields (computed in the same pass to avoid redundant iteration)
@@ -74,5 +92,6 @@ type PlanetDefenderInfo struct {
 	ClosestDefender   *game.Player   // The closest enemy ship
 	MinDefenderDist   float64        // Distance to the closest defender
 	HasCarrierDefense bool           // Whether any defender is carrying armies
+	ClosestBeamer     *game.Player   // The closest enemy orbiting the planet and beaming armies down while not its owner (a capture race), or nil
 	DefenseScore      float64        // Calculated threat score (higher = more dangerous)
 }
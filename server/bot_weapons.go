@@ -28,6 +28,17 @@ func (s *Server) targetVelocity(t *game.Player) Vector2D {
 // - Weapon spread patterns
 // - Enhanced targeting algorithms
 
+// turnToFire reports whether a bot must come about before firing along dir
+// under Config.FireArc. If so it turns toward dir, so the shot opens up on a
+// later tick.
+func (s *Server) turnToFire(p *game.Player, dir float64) bool {
+	if !s.facingBarsFire(p, dir) {
+		return false
+	}
+	p.DesDir = game.NormalizeAngle(dir)
+	return true
+}
+
 // fireBotTorpedo fires a single intercept-aimed torpedo from a bot
 func (s *Server) fireBotTorpedo(p *game.Player, target *game.Player) {
 	s.fireTorpedoSpread(p, target, 1)
@@ -36,8 +47,8 @@ func (s *Server) fireBotTorpedo(p *game.Player, target *game.Player) {
 // fireBotPhaser fires a phaser from a bot using the same line-to-circle hit
 // detection algorithm as human phasers (combat_handlers.go handlePhaser).
 func (s *Server) fireBotPhaser(p *game.Player, target *game.Player) {
-	// Can't fire while cloaked or repairing (same rules as human players)
-	if p.Cloaked || p.Repairing {
+	// Can't fire while cloaked, repairing or with weapons held (same rules as human players)
+	if s.cloakBarsFire(p) || p.Repairing || s.weaponsHeld(p) {
 		return
 	}
 
@@ -57,17 +68,26 @@ func (s *Server) fireBotPhaser(p *game.Player, target *game.Player) {
 	myPhaserRange := game.PhaserRange(shipStats)
 
 	// Range sanity check — don't fire if target is beyond phaser range
-	dist := game.Distance(p.X, p.Y, target.X, target.Y)
+	dist := s.distance(p.X, p.Y, target.X, target.Y)
 	if dist > myPhaserRange {
 		return
 	}
 
-	// Bot aims directly at target
-	course := math.Atan2(target.Y-p.Y, target.X-p.X)
+	// Bot aims directly at target, less precisely when it is jamming
+	course := s.bearing(p.X, p.Y, target.X, target.Y)
+	if target.ECMActive {
+		course += ecmAimError()
+	}
+
+	if s.turnToFire(p, course) {
+		return
+	}
 
 	// Use the same line-to-circle algorithm as human phasers
 	hitTarget, hitDist, _ := s.phaserTargetInLine(p, course, myPhaserRange)
 
+	s.breakCloakToFire(p)
+
 	// Consume fuel and increase weapon temp regardless of hit (same as human)
 	p.Fuel -= phaserCost
 	p.WTemp += 70
@@ -87,8 +107,8 @@ func (s *Server) fireBotPhaser(p *game.Player, target *game.Player) {
 	}
 
 	// Calculate damage based on distance using original formula
-	damage := float64(shipStats.PhaserDamage) * (1.0 - hitDist/myPhaserRange)
-	game.ApplyDamageWithShields(hitTarget, int(damage))
+	damage := game.PhaserDamageAt(shipStats, hitDist)
+	s.applyDamageFrom(hitTarget, s.handicapDamage(p.Team, int(damage)), game.DamagePhaser, p.X, p.Y)
 
 	// Check if target destroyed
 	if hitTarget.Damage >= game.ShipData[hitTarget.Ship].MaxDamage {
@@ -129,8 +149,7 @@ func (s *Server) phaserTargetInLine(shooter *game.Player, course, phaserRange fl
 		}
 
 		// (A, B) is the position of the possible target relative to the shooter
-		A := enemy.X - shooter.X
-		B := enemy.Y - shooter.Y
+		A, B := s.delta(shooter.X, shooter.Y, enemy.X, enemy.Y)
 
 		// Quick bounds check
 		if math.Abs(A) >= phaserRange || math.Abs(B) >= phaserRange {
@@ -169,13 +188,13 @@ func (s *Server) phaserTargetInLine(shooter *game.Player, course, phaserRange fl
 
 // fireBotPhaserAtPlasma fires a phaser at an incoming plasma torpedo to destroy it
 func (s *Server) fireBotPhaserAtPlasma(p *game.Player, plasma *game.Plasma) bool {
-	// Can't fire while cloaked or repairing (same rules as human players)
-	if p.Cloaked || p.Repairing {
+	// Can't fire while cloaked, repairing or with weapons held (same rules as human players)
+	if s.cloakBarsFire(p) || p.Repairing || s.weaponsHeld(p) {
 		return false
 	}
 
 	shipStats := game.ShipData[p.Ship]
-	dist := game.Distance(p.X, p.Y, plasma.X, plasma.Y)
+	dist := s.distance(p.X, p.Y, plasma.X, plasma.Y)
 
 	// Calculate phaser range using original formula
 	myPhaserRange := game.PhaserRange(shipStats)
@@ -196,15 +215,17 @@ func (s *Server) fireBotPhaserAtPlasma(p *game.Player, plasma *game.Plasma) bool
 	}
 
 	// Calculate phaser direction to plasma
-	phaserDir := math.Atan2(plasma.Y-p.Y, plasma.X-p.X)
+	phaserDir := s.bearing(p.X, p.Y, plasma.X, plasma.Y)
+	if s.turnToFire(p, phaserDir) {
+		return false
+	}
 
 	// Check if phaser would hit the plasma using ZAPPLASMADIST
 	// This mirrors the logic in combat_handlers.go
 	C := 10.0 * float64(game.PhaserDist) * math.Cos(phaserDir)
 	D := 10.0 * float64(game.PhaserDist) * math.Sin(phaserDir)
 
-	A := plasma.X - p.X
-	B := plasma.Y - p.Y
+	A, B := s.delta(p.X, p.Y, plasma.X, plasma.Y)
 
 	s_param := (A*C + B*D) / (10.0 * float64(game.PhaserDist) * 10.0 * float64(game.PhaserDist))
 	if s_param < 0 {
@@ -236,6 +257,7 @@ func (s *Server) fireBotPhaserAtPlasma(p *game.Player, plasma *game.Plasma) bool
 		},
 	})
 
+	s.breakCloakToFire(p)
 	p.Fuel -= phaserCost
 	p.WTemp += 70
 
@@ -245,8 +267,8 @@ func (s *Server) fireBotPhaserAtPlasma(p *game.Player, plasma *game.Plasma) bool
 // tryPhaserNearbyPlasma checks for enemy plasma in range and attempts to phaser it
 // Returns true if a plasma was phasered
 func (s *Server) tryPhaserNearbyPlasma(p *game.Player) bool {
-	// Can't fire while cloaked or repairing
-	if p.Cloaked || p.Repairing {
+	// Can't fire while cloaked, repairing or with weapons held
+	if s.cloakBarsFire(p) || p.Repairing || s.weaponsHeld(p) {
 		return false
 	}
 
@@ -264,17 +286,12 @@ func (s *Server) tryPhaserNearbyPlasma(p *game.Player) bool {
 	closestDist := myPhaserRange
 
 	for _, plasma := range s.gameState.Plasmas {
-		// Skip our own plasma or non-active plasma
-		if plasma.Owner == p.ID || plasma.Status != game.TorpMove {
-			continue
-		}
-
-		// Skip friendly plasma
-		if plasma.Team == p.Team {
+		// Skip our own, friendly or non-active plasma
+		if !s.projectileThreatens(p, plasma) {
 			continue
 		}
 
-		dist := game.Distance(p.X, p.Y, plasma.X, plasma.Y)
+		dist := s.distance(p.X, p.Y, plasma.X, plasma.Y)
 		if dist < closestDist {
 			closestPlasma = plasma
 			closestDist = dist
@@ -290,42 +307,57 @@ func (s *Server) tryPhaserNearbyPlasma(p *game.Player) bool {
 
 // fireBotPlasma fires a plasma torpedo from a bot
 func (s *Server) fireBotPlasma(p *game.Player, target *game.Player) bool {
-	// Can't fire while cloaked or repairing (same rules as human players)
-	if p.Cloaked || p.Repairing {
+	// Pre-fire sanity check: don't fire beyond plasma maximum range
+	dist := s.distance(p.X, p.Y, target.X, target.Y)
+	maxPlasmaRange := s.maxPlasmaRange(p.Ship)
+	if dist > maxPlasmaRange {
+		// Don't fire - plasma would expire before reaching target
+		return false
+	}
+
+	// Use unified intercept solver for plasma
+	shooterPos := Point2D{X: p.X, Y: p.Y}
+	targetPos := Point2D{}
+	targetPos.X, targetPos.Y = s.nearestImage(p.X, p.Y, target.X, target.Y)
+	targetVel := s.targetVelocity(target)
+	projSpeed, _ := s.plasmaFlight(p.Ship) // Units/tick
+	fireDir, _ := InterceptDirectionSimple(shooterPos, targetPos, targetVel, projSpeed)
+
+	return s.fireBotPlasmaDir(p, fireDir)
+}
+
+// botCanFirePlasma reports whether p has a plasma ready: a plasma-armed ship
+// under its plasma limit with the fuel and weapon temperature to fire.
+func (s *Server) botCanFirePlasma(p *game.Player) bool {
+	// Can't fire while cloaked, repairing or with weapons held (same rules as human players)
+	if s.cloakBarsFire(p) || p.Repairing || s.weaponsHeld(p) {
 		return false
 	}
 
 	shipStats := game.ShipData[p.Ship]
 
-	if !shipStats.HasPlasma {
+	if !shipStats.HasPlasma || p.NumPlasma >= shipStats.MaxPlasma {
 		return false
 	}
 
 	// Check fuel (using ship-specific multiplier, same as human handler)
-	plasmaCost := shipStats.PlasmaDamage * shipStats.PlasmaFuelMult
-	if p.Fuel < plasmaCost {
+	if p.Fuel < shipStats.PlasmaDamage*shipStats.PlasmaFuelMult {
 		return false
 	}
 
 	// Check weapon temperature against ship-specific limit
-	if p.WTemp > shipStats.MaxWpnTemp-100 {
-		return false
-	}
+	return p.WTemp <= shipStats.MaxWpnTemp-100
+}
 
-	// Pre-fire sanity check: don't fire beyond plasma maximum range
-	dist := game.Distance(p.X, p.Y, target.X, target.Y)
-	maxPlasmaRange := game.MaxPlasmaRangeForShip(p.Ship)
-	if dist > maxPlasmaRange {
-		// Don't fire - plasma would expire before reaching target
+// fireBotPlasmaDir fires a plasma torpedo from a bot along fireDir
+func (s *Server) fireBotPlasmaDir(p *game.Player, fireDir float64) bool {
+	if !s.botCanFirePlasma(p) {
 		return false
 	}
 
-	// Use unified intercept solver for plasma
-	shooterPos := Point2D{X: p.X, Y: p.Y}
-	targetPos := Point2D{X: target.X, Y: target.Y}
-	targetVel := s.targetVelocity(target)
-	projSpeed := float64(shipStats.PlasmaSpeed * 20) // Convert to units/tick
-	fireDir, _ := InterceptDirectionSimple(shooterPos, targetPos, targetVel, projSpeed)
+	shipStats := game.ShipData[p.Ship]
+	plasmaCost := shipStats.PlasmaDamage * shipStats.PlasmaFuelMult
+	speed, fuse := s.plasmaFlight(p.Ship)
 
 	// Create plasma
 	plasma := &game.Plasma{
@@ -334,16 +366,17 @@ func (s *Server) fireBotPlasma(p *game.Player, target *game.Player) bool {
 		X:      p.X,
 		Y:      p.Y,
 		Dir:    fireDir,
-		Speed:  float64(shipStats.PlasmaSpeed * 20), // 20 units per tick
+		Speed:  speed, // 20 units per tick times the plasma speed scale
 		Damage: shipStats.PlasmaDamage,
-		Fuse:   shipStats.PlasmaFuse, // Use original fuse value directly
-		Status: game.TorpMove,        // Moving
-		Team:   p.Team,               // Set team color
+		Fuse:   fuse,                // Original fuse times the plasma range scale
+		Status: game.TorpMove,       // Moving
+		Team:   s.projectileTeam(p), // Set team color
 	}
 
 	s.gameState.Plasmas = append(s.gameState.Plasmas, plasma)
 	s.nextPlasmaID++
 	p.NumPlasma++
+	s.breakCloakToFire(p)
 	p.Fuel -= plasmaCost
 	p.WTemp += 100 // Plasma heats weapons (matching human handler)
 
@@ -352,25 +385,41 @@ func (s *Server) fireBotPlasma(p *game.Player, target *game.Player) bool {
 
 // fireTorpedoSpread fires multiple torpedoes in a spread pattern
 func (s *Server) fireTorpedoSpread(p, target *game.Player, count int) {
-	// Can't fire while cloaked or repairing (same rules as human players)
-	if p.Cloaked || p.Repairing {
+	// Jamming hides the target's velocity: fire unguided at where it is now,
+	// widening the spread to cover where it might go
+	if target.ECMActive {
+		s.fireTorpedoSpreadDir(p, s.bearing(p.X, p.Y, target.X, target.Y)+ecmAimError(), max(count, ECMSpreadTorps))
 		return
 	}
 
-	shipStats := game.ShipData[p.Ship]
-	torpCost := shipStats.TorpDamage * shipStats.TorpFuelMult
-
 	// Use unified intercept solver for base direction
 	shooterPos := Point2D{X: p.X, Y: p.Y}
-	targetPos := Point2D{X: target.X, Y: target.Y}
+	targetPos := Point2D{}
+	targetPos.X, targetPos.Y = s.nearestImage(p.X, p.Y, target.X, target.Y)
 	targetVel := s.targetVelocity(target)
-	projSpeed := float64(shipStats.TorpSpeed * 20) // Convert to units/tick
+	projSpeed := float64(game.ShipData[p.Ship].TorpSpeed * 20) // Convert to units/tick
 	baseDir, _ := InterceptDirectionSimple(shooterPos, targetPos, targetVel, projSpeed)
 
+	s.fireTorpedoSpreadDir(p, baseDir, count)
+}
+
+// fireTorpedoSpreadDir fires count torpedoes in a spread centered on baseDir
+func (s *Server) fireTorpedoSpreadDir(p *game.Player, baseDir float64, count int) {
+	// Can't fire while cloaked, repairing or with weapons held (same rules as human players)
+	if s.cloakBarsFire(p) || p.Repairing || s.weaponsHeld(p) {
+		return
+	}
+	if s.turnToFire(p, baseDir) {
+		return
+	}
+
+	shipStats := game.ShipData[p.Ship]
+	torpCost := shipStats.TorpDamage * shipStats.TorpFuelMult
+
 	spreadAngle := math.Pi / 16 // Spread angle between torpedoes
 
 	for i := 0; i < count; i++ {
-		if p.NumTorps >= game.MaxTorps {
+		if p.NumTorps >= game.MaxTorps || s.galaxyTorpsFull() {
 			break
 		}
 		// Check fuel for each torpedo
@@ -387,6 +436,9 @@ func (s *Server) fireTorpedoSpread(p, target *game.Player, count int) {
 		fireDir := baseDir + offset
 		// Add small random jitter to make each torpedo harder to dodge
 		fireDir += randomJitterRad()
+		if s.facingBarsFire(p, fireDir) {
+			continue // This side of the spread falls outside the fire arc
+		}
 
 		// Create torpedo
 		torp := &game.Torpedo{
@@ -399,26 +451,81 @@ func (s *Server) fireTorpedoSpread(p, target *game.Player, count int) {
 			Damage: shipStats.TorpDamage,
 			Fuse:   shipStats.TorpFuse,
 			Status: game.TorpMove,
-			Team:   p.Team,
+			Team:   s.projectileTeam(p),
 		}
 
 		s.gameState.Torps = append(s.gameState.Torps, torp)
 		s.nextTorpID++
 		p.NumTorps++
+		s.breakCloakToFire(p)
 		p.Fuel -= torpCost
 		p.WTemp += 50
 	}
 }
 
+// firePlasmaTorpCombo leads with a plasma to force target to dodge, then fires
+// a torpedo spread into the path it is predicted to dodge along. Returns true
+// if the plasma was launched (the spread follows whenever torps allow).
+func (s *Server) firePlasmaTorpCombo(p, target *game.Player) bool {
+	if !s.fireBotPlasma(p, target) {
+		return false
+	}
+	plasma := s.gameState.Plasmas[len(s.gameState.Plasmas)-1]
+	x, y := s.predictPlasmaDodgePosition(p, target, plasma)
+	s.fireTorpedoSpreadDir(p, math.Atan2(y-p.Y, x-p.X), ComboTorpCount)
+	return true
+}
+
+// predictPlasmaDodgePosition estimates where target will be when p's torpedoes
+// arrive if it dodges plasma. The target is assumed to hold its course until
+// the plasma is ComboReactTicks from reaching it, then break the way the bots'
+// own getAdvancedDodgeDirection would from there while still pressing toward
+// p, at no less than ComboDodgeSpeedFraction of its top speed.
+func (s *Server) predictPlasmaDodgePosition(p, target *game.Player, plasma *game.Plasma) (float64, float64) {
+	speed := math.Max(target.Speed, float64(game.ShipData[target.Ship].MaxSpeed)*ComboDodgeSpeedFraction)
+	closing := plasma.Speed + target.Speed*20
+	reactTicks := math.Max(0, s.distance(plasma.X, plasma.Y, target.X, target.Y)/closing-ComboReactTicks)
+	breakX := target.X + math.Cos(target.Dir)*target.Speed*20*reactTicks
+	breakY := target.Y + math.Sin(target.Dir)*target.Speed*20*reactTicks
+
+	// Stage the moment the target reacts: a copy of it at the break point
+	// and the plasma advanced to match, restored once the dodge is scored
+	ghost := *target
+	ghost.X, ghost.Y = breakX, breakY
+	ghost.Speed = speed
+	plasmaX, plasmaY := plasma.X, plasma.Y
+	plasma.X += math.Cos(plasma.Dir) * plasma.Speed * reactTicks
+	plasma.Y += math.Sin(plasma.Dir) * plasma.Speed * reactTicks
+	dodgeDir := s.getAdvancedDodgeDirection(&ghost, s.bearing(breakX, breakY, p.X, p.Y), CombatThreat{})
+	plasma.X, plasma.Y = plasmaX, plasmaY
+
+	positionAt := func(ticks float64) (float64, float64) {
+		if ticks <= reactTicks {
+			return target.X + math.Cos(target.Dir)*target.Speed*20*ticks,
+				target.Y + math.Sin(target.Dir)*target.Speed*20*ticks
+		}
+		return breakX + math.Cos(dodgeDir)*speed*20*(ticks-reactTicks),
+			breakY + math.Sin(dodgeDir)*speed*20*(ticks-reactTicks)
+	}
+
+	// Refine the torpedo flight time against the moving aim point
+	torpSpeed := float64(game.ShipData[p.Ship].TorpSpeed * 20)
+	x, y := target.X, target.Y
+	for i := 0; i < 3; i++ {
+		x, y = positionAt(s.distance(p.X, p.Y, x, y) / torpSpeed)
+	}
+	return x, y
+}
+
 // planetDefenseWeaponLogic implements aggressive weapon usage for planet defense
 func (s *Server) planetDefenseWeaponLogic(p *game.Player, enemy *game.Player, enemyDist float64) {
 	shipStats := game.ShipData[p.Ship]
 	firedWeapon := false
 
-	// Weapon usage for planet defense - no facing restrictions needed
+	// Weapon usage for planet defense - facing only matters under Config.FireArc
 
 	// Aggressive torpedo usage - wider criteria than normal combat
-	// Torpedoes can be fired in any direction regardless of ship facing
+	// Torpedoes can be fired in any direction unless Config.FireArc applies
 	// Use velocity-adjusted range to prevent fuse expiry on fast targets
 	effectiveTorpRange := s.getVelocityAdjustedTorpRange(p, enemy)
 	canReach := s.canTorpReachTarget(p, enemy)
@@ -429,7 +536,7 @@ func (s *Server) planetDefenseWeaponLogic(p *game.Player, enemy *game.Player, en
 	}
 
 	// Opportunistic phaser usage - prioritize planet protection over fuel conservation
-	// Phasers can be fired in any direction regardless of ship facing
+	// Phasers can be fired in any direction unless Config.FireArc applies
 	myPhaserRange := game.PhaserRange(shipStats)
 	if !firedWeapon && enemyDist < myPhaserRange && p.Fuel > 1000 && p.WTemp < shipStats.MaxWpnTemp-100 {
 		// Fire phasers more liberally when defending planets
@@ -439,11 +546,11 @@ func (s *Server) planetDefenseWeaponLogic(p *game.Player, enemy *game.Player, en
 	}
 
 	// Enhanced plasma usage for ships that have it - use actual plasma range
-	maxPlasmaRange := game.MaxPlasmaRangeForShip(p.Ship)
-	plasmaDefenseRange := game.EffectivePlasmaRange(p.Ship, 0.90) // 90% of max plasma range
-	plasmaMinRange := maxPlasmaRange * 0.25                       // 25% of max plasma range
+	maxPlasmaRange := s.maxPlasmaRange(p.Ship)
+	plasmaDefenseRange := s.effectivePlasmaRange(p.Ship, 0.90) // 90% of max plasma range
+	plasmaMinRange := maxPlasmaRange * 0.25                    // 25% of max plasma range
 	plasmaCost := shipStats.PlasmaDamage * shipStats.PlasmaFuelMult
-	if !firedWeapon && shipStats.HasPlasma && p.NumPlasma < 1 && enemyDist < plasmaDefenseRange && enemyDist > plasmaMinRange && p.Fuel >= plasmaCost {
+	if !firedWeapon && shipStats.HasPlasma && p.NumPlasma < shipStats.MaxPlasma && enemyDist < plasmaDefenseRange && enemyDist > plasmaMinRange && p.Fuel >= plasmaCost {
 		if s.fireBotPlasma(p, enemy) {
 			p.BotCooldown = 15
 		}
@@ -453,13 +560,14 @@ func (s *Server) planetDefenseWeaponLogic(p *game.Player, enemy *game.Player, en
 // starbaseDefenseWeaponLogic implements weapon usage for starbase planet defense
 func (s *Server) starbaseDefenseWeaponLogic(p *game.Player, enemy *game.Player, enemyDist float64) {
 	shipStats := game.ShipData[p.Ship]
+	cfg := s.config()
 
-	// Starbase weapon usage for planet defense - no facing restrictions needed
+	// Starbase weapon usage for planet defense - facing only matters under Config.FireArc
 
 	// Aggressive torpedo usage - starbases should be dangerous
-	// Torpedoes can be fired in any direction regardless of ship facing
+	// Torpedoes can be fired in any direction unless Config.FireArc applies
 	// Use velocity-adjusted range to prevent fuse expiry
-	effectiveTorpRange := s.getVelocityAdjustedTorpRange(p, enemy)
+	effectiveTorpRange := math.Min(s.getVelocityAdjustedTorpRange(p, enemy), cfg.StarbaseTorpRange)
 	canReach := s.canTorpReachTarget(p, enemy)
 	if canReach && enemyDist < effectiveTorpRange && p.NumTorps < game.MaxTorps-2 && p.Fuel > 1500 && p.WTemp < shipStats.MaxWpnTemp-100 {
 		s.fireBotTorpedo(p, enemy)
@@ -468,9 +576,9 @@ func (s *Server) starbaseDefenseWeaponLogic(p *game.Player, enemy *game.Player,
 	}
 
 	// Aggressive phaser usage for planet defense
-	// Phasers can be fired in any direction regardless of ship facing
+	// Phasers can be fired in any direction unless Config.FireArc applies
 	// Use the canonical phaser range formula for consistency
-	sbPhaserRange := game.PhaserRange(shipStats)
+	sbPhaserRange := math.Min(game.PhaserRange(shipStats), cfg.StarbasePhaserRange)
 	if enemyDist < sbPhaserRange && p.Fuel > 1500 && p.WTemp < shipStats.MaxWpnTemp-100 {
 		s.fireBotPhaser(p, enemy)
 		p.BotCooldown = 5
@@ -479,7 +587,7 @@ func (s *Server) starbaseDefenseWeaponLogic(p *game.Player, enemy *game.Player,
 
 	// Plasma for area denial - wide firing window
 	sbPlasmaCost := shipStats.PlasmaDamage * shipStats.PlasmaFuelMult
-	if shipStats.HasPlasma && p.NumPlasma < 1 && enemyDist < game.StarbasePlasmaMaxRange && enemyDist > 1000 && p.Fuel >= sbPlasmaCost {
+	if shipStats.HasPlasma && p.NumPlasma < shipStats.MaxPlasma && enemyDist < cfg.StarbasePlasmaMaxRange && enemyDist > 1000 && p.Fuel >= sbPlasmaCost {
 		if s.fireBotPlasma(p, enemy) {
 			p.BotCooldown = 12
 			return
@@ -488,18 +596,55 @@ func (s *Server) starbaseDefenseWeaponLogic(p *game.Player, enemy *game.Player,
 
 	// Close-range torpedo fallback - fires when other conditions prevent it, but still
 	// validates the torpedo can reach the intercept point before fuse expires
-	if canReach && enemyDist < game.StarbaseTorpRange && p.NumTorps < game.MaxTorps && p.Fuel > 1000 && p.WTemp < shipStats.MaxWpnTemp-100 {
+	if canReach && enemyDist < cfg.StarbaseTorpRange && p.NumTorps < game.MaxTorps && p.Fuel > 1000 && p.WTemp < shipStats.MaxWpnTemp-100 {
 		s.fireBotTorpedo(p, enemy)
 		p.BotCooldown = 5
 		return
 	}
 }
 
+// tryDetonateEnemyTorps detonates the enemy torpedoes closing on a bot when
+// dodging them is not an option: several are inbound while the bot is pinned
+// against the galaxy edge, or a whole spread is about to land. It pays the
+// usual DetCost per torpedo, only when the bot can afford every one, and
+// waits BotDetonateCooldownFrames between uses. Returns true if anything
+// was detonated.
+func (s *Server) tryDetonateEnemyTorps(p *game.Player, threats CombatThreat) bool {
+	if threats.detonatableTorps < BotDetonateMinTorps || s.gameState.Frame < p.BotDetonateReady {
+		return false
+	}
+	if threats.detonatableTorps < BotDetonateSwarmTorps && !s.botCornered(p) {
+		return false
+	}
+	if p.Fuel < game.ShipData[p.Ship].DetCost*threats.detonatableTorps ||
+		s.cloakBarsFire(p) || s.weaponsHeld(p) {
+		return false
+	}
+
+	detonated, _ := s.detonateEnemyTorps(p)
+	if detonated == 0 {
+		return false
+	}
+	p.BotDetonateReady = s.gameState.Frame + BotDetonateCooldownFrames
+	delete(s.cachedThreats, p.ID) // The detonated torpedoes no longer threaten
+	return true
+}
+
+// botCornered reports whether p is within BotDetonateWallMargin of a galaxy
+// edge it cannot dodge past (a wrapping galaxy has none).
+func (s *Server) botCornered(p *game.Player) bool {
+	if s.config().GalaxyEdge == EdgeWrap {
+		return false
+	}
+	return p.X < BotDetonateWallMargin || p.X > game.GalaxyWidth-BotDetonateWallMargin ||
+		p.Y < BotDetonateWallMargin || p.Y > game.GalaxyHeight-BotDetonateWallMargin
+}
+
 // detonatePassingTorpedoes checks each torpedo individually and only detonates
 // torpedoes that are passing by enemies (not heading for direct hits).
 // This avoids the previous bug where ALL torpedoes were detonated when one triggered.
 func (s *Server) detonatePassingTorpedoes(p *game.Player) {
-	if p.NumTorps == 0 {
+	if p.NumTorps == 0 || s.weaponsHeld(p) {
 		return
 	}
 
@@ -515,15 +660,14 @@ func (s *Server) detonatePassingTorpedoes(p *game.Player) {
 				continue
 			}
 
-			dist := game.Distance(torp.X, torp.Y, enemy.X, enemy.Y)
+			dist := s.distance(torp.X, torp.Y, enemy.X, enemy.Y)
 			if dist < 3000 {
 				nearbyEnemyCount++
 			}
 
 			// Detonate if enemy is in blast radius but torpedo won't hit directly
 			if dist < 2500 && dist > 800 {
-				dx := enemy.X - torp.X
-				dy := enemy.Y - torp.Y
+				dx, dy := s.delta(torp.X, torp.Y, enemy.X, enemy.Y)
 				angleToEnemy := math.Atan2(dy, dx)
 				angleDiff := AngleDifference(angleToEnemy, torp.Dir)
 
@@ -553,3 +697,9 @@ func randomJitterRad() float64 {
 	deg := (rand.Float64()*2 - 1) * maxJitterDeg
 	return deg * math.Pi / 180
 }
+
+// ecmAimError returns a random aim error for firing at a jamming target,
+// up to ECMAimError either way.
+func ecmAimError() float64 {
+	return (rand.Float64()*2 - 1) * ECMAimError
+}
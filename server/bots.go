@@ -5,6 +5,7 @@ import (
 	"math"
 	"math/rand"
 	"strings"
+	"time"
 
 	"github.com/lab1702/netrek-web/game"
 )
@@ -15,6 +16,12 @@ import (
 // already has its one allowed starbase, or there is no free player slot). The
 // boolean lets callers like /fillbots avoid counting rejected adds.
 func (s *Server) AddBot(team int, ship game.ShipType) bool {
+	return s.AddBotWithCaution(team, ship, "")
+}
+
+// AddBotWithCaution is AddBot with a caution profile for the new bot; an
+// empty caution uses the server's Config.BotCaution.
+func (s *Server) AddBotWithCaution(team int, ship game.ShipType, caution string) bool {
 	s.gameState.Mu.Lock()
 	defer s.gameState.Mu.Unlock()
 
@@ -42,7 +49,7 @@ func (s *Server) AddBot(team int, ship game.ShipType) bool {
 
 	// Initialize bot player (p.ID is already set by NewGameState)
 	p := s.gameState.Players[botID]
-	p.Name = fmt.Sprintf("[BOT] %s", BotNames[rand.Intn(len(BotNames))])
+	p.Name = "[BOT] " + s.claimBotName(botID)
 	p.Team = team
 	p.Ship = ship
 	p.Status = game.StatusAlive
@@ -50,20 +57,29 @@ func (s *Server) AddBot(team int, ship game.ShipType) bool {
 
 	p.Connected = true
 	p.IsBot = true
+	p.Dummy = false
 	p.BotTarget = -1
 	p.BotPlanetApproachID = -1
 	p.BotDefenseTarget = -1
 	p.BotCooldown = 0
+	p.BotMemoryUntil = 0
+	p.BotRecharging = false
+	p.BotCaution = caution
+	p.BotTakeoverOf = ""
+	p.BeamCount = 0
+	p.SpecialTimer = 0
+	p.Rating = 0
+	if s.config().Ratings {
+		p.Rating = game.BotRating
+	}
 
 	// Set initial position based on team (clamped to galaxy bounds)
-	p.X, p.Y = spawnPosition(team)
+	p.X, p.Y = s.spawnPosition(team)
 	p.Dir = rand.Float64() * 2 * math.Pi
 
 	// Initialize ship stats
 	shipStats := game.ShipData[p.Ship]
-	p.Shields = shipStats.MaxShields
-	p.Damage = 0
-	p.Fuel = shipStats.MaxFuel
+	s.spawnLoadout(p, shipStats)
 	p.WTemp = 0
 	p.ETemp = 0
 	p.Speed = 0
@@ -79,7 +95,7 @@ func (s *Server) AddBot(team int, ship game.ShipType) bool {
 // UpdateBots updates all bot players' AI
 func (s *Server) UpdateBots() {
 	for _, p := range s.gameState.Players {
-		if !p.IsBot || p.Status != game.StatusAlive {
+		if !p.IsBot || p.Dummy || p.Status != game.StatusAlive {
 			continue
 		}
 
@@ -97,7 +113,7 @@ func (s *Server) UpdateBots() {
 		}
 
 		// Safety check: Fix stuck bombing state
-		if p.Bombing && p.Orbiting >= 0 && p.Orbiting < len(s.gameState.Planets) {
+		if p.Bombing && p.Orbiting >= 0 && p.Orbiting < len(s.gameState.Planets) && s.gameState.Planets[p.Orbiting] != nil {
 			planet := s.gameState.Planets[p.Orbiting]
 			// Stop bombing if planet is friendly or has no armies
 			if planet.Owner == p.Team || planet.Armies == 0 {
@@ -108,6 +124,9 @@ func (s *Server) UpdateBots() {
 
 		// Run hard mode AI for all bots
 		s.updateBotHard(p)
+
+		// Whatever the AI chose, never fly into a deadly edge
+		s.steerClearOfDeadlyEdge(p)
 	}
 }
 
@@ -138,6 +157,32 @@ func (s *Server) updateBotHard(p *game.Player) {
 		return
 	}
 
+	// SURVIVAL: critically damaged and outnumbered - flee before anything else
+	if s.shouldPanicRetreat(p) {
+		s.panicRetreat(p)
+		return
+	}
+
+	// Held in an enemy tractor beam - break free and fight the tractoring ship
+	if tractorer := s.findTractoringEnemy(p); tractorer != nil {
+		s.breakTractor(p, tractorer)
+		return
+	}
+
+	// Survival attackers converge on the defended starbase
+	if base := s.survivalBaseFor(p); base != nil {
+		s.assaultBase(p, base)
+		return
+	}
+
+	// The team interceptor drops everything for an inbound enemy carrier
+	if s.isTeamInterceptor(p) {
+		if carrier := s.findIncomingCarrier(p); carrier != nil {
+			s.interceptCarrier(p, carrier)
+			return
+		}
+	}
+
 	// HIGHEST PRIORITY: Planet defense - check for friendly planets under immediate threat
 	if planet, enemy, enemyDist := s.getThreatenedFriendlyPlanet(p); planet != nil && enemy != nil {
 		s.defendPlanet(p, planet, enemy, enemyDist)
@@ -153,19 +198,30 @@ func (s *Server) updateBotHard(p *game.Player) {
 	enemyArmyPlanet := s.findNearestEnemyArmyPlanet(p)
 	takePlanet := s.findBestPlanetToTake(p)
 
+	// In tournament play the commander's push target replaces the bot's own
+	// choice of planet to bomb or take for the bots carrying out the push
+	if planet := s.teamOrderPlanet(p, TeamOrderPush); planet != nil && !s.assaultAbandoned(p, planet) {
+		if planet.Owner != p.Team && planet.Owner != game.TeamNone && planet.Armies > 0 {
+			enemyArmyPlanet = planet
+		} else if planet.Owner != p.Team {
+			takePlanet = planet
+		}
+	}
+
 	// Check repair/fuel needs with strategic decisions
-	needRepair := p.Damage > shipStats.MaxDamage/2
-	needFuel := p.Fuel < shipStats.MaxFuel/3
+	caution := s.botCaution(p)
+	needRepair := float64(p.Damage) > float64(shipStats.MaxDamage)*caution.RepairDamage
+	needFuel := float64(p.Fuel) < float64(shipStats.MaxFuel)*caution.RefuelFuel
 	criticalDamage := p.Damage > shipStats.MaxDamage*3/4
 
 	nearestEnemy := s.findNearestEnemy(p)
 	enemyDist := MaxSearchDistance
 	if nearestEnemy != nil {
-		enemyDist = game.Distance(p.X, p.Y, nearestEnemy.X, nearestEnemy.Y)
+		enemyDist = s.distance(p.X, p.Y, nearestEnemy.X, nearestEnemy.Y)
 	}
 
 	// Check if currently orbiting for repair/fuel
-	if p.Orbiting >= 0 && p.Orbiting < len(s.gameState.Planets) {
+	if p.Orbiting >= 0 && p.Orbiting < len(s.gameState.Planets) && s.gameState.Planets[p.Orbiting] != nil {
 		orbitPlanet := s.gameState.Planets[p.Orbiting]
 		if orbitPlanet.Owner == p.Team {
 			// Continue repairing if needed and safe (must be well outside phaser
@@ -197,15 +253,37 @@ func (s *Server) updateBotHard(p *game.Player) {
 	// Cancel repair mode if threatened (a visible enemy is close, or we just
 	// took damage from an attacker we may not be able to see).
 	if p.Repairing && (enemyDist < RepairSafetyDistance || recentlyHit) {
-		p.Repairing = false
-		p.RepairRequest = false
+		cancelRepair(p)
+	}
+
+	// Low on fuel with nobody close: break off and recharge before fighting on
+	if s.tryRecharge(p, enemyDist, recentlyHit) {
+		return
 	}
 
 	// Check if we were trying to approach a planet but got sidetracked fighting defenders
-	if p.BotPlanetApproachID >= 0 && p.BotPlanetApproachID < len(s.gameState.Planets) {
+	if p.BotPlanetApproachID >= 0 && p.BotPlanetApproachID < len(s.gameState.Planets) && s.gameState.Planets[p.BotPlanetApproachID] != nil {
 		approachPlanet := s.gameState.Planets[p.BotPlanetApproachID]
 		defenderInfo := s.detectPlanetDefenders(approachPlanet, p.Team)
 
+		// Enemies beaming at the planet are racing us for it: clear them first
+		if p.Armies == 0 && s.raceForPlanet(p, approachPlanet, defenderInfo) {
+			return
+		}
+
+		// Abandon the assault if the planet has been reinforced since we
+		// committed and no allies are left to support us; the objective
+		// selection below then picks another planet
+		if defenderInfo.DefenseScore >= p.BotApproachDefense+AssaultAbandonScoreRise && s.countAlliesNear(p, AssaultSupportRange) == 0 {
+			s.abandonAssault(p, approachPlanet)
+			if enemyArmyPlanet == approachPlanet {
+				enemyArmyPlanet = s.findNearestEnemyArmyPlanet(p)
+			}
+			if takePlanet == approachPlanet {
+				takePlanet = s.findBestPlanetToTake(p)
+			}
+		}
+
 		// Check if defenders are cleared or pushed far enough away
 		defendersCleared := defenderInfo.DefenderCount == 0 || defenderInfo.MinDefenderDist > 10000
 
@@ -214,8 +292,8 @@ func (s *Server) updateBotHard(p *game.Player) {
 		if p.BotTarget >= 0 && p.BotTarget < game.MaxPlayers {
 			target := s.gameState.Players[p.BotTarget]
 			if target.Status == game.StatusAlive {
-				targetDist := game.Distance(p.X, p.Y, target.X, target.Y)
-				planetDist := game.Distance(target.X, target.Y, approachPlanet.X, approachPlanet.Y)
+				targetDist := s.distance(p.X, p.Y, target.X, target.Y)
+				planetDist := s.distance(target.X, target.Y, approachPlanet.X, approachPlanet.Y)
 				// Target is still threatening if alive, close to us, and near the planet
 				targetStillThreatening = targetDist < 8000 && planetDist < 12000
 			}
@@ -223,14 +301,13 @@ func (s *Server) updateBotHard(p *game.Player) {
 
 		if defendersCleared && !targetStillThreatening {
 			// Defenders are cleared, resume planet approach
-			dist := game.Distance(p.X, p.Y, approachPlanet.X, approachPlanet.Y)
+			dist := s.distance(p.X, p.Y, approachPlanet.X, approachPlanet.Y)
 			if dist < OrbitDistance {
 				// Close enough to planet, clear approach ID and let normal logic take over
 				p.BotPlanetApproachID = -1
 			} else {
 				// Navigate back to the planet
-				dx := approachPlanet.X - p.X
-				dy := approachPlanet.Y - p.Y
+				dx, dy := s.delta(p.X, p.Y, approachPlanet.X, approachPlanet.Y)
 				baseDir := math.Atan2(dy, dx)
 				desiredSpeed := s.getOptimalSpeed(p, dist)
 
@@ -244,19 +321,23 @@ func (s *Server) updateBotHard(p *game.Player) {
 	// Repair/fuel decision based on threat level
 	if (needRepair || needFuel) && (enemyDist > 15000 || criticalDamage) {
 		var targetPlanet *game.Planet
-		if needFuel && fuelPlanet != nil {
-			targetPlanet = fuelPlanet
-		} else if needRepair && repairPlanet != nil {
-			targetPlanet = repairPlanet
+		if needRepair && needFuel {
+			// One planet that does both saves shuttling between two
+			targetPlanet = s.findNearestRepairAndFuelPlanet(p)
+		}
+		if targetPlanet == nil {
+			if needFuel && fuelPlanet != nil {
+				targetPlanet = fuelPlanet
+			} else if needRepair && repairPlanet != nil {
+				targetPlanet = repairPlanet
+			}
 		}
 
 		if targetPlanet != nil {
-			dist := game.Distance(p.X, p.Y, targetPlanet.X, targetPlanet.Y)
+			dist := s.distance(p.X, p.Y, targetPlanet.X, targetPlanet.Y)
 			if dist < OrbitDistance {
 				// Start orbiting for repair
-				p.Orbiting = targetPlanet.ID
-				targetPlanet.Info |= p.Team // Update planet info
-				p.DesSpeed = 0
+				s.botOrbit(p, targetPlanet)
 				p.Shields_up = false
 				// Activate repair mode if damaged over 50%
 				if needRepair && !p.Repairing {
@@ -267,8 +348,7 @@ func (s *Server) updateBotHard(p *game.Player) {
 			} else {
 				// Navigate to repair/fuel planet with torpedo dodging
 				p.Orbiting = -1
-				dx := targetPlanet.X - p.X
-				dy := targetPlanet.Y - p.Y
+				dx, dy := s.delta(p.X, p.Y, targetPlanet.X, targetPlanet.Y)
 				baseDir := math.Atan2(dy, dx)
 				desiredSpeed := s.getOptimalSpeed(p, dist)
 
@@ -279,40 +359,48 @@ func (s *Server) updateBotHard(p *game.Player) {
 		}
 	}
 
+	// Search where a recently engaged target cloaked before moving on
+	if p.Armies == 0 && !p.Bombing && enemyDist > EnemyClose && s.searchLastKnownTarget(p) {
+		return
+	}
+
 	// TOURNAMENT MODE: Prioritize planet conquest
 	if s.gameState.T_mode {
 		// In tournament mode, focus on strategic objectives
 
+		// The space controller holds the center instead of taking planets
+		if p.Armies == 0 && s.isTeamSpaceController(p) {
+			s.holdCenter(p, nearestEnemy, enemyDist)
+			return
+		}
+
 		// If carrying armies, prioritize delivering them to NEUTRAL planets
 		if p.Armies > 0 {
 			// First, look for neutral planets only
 			targetPlanet := s.findNearestNeutralPlanet(p)
 
 			if targetPlanet != nil {
-				dist := game.Distance(p.X, p.Y, targetPlanet.X, targetPlanet.Y)
+				dist := s.distance(p.X, p.Y, targetPlanet.X, targetPlanet.Y)
 
 				if dist < OrbitDistance {
 					// At planet
 					if p.Orbiting != targetPlanet.ID {
-						p.Orbiting = targetPlanet.ID
-						targetPlanet.Info |= p.Team // Update planet info
-						p.DesSpeed = 0
+						s.botOrbit(p, targetPlanet)
 					}
 
-					// Neutral planets should have no armies, just beam down
+					// Neutral planets should have no armies, just beam down,
+					// shooting at anyone racing us for it
 					p.Bombing = false
 					p.Beaming = true
 					p.BeamingUp = false
-					p.BotCooldown = 50
+					p.BotCooldown = s.contestBeamer(p, targetPlanet, s.botBeamCooldown(p))
 					return
 				} else {
 					// Navigate to neutral planet with torpedo dodging
 					p.Orbiting = -1
 					p.Bombing = false
-					p.Beaming = false
-					p.BeamingUp = false
-					dx := targetPlanet.X - p.X
-					dy := targetPlanet.Y - p.Y
+					stopBeaming(p)
+					dx, dy := s.delta(p.X, p.Y, targetPlanet.X, targetPlanet.Y)
 					baseDir := math.Atan2(dy, dx)
 					desiredSpeed := s.getOptimalSpeed(p, dist)
 
@@ -336,7 +424,7 @@ func (s *Server) updateBotHard(p *game.Player) {
 		var targetPlanet *game.Planet
 
 		// Check if currently bombing an enemy planet - finish the job first
-		if p.Bombing && p.Orbiting >= 0 && p.Orbiting < len(s.gameState.Planets) {
+		if p.Bombing && p.Orbiting >= 0 && p.Orbiting < len(s.gameState.Planets) && s.gameState.Planets[p.Orbiting] != nil {
 			currentPlanet := s.gameState.Planets[p.Orbiting]
 			if currentPlanet.Owner != p.Team && currentPlanet.Owner != game.TeamNone && currentPlanet.Armies > 0 {
 				// Still bombing an enemy planet - continue unless in extreme danger
@@ -368,7 +456,7 @@ func (s *Server) updateBotHard(p *game.Player) {
 		}
 
 		if targetPlanet != nil {
-			dist := game.Distance(p.X, p.Y, targetPlanet.X, targetPlanet.Y)
+			dist := s.distance(p.X, p.Y, targetPlanet.X, targetPlanet.Y)
 
 			// Check for defenders around the target planet
 			defenderInfo := s.detectPlanetDefenders(targetPlanet, p.Team)
@@ -376,9 +464,7 @@ func (s *Server) updateBotHard(p *game.Player) {
 			if dist < OrbitDistance {
 				// At planet - perform appropriate action
 				if p.Orbiting != targetPlanet.ID {
-					p.Orbiting = targetPlanet.ID
-					targetPlanet.Info |= p.Team // Update planet info
-					p.DesSpeed = 0
+					s.botOrbit(p, targetPlanet)
 				}
 
 				if targetPlanet.Owner == p.Team {
@@ -388,12 +474,11 @@ func (s *Server) updateBotHard(p *game.Player) {
 						p.Bombing = false // Stop bombing if planet is now friendly
 						p.Beaming = true
 						p.BeamingUp = true
-						p.BotCooldown = 50
+						p.BotCooldown = s.botBeamCooldown(p)
 					} else {
 						// Can't beam up (no kill streak or full), leave orbit and find enemies
 						p.Bombing = false
-						p.Beaming = false
-						p.BeamingUp = false
+						stopBeaming(p)
 						p.Orbiting = -1
 						p.BotCooldown = 10
 						// Look for combat opportunities
@@ -404,14 +489,14 @@ func (s *Server) updateBotHard(p *game.Player) {
 					}
 				} else {
 					// Enemy or neutral planet - check if it still needs bombing
-					if targetPlanet.Owner != game.TeamNone && targetPlanet.Owner != p.Team && targetPlanet.Armies > 0 {
-						// Enemy planet with armies - keep bombing it
+					if targetPlanet.Owner != game.TeamNone && targetPlanet.Owner != p.Team {
+						// Enemy planet - keep bombing until it drops to
+						// independent; bombing alone never captures it
 						p.Bombing = true
-						p.Beaming = false
-						p.BeamingUp = false
+						stopBeaming(p)
 						p.BotCooldown = 10 // Reduced from 100 to re-evaluate sooner
-					} else if targetPlanet.Armies == 0 || targetPlanet.Owner == game.TeamNone {
-						// No armies or neutral planet
+					} else {
+						// Neutral planet - only beaming down captures it
 						p.Bombing = false // Stop bombing if no armies left
 						if p.Armies > 0 {
 							// Beam down to take it
@@ -420,8 +505,7 @@ func (s *Server) updateBotHard(p *game.Player) {
 							p.BotCooldown = 10 // Reduced from 50 to be more responsive
 						} else {
 							// No armies to beam down, leave orbit
-							p.Beaming = false
-							p.BeamingUp = false
+							stopBeaming(p)
 							p.Orbiting = -1
 							p.BotCooldown = 10
 						}
@@ -429,6 +513,11 @@ func (s *Server) updateBotHard(p *game.Player) {
 				}
 				return
 			} else {
+				// Enemies beaming at the planet are racing us for it: clear them first
+				if s.raceForPlanet(p, targetPlanet, defenderInfo) {
+					return
+				}
+
 				// Determine if we should engage defenders before approaching planet
 				const DANGER_THRESHOLD = 2500.0  // Defense score threshold for engaging
 				const MIN_SAFE_DISTANCE = 6000.0 // Distance threshold for safe approach
@@ -456,18 +545,9 @@ func (s *Server) updateBotHard(p *game.Player) {
 
 					// Abort if too many defenders and no allies nearby
 					if defenderInfo.DefenderCount >= 3 {
-						alliesNearby := 0
-						for _, ally := range s.gameState.Players {
-							if ally.Status == game.StatusAlive && ally.Team == p.Team && ally.ID != p.ID {
-								allyDist := game.Distance(p.X, p.Y, ally.X, ally.Y)
-								if allyDist < 15000 {
-									alliesNearby++
-								}
-							}
-						}
-						if alliesNearby == 0 {
+						if s.countAlliesNear(p, AssaultSupportRange) == 0 {
 							// Too dangerous, abort this planet
-							p.BotPlanetApproachID = -1
+							s.abandonAssault(p, targetPlanet)
 							p.BotCooldown = 50 // Look for different target
 							return
 						}
@@ -476,28 +556,31 @@ func (s *Server) updateBotHard(p *game.Player) {
 
 				if shouldEngageDefenders && primaryDefender != nil {
 					// Set planet approach ID so we can resume after clearing defender
+					if p.BotPlanetApproachID != targetPlanet.ID {
+						p.BotApproachDefense = defenderInfo.DefenseScore
+					}
 					p.BotPlanetApproachID = targetPlanet.ID
 
 					// Clear planet-specific states
 					p.Orbiting = -1
 					p.Bombing = false
-					p.Beaming = false
-					p.BeamingUp = false
+					stopBeaming(p)
 
 					// Engage the primary defender instead of going to planet
-					defenderDist := game.Distance(p.X, p.Y, primaryDefender.X, primaryDefender.Y)
+					defenderDist := s.distance(p.X, p.Y, primaryDefender.X, primaryDefender.Y)
 					s.engageCombat(p, primaryDefender, defenderDist)
 					return
 				} else {
 					// Safe to approach planet directly or no significant defenders
 					p.Orbiting = -1
 					p.Bombing = false
-					p.Beaming = false
-					p.BeamingUp = false
+					stopBeaming(p)
+					if p.BotPlanetApproachID != targetPlanet.ID {
+						p.BotApproachDefense = defenderInfo.DefenseScore
+					}
 					p.BotPlanetApproachID = targetPlanet.ID // Track our objective
 
-					dx := targetPlanet.X - p.X
-					dy := targetPlanet.Y - p.Y
+					dx, dy := s.delta(p.X, p.Y, targetPlanet.X, targetPlanet.Y)
 					baseDir := math.Atan2(dy, dx)
 					desiredSpeed := s.getOptimalSpeed(p, dist)
 
@@ -544,7 +627,7 @@ func (s *Server) updateBotHard(p *game.Player) {
 		case BotRoleHunter:
 			// Aggressive enemy hunting - but retreat if heavily damaged
 			if target := s.selectBestCombatTarget(p); target != nil {
-				dist := game.Distance(p.X, p.Y, target.X, target.Y)
+				dist := s.distance(p.X, p.Y, target.X, target.Y)
 				// Retreat if heavily damaged and outgunned
 				if criticalDamage && dist < 6000 {
 					// Disengage and seek repair instead of fighting to the death
@@ -556,13 +639,15 @@ func (s *Server) updateBotHard(p *game.Player) {
 			}
 
 		case BotRoleDefender:
+			// Mine the lane an enemy is taking toward a nearby frontline planet
+			s.tryPlasmaAreaDenial(p)
+
 			// Defend friendly planets
 			if planet := s.findPlanetToDefend(p); planet != nil {
-				dist := game.Distance(p.X, p.Y, planet.X, planet.Y)
+				dist := s.distance(p.X, p.Y, planet.X, planet.Y)
 				if dist > 5000 {
 					// Move to defend with torpedo dodging
-					dx := planet.X - p.X
-					dy := planet.Y - p.Y
+					dx, dy := s.delta(p.X, p.Y, planet.X, planet.Y)
 					baseDir := math.Atan2(dy, dx)
 					desiredSpeed := float64(shipStats.MaxSpeed)
 
@@ -582,14 +667,12 @@ func (s *Server) updateBotHard(p *game.Player) {
 		case BotRoleRaider:
 			// Hit and run tactics on enemy infrastructure
 			if planet := s.findPlanetToRaid(p); planet != nil {
-				dist := game.Distance(p.X, p.Y, planet.X, planet.Y)
+				dist := s.distance(p.X, p.Y, planet.X, planet.Y)
 				if dist < OrbitDistance {
 					// Quick bomb and run
 					if planet.Armies > 0 && planet.Owner != p.Team {
-						p.Orbiting = planet.ID
-						planet.Info |= p.Team // Update planet info
+						s.botOrbit(p, planet)
 						p.Bombing = true
-						p.DesSpeed = 0
 						p.BotCooldown = 30
 					} else {
 						// Move to next target
@@ -597,8 +680,7 @@ func (s *Server) updateBotHard(p *game.Player) {
 					}
 				} else {
 					// Approach at high speed with torpedo dodging
-					dx := planet.X - p.X
-					dy := planet.Y - p.Y
+					dx, dy := s.delta(p.X, p.Y, planet.X, planet.Y)
 					baseDir := math.Atan2(dy, dx)
 					desiredSpeed := float64(shipStats.MaxSpeed)
 
@@ -607,6 +689,29 @@ func (s *Server) updateBotHard(p *game.Player) {
 				}
 				return
 			}
+
+		case BotRoleScout:
+			// Gather intel on enemy planets, staying out of fights
+			if planet := s.findUnscoutedEnemyPlanet(p); planet != nil {
+				s.scoutPlanet(p, planet, nearestEnemy, enemyDist)
+				return
+			}
+
+		case BotRoleInterceptor:
+			// Hold the border, but still fight enemies that come close
+			if (nearestEnemy == nil || enemyDist > InterceptorEngageRange) && s.patrolBorder(p) {
+				return
+			}
+
+		case BotRoleSpaceControl:
+			s.holdCenter(p, nearestEnemy, enemyDist)
+			return
+
+		case BotRoleScreen:
+			if _, planet := s.findHumanAssault(p); planet != nil {
+				s.screenHuman(p, planet)
+				return
+			}
 		}
 
 		// Fallback to combat if no specific role
@@ -628,6 +733,7 @@ func (s *Server) updateBotHard(p *game.Player) {
 // Starbases are cautious, defensive, and focused on protecting territory
 func (s *Server) updateStarbaseBot(p *game.Player) {
 	shipStats := game.ShipData[p.Ship]
+	detectRange := s.config().StarbaseEnemyDetectRange
 
 	// HIGHEST PRIORITY: Planet defense - check for friendly planets under immediate threat
 	if planet, enemy, enemyDist := s.getThreatenedFriendlyPlanet(p); planet != nil && enemy != nil {
@@ -643,11 +749,11 @@ func (s *Server) updateStarbaseBot(p *game.Player) {
 	nearestEnemy := s.findNearestEnemy(p)
 	enemyDist := MaxSearchDistance
 	if nearestEnemy != nil {
-		enemyDist = game.Distance(p.X, p.Y, nearestEnemy.X, nearestEnemy.Y)
+		enemyDist = s.distance(p.X, p.Y, nearestEnemy.X, nearestEnemy.Y)
 	}
 
 	// Priority 2: Combat overrides all other behaviors when enemy is in detection range
-	if nearestEnemy != nil && enemyDist < game.StarbaseEnemyDetectRange {
+	if nearestEnemy != nil && enemyDist < detectRange {
 		s.starbaseDefensiveCombat(p, nearestEnemy, enemyDist)
 		return
 	}
@@ -664,7 +770,7 @@ func (s *Server) updateStarbaseBot(p *game.Player) {
 	threatenedPlanet := s.findMostThreatenedFriendlyPlanet(p)
 
 	// Critical needs - get to safety first
-	if criticalDamage || (needRepair && enemyDist < game.StarbaseEnemyDetectRange) {
+	if criticalDamage || (needRepair && enemyDist < detectRange) {
 		var safetyPlanet *game.Planet
 		if repairPlanet != nil {
 			safetyPlanet = repairPlanet
@@ -675,24 +781,21 @@ func (s *Server) updateStarbaseBot(p *game.Player) {
 		}
 
 		if safetyPlanet != nil {
-			dist := game.Distance(p.X, p.Y, safetyPlanet.X, safetyPlanet.Y)
+			dist := s.distance(p.X, p.Y, safetyPlanet.X, safetyPlanet.Y)
 			if dist < OrbitDistance {
 				// Safe at friendly planet - repair
-				p.Orbiting = safetyPlanet.ID
-				safetyPlanet.Info |= p.Team // Update planet info
-				p.DesSpeed = 0
+				s.botOrbit(p, safetyPlanet)
 				p.Shields_up = false
 				if needRepair {
 					p.Repairing = true
 				}
 				p.BotCooldown = 30
 				return
-			} else if enemyDist > game.StarbaseEnemyDetectRange {
+			} else if enemyDist > detectRange {
 				// Move cautiously to safety
 				p.Orbiting = -1
-				p.Repairing = false
-				dx := safetyPlanet.X - p.X
-				dy := safetyPlanet.Y - p.Y
+				cancelRepair(p)
+				dx, dy := s.delta(p.X, p.Y, safetyPlanet.X, safetyPlanet.Y)
 				p.DesDir = math.Atan2(dy, dx)
 				p.DesSpeed = 2 // Very slow, cautious movement
 				p.Shields_up = true
@@ -702,12 +805,12 @@ func (s *Server) updateStarbaseBot(p *game.Player) {
 	}
 
 	// Currently orbiting - stay put if it's beneficial
-	if p.Orbiting >= 0 && p.Orbiting < len(s.gameState.Planets) {
+	if p.Orbiting >= 0 && p.Orbiting < len(s.gameState.Planets) && s.gameState.Planets[p.Orbiting] != nil {
 		orbitPlanet := s.gameState.Planets[p.Orbiting]
 		if orbitPlanet.Owner == p.Team {
 			// At friendly planet - consider staying
 			isCorePlanet := s.isCorePlanet(orbitPlanet, p.Team)
-			isSafe := enemyDist > game.StarbaseEnemyDetectRange+3000 || (enemyDist > game.StarbaseTorpRange && isCorePlanet)
+			isSafe := enemyDist > detectRange+3000 || (enemyDist > s.config().StarbaseTorpRange && isCorePlanet)
 
 			if (needRepair || needFuel) && isSafe {
 				// Stay and repair/refuel
@@ -736,22 +839,19 @@ func (s *Server) updateStarbaseBot(p *game.Player) {
 		// Team controls significant territory - can be more aggressive in defense
 		if threatenedPlanet != nil {
 			// Move to defend threatened planet
-			dist := game.Distance(p.X, p.Y, threatenedPlanet.X, threatenedPlanet.Y)
+			dist := s.distance(p.X, p.Y, threatenedPlanet.X, threatenedPlanet.Y)
 			if dist > 4000 {
 				// Move closer to threatened planet
 				p.Orbiting = -1
-				p.Repairing = false
-				dx := threatenedPlanet.X - p.X
-				dy := threatenedPlanet.Y - p.Y
+				cancelRepair(p)
+				dx, dy := s.delta(p.X, p.Y, threatenedPlanet.X, threatenedPlanet.Y)
 				p.DesDir = math.Atan2(dy, dx)
 				p.DesSpeed = 2 // Slow, deliberate movement
 				p.Shields_up = true
 				return
 			} else {
 				// Close to threatened planet - defend it
-				p.Orbiting = threatenedPlanet.ID
-				threatenedPlanet.Info |= p.Team // Update planet info
-				p.DesSpeed = 0
+				s.botOrbit(p, threatenedPlanet)
 				// Combat handled by priority check above, no need for duplicate logic
 				p.BotCooldown = 10
 				return
@@ -760,22 +860,19 @@ func (s *Server) updateStarbaseBot(p *game.Player) {
 	} else {
 		// Team controls less than 1/4 - stay near core planets
 		if corePlanet != nil {
-			dist := game.Distance(p.X, p.Y, corePlanet.X, corePlanet.Y)
+			dist := s.distance(p.X, p.Y, corePlanet.X, corePlanet.Y)
 			if dist > 3000 {
 				// Move back to core area
 				p.Orbiting = -1
-				p.Repairing = false
-				dx := corePlanet.X - p.X
-				dy := corePlanet.Y - p.Y
+				cancelRepair(p)
+				dx, dy := s.delta(p.X, p.Y, corePlanet.X, corePlanet.Y)
 				p.DesDir = math.Atan2(dy, dx)
 				p.DesSpeed = 2
 				p.Shields_up = true
 				return
 			} else {
 				// Near core planet - defend it
-				p.Orbiting = corePlanet.ID
-				corePlanet.Info |= p.Team // Update planet info
-				p.DesSpeed = 0
+				s.botOrbit(p, corePlanet)
 				// Use proper threat assessment for shield decisions
 				s.assessAndActivateShields(p)
 				// Combat handled by priority check above, no need for duplicate logic
@@ -801,8 +898,10 @@ func (s *Server) starbaseDefensiveCombat(p *game.Player, enemy *game.Player, dis
 	s.tryPhaserNearbyPlasma(p)
 
 	// Fire weapons regardless of facing - starbases can fire in any direction
+	// unless Config.FireArc applies
 	shipStats := game.ShipData[p.Ship]
-	effectiveTorpRange := float64(game.EffectiveTorpRangeForShip(p.Ship, shipStats))
+	cfg := s.config()
+	effectiveTorpRange := math.Min(float64(game.EffectiveTorpRangeForShip(p.Ship, shipStats)), cfg.StarbaseTorpRange)
 	canReach := s.canTorpReachTarget(p, enemy)
 
 	// Torpedoes at long range
@@ -813,7 +912,7 @@ func (s *Server) starbaseDefensiveCombat(p *game.Player, enemy *game.Player, dis
 	}
 
 	// Phasers at medium range - fire at any target, not just damaged ones
-	sbPhaserRange := game.PhaserRange(shipStats)
+	sbPhaserRange := math.Min(game.PhaserRange(shipStats), cfg.StarbasePhaserRange)
 	if dist < sbPhaserRange && p.Fuel > 1500 && p.WTemp < shipStats.MaxWpnTemp-100 {
 		s.fireBotPhaser(p, enemy)
 		p.BotCooldown = 5
@@ -822,7 +921,7 @@ func (s *Server) starbaseDefensiveCombat(p *game.Player, enemy *game.Player, dis
 
 	// Plasma for area denial
 	sbPlasmaCost := shipStats.PlasmaDamage * shipStats.PlasmaFuelMult
-	if shipStats.HasPlasma && p.NumPlasma < 1 && dist < game.StarbasePlasmaMaxRange && dist > 1000 && p.Fuel >= sbPlasmaCost {
+	if shipStats.HasPlasma && p.NumPlasma < shipStats.MaxPlasma && dist < cfg.StarbasePlasmaMaxRange && dist > 1000 && p.Fuel >= sbPlasmaCost {
 		if s.fireBotPlasma(p, enemy) {
 			p.BotCooldown = 12
 			return
@@ -837,14 +936,13 @@ func (s *Server) starbaseDefensiveCombat(p *game.Player, enemy *game.Player, dis
 func (s *Server) starbaseDefensivePatrol(p *game.Player) {
 	// Find center of friendly territory
 	centerX, centerY := s.calculateTeamCenter(p.Team)
-	dist := game.Distance(p.X, p.Y, centerX, centerY)
+	dist := s.distance(p.X, p.Y, centerX, centerY)
 
 	// Stay within 15000 units of team center
 	if dist > 15000 {
 		// Move back towards team center
 		p.Orbiting = -1
-		dx := centerX - p.X
-		dy := centerY - p.Y
+		dx, dy := s.delta(p.X, p.Y, centerX, centerY)
 		p.DesDir = math.Atan2(dy, dx)
 		p.DesSpeed = 2
 		p.Shields_up = true
@@ -885,7 +983,7 @@ func (s *Server) findMostThreatenedFriendlyPlanet(p *game.Player) *game.Planet {
 			if enemy.Team == p.Team || enemy.Status != game.StatusAlive {
 				continue
 			}
-			dist := game.Distance(planet.X, planet.Y, enemy.X, enemy.Y)
+			dist := s.distance(planet.X, planet.Y, enemy.X, enemy.Y)
 			if dist < 15000 {
 				// Closer enemies are more threatening
 				threatLevel += (15000 - dist) / 15000
@@ -905,6 +1003,13 @@ func (s *Server) findMostThreatenedFriendlyPlanet(p *game.Player) *game.Planet {
 	return mostThreatened
 }
 
+// botBeamCooldown is how long a bot commits to beaming before re-evaluating:
+// long enough to move BotBeamBatch armies at p's beam rate.
+func (s *Server) botBeamCooldown(p *game.Player) int {
+	beams := (BotBeamBatch + s.config().BeamArmies - 1) / s.config().BeamArmies
+	return beams * s.beamFrames(p)
+}
+
 // RemoveBot removes a bot player from the game
 func (s *Server) RemoveBot(botID int) {
 	if botID < 0 || botID >= game.MaxPlayers {
@@ -919,9 +1024,11 @@ func (s *Server) RemoveBot(botID int) {
 		return
 	}
 
+	s.releaseBotName(botID)
 	p.Status = game.StatusFree
 	p.Connected = false
 	p.IsBot = false
+	p.Dummy = false
 	p.Name = ""
 
 	// Clear tractor/pressor references from other players targeting this bot
@@ -953,27 +1060,50 @@ func (s *Server) findNearestFuelPlanet(p *game.Player) *game.Planet {
 	})
 }
 
-// AutoBalanceBots adds or removes bots to balance teams
-// Players and bots count equally as team members for balancing
-func (s *Server) AutoBalanceBots() {
-	// Count all team members (both human players and bots)
-	teamCounts := make(map[int]int)
-	teams := []int{game.TeamFed, game.TeamRom, game.TeamKli, game.TeamOri}
+// findNearestRepairAndFuelPlanet finds the closest friendly planet that both
+// repairs and refuels.
+func (s *Server) findNearestRepairAndFuelPlanet(p *game.Player) *game.Planet {
+	const both = game.PlanetRepair | game.PlanetFuel
+	return s.nearestPlanet(p, func(pl *game.Planet) bool {
+		return pl.Owner == p.Team && pl.Flags&both == both
+	})
+}
 
-	// Initialize team counts
-	for _, team := range teams {
-		teamCounts[team] = 0
-	}
+// teamRoster is a snapshot of each team's members: connected humans and bots
+// in any non-free state (dead or exploding ships will respawn), not counting
+// practice dummies.
+type teamRoster struct {
+	members map[int]int   // Humans and bots
+	humans  map[int]int   // Humans only
+	bots    map[int][]int // Bot slots, lowest first
+}
+
+// teamRoster counts the members of each team. Acquires the gameState read
+// lock internally.
+func (s *Server) teamRoster() teamRoster {
+	r := teamRoster{members: make(map[int]int), humans: make(map[int]int), bots: make(map[int][]int)}
 
 	s.gameState.Mu.RLock()
 	for _, p := range s.gameState.Players {
-		// Count all non-free connected players (including dead/exploding),
-		// since they will respawn and are still team members.
-		if p.Status != game.StatusFree && p.Connected {
-			teamCounts[p.Team]++
+		if p.Status == game.StatusFree || !p.Connected || p.Dummy {
+			continue
+		}
+		r.members[p.Team]++
+		if p.IsBot {
+			r.bots[p.Team] = append(r.bots[p.Team], p.ID)
+		} else {
+			r.humans[p.Team]++
 		}
 	}
 	s.gameState.Mu.RUnlock()
+	return r
+}
+
+// teamMemberCounts counts the members (both human players and bots, but not
+// practice dummies) of each team and returns the counts along with the size of the largest team.
+// Acquires the gameState read lock internally.
+func (s *Server) teamMemberCounts() (map[int]int, int) {
+	teamCounts := s.teamRoster().members
 
 	// Find team with most members (players + bots)
 	maxCount := 0
@@ -982,6 +1112,64 @@ func (s *Server) AutoBalanceBots() {
 			maxCount = count
 		}
 	}
+	return teamCounts, maxCount
+}
+
+// addBalanceBots adds up to n bots to team, each in the ship the team needs
+// most, and returns how many joined. It stops early when the server is full.
+func (s *Server) addBalanceBots(team, n int) int {
+	added := 0
+	for ; added < n; added++ {
+		// Choose ship type based on team needs (read game state under lock)
+		s.gameState.Mu.RLock()
+		ship := s.selectBotShipType(team)
+		s.gameState.Mu.RUnlock()
+		if !s.AddBot(team, ship) {
+			break
+		}
+	}
+	return added
+}
+
+// announceBalanceBots tells everyone how many bots auto-balance added to each
+// team, e.g. "Auto-balance: added 2 bots to Romulans, 1 bot to Klingons".
+func (s *Server) announceBalanceBots(added map[int]int) {
+	var messages []string
+	for _, team := range []int{game.TeamFed, game.TeamRom, game.TeamKli, game.TeamOri} {
+		if added[team] > 0 {
+			botWord := "bot"
+			if added[team] > 1 {
+				botWord = "bots"
+			}
+			messages = append(messages, fmt.Sprintf("%d %s to %s", added[team], botWord, s.config().Map.TeamName(team)))
+		}
+	}
+	s.broadcastInfo(fmt.Sprintf("Auto-balance: added %s", strings.Join(messages, ", ")))
+}
+
+// backfillTeam adds a single bot to team when it has fallen behind the
+// largest team, e.g. after a human disconnects. Returns true if a bot joined.
+func (s *Server) backfillTeam(team int) bool {
+	teamCounts, maxCount := s.teamMemberCounts()
+	if teamCounts[team] >= maxCount || s.addBalanceBots(team, 1) == 0 {
+		return false
+	}
+
+	s.broadcastInfo(fmt.Sprintf("Auto-balance: added 1 bot to %s to replace a departed player",
+		formatTeamNames(getTeamNamesFromFlag(s.config().Map, team))))
+	return true
+}
+
+// AutoBalanceBots adds or removes bots to balance teams
+// Players and bots count equally as team members for balancing
+func (s *Server) AutoBalanceBots() {
+	// Survival mode's teams are the defenders and the attack waves
+	if s.config().Survival {
+		s.broadcastInfo("Auto-balance: not available in survival mode")
+		return
+	}
+
+	teamCounts, maxCount := s.teamMemberCounts()
 
 	// If no one is on the server, don't add bots
 	if maxCount == 0 {
@@ -992,46 +1180,79 @@ func (s *Server) AutoBalanceBots() {
 	// Balance teams by adding bots with appropriate ship types
 	botsAdded := make(map[int]int)
 	totalBotsAdded := 0
-
-	for _, team := range teams {
-		deficit := maxCount - teamCounts[team]
-		for deficit > 0 {
-			// Choose ship type based on team needs (read game state under lock)
-			s.gameState.Mu.RLock()
-			ship := s.selectBotShipType(team)
-			s.gameState.Mu.RUnlock()
-			s.AddBot(team, ship)
-			botsAdded[team]++
-			totalBotsAdded++
-			deficit--
-		}
+	for _, team := range []int{game.TeamFed, game.TeamRom, game.TeamKli, game.TeamOri} {
+		botsAdded[team] = s.addBalanceBots(team, maxCount-teamCounts[team])
+		totalBotsAdded += botsAdded[team]
 	}
 
 	// Send feedback message
 	if totalBotsAdded == 0 {
 		s.broadcastInfo("Auto-balance: teams already balanced, no bots added")
 	} else {
-		// Build a descriptive message about what was added
-		var messages []string
-		teamNames := map[int]string{
-			game.TeamFed: "Federation",
-			game.TeamRom: "Romulan",
-			game.TeamKli: "Klingon",
-			game.TeamOri: "Orion",
+		s.announceBalanceBots(botsAdded)
+	}
+}
+
+// autoBalanceInterval is how often the game loop runs scheduled auto-balance.
+const autoBalanceInterval = 5 * time.Second
+
+// scheduledAutoBalance evens out teams with bots against the largest human
+// team, adding and announcing bots the same way as AutoBalanceBots. Teams
+// short by at least the configured threshold get bots at once. Teams over
+// strength only lose bots once they have stayed over for the configured
+// delay, so a human dropping out mid-fight doesn't yank the bots balancing
+// against them. Called from the game loop.
+func (s *Server) scheduledAutoBalance(now time.Time) {
+	cfg := s.config()
+	teams := []int{game.TeamFed, game.TeamRom, game.TeamKli, game.TeamOri}
+	roster := s.teamRoster()
+
+	target := 0
+	for _, team := range teams {
+		target = max(target, roster.humans[team])
+	}
+	if target == 0 {
+		return // updateGame clears the bots once the last human leaves
+	}
+
+	if s.balanceSurplusSince == nil {
+		s.balanceSurplusSince = make(map[int]time.Time)
+	}
+	threshold := max(cfg.AutoBalanceThreshold, 1)
+	added := make(map[int]int)
+	for _, team := range teams {
+		if deficit := target - roster.members[team]; deficit >= threshold {
+			delete(s.balanceSurplusSince, team)
+			added[team] = s.addBalanceBots(team, deficit)
+			continue
 		}
 
-		for _, team := range teams {
-			if botsAdded[team] > 0 {
-				botWord := "bot"
-				if botsAdded[team] > 1 {
-					botWord = "bots"
-				}
-				messages = append(messages, fmt.Sprintf("%d %s to %s", botsAdded[team], botWord, teamNames[team]))
-			}
+		surplus := min(roster.members[team]-target, len(roster.bots[team]))
+		if surplus < threshold {
+			delete(s.balanceSurplusSince, team)
+			continue
+		}
+		since, ok := s.balanceSurplusSince[team]
+		if !ok {
+			s.balanceSurplusSince[team] = now
+			continue
+		}
+		if now.Sub(since) < time.Duration(cfg.AutoBalanceRemoveDelay)*time.Second {
+			continue
+		}
+		// Remove the newest (highest-slot) bots first
+		teamBots := roster.bots[team]
+		for i := 0; i < surplus; i++ {
+			s.RemoveBot(teamBots[len(teamBots)-1-i])
 		}
+		delete(s.balanceSurplusSince, team)
+	}
 
-		messageText := fmt.Sprintf("Auto-balance: added %s", strings.Join(messages, ", "))
-		s.broadcastInfo(messageText)
+	for _, n := range added {
+		if n > 0 {
+			s.announceBalanceBots(added)
+			break
+		}
 	}
 }
 
@@ -1053,7 +1274,7 @@ func (s *Server) starbaseDefendPlanet(p *game.Player, planet *game.Planet, enemy
 	s.starbaseDefenseWeaponLogic(p, enemy, enemyDist)
 
 	// Check if threat is gone
-	if enemy.Status != game.StatusAlive || enemyDist > game.StarbaseEnemyDetectRange+5000 {
+	if enemy.Status != game.StatusAlive || enemyDist > s.config().StarbaseEnemyDetectRange+5000 {
 		if threatenedPlanet, _, _ := s.getThreatenedFriendlyPlanet(p); threatenedPlanet == nil {
 			p.BotDefenseTarget = -1
 			p.BotCooldown = 15
@@ -1088,7 +1309,7 @@ func (s *Server) getPlanetThreats() map[int]planetThreat {
 	threats := make(map[int]planetThreat, len(s.gameState.Planets))
 	for i := range s.gameState.Planets {
 		planet := s.gameState.Planets[i]
-		if planet.Owner == game.TeamNone {
+		if planet == nil || planet.Owner == game.TeamNone {
 			continue // unowned planets are never defended by a bot
 		}
 
@@ -1102,7 +1323,7 @@ func (s *Server) getPlanetThreats() map[int]planetThreat {
 				continue
 			}
 
-			enemyToPlanetDist := game.Distance(enemy.X, enemy.Y, planet.X, planet.Y)
+			enemyToPlanetDist := s.distance(enemy.X, enemy.Y, planet.X, planet.Y)
 			isThreatening := false
 			currentThreatScore := 0.0
 
@@ -1113,7 +1334,7 @@ func (s *Server) getPlanetThreats() map[int]planetThreat {
 			} else {
 				// Check if enemy is moving toward the planet (vector analysis)
 				if enemy.Speed > 1.0 && enemyToPlanetDist < 12000 {
-					angleToPlanet := math.Atan2(planet.Y-enemy.Y, planet.X-enemy.X)
+					angleToPlanet := s.bearing(enemy.X, enemy.Y, planet.X, planet.Y)
 					// AngleDifference fully normalizes the wrap (a manual
 					// single-fold can leave a negative angle).
 					angleDiff := AngleDifference(enemy.Dir, angleToPlanet)
@@ -1170,12 +1391,12 @@ func (s *Server) getThreatenedFriendlyPlanet(p *game.Player) (*game.Planet, *gam
 	// Check each friendly planet within bot's scanning range
 	for i := range s.gameState.Planets {
 		planet := s.gameState.Planets[i]
-		if planet.Owner != p.Team {
+		if planet == nil || planet.Owner != p.Team {
 			continue
 		}
 
 		// Only check planets within bot's detection range
-		botToPlanetDist := game.Distance(p.X, p.Y, planet.X, planet.Y)
+		botToPlanetDist := s.distance(p.X, p.Y, planet.X, planet.Y)
 		if botToPlanetDist > PlanetDefenseDetectRadius {
 			continue
 		}
@@ -1192,7 +1413,7 @@ func (s *Server) getThreatenedFriendlyPlanet(p *game.Player) (*game.Planet, *gam
 			bestPlanet = planet
 			bestEnemy = pt.closestEnemy
 			// Bot-to-enemy distance for weapon range checks and positioning.
-			bestBotToEnemyDist = game.Distance(p.X, p.Y, pt.closestEnemy.X, pt.closestEnemy.Y)
+			bestBotToEnemyDist = s.distance(p.X, p.Y, pt.closestEnemy.X, pt.closestEnemy.Y)
 		}
 	}
 
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/lab1702/netrek-web/game"
@@ -231,3 +233,1175 @@ func TestStarbaseDefense(t *testing.T) {
 
 	t.Log("Starbase defense test completed successfully")
 }
+
+func TestBotPanicRetreatWhenCriticalAndOutnumbered(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{
+		gameState: gs,
+		broadcast: make(chan ServerMessage, 100),
+	}
+	gs.Frame = 1
+
+	// A single friendly planet due west of the bot
+	for _, planet := range gs.Planets {
+		planet.Owner = game.TeamNone
+	}
+	haven := gs.Planets[0]
+	haven.Owner = game.TeamFed
+	haven.X = 30000
+	haven.Y = 50000
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X = 50000
+	bot.Y = 50000
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.Damage = 85 // 85% of cruiser hull
+	bot.BotPrevDamage = 85
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+
+	// Two enemies closing from the east
+	for i, y := range []float64{49000, 51000} {
+		enemy := gs.Players[i+1]
+		enemy.Status = game.StatusAlive
+		enemy.Team = game.TeamRom
+		enemy.Ship = game.ShipCruiser
+		enemy.X = 53000
+		enemy.Y = y
+	}
+
+	server.updateBotHard(bot)
+
+	if len(gs.Torps) != 0 || len(gs.Plasmas) != 0 {
+		t.Errorf("panicking bot should hold fire, got %d torps and %d plasmas", len(gs.Torps), len(gs.Plasmas))
+	}
+	if !bot.Cloaked {
+		t.Error("panicking bot with fuel to spare should cloak")
+	}
+	if bot.DesSpeed != float64(game.ShipData[game.ShipCruiser].MaxSpeed) {
+		t.Errorf("panicking bot should flee at max speed, got DesSpeed=%.1f", bot.DesSpeed)
+	}
+	// Heading should point west toward the friendly planet
+	if math.Cos(bot.DesDir) > -0.9 {
+		t.Errorf("panicking bot should head toward friendly planet (west), got DesDir=%.2f", bot.DesDir)
+	}
+}
+
+// TestBotPrefersRepairAndFuelPlanet verifies that a bot needing both repair
+// and fuel heads for a planet that offers both rather than the nearer
+// single-purpose planets.
+func TestBotPrefersRepairAndFuelPlanet(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+	for _, planet := range gs.Planets {
+		planet.Owner = game.TeamNone
+		planet.Flags &^= game.PlanetRepair | game.PlanetFuel
+	}
+	place := func(idx int, x, y float64, flags int) *game.Planet {
+		planet := gs.Planets[idx]
+		planet.Owner = game.TeamFed
+		planet.X, planet.Y = x, y
+		planet.Flags |= flags
+		return planet
+	}
+	place(0, 55000, 50000, game.PlanetRepair)                         // east, close
+	place(1, 45000, 50000, game.PlanetFuel)                           // west, close
+	dual := place(2, 50000, 65000, game.PlanetRepair|game.PlanetFuel) // south, farther
+
+	stats := game.ShipData[game.ShipCruiser]
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X = 50000
+	bot.Y = 50000
+	bot.Speed = 6
+	bot.Damage = stats.MaxDamage * 3 / 5
+	bot.Fuel = stats.MaxFuel / 5
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+	bot.BotPlanetApproachID = -1
+
+	server.updateBotHard(bot)
+
+	want := math.Atan2(dual.Y-bot.Y, dual.X-bot.X)
+	if math.Abs(game.NormalizeAngle(bot.DesDir-want+math.Pi)-math.Pi) > 0.2 {
+		t.Errorf("bot heading %.2f, want toward repair+fuel planet at %.2f", bot.DesDir, want)
+	}
+}
+
+// TestPlanetFireScaleDetersBotFromDefendedPlanet verifies that raising planet
+// fire damage steers a bot away from a heavily garrisoned enemy planet it would
+// otherwise pick over a quieter neutral one.
+func TestPlanetFireScaleDetersBotFromDefendedPlanet(t *testing.T) {
+	pick := func(scale float64) (chosen, defended, quiet *game.Planet) {
+		cfg := DefaultConfig()
+		cfg.PlanetFireScale = scale
+		gs := game.NewGameState()
+		server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+		for _, planet := range gs.Planets {
+			planet.Owner = game.TeamFed
+			planet.Flags = 0
+			planet.X, planet.Y = 10000, 10000
+		}
+		defended = gs.Planets[0]
+		defended.Owner = game.TeamRom
+		defended.Armies = 30
+		defended.X, defended.Y = 58000, 50000
+		quiet = gs.Planets[1]
+		quiet.Owner = game.TeamNone
+		quiet.Armies = 30
+		quiet.X, quiet.Y = 50000, 62000
+
+		bot := gs.Players[0]
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = game.ShipCruiser
+		bot.IsBot = true
+		bot.Connected = true
+		bot.X, bot.Y = 50000, 50000
+
+		return server.findBestPlanetToTake(bot), defended, quiet
+	}
+
+	if chosen, defended, _ := pick(1.0); chosen != defended {
+		t.Fatalf("at normal planet fire the bot picked %v, want the closer defended planet", chosen.Name)
+	}
+	if chosen, _, quiet := pick(10.0); chosen != quiet {
+		t.Errorf("at 10x planet fire the bot picked %v, want the undefended neutral planet", chosen.Name)
+	}
+}
+
+// TestInterceptorPrefersIncomingCarrier verifies that a team's interceptor bot
+// ignores a nearby enemy with no armies and runs down a distant enemy carrier
+// heading for a friendly planet.
+func TestInterceptorPrefersIncomingCarrier(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+	for _, planet := range gs.Planets {
+		planet.Owner = game.TeamNone
+		planet.X, planet.Y = 90000, 90000
+	}
+	home := gs.Planets[0]
+	home.Owner = game.TeamFed
+	home.X, home.Y = 30000, 50000
+
+	var interceptor *game.Player
+	for i := 0; i < InterceptorMinTeamBots; i++ {
+		bot := gs.Players[i]
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = game.ShipCruiser
+		bot.IsBot = true
+		bot.Connected = true
+		bot.X, bot.Y = 20000, 20000+float64(i)*3000
+		bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+		bot.Orbiting = -1
+		bot.Tractoring = -1
+		bot.Pressoring = -1
+		bot.BotTarget = -1
+		bot.BotDefenseTarget = -1
+		bot.BotPlanetApproachID = -1
+		interceptor = bot
+	}
+	interceptor.X, interceptor.Y = 40000, 50000
+
+	bystander := gs.Players[10]
+	bystander.Status = game.StatusAlive
+	bystander.Team = game.TeamRom
+	bystander.Ship = game.ShipCruiser
+	bystander.X, bystander.Y = 43000, 50000
+
+	carrier := gs.Players[11]
+	carrier.Status = game.StatusAlive
+	carrier.Team = game.TeamRom
+	carrier.Ship = game.ShipCruiser
+	carrier.Armies = 4
+	carrier.X, carrier.Y = 30000, 66000
+	carrier.Dir = -math.Pi / 2 // Straight at the Federation planet
+	carrier.Speed = 6
+
+	if !server.isTeamInterceptor(interceptor) {
+		t.Fatal("highest-slot bot should be the team interceptor")
+	}
+	if server.isTeamInterceptor(gs.Players[0]) {
+		t.Fatal("only one bot per team should intercept")
+	}
+
+	server.updateBotHard(interceptor)
+
+	if interceptor.BotTarget != carrier.ID {
+		t.Fatalf("interceptor targeted player %d, want carrier %d", interceptor.BotTarget, carrier.ID)
+	}
+	toCarrier := math.Atan2(carrier.Y-interceptor.Y, carrier.X-interceptor.X)
+	if diff := AngleDifference(interceptor.DesDir, toCarrier); diff > math.Pi/4 {
+		t.Errorf("interceptor heading %.2f, want toward the carrier at %.2f", interceptor.DesDir, toCarrier)
+	}
+}
+
+// TestBotAbandonsReinforcedPlanetAssault verifies that a bot approaching a
+// planet gives up the approach when two new defenders arrive and it has no
+// allies left to support it.
+func TestBotAbandonsReinforcedPlanetAssault(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+	target := gs.Planets[20]
+	target.Owner = game.TeamRom
+	target.Armies = 3
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X = target.X - 12000
+	bot.Y = target.Y
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+	bot.BotTarget = -1
+	bot.BotDefenseTarget = -1
+
+	// Committed to the planet while it was undefended
+	bot.BotPlanetApproachID = target.ID
+	bot.BotApproachDefense = 0
+
+	// Two defenders arrive at the planet
+	for i, id := range []int{1, 2} {
+		d := gs.Players[id]
+		d.Status = game.StatusAlive
+		d.Team = game.TeamRom
+		d.Ship = game.ShipCruiser
+		d.X = target.X + 1000
+		d.Y = target.Y + float64(i)*800
+	}
+
+	server.updateBotHard(bot)
+
+	if bot.BotPlanetApproachID == target.ID {
+		t.Error("bot should abandon its approach to a reinforced planet with no allies nearby")
+	}
+}
+
+// TestBotPicksNewTargetAfterAbandoningAssault verifies that over several
+// decisions a bot that abandoned a reinforced planet goes for another planet
+// instead of re-selecting the one it just gave up on.
+func TestBotPicksNewTargetAfterAbandoningAssault(t *testing.T) {
+	gs := game.NewGameState()
+	gs.T_mode = true
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+	for _, planet := range gs.Planets {
+		planet.Owner = game.TeamNone
+		planet.Armies = 0
+	}
+	target := gs.Planets[20]
+	target.Owner = game.TeamRom
+	target.Armies = 10
+	other := gs.Planets[5]
+	other.Owner = game.TeamRom
+	other.Armies = 10
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X = target.X - 12000
+	bot.Y = target.Y
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+	bot.BotTarget = -1
+	bot.BotDefenseTarget = -1
+
+	// Committed while the planet was undefended, then two defenders arrive
+	bot.BotPlanetApproachID = target.ID
+	bot.BotApproachDefense = 0
+	for i, id := range []int{1, 2} {
+		d := gs.Players[id]
+		d.Status = game.StatusAlive
+		d.Team = game.TeamRom
+		d.Ship = game.ShipCruiser
+		d.X = target.X + 1000
+		d.Y = target.Y + float64(i)*800
+	}
+
+	for frame := 1; frame <= 10; frame++ {
+		gs.Frame = int64(frame)
+		bot.BotCooldown = 0
+		server.updateBotHard(bot)
+		if bot.BotPlanetApproachID == target.ID {
+			t.Fatalf("frame %d: bot went back to the planet it abandoned", frame)
+		}
+	}
+	if bot.BotPlanetApproachID != other.ID {
+		t.Errorf("bot approach planet = %d, want the other enemy planet %d", bot.BotPlanetApproachID, other.ID)
+	}
+}
+
+// TestBotTargetsEnemyBeamingAtContestedPlanet verifies that a bot approaching
+// a planet an enemy is beaming armies onto goes after the beamer first, even
+// with another enemy closer to it.
+func TestBotTargetsEnemyBeamingAtContestedPlanet(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+	for _, planet := range gs.Planets {
+		planet.Owner = game.TeamNone
+		planet.Armies = 0
+	}
+	target := gs.Planets[20]
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X = target.X - 9000
+	bot.Y = target.Y
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+	bot.BotTarget = -1
+	bot.BotDefenseTarget = -1
+	bot.BotPlanetApproachID = target.ID
+
+	// An escort sits between the bot and the planet
+	escort := gs.Players[1]
+	escort.Status = game.StatusAlive
+	escort.Team = game.TeamRom
+	escort.Ship = game.ShipCruiser
+	escort.X = target.X - 6000
+	escort.Y = target.Y
+	escort.Orbiting = -1
+
+	// A carrier orbits the planet beaming its armies down
+	beamer := gs.Players[2]
+	beamer.Status = game.StatusAlive
+	beamer.Team = game.TeamRom
+	beamer.Ship = game.ShipAssault
+	beamer.X, beamer.Y = target.X+800, target.Y
+	beamer.Orbiting = target.ID
+	beamer.Armies = 4
+	beamer.Beaming = true
+
+	server.updateBotHard(bot)
+
+	if bot.BotTarget != beamer.ID {
+		t.Errorf("bot targeted player %d, want the beaming carrier %d", bot.BotTarget, beamer.ID)
+	}
+	if bot.BotPlanetApproachID != target.ID {
+		t.Errorf("bot approach planet = %d, want to stay committed to %d", bot.BotPlanetApproachID, target.ID)
+	}
+
+	// Beaming armies up from the planet is no race for it
+	beamer.BeamingUp = true
+	if info := server.detectPlanetDefenders(target, bot.Team); info.ClosestBeamer != nil {
+		t.Errorf("an enemy beaming up counted as racing for the planet")
+	}
+
+	// Nor is reinforcing a planet the enemy already owns
+	beamer.BeamingUp = false
+	target.Owner = game.TeamRom
+	if info := server.detectPlanetDefenders(target, bot.Team); info.ClosestBeamer != nil {
+		t.Errorf("an enemy reinforcing its own planet counted as racing for it")
+	}
+}
+
+// TestScoutBotScoutsUnscoutedEnemyPlanet verifies that a team's scout bot
+// flies to an enemy planet its team has no intel on and scouts it by orbiting,
+// as a human must.
+func TestScoutBotScoutsUnscoutedEnemyPlanet(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+	allTeams := game.TeamFed | game.TeamRom | game.TeamKli | game.TeamOri
+	for _, planet := range gs.Planets {
+		planet.Info = allTeams
+	}
+	target := gs.Planets[20]
+	target.Owner = game.TeamRom
+	target.Info = game.TeamRom
+	target.X, target.Y = 50000, 70000
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipScout
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X, bot.Y = 50000, 50000
+	bot.Fuel = game.ShipData[game.ShipScout].MaxFuel
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+	bot.BotTarget = -1
+	bot.BotDefenseTarget = -1
+	bot.BotPlanetApproachID = -1
+
+	if role := server.selectBotBehavior(bot); role != BotRoleScout {
+		t.Fatalf("role = %q, want %q", role, BotRoleScout)
+	}
+
+	for frame := 1; frame <= 600 && target.Info&game.TeamFed == 0; frame++ {
+		gs.Frame = int64(frame)
+		server.UpdateBots()
+		server.updatePlayerPhysics(bot, 0)
+	}
+
+	if target.Info&game.TeamFed == 0 {
+		t.Fatalf("scout never scouted %s (ended at %.0f, %.0f)", target.Name, bot.X, bot.Y)
+	}
+	if bot.Orbiting != target.ID {
+		t.Errorf("scout gained intel on %s without orbiting it", target.Name)
+	}
+	if role := server.selectBotBehavior(bot); role == BotRoleScout {
+		t.Error("scout role should end once every enemy planet is scouted")
+	}
+}
+
+// TestTeamScoutIsFastestBot verifies that a team without a scout ship sends
+// its fastest bot to scout, and that a scout ship takes the role once the
+// team has one.
+func TestTeamScoutIsFastestBot(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+	ships := []game.ShipType{game.ShipCruiser, game.ShipDestroyer, game.ShipBattleship}
+	for i, ship := range ships {
+		bot := gs.Players[i]
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = ship
+		bot.IsBot = true
+	}
+
+	for i := range ships {
+		if got, want := server.isTeamScout(gs.Players[i]), i == 1; got != want {
+			t.Errorf("bot %d (%s) isTeamScout = %v, want %v", i, game.ShipData[ships[i]].Name, got, want)
+		}
+	}
+
+	scout := gs.Players[3]
+	scout.Status = game.StatusAlive
+	scout.Team = game.TeamFed
+	scout.Ship = game.ShipScout
+	scout.IsBot = true
+	if !server.isTeamScout(scout) || server.isTeamScout(gs.Players[1]) {
+		t.Error("the scout ship should take the scout role from the destroyer")
+	}
+}
+
+// TestSpaceControllerHoldsCenter verifies that the space control bot fires on
+// an enemy crossing the galaxy center but stays near the center instead of
+// pursuing it toward the edge.
+func TestSpaceControllerHoldsCenter(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 1000)}
+
+	centerX, centerY := game.GalaxyWidth/2.0, game.GalaxyHeight/2.0
+	for _, planet := range gs.Planets {
+		planet.Owner = game.TeamNone
+		planet.X, planet.Y = 5000, 5000
+	}
+
+	ships := []game.ShipType{game.ShipBattleship, game.ShipCruiser, game.ShipCruiser, game.ShipCruiser}
+	for i, ship := range ships {
+		bot := gs.Players[i]
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = ship
+		bot.IsBot = true
+		bot.Connected = true
+		bot.X, bot.Y = 10000, 10000+float64(i)*3000
+		bot.Fuel = game.ShipData[ship].MaxFuel
+		bot.Orbiting = -1
+		bot.Tractoring = -1
+		bot.Pressoring = -1
+		bot.BotTarget = -1
+		bot.BotDefenseTarget = -1
+		bot.BotPlanetApproachID = -1
+	}
+	controller := gs.Players[0]
+	controller.X, controller.Y = centerX, centerY
+
+	if !server.isTeamSpaceController(controller) {
+		t.Fatal("the battleship should hold the center")
+	}
+	if server.isTeamSpaceController(gs.Players[1]) || server.isTeamSpaceController(gs.Players[3]) {
+		t.Fatal("only one bot per team should hold the center")
+	}
+	if role := server.selectBotBehavior(controller); role != BotRoleSpaceControl {
+		t.Fatalf("controller role = %q, want %q", role, BotRoleSpaceControl)
+	}
+
+	// An enemy crosses near the center and runs for the edge
+	enemy := gs.Players[10]
+	enemy.Status = game.StatusAlive
+	enemy.Team = game.TeamRom
+	enemy.Ship = game.ShipCruiser
+	enemy.X, enemy.Y = centerX+4000, centerY
+	enemy.Dir, enemy.DesDir = 0, 0
+	enemy.Speed, enemy.DesSpeed = 6, 6
+
+	engaged := false
+	maxDist := 0.0
+	for tick := 0; tick < 300; tick++ {
+		if controller.BotCooldown > 0 {
+			controller.BotCooldown--
+		}
+		server.updateBotHard(controller)
+		server.updatePlayerPhysics(controller, controller.ID)
+		server.updatePlayerPhysics(enemy, enemy.ID)
+		// Weapon heat shows the controller fired on its target
+		if controller.BotTarget == enemy.ID && controller.WTemp > 0 {
+			engaged = true
+		}
+		maxDist = math.Max(maxDist, game.Distance(controller.X, controller.Y, centerX, centerY))
+	}
+
+	if !engaged {
+		t.Error("space controller never fired on the passing enemy")
+	}
+	// Allow a little overshoot while turning back at the zone edge
+	if maxDist > SpaceControlRadius+2000 {
+		t.Errorf("space controller strayed %.0f from center, want within about %.0f", maxDist, SpaceControlRadius)
+	}
+	if enemyDist := game.Distance(controller.X, controller.Y, enemy.X, enemy.Y); enemyDist < 20000 {
+		t.Fatalf("enemy only %.0f away after 300 ticks; test needs it to flee", enemyDist)
+	}
+}
+
+// TestDefenderMinesApproachLine verifies that a planet defender facing an
+// inbound enemy fires a spread across the enemy's approach line, ahead of
+// the enemy, instead of straight at it.
+func TestDefenderMinesApproachLine(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 10)}
+
+	planet := gs.Planets[0]
+	planet.Owner = game.TeamFed
+	planet.X, planet.Y = 50000, 50000
+
+	defender := gs.Players[0]
+	defender.Status = game.StatusAlive
+	defender.Team = game.TeamFed
+	defender.Ship = game.ShipCruiser
+	defender.IsBot = true
+	defender.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	defender.X, defender.Y = 48000, 46000
+
+	// The enemy flies due east, straight at the planet
+	enemy := gs.Players[1]
+	enemy.Status = game.StatusAlive
+	enemy.Team = game.TeamRom
+	enemy.Ship = game.ShipCruiser
+	enemy.X, enemy.Y = 42000, 50000
+	enemy.Dir = 0
+	enemy.Speed = 8
+
+	server.defendPlanet(defender, planet, enemy, game.Distance(defender.X, defender.Y, enemy.X, enemy.Y))
+
+	if len(gs.Torps) != MineSpreadTorps {
+		t.Fatalf("defender fired %d torpedoes, want a %d-torpedo wall", len(gs.Torps), MineSpreadTorps)
+	}
+	for _, torp := range gs.Torps {
+		// Where the torp's path crosses the approach line (y = 50000)
+		sin := math.Sin(torp.Dir)
+		if sin <= 0 {
+			t.Fatalf("torpedo heading %.2f never reaches the approach line", torp.Dir)
+		}
+		ticks := (enemy.Y - torp.Y) / (torp.Speed * sin)
+		crossX := torp.X + torp.Speed*math.Cos(torp.Dir)*ticks
+		if ticks > float64(torp.Fuse) {
+			t.Errorf("torpedo heading %.2f burns out before the approach line", torp.Dir)
+		}
+		if crossX <= enemy.X || crossX >= planet.X {
+			t.Errorf("torpedo crosses the approach line at x=%.0f, want between the enemy (%.0f) and the planet (%.0f)", crossX, enemy.X, planet.X)
+		}
+	}
+}
+
+// TestBotsSurviveNilPlanetSlots verifies that the bot AI tolerates a game
+// state whose planet table has empty slots, in and out of tournament mode.
+func TestBotsSurviveNilPlanetSlots(t *testing.T) {
+	for _, tMode := range []bool{false, true} {
+		server := NewServer()
+		gs := server.gameState
+
+		teams := []int{game.TeamFed, game.TeamRom, game.TeamKli, game.TeamOri}
+		ships := []game.ShipType{game.ShipScout, game.ShipDestroyer, game.ShipCruiser, game.ShipBattleship, game.ShipAssault, game.ShipStarbase}
+		for _, team := range teams {
+			for _, ship := range ships {
+				server.AddBot(team, ship)
+			}
+		}
+
+		// Knock out every third planet, including ones the bots may be
+		// orbiting or heading for
+		for i := 0; i < game.MaxPlanets; i += 3 {
+			gs.Planets[i] = nil
+		}
+		for _, p := range gs.Players {
+			if p.IsBot && p.Status == game.StatusAlive {
+				p.Orbiting = 0
+				p.BotPlanetApproachID = 3
+			}
+		}
+		gs.T_mode = tMode
+
+		for frame := 0; frame < 100; frame++ {
+			gs.Frame++
+			for _, p := range gs.Players {
+				p.BotCooldown = 0
+			}
+			server.UpdateBots()
+		}
+	}
+}
+
+// TestCommanderPushDrawsBotsToPlanet verifies that when the team commander
+// orders a push on one planet, the half of the team closest to it converges
+// on it, even though it lies beyond the range at which they would pick raid
+// targets themselves.
+func TestCommanderPushDrawsBotsToPlanet(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 1000)}
+
+	target := gs.Planets[21] // Pliedes V, a Klingon planet
+	target.Armies = 5
+
+	bots := []*game.Player{gs.Players[0], gs.Players[1], gs.Players[2], gs.Players[3]}
+	for i, bot := range bots {
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = game.ShipCruiser
+		bot.IsBot = true
+		bot.Connected = true
+		bot.X, bot.Y = 35000-float64(i)*2000, 55000+float64(i)*4000
+		if i == 2 {
+			bot.Ship = game.ShipBattleship // holds the galaxy center
+		}
+		bot.Fuel = game.ShipData[bot.Ship].MaxFuel
+		bot.Orbiting = -1
+		bot.Tractoring = -1
+		bot.Pressoring = -1
+		bot.BotTarget = -1
+		bot.BotDefenseTarget = -1
+		bot.BotPlanetApproachID = -1
+	}
+
+	startDist := make([]float64, len(bots))
+	for i, bot := range bots {
+		startDist[i] = game.Distance(bot.X, bot.Y, target.X, target.Y)
+		if startDist[i] < 30000 {
+			t.Fatalf("bot %d starts only %.0f from the target; test needs it out of raid range", i, startDist[i])
+		}
+	}
+
+	gs.TeamOrders[game.TeamFed] = game.TeamOrder{Objective: TeamOrderPush, Planet: target.ID, Target: -1}
+	bots = bots[:2]
+	for i, bot := range bots {
+		if role := server.selectBotBehavior(bot); role != BotRoleRaider {
+			t.Fatalf("bot %d role under a push order = %q, want %q", i, role, BotRoleRaider)
+		}
+	}
+
+	for tick := 0; tick < 200; tick++ {
+		gs.Frame++
+		for _, bot := range bots {
+			if bot.BotCooldown > 0 {
+				bot.BotCooldown--
+			}
+			server.updateBotHard(bot)
+			server.updatePlayerPhysics(bot, bot.ID)
+		}
+	}
+
+	for i, bot := range bots {
+		if dist := game.Distance(bot.X, bot.Y, target.X, target.Y); dist > startDist[i]/3 {
+			t.Errorf("bot %d is still %.0f from the push target (started %.0f)", i, dist, startDist[i])
+		}
+	}
+}
+
+// TestCommanderOrderTakesShareOfTeam verifies that a defend order only draws
+// the bots closest to the threatened planet, and that the rest of the team
+// keeps its dynamic role.
+func TestCommanderOrderTakesShareOfTeam(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 1000)}
+
+	planet := gs.Planets[0] // Earth
+	for i := 0; i < 2; i++ {
+		bot := gs.Players[i]
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = game.ShipCruiser
+		bot.IsBot = true
+		bot.Connected = true
+		bot.BotTarget = -1
+		bot.BotDefenseTarget = -1
+		bot.X, bot.Y = planet.X+float64(i+1)*5000, planet.Y
+	}
+
+	gs.TeamOrders[game.TeamFed] = game.TeamOrder{Objective: TeamOrderDefend, Planet: planet.ID, Target: -1}
+	if role := server.selectBotBehavior(gs.Players[0]); role != BotRoleDefender {
+		t.Errorf("bot nearest the planet: role = %q, want %q", role, BotRoleDefender)
+	}
+	if role := server.selectBotBehavior(gs.Players[1]); role != BotRoleHunter {
+		t.Errorf("bot beyond the order's share: role = %q, want its dynamic role %q", role, BotRoleHunter)
+	}
+}
+
+// TestCommanderOrderSparesDefenseAndScout verifies that push and hunt orders
+// leave the defense squad defending and the team scout scouting.
+func TestCommanderOrderSparesDefenseAndScout(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 1000)}
+
+	gs.Planets[21].Info = game.TeamKli // Pliedes V is still unscouted
+
+	ships := []game.ShipType{game.ShipCruiser, game.ShipScout}
+	for i, ship := range ships {
+		bot := gs.Players[i]
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = ship
+		bot.IsBot = true
+		bot.Connected = true
+		bot.BotTarget = -1
+		bot.BotDefenseTarget = -1
+	}
+	gs.BotSquads[0] = SquadDefense
+	gs.BotSquads[1] = SquadOffense
+
+	for _, tc := range []struct {
+		order game.TeamOrder
+		want  []string
+	}{
+		{game.TeamOrder{Objective: TeamOrderPush, Planet: 21, Target: -1}, []string{BotRoleDefender, BotRoleScout}},
+		{game.TeamOrder{Objective: TeamOrderHunt, Planet: -1, Target: 5}, []string{BotRoleDefender, BotRoleScout}},
+	} {
+		gs.TeamOrders[game.TeamFed] = tc.order
+		for i, want := range tc.want {
+			if role := server.selectBotBehavior(gs.Players[i]); role != want {
+				t.Errorf("%s order: bot %d role = %q, want %q", tc.order.Objective, i, role, want)
+			}
+		}
+	}
+}
+
+// TestSquadsSplitTeamAndStayStable verifies that six bots on a team in an even
+// game are split evenly between offense and defense, that each bot not on a
+// special team duty follows its squad's role, and that the assignment holds between rebalances.
+func TestSquadsSplitTeamAndStayStable(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 1000)}
+
+	for i := 0; i < 6; i++ {
+		bot := gs.Players[i]
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = game.ShipCruiser
+		bot.IsBot = true
+		bot.Connected = true
+	}
+
+	gs.Frame = 1
+	server.updateSquads()
+	initial := make(map[int]string)
+	defense := 0
+	for i := 0; i < 6; i++ {
+		squad := gs.BotSquads[i]
+		initial[i] = squad
+		role := server.selectBotBehavior(gs.Players[i])
+		special := role == BotRoleInterceptor || role == BotRoleSpaceControl // Team duties outrank squads
+		switch squad {
+		case SquadDefense:
+			defense++
+			if role != BotRoleDefender && !special {
+				t.Errorf("defense bot %d role = %q, want %q", i, role, BotRoleDefender)
+			}
+		case SquadOffense:
+			if role != BotRoleHunter && role != BotRoleRaider && !special {
+				t.Errorf("offense bot %d role = %q, want hunter or raider", i, role)
+			}
+		default:
+			t.Fatalf("bot %d has no squad", i)
+		}
+	}
+	if defense != 3 {
+		t.Errorf("defense squad has %d of 6 bots, want 3", defense)
+	}
+
+	// Neither the frames between rebalances nor an unchanged rebalance
+	// reshuffle the squads
+	for gs.Frame = 2; gs.Frame <= SquadRebalanceFrames; gs.Frame++ {
+		server.updateSquads()
+	}
+	for i, want := range initial {
+		if got := gs.BotSquads[i]; got != want {
+			t.Errorf("bot %d squad changed from %q to %q", i, want, got)
+		}
+	}
+}
+
+// TestBotScreensHumanAssault verifies a bot near a human teammate orbiting an
+// enemy planet takes the screen role and moves out to meet an approaching
+// enemy defender rather than joining the assault.
+func TestBotScreensHumanAssault(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 1000)}
+
+	planet := gs.Planets[10] // Romulus
+	planet.Owner = game.TeamRom
+
+	human := gs.Players[0]
+	human.Status = game.StatusAlive
+	human.Team = game.TeamFed
+	human.Ship = game.ShipAssault
+	human.Connected = true
+	human.X, human.Y = planet.X+OrbitDistance, planet.Y
+	human.Orbiting = planet.ID
+	human.Bombing = true
+
+	bot := gs.Players[1]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X, bot.Y = planet.X-8000, planet.Y
+	bot.Fuel = game.ShipData[bot.Ship].MaxFuel
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+	bot.BotTarget = -1
+	bot.BotDefenseTarget = -1
+	bot.BotPlanetApproachID = -1
+
+	enemy := gs.Players[2]
+	enemy.Status = game.StatusAlive
+	enemy.Team = game.TeamRom
+	enemy.Ship = game.ShipCruiser
+	enemy.Connected = true
+	enemy.X, enemy.Y = planet.X, planet.Y+12000
+	enemy.Dir = -math.Pi / 2
+	enemy.Speed = 4
+
+	if role := server.selectBotBehavior(bot); role != BotRoleScreen {
+		t.Fatalf("role beside a human's planet assault = %q, want %q", role, BotRoleScreen)
+	}
+
+	for tick := 0; tick < 100; tick++ {
+		gs.Frame++
+		if bot.BotCooldown > 0 {
+			bot.BotCooldown--
+		}
+		server.updateBotHard(bot)
+		server.updatePlayerPhysics(bot, bot.ID)
+	}
+
+	if bot.Orbiting >= 0 || bot.Bombing {
+		t.Error("screening bot joined the assault instead of covering it")
+	}
+	if bot.BotTarget != enemy.ID {
+		t.Errorf("screening bot target = %d, want the approaching enemy %d", bot.BotTarget, enemy.ID)
+	}
+	// The screen should now stand between the enemy and the human
+	botDist := game.Distance(bot.X, bot.Y, enemy.X, enemy.Y)
+	humanDist := game.Distance(human.X, human.Y, enemy.X, enemy.Y)
+	if botDist >= humanDist {
+		t.Errorf("screening bot is %.0f from the enemy, no closer than the human at %.0f", botDist, humanDist)
+	}
+}
+
+// TestScreenLimitedPerHuman verifies only the ScreenMaxPerHuman closest bots
+// screen a human's assault, and a bot in the defense squad never does.
+func TestScreenLimitedPerHuman(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 1000)}
+
+	planet := gs.Planets[10] // Romulus
+	planet.Owner = game.TeamRom
+
+	human := gs.Players[0]
+	human.Status = game.StatusAlive
+	human.Team = game.TeamFed
+	human.Ship = game.ShipAssault
+	human.Connected = true
+	human.X, human.Y = planet.X+OrbitDistance, planet.Y
+	human.Orbiting = planet.ID
+	human.Bombing = true
+
+	for i := 1; i <= 4; i++ {
+		bot := gs.Players[i]
+		bot.Status = game.StatusAlive
+		bot.Team = game.TeamFed
+		bot.Ship = game.ShipCruiser
+		bot.IsBot = true
+		bot.Connected = true
+		bot.X, bot.Y = planet.X-float64(1000*(i+3)), planet.Y
+	}
+	// The closest bot defends, so the next two take the screen
+	gs.BotSquads[1] = SquadDefense
+
+	for i, want := range []bool{false, true, true, false} {
+		bot := gs.Players[i+1]
+		if got, _ := server.findHumanAssault(bot); (got == human) != want {
+			t.Errorf("bot %d screens the human = %v, want %v", bot.ID, got == human, want)
+		}
+	}
+}
+
+// TestLowFuelBotBreaksOffToRecharge verifies a bot low on fuel with no enemy
+// close by stops chasing its target, drops shields and heads for a friendly
+// fuel planet, re-engaging only once its fuel is nearly full.
+func TestLowFuelBotBreaksOffToRecharge(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 1000)}
+
+	// Earth, due south of the bot, is the only Federation fuel planet
+	earth := gs.Planets[0]
+	for _, planet := range gs.Planets {
+		if planet.ID != earth.ID && planet.Owner == game.TeamFed {
+			planet.Flags &^= game.PlanetFuel
+		}
+	}
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X, bot.Y = earth.X, earth.Y-10000
+	bot.Fuel = game.ShipData[bot.Ship].MaxFuel / 10
+	bot.Shields_up = true
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+	bot.BotDefenseTarget = -1
+	bot.BotPlanetApproachID = -1
+
+	// An enemy to the north, outside immediate threat range but close
+	// enough that the bot would otherwise keep chasing it
+	enemy := gs.Players[1]
+	enemy.Status = game.StatusAlive
+	enemy.Team = game.TeamRom
+	enemy.Ship = game.ShipCruiser
+	enemy.Connected = true
+	enemy.X, enemy.Y = bot.X, bot.Y-10000
+	bot.BotTarget = enemy.ID
+
+	startEnemyDist := game.Distance(bot.X, bot.Y, enemy.X, enemy.Y)
+	startEarthDist := game.Distance(bot.X, bot.Y, earth.X, earth.Y)
+	for tick := 0; tick < 50; tick++ {
+		gs.Frame++
+		if bot.BotCooldown > 0 {
+			bot.BotCooldown--
+		}
+		server.updateBotHard(bot)
+		server.updatePlayerPhysics(bot, bot.ID)
+	}
+
+	if !bot.BotRecharging {
+		t.Fatal("low-fuel bot with no close threat did not break off to recharge")
+	}
+	if bot.Shields_up {
+		t.Error("recharging bot kept its shields up")
+	}
+	if dist := game.Distance(bot.X, bot.Y, enemy.X, enemy.Y); dist <= startEnemyDist {
+		t.Errorf("recharging bot closed on the enemy (%.0f, started %.0f)", dist, startEnemyDist)
+	}
+	if dist := game.Distance(bot.X, bot.Y, earth.X, earth.Y); dist >= startEarthDist {
+		t.Errorf("recharging bot did not head for the fuel planet (%.0f, started %.0f)", dist, startEarthDist)
+	}
+
+	// Nearly full again, it goes back to the fight
+	bot.Fuel = game.ShipData[bot.Ship].MaxFuel
+	if server.tryRecharge(bot, startEnemyDist, false) || bot.BotRecharging {
+		t.Error("bot kept recharging with full fuel")
+	}
+}
+
+// TestBotNamesStayUnique verifies that bots beyond the name pool get numbered
+// names rather than duplicates, and that a removed bot's name can be reused.
+func TestBotNamesStayUnique(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BotNames = []string{"Alpha", "Beta", "Gamma"}
+	server := NewServerWithConfig(cfg)
+
+	names := make(map[string]int)
+	for i := 0; i < 5; i++ {
+		if !server.AddBot(game.TeamFed, game.ShipDestroyer) {
+			t.Fatalf("AddBot %d failed", i)
+		}
+	}
+	for _, p := range server.gameState.Players {
+		if p.IsBot {
+			names[p.Name] = p.ID
+		}
+	}
+	if len(names) != 5 {
+		t.Fatalf("5 bots got %d distinct names: %v", len(names), names)
+	}
+	for _, base := range cfg.BotNames {
+		if _, ok := names["[BOT] "+base]; !ok {
+			t.Errorf("pool name %q unused while numbered names were handed out", base)
+		}
+	}
+	suffixed := 0
+	for name := range names {
+		if strings.HasSuffix(name, "-2") {
+			suffixed++
+		}
+	}
+	if suffixed != 2 {
+		t.Errorf("%d bots have a -2 suffix, want the 2 beyond the pool: %v", suffixed, names)
+	}
+
+	// Removing Beta frees it for the next bot ahead of any suffixed name
+	server.RemoveBot(names["[BOT] Beta"])
+	if !server.AddBot(game.TeamFed, game.ShipDestroyer) {
+		t.Fatal("AddBot after removal failed")
+	}
+	found := false
+	for _, p := range server.gameState.Players {
+		if p.IsBot && p.Name == "[BOT] Beta" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("the removed bot's name should return to the pool")
+	}
+}
+
+// TestBotNameHeldWhileSlotLive verifies that a live bot slot keeps its pool
+// name while an administrator flies it, and that a bot flying a disconnected
+// player's ship keeps that player's name out of the pool.
+func TestBotNameHeldWhileSlotLive(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BotNames = []string{"Alpha", "Beta"}
+	cfg.BotTakeoverOnDisconnect = true
+	server := NewServerWithConfig(cfg)
+
+	if !server.AddBot(game.TeamFed, game.ShipDestroyer) {
+		t.Fatal("AddBot failed")
+	}
+	var possessed *game.Player
+	for _, p := range server.gameState.Players {
+		if p.IsBot {
+			possessed = p
+		}
+	}
+	possessed.IsBot = false // Possessed by an administrator
+
+	human := server.gameState.Players[5]
+	human.Status = game.StatusAlive
+	human.Name = "Beta"
+	human.Connected = true
+	human.OwnerClientID = 42
+	if !server.takeOverDisconnectedSlot(42, 5) {
+		t.Fatal("takeover failed")
+	}
+
+	if !server.AddBot(game.TeamRom, game.ShipDestroyer) {
+		t.Fatal("AddBot after takeover failed")
+	}
+	for _, p := range server.gameState.Players {
+		if p.IsBot && p.ID != 5 && p != possessed {
+			if p.Name == possessed.Name || p.Name == "[BOT] Beta" {
+				t.Errorf("new bot reused a held name: %q", p.Name)
+			}
+		}
+	}
+}
+
+// TestBotOrbitsWithShieldsDownUnderRule verifies that under the
+// shields-down rule a bot attacking a quiet planet lowers its shields to hold
+// the orbit, and leaves orbit with shields up once an enemy closes in.
+func TestBotOrbitsWithShieldsDownUnderRule(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ShieldsDownToOrbit = true
+	gs := game.NewGameState()
+	gs.T_mode = true
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 1000), cfg: &cfg}
+
+	for _, planet := range gs.Planets {
+		planet.Owner = game.TeamNone
+		planet.Armies = 0
+	}
+	target := gs.Planets[20]
+	target.Owner = game.TeamRom
+	target.Armies = 10
+
+	bot := gs.Players[0]
+	bot.Status = game.StatusAlive
+	bot.Team = game.TeamFed
+	bot.Ship = game.ShipCruiser
+	bot.IsBot = true
+	bot.Connected = true
+	bot.X = target.X + 500
+	bot.Y = target.Y
+	bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	bot.Shields_up = true
+	bot.Orbiting = -1
+	bot.Tractoring = -1
+	bot.Pressoring = -1
+	bot.BotTarget = -1
+	bot.BotDefenseTarget = -1
+	bot.BotPlanetApproachID = -1
+
+	step := func() {
+		gs.Frame++
+		server.updatePlanetInteractions()
+		server.UpdateBots()
+	}
+
+	for i := 0; i < 50; i++ {
+		step()
+	}
+	if bot.Orbiting != target.ID || bot.Shields_up {
+		t.Fatalf("quiet planet: Orbiting=%d Shields_up=%v, want orbiting planet %d with shields down",
+			bot.Orbiting, bot.Shields_up, target.ID)
+	}
+
+	enemy := gs.Players[1]
+	enemy.Status = game.StatusAlive
+	enemy.Team = game.TeamRom
+	enemy.Ship = game.ShipCruiser
+	enemy.Connected = true
+	enemy.X = bot.X + 1500
+	enemy.Y = bot.Y
+	enemy.Orbiting = -1
+
+	for i := 0; i < 30 && bot.Orbiting >= 0; i++ {
+		step()
+	}
+	if bot.Orbiting >= 0 || !bot.Shields_up {
+		t.Errorf("enemy close: Orbiting=%d Shields_up=%v, want the bot out of orbit with shields up",
+			bot.Orbiting, bot.Shields_up)
+	}
+}
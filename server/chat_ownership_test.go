@@ -51,3 +51,86 @@ func TestChatMessageRequiresSlotOwnership(t *testing.T) {
 		}
 	})
 }
+
+// TestObserverChatReachesOnlyObservers verifies observer chat is delivered to
+// every observer, the sender included, and to none of the players still alive.
+func TestObserverChatReachesOnlyObservers(t *testing.T) {
+	s := NewServer()
+	clients := make([]*Client, 3)
+	for i, status := range []int{game.StatusObserve, game.StatusObserve, game.StatusAlive} {
+		p := s.gameState.Players[i]
+		p.Status = status
+		p.Team = game.TeamFed
+		p.Name = "Player"
+		p.Connected = true
+		p.OwnerClientID = i + 1
+		clients[i] = &Client{ID: i + 1, server: s, send: make(chan ServerMessage, 10)}
+		clients[i].SetPlayerID(i)
+		s.clients[clients[i].ID] = clients[i]
+	}
+
+	msg, _ := json.Marshal(MessageData{Text: "what a dogfight"})
+	clients[0].handleObserverMessage(msg)
+
+	for i, want := range []bool{true, true, false} {
+		got := false
+		select {
+		case m := <-clients[i].send:
+			data, _ := m.Data.(map[string]interface{})
+			got = data["type"] == "observer"
+		default:
+		}
+		if got != want {
+			t.Errorf("client %d received observer chat = %v, want %v", i, got, want)
+		}
+	}
+
+	// A player still in the match cannot post to the observer channel
+	clients[2].handleObserverMessage(msg)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-clients[i].send:
+			t.Errorf("observer %d received chat sent by an alive player", i)
+		default:
+		}
+	}
+}
+
+// TestSpectatorChatWithoutLives verifies that without a lives limit, clients
+// watching without a ship of their own share the observer channel, while
+// players in the match, dead and waiting to respawn included, never see it.
+func TestSpectatorChatWithoutLives(t *testing.T) {
+	s := NewServer()
+	clients := make([]*Client, 4)
+	for i := range clients {
+		clients[i] = &Client{ID: i + 1, server: s, send: make(chan ServerMessage, 10)}
+		clients[i].SetPlayerID(-1)
+		s.clients[clients[i].ID] = clients[i]
+	}
+	for i, status := range []int{game.StatusAlive, game.StatusDead} {
+		c := clients[2+i]
+		p := s.gameState.Players[i]
+		p.Status = status
+		p.Team = game.TeamFed
+		p.Name = "Player"
+		p.Connected = true
+		p.OwnerClientID = c.ID
+		c.SetPlayerID(i)
+	}
+
+	msg, _ := json.Marshal(MessageData{Text: "nice save"})
+	clients[0].handleObserverMessage(msg)
+
+	for i, want := range []bool{true, true, false, false} {
+		got := false
+		select {
+		case m := <-clients[i].send:
+			data, _ := m.Data.(map[string]interface{})
+			got = data["type"] == "observer"
+		default:
+		}
+		if got != want {
+			t.Errorf("client %d received spectator chat = %v, want %v", i, got, want)
+		}
+	}
+}
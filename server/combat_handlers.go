@@ -31,13 +31,18 @@ func (c *Client) handleFire(data json.RawMessage) {
 		return
 	}
 
-	// Can't fire while cloaked or repairing
-	if p.Cloaked || p.Repairing {
+	// Can't fire while cloaked, repairing or with weapons held
+	if c.server.cloakBarsFire(p) || p.Repairing || c.server.weaponsHeld(p) {
+		return
+	}
+
+	// Torpedoes only launch within the fire arc
+	if c.server.facingBarsFire(p, fireData.Dir) {
 		return
 	}
 
 	// Check if can fire torpedo
-	if p.NumTorps >= game.MaxTorps {
+	if p.NumTorps >= game.MaxTorps || c.server.galaxyTorpsFull() {
 		return // Too many torps out
 	}
 
@@ -65,12 +70,13 @@ func (c *Client) handleFire(data json.RawMessage) {
 		Damage: shipStats.TorpDamage,
 		Fuse:   shipStats.TorpFuse, // Use ship-specific torpedo fuse
 		Status: game.TorpMove,      // Moving
-		Team:   p.Team,
+		Team:   c.server.projectileTeam(p),
 	}
 
 	c.server.gameState.Torps = append(c.server.gameState.Torps, torp)
 	c.server.nextTorpID++
 	p.NumTorps++
+	c.server.breakCloakToFire(p)
 	p.Fuel -= torpCost
 	p.WTemp += 50
 }
@@ -98,8 +104,28 @@ func (c *Client) handlePhaser(data json.RawMessage) {
 		return
 	}
 
-	// Can't fire while cloaked or repairing
-	if p.Cloaked || p.Repairing {
+	// Can't fire while cloaked, repairing or with weapons held
+	if c.server.cloakBarsFire(p) || p.Repairing || c.server.weaponsHeld(p) {
+		return
+	}
+
+	// Get phaser direction (use provided direction or calculate from target)
+	var course float64
+	if phaserData.Target >= 0 && phaserData.Target < game.MaxPlayers {
+		// Calculate direction to specific target
+		targetPlayer := c.server.gameState.Players[phaserData.Target]
+		if targetPlayer != nil && targetPlayer.Status == game.StatusAlive {
+			course = c.server.bearing(p.X, p.Y, targetPlayer.X, targetPlayer.Y)
+		} else {
+			return // Invalid target
+		}
+	} else {
+		// Use the provided direction (can be any angle from -π to π)
+		course = phaserData.Dir
+	}
+
+	// Phasers only fire within the fire arc
+	if c.server.facingBarsFire(p, course) {
 		return
 	}
 
@@ -116,27 +142,14 @@ func (c *Client) handlePhaser(data json.RawMessage) {
 		return
 	}
 
+	c.server.breakCloakToFire(p)
+
 	// Consume fuel and increase weapon temp regardless of hit
 	p.Fuel -= phaserCost
 	p.WTemp += 70
 
 	myPhaserRange := game.PhaserRange(shipStats)
 
-	// Get phaser direction (use provided direction or calculate from target)
-	var course float64
-	if phaserData.Target >= 0 && phaserData.Target < game.MaxPlayers {
-		// Calculate direction to specific target
-		targetPlayer := c.server.gameState.Players[phaserData.Target]
-		if targetPlayer != nil && targetPlayer.Status == game.StatusAlive {
-			course = math.Atan2(targetPlayer.Y-p.Y, targetPlayer.X-p.X)
-		} else {
-			return // Invalid target
-		}
-	} else {
-		// Use the provided direction (can be any angle from -π to π)
-		course = phaserData.Dir
-	}
-
 	// Find the nearest enemy ship on the phaser line; keep rangeSq so the
 	// plasma scan below only considers plasmas closer than the hit ship.
 	target, targetDist, rangeSq := c.server.phaserTargetInLine(p, course, myPhaserRange)
@@ -157,8 +170,7 @@ func (c *Client) handlePhaser(data json.RawMessage) {
 			continue
 		}
 
-		A := plasma.X - p.X
-		B := plasma.Y - p.Y
+		A, B := c.server.delta(p.X, p.Y, plasma.X, plasma.Y)
 
 		if math.Abs(A) >= myPhaserRange || math.Abs(B) >= myPhaserRange {
 			continue
@@ -204,11 +216,11 @@ func (c *Client) handlePhaser(data json.RawMessage) {
 	// Fire at target if found
 	if target != nil {
 		// Calculate damage based on distance using original formula
-		damage := float64(shipStats.PhaserDamage) * (1.0 - targetDist/myPhaserRange)
+		damage := game.PhaserDamageAt(shipStats, targetDist)
 		log.Printf("Phaser hit: player %d hit player %d for %.1f damage at range %.0f", p.ID, target.ID, damage, targetDist)
 
 		// Apply damage to shields first, then hull (round instead of truncate)
-		actualDamage := game.ApplyDamageWithShields(target, int(math.Round(damage)))
+		actualDamage := c.server.applyDamageFrom(target, c.server.handicapDamage(p.Team, int(math.Round(damage))), game.DamagePhaser, p.X, p.Y)
 
 		if target.Damage >= game.ShipData[target.Ship].MaxDamage {
 			c.server.killPlayer(target, p.ID, game.KillPhaser, actualDamage)
@@ -272,8 +284,8 @@ func (c *Client) handlePlasma(data json.RawMessage) {
 		return
 	}
 
-	// Can't fire while cloaked or repairing
-	if p.Cloaked || p.Repairing {
+	// Can't fire while cloaked, repairing or with weapons held
+	if c.server.cloakBarsFire(p) || p.Repairing || c.server.weaponsHeld(p) {
 		return
 	}
 
@@ -283,8 +295,8 @@ func (c *Client) handlePlasma(data json.RawMessage) {
 		return // Ship can't fire plasma
 	}
 
-	// Check if can fire plasma (only 1 at a time)
-	if p.NumPlasma >= game.MaxPlasma {
+	// Check the ship's limit on plasmas in flight
+	if p.NumPlasma >= shipStats.MaxPlasma {
 		return // Already have plasma out
 	}
 
@@ -300,22 +312,24 @@ func (c *Client) handlePlasma(data json.RawMessage) {
 	}
 
 	// Fire plasma torpedo
+	speed, fuse := c.server.plasmaFlight(p.Ship)
 	plasma := &game.Plasma{
 		ID:     c.server.nextPlasmaID,
 		Owner:  p.ID,
 		X:      p.X,
 		Y:      p.Y,
 		Dir:    plasmaData.Dir,
-		Speed:  float64(shipStats.PlasmaSpeed * 20), // Warp speed: 20 units per tick at 10 ticks/sec
+		Speed:  speed, // Warp speed: 20 units per tick at 10 ticks/sec, times the plasma speed scale
 		Damage: shipStats.PlasmaDamage,
-		Fuse:   shipStats.PlasmaFuse, // Use original fuse value directly (already scaled for our 10 FPS)
-		Status: game.TorpMove,        // Moving
-		Team:   p.Team,
+		Fuse:   fuse,          // Original fuse (already scaled for our 10 FPS) times the plasma range scale
+		Status: game.TorpMove, // Moving
+		Team:   c.server.projectileTeam(p),
 	}
 
 	c.server.gameState.Plasmas = append(c.server.gameState.Plasmas, plasma)
 	c.server.nextPlasmaID++
 	p.NumPlasma++
+	c.server.breakCloakToFire(p)
 	p.Fuel -= plasmaCost
 	p.WTemp += 100 // Plasma heats weapons more
 }
@@ -334,52 +348,22 @@ func (c *Client) handleDetonate(data json.RawMessage) {
 		return
 	}
 
-	// Can't detonate while cloaked
-	if p.Cloaked {
+	// Can't detonate while cloaked or with weapons held
+	if c.server.cloakBarsFire(p) || c.server.weaponsHeld(p) {
 		return
 	}
 
-	// Get ship stats for detonate cost
-	shipStats := game.ShipData[p.Ship]
-
-	// Find and detonate enemy torpedoes near this player
-	detonatedCount := 0
-	for _, torp := range c.server.gameState.Torps {
-		if torp.Status != game.TorpMove || torp.Owner == p.ID {
-			continue
-		}
-		// Only detonate enemy torpedoes using torp.Team directly
-		if torp.Team == p.Team {
-			continue
-		}
-		// Check if torpedo is within detonate range
-		dist := game.Distance(p.X, p.Y, torp.X, torp.Y)
-		if dist > float64(game.PhaserDist) {
-			continue
-		}
-		{
-			// Check if we have enough fuel
-			if p.Fuel < shipStats.DetCost {
-				// Not enough fuel to detonate (non-blocking)
-				c.server.tryBroadcast(ServerMessage{
-					Type: "message",
-					Data: map[string]interface{}{
-						"text": "Not enough fuel to detonate",
-						"type": "error",
-						"to":   p.ID, // Send only to this player
-					},
-				})
-				break
-			}
-			// Mark the torpedo as detonating so updateTorpedoes removes it in
-			// place next frame. Setting Fuse=1 instead would let the torp move
-			// a full step and run its collision check one more tick, so a
-			// "neutralized" torp could still strike a target before vanishing.
-			torp.Status = game.TorpDet
-			detonatedCount++
-			// Deduct fuel cost
-			p.Fuel -= shipStats.DetCost
-		}
+	detonatedCount, outOfFuel := c.server.detonateEnemyTorps(p)
+	if outOfFuel {
+		// Not enough fuel to detonate (non-blocking)
+		c.server.tryBroadcast(ServerMessage{
+			Type: "message",
+			Data: map[string]interface{}{
+				"text": "Not enough fuel to detonate",
+				"type": "error",
+				"to":   p.ID, // Send only to this player
+			},
+		})
 	}
 
 	// Send feedback message (non-blocking)
@@ -394,6 +378,39 @@ func (c *Client) handleDetonate(data json.RawMessage) {
 	}
 }
 
+// detonateEnemyTorps detonates the enemy torpedoes within phaser distance of
+// p, paying the ship's DetCost in fuel for each. outOfFuel reports that fuel
+// ran out before every torpedo in range was detonated. Caller must hold
+// gameState.Mu and have checked that p may fire.
+func (s *Server) detonateEnemyTorps(p *game.Player) (detonated int, outOfFuel bool) {
+	shipStats := game.ShipData[p.Ship]
+	for _, torp := range s.gameState.Torps {
+		if torp.Status != game.TorpMove || torp.Owner == p.ID {
+			continue
+		}
+		// Only detonate enemy torpedoes using torp.Team directly
+		if torp.Team == p.Team {
+			continue
+		}
+		// Check if torpedo is within detonate range
+		if s.distance(p.X, p.Y, torp.X, torp.Y) > float64(game.PhaserDist) {
+			continue
+		}
+		if p.Fuel < shipStats.DetCost {
+			return detonated, true
+		}
+		// Mark the torpedo as detonating so updateTorpedoes removes it in
+		// place next frame. Setting Fuse=1 instead would let the torp move
+		// a full step and run its collision check one more tick, so a
+		// "neutralized" torp could still strike a target before vanishing.
+		torp.Status = game.TorpDet
+		detonated++
+		s.breakCloakToFire(p)
+		p.Fuel -= shipStats.DetCost
+	}
+	return detonated, false
+}
+
 // handleShields toggles shields
 func (c *Client) handleShields(data json.RawMessage) {
 	if !c.validPlayerID() {
@@ -413,13 +430,27 @@ func (c *Client) handleShields(data json.RawMessage) {
 
 	// Raising shields cancels repair mode and repair request
 	if p.Shields_up {
-		if p.RepairRequest {
-			p.RepairRequest = false
-		}
-		if p.Repairing {
-			p.Repairing = false
-		}
+		cancelRepair(p)
+	}
+}
+
+// handleAssist toggles dogfight assist, in which the server raises and lowers
+// the player's shields from the same threat assessment bots use. Movement and
+// weapons stay under the player's control.
+func (c *Client) handleAssist(data json.RawMessage) {
+	if !c.validPlayerID() {
+		return
 	}
+
+	c.server.gameState.Mu.Lock()
+	defer c.server.gameState.Mu.Unlock()
+
+	p := c.getAlivePlayer()
+	if p == nil {
+		return
+	}
+
+	p.Assist = !p.Assist
 }
 
 // handleTractor handles tractor beam engagement
@@ -479,7 +510,7 @@ func (c *Client) handleBeamEngage(data json.RawMessage, pressor bool) {
 			target := c.server.gameState.Players[beamData.TargetID]
 			if target != nil && target.Status == game.StatusAlive && target.ID != p.ID {
 				// Check range (using ship-specific range)
-				dist := game.Distance(p.X, p.Y, target.X, target.Y)
+				dist := c.server.distance(p.X, p.Y, target.X, target.Y)
 				tractorRange := float64(game.TractorDist) * game.ShipData[p.Ship].TractorRange
 				if dist <= tractorRange {
 					*beam = beamData.TargetID
@@ -496,6 +527,7 @@ func (c *Client) handleCloak(data json.RawMessage) {
 	}
 
 	var message string
+	recloakBlocked := false
 
 	// Lock scope: toggle cloak and build message
 	func() {
@@ -507,6 +539,12 @@ func (c *Client) handleCloak(data json.RawMessage) {
 			return
 		}
 
+		// A ship that just fired its way out of cloak has to wait
+		if !p.Cloaked && c.server.recloakBlocked(p) {
+			recloakBlocked = true
+			return
+		}
+
 		// Toggle cloak
 		p.Cloaked = !p.Cloaked
 
@@ -518,8 +556,54 @@ func (c *Client) handleCloak(data json.RawMessage) {
 		}
 	}()
 
+	if recloakBlocked {
+		c.sendMsg(ServerMessage{
+			Type: MsgTypeMessage,
+			Data: map[string]interface{}{
+				"text": "Cloaking device is still recovering from firing",
+				"type": "warning",
+			},
+		})
+	}
+
 	// Send cloak status message to all clients (after releasing lock to avoid deadlock)
 	if message != "" {
 		c.server.broadcastInfo(message)
 	}
 }
+
+// handleECM toggles electronic countermeasures. While jamming, enemies can't
+// lock on and existing locks drop, at a steady fuel cost.
+func (c *Client) handleECM(data json.RawMessage) {
+	if !c.validPlayerID() {
+		return
+	}
+
+	var message string
+
+	// Lock scope: toggle ECM and build message
+	func() {
+		c.server.gameState.Mu.Lock()
+		defer c.server.gameState.Mu.Unlock()
+
+		p := c.getAlivePlayer()
+		if p == nil {
+			return
+		}
+
+		if !p.ECMActive && p.Fuel < game.ECMFuelCost {
+			return
+		}
+		p.ECMActive = !p.ECMActive
+
+		if p.ECMActive {
+			message = fmt.Sprintf("%s is jamming", formatPlayerName(p))
+		} else {
+			message = fmt.Sprintf("%s stopped jamming", formatPlayerName(p))
+		}
+	}()
+
+	if message != "" {
+		c.server.broadcastInfo(message)
+	}
+}
@@ -171,3 +171,61 @@ func (c *Client) handlePrivateMessage(data json.RawMessage) {
 		}
 	}
 }
+
+// handleObserverMessage handles observer-only chat: commentary from clients
+// watching the match (no player of their own, or out of lives) goes to the
+// other observers and never reaches the players still in the match.
+func (c *Client) handleObserverMessage(data json.RawMessage) {
+	var msgData MessageData
+	if err := json.Unmarshal(data, &msgData); err != nil {
+		return
+	}
+
+	// Sanitize the message text to prevent XSS
+	msgData.Text = sanitizeText(msgData.Text)
+
+	// Lock ordering: s.mu first, then gameState.Mu
+	c.server.mu.RLock()
+	defer c.server.mu.RUnlock()
+	c.server.gameState.Mu.RLock()
+	if !c.observing() {
+		c.server.gameState.Mu.RUnlock()
+		c.sendMsg(ServerMessage{
+			Type: MsgTypeMessage,
+			Data: map[string]interface{}{
+				"text": "Observer chat is only open to observers",
+				"type": "warning",
+			},
+		})
+		return
+	}
+	playerID := -1
+	senderName := fmt.Sprintf("Observer %d", c.ID)
+	if p := c.getPlayer(); p != nil && p.Status == game.StatusObserve {
+		playerID = p.ID
+		senderName = formatPlayerName(p)
+	}
+	var observers []*Client
+	for _, client := range c.server.clients {
+		if client.observing() {
+			observers = append(observers, client)
+		}
+	}
+	c.server.gameState.Mu.RUnlock()
+
+	obsMsg := ServerMessage{
+		Type: MsgTypeMessage,
+		Data: map[string]interface{}{
+			"text": fmt.Sprintf("[OBS] %s: %s", senderName, msgData.Text),
+			"type": "observer",
+			"from": playerID,
+		},
+	}
+	for _, client := range observers {
+		select {
+		case client.send <- obsMsg:
+		default:
+			// Client's send channel is full, skip
+		}
+	}
+}
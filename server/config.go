@@ -0,0 +1,248 @@
+package server
+
+import "github.com/lab1702/netrek-web/game"
+
+// Config holds operator-tunable gameplay settings. DefaultConfig reproduces
+// the classic behavior; main.go exposes individual fields as command-line
+// flags.
+type Config struct {
+	// Starbase bot engagement ranges
+	StarbaseEnemyDetectRange float64 // Distance at which a starbase bot switches to combat
+	StarbaseTorpRange        float64 // Maximum torpedo firing range for starbase bots
+	StarbasePhaserRange      float64 // Maximum phaser firing range for starbase bots
+	StarbasePlasmaMaxRange   float64 // Maximum plasma firing range for starbase bots
+
+	// Bot navigation
+	BotSeparation          bool    // Blend ally separation into bot steering (disable to observe raw decisions)
+	BotSepMinSafeDistance  float64 // Allies closer than this push a bot away
+	BotSepIdealDistance    float64 // Spacing bots try to keep from each other
+	BotSepCriticalDistance float64 // Allies closer than this trigger emergency separation
+
+	// Bot caution
+	BotCaution string // Default fuel/damage caution profile for bots (CautionConservative, CautionBalanced or CautionAggressive)
+
+	// Bot names
+	BotNames []string // Names bots are given, each used once before numbered repeats (nil uses BotNames)
+
+	// Custom map
+	Map *game.MapConfig // Explicit planet flag assignments (nil uses the random INL layout)
+
+	// Timed game events
+	EventInterval int // Seconds between random game events (0 disables events)
+	EventDuration int // Seconds each game event lasts
+
+	// Team balance
+	BackfillOnDisconnect    bool // Replace a departing human with a bot when their team falls behind
+	BotTakeoverOnDisconnect bool // Hand a departing human's ship to a bot instead of freeing the slot
+	AutoBalance             bool // Periodically add or remove bots so every team matches the largest human team
+	AutoBalanceThreshold    int  // Smallest gap (in ships) from the target team size that auto-balance acts on
+	AutoBalanceRemoveDelay  int  // Seconds a team must stay over strength before its surplus bots are removed
+
+	// Handicapping
+	HandicapGap    int                  // Human player gap to the largest team at which a smaller team is handicapped (0 = off)
+	HandicapRepair float64              // Extra repair speed for a handicapped team per human it is short (0.1 = 10% each)
+	TeamHandicaps  map[int]TeamHandicap // Fixed per-team multipliers by team flag (teams not listed are unchanged)
+
+	// Survival mode
+	Survival             bool // Co-op: humans defend a starbase against growing waves of attacking bots
+	SurvivalWaveInterval int  // Seconds between survival attack waves
+
+	// Match start
+	ReadyCheck  bool    // Hold tournament start until enough connected humans type /ready
+	ReadyQuorum float64 // Fraction of connected humans that must be ready to start the match (0 < q <= 1)
+
+	// Networking
+	FPS                int  // Game loop ticks (and state updates) per second, a multiple of game.FPS
+	WSCompression      bool // Negotiate per-message deflate unless the client opts out
+	MaxConnections     int  // Concurrent WebSocket connections accepted, including queued and watching clients
+	MinProtocolVersion int  // Oldest client protocol version allowed to log in
+
+	// Development
+	HotSeat bool // Let one connection log in and drive several player slots, picked by each message's slot index
+
+	// Administration
+	AdminToken string // Token required by admin-only HTTP endpoints (empty disables them)
+	WebhookURL string // URL that receives a JSON POST when a match ends (empty disables it)
+
+	// Weapon balance
+	DamageScale      float64 // Multiplier on all weapon, explosion and planet damage (MinDamageScale..MaxDamageScale)
+	PlasmaSpeedScale float64 // Multiplier on every ship's plasma speed (range grows with it)
+	PlasmaRangeScale float64 // Multiplier on every ship's plasma fuse, and so its range at a given speed
+	FireArc          float64 // Degrees of arc, centered on the bow, into which torpedoes and phasers may be fired (0 = any direction)
+
+	// Scoring
+	Ratings     bool   // Track Elo-style player ratings updated at each kill
+	RatingsFile string // JSON file ratings are loaded from at startup and saved to as they change ("" keeps them in memory only)
+
+	// Elimination
+	Lives int // Deaths a player may take per match before sitting out as an observer (0 = unlimited)
+
+	// Spawn protection
+	SpawnProtectRadius  float64 // Radius of the zone around each home world where respawned ships are protected (0 disables)
+	SpawnProtectSeconds int     // Seconds a respawned ship stays protected while inside its spawn zone
+
+	// Ship handling
+	WarpUp           bool    // Heavy ships accelerate slowly until they clear low warp
+	TurnRateScale    float64 // Multiplier on every ship's turn rate
+	DamageDecelScale float64 // Multiplier on deceleration while damage holds a ship above its reduced top speed
+	ShipSpecials     bool    // Enable per-ship special abilities such as the battleship shield overcharge
+
+	// Ship refits
+	RefitMode string // RefitPerLife, RefitFree or RefitRotation
+
+	// Spawn loadout
+	StartFuel   float64 // Fraction of max fuel a freshly spawned ship carries (below 1 for a resource-scarce game)
+	StartDamage float64 // Fraction of max damage a freshly spawned ship carries, as a refit penalty (0 starts fully repaired)
+
+	// Galaxy edges
+	GalaxyEdge string // EdgeBounce, EdgeWrap or EdgeKill
+
+	// Free-for-all
+	FreeForAll bool // Torpedoes and plasmas are neutral and can hit anyone but their owner
+
+	// Performance
+	MaxGalaxyTorps int // Live torpedoes allowed in the galaxy at once; fire beyond it is refused (0 = unlimited)
+
+	// Planet armies
+	ArmyMultiplier int  // Multiplier on starting planet armies and army growth (2 for a fast double-armies game)
+	NeutralStart   bool // Only the home worlds start team-owned; every other planet begins neutral
+
+	// Planet capture
+	ConquestFraction   float64 // Fraction of all planets a team must own for a conquest victory (1 = every planet)
+	ShieldsDownToOrbit bool    // Orbiting, bombing and beaming need shields down; raising them breaks orbit
+	CaptureNeedsArmies bool    // Armies beamed onto an independent planet fight its armies first, so taking it needs more armies than it holds
+
+	// Army transfer rates
+	BombFrames         int // Frames between bombing runs while orbiting an enemy planet
+	BeamFrames         int // Frames between single-army beam transfers
+	StarbaseBombFrames int // BombFrames for starbases, which bomb slowly
+	StarbaseBeamFrames int // BeamFrames for starbases, which move their large army loads quickly
+	BeamArmies         int // Most armies moved by a single beam transfer
+
+	// Planet defenses
+	PlanetFireRange float64 // Distance at which hostile planets fire on ships
+	PlanetFireScale float64 // Multiplier on planet fire damage (armies/10 + 2 per volley)
+
+	// Repair havens
+	RepairSafeRadius    float64 // Weapons can't be fired within this distance of a repair planet (0 disables)
+	RepairSafeOwnerOnly bool    // Only enemies of a repair planet's owner are barred from firing near it
+
+	// Repair
+	RepairRateScale float64 // Multiplier on repair speed (shorter intervals between repair steps)
+	RepairFuelCost  int     // Fuel burned per tick while repairing
+	OrbitRepair     bool    // Players start with orbit auto-repair on (they can still toggle it)
+
+	// Shields
+	ShieldAbsorbAll  float64               // Fraction of every weapon's damage raised shields absorb (1 = classic full absorption)
+	ShieldAbsorb     game.ShieldAbsorption // Per-weapon fractions overriding ShieldAbsorbAll; a negative entry uses ShieldAbsorbAll
+	ShieldRearFactor float64               // Share of that absorption left against weapon hits from dead astern, rising to full on the bow (1 = omnidirectional)
+
+	// Ship explosions
+	ExplosionFullDist float64 // Ships this close to an exploding ship take its full explosion damage
+	ExplosionMaxDist  float64 // Explosion damage falls off linearly to nothing at this distance
+
+	// Cloaking
+	DecloakOnFire    bool    // Firing out of cloak drops the cloak instead of being refused, and blocks recloaking for game.RecloakDelayFrames
+	CloakCostScale   float64 // Multiplier on every ship's per-tick cloak fuel cost
+	CloakDetectRange float64 // Range within which bots can detect and engage cloaked ships
+}
+
+// Refit rules selected by Config.RefitMode.
+const (
+	RefitPerLife  = "per-life" // /refit queues a ship for the next respawn
+	RefitFree     = "free"     // /refit also swaps ships at once while orbiting a friendly repair planet
+	RefitRotation = "rotation" // /refit is disabled; each respawn brings the next ship in the cycle
+)
+
+// Galaxy edge behavior selected by Config.GalaxyEdge.
+const (
+	EdgeBounce = "bounce" // Ships bounce off the edge; projectiles leaving the galaxy expire
+	EdgeWrap   = "wrap"   // Ships and projectiles leaving one edge reappear at the opposite one; ranges reach across the edge
+	EdgeKill   = "kill"   // Ships that reach the edge are destroyed; projectiles leaving the galaxy expire
+)
+
+// Allowed range for Config.DamageScale.
+const (
+	MinDamageScale = 0.5
+	MaxDamageScale = 2.0
+)
+
+// DefaultConfig returns the configuration matching the original game constants.
+func DefaultConfig() Config {
+	return Config{
+		StarbaseEnemyDetectRange: game.StarbaseEnemyDetectRange,
+		StarbaseTorpRange:        game.StarbaseTorpRange,
+		StarbasePhaserRange:      game.PhaserRange(game.ShipData[game.ShipStarbase]),
+		StarbasePlasmaMaxRange:   game.StarbasePlasmaMaxRange,
+		BotSeparation:            true,
+		BotSepMinSafeDistance:    SepMinSafeDistance,
+		BotSepIdealDistance:      SepIdealDistance,
+		BotSepCriticalDistance:   SepCriticalDistance,
+		BotCaution:               CautionBalanced,
+		EventDuration:            60,
+		AutoBalanceThreshold:     1,
+		AutoBalanceRemoveDelay:   30,
+		HandicapRepair:           0.1,
+		SurvivalWaveInterval:     60,
+		ReadyQuorum:              1.0,
+		WSCompression:            true,
+		MaxConnections:           maxConnections,
+		FPS:                      game.FPS,
+		MinProtocolVersion:       1,
+		DamageScale:              1.0,
+		PlasmaSpeedScale:         1.0,
+		PlasmaRangeScale:         1.0,
+		TurnRateScale:            1.0,
+		DamageDecelScale:         1.0,
+		ArmyMultiplier:           1,
+		ConquestFraction:         1.0,
+		BombFrames:               5,
+		BeamFrames:               5,
+		StarbaseBombFrames:       10,
+		StarbaseBeamFrames:       3,
+		BeamArmies:               1,
+		PlanetFireRange:          game.PlanetFireDist,
+		PlanetFireScale:          1.0,
+		RepairRateScale:          1.0,
+		RepairFuelCost:           1,
+		ShieldAbsorbAll:          1.0,
+		ShieldAbsorb:             game.ShieldAbsorption{Torp: -1, Phaser: -1, Plasma: -1},
+		ShieldRearFactor:         1.0,
+		ExplosionFullDist:        game.ShipExplosionDist,
+		ExplosionMaxDist:         game.ShipExplosionMaxDist,
+		CloakCostScale:           1.0,
+		CloakDetectRange:         TargetCloakDetectRange,
+		RefitMode:                RefitPerLife,
+		GalaxyEdge:               EdgeBounce,
+		StartFuel:                1.0,
+		SpawnProtectSeconds:      5,
+	}
+}
+
+// defaultConfig backs servers that were constructed without a configuration
+// (tests build Server literals directly). It must be treated as read-only.
+var defaultConfig = DefaultConfig()
+
+// config returns the active configuration for this server.
+func (s *Server) config() *Config {
+	if s.cfg != nil {
+		return s.cfg
+	}
+	return &defaultConfig
+}
+
+// ShieldAbsorption returns the share of each weapon's damage raised shields
+// absorb: the weapon's own ShieldAbsorb entry when set, else ShieldAbsorbAll.
+func (c *Config) ShieldAbsorption() game.ShieldAbsorption {
+	pick := func(f float64) float64 {
+		if f < 0 {
+			return c.ShieldAbsorbAll
+		}
+		return f
+	}
+	return game.ShieldAbsorption{
+		Torp:   pick(c.ShieldAbsorb.Torp),
+		Phaser: pick(c.ShieldAbsorb.Phaser),
+		Plasma: pick(c.ShieldAbsorb.Plasma),
+	}
+}
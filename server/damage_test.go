@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/lab1702/netrek-web/game"
@@ -88,7 +89,7 @@ func TestApplyDamageWithShields(t *testing.T) {
 				Shields_up: tt.shieldsUp,
 			}
 
-			dealt := game.ApplyDamageWithShields(player, tt.damageAmount)
+			dealt := game.ApplyDamageWithShields(player, tt.damageAmount, game.DamageTorp, game.FullShieldAbsorption)
 
 			if player.Shields != tt.expectedShields {
 				t.Errorf("Expected shields %d, got %d", tt.expectedShields, player.Shields)
@@ -103,6 +104,115 @@ func TestApplyDamageWithShields(t *testing.T) {
 	}
 }
 
+// TestShieldAbsorptionByDamageType verifies that with shields weakened
+// against plasma, a shielded plasma hit bleeds more into the hull than a
+// phaser hit of the same size.
+func TestShieldAbsorptionByDamageType(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ShieldAbsorb.Plasma = 0.5
+	server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 10), cfg: &cfg}
+
+	hit := func(kind game.DamageType) *game.Player {
+		p := &game.Player{Ship: game.ShipCruiser, Shields: 100, Shields_up: true}
+		server.applyDamage(p, 60, kind)
+		return p
+	}
+
+	phasered := hit(game.DamagePhaser)
+	if phasered.Shields != 40 || phasered.Damage != 0 {
+		t.Errorf("phaser hit left shields %d, hull %d; want 40 and 0", phasered.Shields, phasered.Damage)
+	}
+	plasmaed := hit(game.DamagePlasma)
+	if plasmaed.Shields != 70 || plasmaed.Damage != 30 {
+		t.Errorf("plasma hit left shields %d, hull %d; want 70 and 30", plasmaed.Shields, plasmaed.Damage)
+	}
+	if plasmaed.Damage <= phasered.Damage {
+		t.Errorf("plasma hull damage %d should exceed phaser hull damage %d", plasmaed.Damage, phasered.Damage)
+	}
+}
+
+// TestShieldAbsorbAllFillsUnsetWeapons verifies that the all-weapon shield
+// absorption applies to every weapon without its own setting, and that a
+// per-weapon setting overrides it.
+func TestShieldAbsorbAllFillsUnsetWeapons(t *testing.T) {
+	cfg := DefaultConfig()
+	if got := cfg.ShieldAbsorption(); got != game.FullShieldAbsorption {
+		t.Errorf("default absorption = %+v, want %+v", got, game.FullShieldAbsorption)
+	}
+
+	cfg.ShieldAbsorbAll = 0.75
+	cfg.ShieldAbsorb.Plasma = 0.5
+	want := game.ShieldAbsorption{Torp: 0.75, Phaser: 0.75, Plasma: 0.5}
+	if got := cfg.ShieldAbsorption(); got != want {
+		t.Errorf("absorption = %+v, want %+v", got, want)
+	}
+}
+
+// TestPercentageShieldsBleedThrough verifies that identical hits do more
+// hull damage under percentage shields than under the classic full-absorb
+// model, while leaving shields stronger, and that damage past the shields'
+// capacity reaches the hull under both.
+func TestPercentageShieldsBleedThrough(t *testing.T) {
+	hullDamage := func(absorb game.ShieldAbsorption, kind game.DamageType, damage int) (hull, shields int) {
+		cfg := DefaultConfig()
+		cfg.ShieldAbsorb = absorb
+		server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 10), cfg: &cfg}
+		p := &game.Player{Ship: game.ShipCruiser, Shields: 100, Shields_up: true}
+		server.applyDamage(p, damage, kind)
+		return p.Damage, p.Shields
+	}
+
+	percentage := game.ShieldAbsorption{Torp: 0.6, Phaser: 0.6, Plasma: 0.6}
+	for _, kind := range []game.DamageType{game.DamageTorp, game.DamagePhaser, game.DamagePlasma} {
+		fullHull, fullShields := hullDamage(game.FullShieldAbsorption, kind, 50)
+		pctHull, pctShields := hullDamage(percentage, kind, 50)
+		if fullHull != 0 || fullShields != 50 {
+			t.Errorf("kind %d full absorb: hull %d, shields %d; want 0 and 50", kind, fullHull, fullShields)
+		}
+		if pctHull != 20 || pctShields != 70 {
+			t.Errorf("kind %d percentage: hull %d, shields %d; want 20 and 70", kind, pctHull, pctShields)
+		}
+
+		// A hit larger than the shields overflows into the hull either way
+		fullHull, _ = hullDamage(game.FullShieldAbsorption, kind, 200)
+		pctHull, _ = hullDamage(percentage, kind, 200)
+		if fullHull != 100 || pctHull != 100 {
+			t.Errorf("kind %d overflow: full-absorb hull %d, percentage hull %d; want 100 and 100", kind, fullHull, pctHull)
+		}
+	}
+}
+
+// TestDirectionalShieldsWeakerAstern verifies that with directional shields a
+// torpedo from directly behind puts more damage on the hull than the same
+// torpedo on the bow.
+func TestDirectionalShieldsWeakerAstern(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ShieldRearFactor = 0.25
+	server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 10), cfg: &cfg}
+
+	hit := func(torpDir float64) *game.Player {
+		p := &game.Player{ID: 0, Ship: game.ShipCruiser, Shields: 100, Shields_up: true, X: 50000, Y: 50000, Dir: 0}
+		torp := &game.Torpedo{Owner: 1, X: p.X, Y: p.Y, Dir: torpDir, Speed: 240, Damage: 40}
+		server.handleProjectileHit(torp, p, game.KillTorp)
+		return p
+	}
+
+	bow := hit(math.Pi) // Flying west into the ship's nose
+	stern := hit(0)     // Flying east up its tail
+	if bow.Damage != 0 || bow.Shields != 60 {
+		t.Errorf("bow hit left shields %d, hull %d; want 60 and 0", bow.Shields, bow.Damage)
+	}
+	if stern.Damage != 30 || stern.Shields != 90 {
+		t.Errorf("stern hit left shields %d, hull %d; want 90 and 30", stern.Shields, stern.Damage)
+	}
+
+	// Omnidirectional shields (the default) treat both the same
+	cfg.ShieldRearFactor = 1
+	if bow, stern := hit(math.Pi), hit(0); bow.Damage != stern.Damage {
+		t.Errorf("omnidirectional shields: bow hull %d, stern hull %d; want equal", bow.Damage, stern.Damage)
+	}
+}
+
 func TestTorpedoShieldHandling(t *testing.T) {
 	server := &Server{
 		gameState: game.NewGameState(),
@@ -203,6 +313,79 @@ func TestShipExplosionShieldHandling(t *testing.T) {
 	}
 }
 
+func TestShipExplosionUsesShipStatsOverride(t *testing.T) {
+	// Tune the cruiser's explosion yield for this test only
+	original := game.ShipData[game.ShipCruiser]
+	defer func() { game.ShipData[game.ShipCruiser] = original }()
+	tuned := original
+	tuned.ExplosionDamage = 40
+	game.ShipData[game.ShipCruiser] = tuned
+
+	server := &Server{
+		gameState: game.NewGameState(),
+		broadcast: make(chan ServerMessage, 10),
+	}
+
+	explodingShip := server.gameState.Players[0]
+	explodingShip.Status = game.StatusExplode
+	explodingShip.ExplodeTimer = game.ExplodeTimerFrames
+	explodingShip.Ship = game.ShipCruiser
+	explodingShip.X = 50000
+	explodingShip.Y = 50000
+	explodingShip.WhyDead = game.KillTorp
+
+	target := server.gameState.Players[1]
+	target.Status = game.StatusAlive
+	target.Ship = game.ShipBattleship
+	target.X = 50000
+	target.Y = 50000
+	target.Shields_up = false
+
+	server.updateGame()
+
+	if target.Damage != 40 {
+		t.Errorf("Expected hull damage 40 from tuned cruiser explosion, got %d", target.Damage)
+	}
+}
+
+// TestExplosionRadiusConfig verifies widening the explosion radius reaches a
+// ship that survives untouched at the default radius.
+func TestExplosionRadiusConfig(t *testing.T) {
+	for _, maxDist := range []float64{game.ShipExplosionMaxDist, 5000} {
+		cfg := DefaultConfig()
+		cfg.ExplosionMaxDist = maxDist
+		server := &Server{
+			gameState: game.NewGameState(),
+			broadcast: make(chan ServerMessage, 10),
+			cfg:       &cfg,
+		}
+
+		explodingShip := server.gameState.Players[0]
+		explodingShip.Status = game.StatusExplode
+		explodingShip.ExplodeTimer = game.ExplodeTimerFrames
+		explodingShip.Ship = game.ShipCruiser
+		explodingShip.X = 50000
+		explodingShip.Y = 50000
+		explodingShip.WhyDead = game.KillTorp
+
+		target := server.gameState.Players[1]
+		target.Status = game.StatusAlive
+		target.Ship = game.ShipCruiser
+		target.X = 53500
+		target.Y = 50000
+		target.Shields_up = false
+
+		server.updateGame()
+
+		if maxDist == game.ShipExplosionMaxDist && target.Damage != 0 {
+			t.Errorf("default radius: ship 3500 away took %d damage, want none", target.Damage)
+		}
+		if maxDist > game.ShipExplosionMaxDist && target.Damage == 0 {
+			t.Errorf("radius %.0f: ship 3500 away took no damage", maxDist)
+		}
+	}
+}
+
 func TestPhaserShieldHandling(t *testing.T) {
 	// Test that our refactored phaser still works correctly
 	server := &Server{
@@ -269,3 +452,231 @@ func TestPhaserShieldHandling(t *testing.T) {
 		}
 	}
 }
+
+func TestDamageScaleHalvesPhaserDamage(t *testing.T) {
+	firePhaser := func(scale float64) int {
+		cfg := DefaultConfig()
+		cfg.DamageScale = scale
+		server := &Server{
+			gameState: game.NewGameState(),
+			broadcast: make(chan ServerMessage, 10),
+			cfg:       &cfg,
+		}
+		server.clients = make(map[int]*Client)
+
+		shooter := server.gameState.Players[0]
+		shooter.Status = game.StatusAlive
+		shooter.Ship = game.ShipCruiser
+		shooter.Team = game.TeamFed
+		shooter.Fuel = 10000
+
+		// Battleship with shields down so the whole hit lands on the hull
+		target := server.gameState.Players[1]
+		target.Status = game.StatusAlive
+		target.Ship = game.ShipBattleship
+		target.Team = game.TeamKli
+		target.X = 500
+
+		client := &Client{
+			server: server,
+			send:   make(chan ServerMessage, 10),
+		}
+		client.SetPlayerID(0)
+		client.handlePhaser(json.RawMessage(`{"target":1}`))
+		return target.Damage
+	}
+
+	full := firePhaser(1.0)
+	half := firePhaser(0.5)
+	if full == 0 {
+		t.Fatal("phaser did not hit at scale 1.0")
+	}
+	if want := int(math.Round(float64(full) * 0.5)); half != want {
+		t.Errorf("damage at scale 0.5 = %d, want %d (half of %d)", half, want, full)
+	}
+}
+
+func TestSpawnProtectionOnlyCoversFreshSpawns(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SpawnProtectRadius = 8000
+	server := &Server{
+		gameState: game.NewGameState(),
+		broadcast: make(chan ServerMessage, 10),
+		cfg:       &cfg,
+	}
+	server.gameState.Frame = 100
+
+	// Freshly respawned ship
+	fresh := server.gameState.Players[0]
+	fresh.Team = game.TeamFed
+	fresh.Ship = game.ShipCruiser
+	fresh.Status = game.StatusDead
+	server.respawnPlayer(fresh)
+
+	// Long-alive ally parked at the same spot
+	veteran := server.gameState.Players[1]
+	veteran.Status = game.StatusAlive
+	veteran.Team = game.TeamFed
+	veteran.Ship = game.ShipCruiser
+	veteran.X, veteran.Y = fresh.X, fresh.Y
+
+	if applied := server.applyDamage(fresh, 30, game.DamageTorp); applied != 0 || fresh.Damage != 0 {
+		t.Errorf("Fresh spawn took %d damage (hull %d), want none", applied, fresh.Damage)
+	}
+	if applied := server.applyDamage(veteran, 30, game.DamageTorp); applied != 30 {
+		t.Errorf("Established ship took %d damage, want 30", applied)
+	}
+
+	// Protection lapses once the timer runs out
+	server.gameState.Frame += int64(cfg.SpawnProtectSeconds * game.FPS)
+	if applied := server.applyDamage(fresh, 30, game.DamageTorp); applied != 30 {
+		t.Errorf("Spawn protection should have expired, took %d damage", applied)
+	}
+}
+
+// TestSpawnProtectionEndsOnLeavingZone verifies that checking protection
+// changes nothing, and that the per-tick update ends it for good once the
+// ship leaves its spawn zone, even if it flies back in.
+func TestSpawnProtectionEndsOnLeavingZone(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SpawnProtectRadius = 8000
+	server := &Server{
+		gameState: game.NewGameState(),
+		broadcast: make(chan ServerMessage, 10),
+		cfg:       &cfg,
+	}
+	server.gameState.Frame = 100
+
+	p := server.gameState.Players[0]
+	p.Team = game.TeamFed
+	p.Ship = game.ShipCruiser
+	p.Status = game.StatusDead
+	server.respawnPlayer(p)
+	homeX, homeY := server.teamHome(p.Team)
+	until := p.SpawnProtectUntil
+
+	p.X = homeX + cfg.SpawnProtectRadius + 1000
+	if server.spawnProtected(p) {
+		t.Error("ship outside its spawn zone is still protected")
+	}
+	if p.SpawnProtectUntil != until {
+		t.Errorf("spawnProtected changed SpawnProtectUntil to %d, want %d", p.SpawnProtectUntil, until)
+	}
+
+	server.updateSpawnProtection(p)
+	p.X, p.Y = homeX, homeY
+	if server.spawnProtected(p) {
+		t.Error("ship regained spawn protection after leaving and returning to its zone")
+	}
+}
+
+// TestPhaserRangeMultDecouplesRangeFromDamage verifies PhaserRangeMult
+// stretches phaser reach without changing damage falloff.
+func TestPhaserRangeMultDecouplesRangeFromDamage(t *testing.T) {
+	firePhaser := func(rangeMult, dist float64) int {
+		original := game.ShipData[game.ShipCruiser]
+		defer func() { game.ShipData[game.ShipCruiser] = original }()
+		tuned := original
+		tuned.PhaserRangeMult = rangeMult
+		game.ShipData[game.ShipCruiser] = tuned
+
+		server := &Server{
+			gameState: game.NewGameState(),
+			broadcast: make(chan ServerMessage, 10),
+		}
+		server.clients = make(map[int]*Client)
+
+		shooter := server.gameState.Players[0]
+		shooter.Status = game.StatusAlive
+		shooter.Ship = game.ShipCruiser
+		shooter.Team = game.TeamFed
+		shooter.Fuel = 10000
+
+		target := server.gameState.Players[1]
+		target.Status = game.StatusAlive
+		target.Ship = game.ShipBattleship
+		target.Team = game.TeamKli
+		target.X = dist
+
+		client := &Client{
+			server: server,
+			send:   make(chan ServerMessage, 10),
+		}
+		client.SetPlayerID(0)
+		client.handlePhaser(json.RawMessage(`{"target":1}`))
+		return target.Damage
+	}
+
+	// The cruiser's coupled range is PhaserDist * 100 / 100 = 6000
+	if got := firePhaser(0, 7000); got != 0 {
+		t.Errorf("default range hit a target at 7000 for %d damage", got)
+	}
+	if got := firePhaser(1.5, 7000); got == 0 {
+		t.Error("PhaserRangeMult 1.5 did not reach a target at 7000")
+	}
+
+	// Point blank the full PhaserDamage lands whatever the range
+	base, wide := firePhaser(0, 1), firePhaser(1.5, 1)
+	if want := game.ShipData[game.ShipCruiser].PhaserDamage; base != want || wide != want {
+		t.Errorf("point-blank damage = %d (default) and %d (1.5x range), want %d for both", base, wide, want)
+	}
+
+	// Mid-range damage falls off over the unscaled range, so a longer reach
+	// does not make phasers hit harder
+	for _, mult := range []float64{1, 1.5} {
+		if got, want := firePhaser(mult, 3000), firePhaser(0, 3000); got != want {
+			t.Errorf("PhaserRangeMult %.1f dealt %d damage at 3000, want %d", mult, got, want)
+		}
+	}
+}
+
+// TestTeamHandicapScalesDamageDealt verifies a team's damage handicap scales
+// the damage its ships deal, and that the admin endpoint's setting overrides
+// the configured one.
+func TestTeamHandicapScalesDamageDealt(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TeamHandicaps = map[int]TeamHandicap{
+		game.TeamRom: {Damage: 0.5, Fuel: 1, Repair: 1},
+		game.TeamKli: {Damage: 1.5, Fuel: 1, Repair: 1},
+	}
+	server := &Server{
+		gameState: game.NewGameState(),
+		broadcast: make(chan ServerMessage, 10),
+		cfg:       &cfg,
+	}
+
+	hit := func(team int) int {
+		shooter := server.gameState.Players[0]
+		shooter.Status = game.StatusAlive
+		shooter.Team = team
+
+		// Battleship with shields down so the whole hit lands on the hull
+		target := server.gameState.Players[1]
+		target.Status = game.StatusAlive
+		target.Ship = game.ShipBattleship
+		target.Team = game.TeamOri
+		target.Damage = 0
+
+		server.handleProjectileHit(&game.Torpedo{Owner: 0, Damage: 40}, target, game.KillTorp)
+		return target.Damage
+	}
+
+	for _, tc := range []struct {
+		team int
+		want int
+	}{
+		{game.TeamFed, 40},
+		{game.TeamRom, 20},
+		{game.TeamKli, 60},
+	} {
+		if got := hit(tc.team); got != tc.want {
+			t.Errorf("torpedo from team %d dealt %d, want %d", tc.team, got, tc.want)
+		}
+	}
+
+	// The admin endpoint's setting overrides the configured one
+	server.teamHandicaps = map[int]TeamHandicap{game.TeamRom: noTeamHandicap}
+	if got := hit(game.TeamRom); got != 40 {
+		t.Errorf("torpedo from cleared team dealt %d, want 40", got)
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import "github.com/lab1702/netrek-web/game"
+
+// The director camera points observers and stream overlays at the busiest
+// part of the galaxy. Each tick the galaxy is split into a grid and every
+// cell is scored for combat activity; the camera follows the top cell,
+// switching only when another cell is clearly busier so it doesn't flicker
+// between two fights of similar size.
+const (
+	directorCellSize = 10000 // Side of a director grid cell
+	directorGrid     = game.GalaxyWidth / directorCellSize
+
+	DirectorShipWeight   = 1.0  // Activity per living ship
+	DirectorTorpWeight   = 1.0  // Activity per torpedo in flight
+	DirectorPlasmaWeight = 3.0  // Activity per plasma in flight
+	DirectorKillWeight   = 10.0 // Activity per recent kill
+	DirectorKillFrames   = 50   // Frames a kill keeps drawing the camera (5 seconds at 10 FPS)
+	DirectorSwitchMargin = 1.5  // Factor by which a new cell must outscore the current one to take focus
+)
+
+// directorFocus is where the director camera is pointed.
+type directorFocus struct {
+	Active bool    `json:"active"` // False until anything has happened worth watching
+	Cell   int     `json:"cell"`   // Grid cell in focus, row*directorGrid + column
+	X      float64 `json:"x"`      // Activity-weighted center of the cell in focus
+	Y      float64 `json:"y"`
+}
+
+// directorCell returns the grid cell containing (x, y), clamped to the galaxy.
+func directorCell(x, y float64) int {
+	col := min(max(int(x)/directorCellSize, 0), directorGrid-1)
+	row := min(max(int(y)/directorCellSize, 0), directorGrid-1)
+	return row*directorGrid + col
+}
+
+// updateDirector rescores the grid and moves the director camera. Must be
+// called under gameState.Mu write lock.
+func (s *Server) updateDirector() {
+	var score, sumX, sumY [directorGrid * directorGrid]float64
+	add := func(x, y, weight float64) {
+		c := directorCell(x, y)
+		score[c] += weight
+		sumX[c] += x * weight
+		sumY[c] += y * weight
+	}
+
+	gs := s.gameState
+	for _, p := range gs.Players {
+		if p.Status == game.StatusAlive {
+			add(p.X, p.Y, DirectorShipWeight)
+		}
+	}
+	for _, t := range gs.Torps {
+		add(t.X, t.Y, DirectorTorpWeight)
+	}
+	for _, pl := range gs.Plasmas {
+		add(pl.X, pl.Y, DirectorPlasmaWeight)
+	}
+	for _, k := range gs.KillFeed {
+		if gs.Frame-k.Frame <= DirectorKillFrames {
+			add(k.X, k.Y, DirectorKillWeight)
+		}
+	}
+
+	best := 0
+	for c := range score {
+		if score[c] > score[best] {
+			best = c
+		}
+	}
+	if score[best] == 0 {
+		return // Nothing going on: hold the last shot
+	}
+
+	focus := &s.director
+	if focus.Active && focus.Cell != best && score[focus.Cell] > 0 && score[best] < score[focus.Cell]*DirectorSwitchMargin {
+		best = focus.Cell
+	}
+	focus.Active = true
+	focus.Cell = best
+	focus.X = sumX[best] / score[best]
+	focus.Y = sumY[best] / score[best]
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// TestDirectorFollowsCombat verifies that the director camera picks the grid
+// cell with the most combat activity over one with more idle ships, and
+// moves on once a bigger fight breaks out elsewhere.
+func TestDirectorFollowsCombat(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs}
+
+	place := func(id int, x, y float64) {
+		p := gs.Players[id]
+		p.Status = game.StatusAlive
+		p.X, p.Y = x, y
+	}
+
+	// Three ships idling in one cell
+	for i := 0; i < 3; i++ {
+		place(i, 85000+float64(i)*1000, 85000)
+	}
+	// A two-ship dogfight with torpedoes in flight in another
+	place(3, 22000, 31000)
+	place(4, 24000, 31000)
+	for i := 0; i < 4; i++ {
+		gs.Torps = append(gs.Torps, &game.Torpedo{X: 23000, Y: 31000 + float64(i)*100})
+	}
+
+	server.updateDirector()
+	if want := directorCell(23000, 31000); !server.director.Active || server.director.Cell != want {
+		t.Fatalf("director on cell %d (active %v), want the dogfight's cell %d", server.director.Cell, server.director.Active, want)
+	}
+	if cell := directorCell(server.director.X, server.director.Y); cell != server.director.Cell {
+		t.Errorf("focus point (%.0f, %.0f) lies outside cell %d", server.director.X, server.director.Y, server.director.Cell)
+	}
+
+	// A kill by the idle group draws the camera there
+	gs.Frame = 100
+	gs.KillFeed = append(gs.KillFeed, game.KillEvent{Frame: 99, Victim: 2, Killer: 1, X: 86000, Y: 85000})
+	server.updateDirector()
+	if want := directorCell(86000, 85000); server.director.Cell != want {
+		t.Errorf("after a kill the director is on cell %d, want %d", server.director.Cell, want)
+	}
+}
+
+// TestDirectorHoldsFocusOnCloseScores verifies that the camera doesn't jump
+// to a cell that is only slightly busier than the one it is watching.
+func TestDirectorHoldsFocusOnCloseScores(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs}
+
+	for i := 0; i < 4; i++ {
+		p := gs.Players[i]
+		p.Status = game.StatusAlive
+		p.X, p.Y = 15000, 15000
+	}
+	server.updateDirector()
+	watching := server.director.Cell
+
+	for i := 4; i < 9; i++ {
+		p := gs.Players[i]
+		p.Status = game.StatusAlive
+		p.X, p.Y = 75000, 75000
+	}
+	server.updateDirector()
+	if server.director.Cell != watching {
+		t.Errorf("director switched to cell %d for a 5-vs-4 difference", server.director.Cell)
+	}
+}
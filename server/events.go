@@ -0,0 +1,50 @@
+package server
+
+import (
+	"math/rand"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// gameEvents lists the events that can be picked by the scheduler, with the
+// announcement broadcast when each one starts.
+var gameEvents = []struct {
+	id       string
+	announce string
+}{
+	{game.EventDoubleGrowth, "Double army growth! Planets grow armies twice as fast"},
+	{game.EventRapidFire, "Rapid fire! Weapons cool twice as fast"},
+}
+
+// updateGameEvents starts and ends timed game events. Every EventInterval
+// seconds a random event begins and runs for EventDuration seconds.
+// Must be called under gameState.Mu write lock.
+func (s *Server) updateGameEvents() {
+	cfg := s.config()
+	gs := s.gameState
+
+	if gs.ActiveEvent != game.EventNone {
+		if gs.Frame >= gs.EventEndFrame {
+			gs.ActiveEvent = game.EventNone
+			gs.EventEndFrame = 0
+			s.broadcastInfo("The game event has ended - normal rules resume")
+		}
+		return
+	}
+
+	if cfg.EventInterval <= 0 || gs.GameOver {
+		return
+	}
+	if gs.Frame%int64(cfg.EventInterval*game.FPS) == 0 {
+		ev := gameEvents[rand.Intn(len(gameEvents))]
+		s.startGameEvent(ev.id, ev.announce)
+	}
+}
+
+// startGameEvent activates the given event for the configured duration and
+// announces it to all players.
+func (s *Server) startGameEvent(id, announce string) {
+	s.gameState.ActiveEvent = id
+	s.gameState.EventEndFrame = s.gameState.Frame + int64(s.config().EventDuration*game.FPS)
+	s.broadcastInfo(announce)
+}
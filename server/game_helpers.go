@@ -30,6 +30,29 @@ func formatPlayerName(p *game.Player) string {
 	return fmt.Sprintf("%s [%s%02d]", p.Name, teamName, slot)
 }
 
+// outOfLives reports whether p has died as often as the lives limit allows
+// this match and must sit out instead of respawning.
+func (s *Server) outOfLives(p *game.Player) bool {
+	lives := s.config().Lives
+	return lives > 0 && p.Deaths >= lives
+}
+
+// livesLeft maps every player in the match to the lives they have left,
+// or returns nil when lives are unlimited. Caller must hold gameState.Mu.
+func (s *Server) livesLeft() map[int]int {
+	lives := s.config().Lives
+	if lives <= 0 {
+		return nil
+	}
+	left := make(map[int]int)
+	for _, p := range s.gameState.Players {
+		if p.Status != game.StatusFree && p.Team != game.TeamNone {
+			left[p.ID] = max(0, lives-p.Deaths)
+		}
+	}
+	return left
+}
+
 // respawnPlayer respawns a dead player at their home planet
 func (s *Server) respawnPlayer(p *game.Player) {
 	// IMPORTANT: Preserve the ship type for bots unless they have a pending refit
@@ -44,6 +67,11 @@ func (s *Server) respawnPlayer(p *game.Player) {
 	p.KillsStreak = 0        // Reset kill streak on death
 	p.RespawnMsgSent = false // Reset respawn message flag
 
+	// Rotation mode assigns the next ship in the cycle; starbases keep their post
+	if s.config().RefitMode == RefitRotation && p.Ship != game.ShipStarbase {
+		p.NextShipType = int(nextRotationShip(p.Ship))
+	}
+
 	// Check for pending refit before resetting ship stats
 	// Use NumShipTypes constant for validation (ShipData is a map, len() may not be reliable)
 	if p.NextShipType >= 0 && p.NextShipType < game.NumShipTypes {
@@ -78,15 +106,14 @@ func (s *Server) respawnPlayer(p *game.Player) {
 
 	// Reset ship stats
 	shipStats := game.ShipData[p.Ship]
-	p.Shields = shipStats.MaxShields
-	p.Damage = 0
-	p.Fuel = shipStats.MaxFuel
+	s.spawnLoadout(p, shipStats)
 	p.WTemp = 0
 	p.ETemp = 0
 	p.Speed = 0
 	p.DesSpeed = 0
 	p.Shields_up = false // Shields DOWN by default when respawning
 	p.Cloaked = false
+	p.ECMActive = false
 	p.Tractoring = -1
 	p.Pressoring = -1
 
@@ -95,8 +122,7 @@ func (s *Server) respawnPlayer(p *game.Player) {
 	p.RepairRequest = false
 	p.RepairCounter = 0
 	p.Bombing = false
-	p.Beaming = false
-	p.BeamingUp = false
+	stopBeaming(p)
 	p.Orbiting = -1
 	p.Armies = 0 // Clear any armies being carried
 	p.NumTorps = 0
@@ -105,6 +131,7 @@ func (s *Server) respawnPlayer(p *game.Player) {
 	// Reset engine overheat state
 	p.EngineOverheat = false
 	p.OverheatTimer = 0
+	p.SpecialTimer = 0
 
 	// Reset lock-on
 	p.LockType = "none"
@@ -115,7 +142,18 @@ func (s *Server) respawnPlayer(p *game.Player) {
 	p.AccFrac = 0 // Reset fractional acceleration accumulator
 
 	// Set position near home planet with random offset (like original Netrek)
-	p.X, p.Y = spawnPosition(p.Team)
+	p.X, p.Y = s.spawnPosition(p.Team)
+
+	// Briefly protect the new ship from spawn campers while it stays home
+	p.SpawnProtectUntil = 0
+	if cfg := s.config(); cfg.SpawnProtectRadius > 0 {
+		p.SpawnProtectUntil = s.gameState.Frame + int64(cfg.SpawnProtectSeconds*game.FPS)
+	}
+
+	p.RecloakFrame = 0
+	p.BotDetonateReady = 0
+	p.BotDenialReady = 0
+	p.BotAbandonedUntil = 0
 
 	// Random starting direction
 	p.Dir = rand.Float64() * 2 * math.Pi
@@ -126,6 +164,50 @@ func (s *Server) respawnPlayer(p *game.Player) {
 
 }
 
+// nextRotationShip returns the ship that follows ship in the forced rotation
+// (Scout through Assault; starbases are not part of the cycle).
+func nextRotationShip(ship game.ShipType) game.ShipType {
+	return (ship + 1) % game.ShipStarbase
+}
+
+// dockedForRefit reports whether p is alive and orbiting a friendly repair
+// planet, where free refit mode allows an immediate ship change.
+func (s *Server) dockedForRefit(p *game.Player) bool {
+	if p.Status != game.StatusAlive || p.Orbiting < 0 || p.Orbiting >= len(s.gameState.Planets) {
+		return false
+	}
+	planet := s.gameState.Planets[p.Orbiting]
+	return planet != nil && planet.Owner == p.Team && planet.Flags&game.PlanetRepair != 0
+}
+
+// spawnLoadout sets a freshly spawned ship's shields, hull and fuel: full
+// shields, with fuel and damage scaled by Config.StartFuel and
+// Config.StartDamage. Every path that puts a player in a new ship (respawn,
+// joining, bots, refits and the tournament start) goes through here.
+func (s *Server) spawnLoadout(p *game.Player, shipStats game.ShipStats) {
+	cfg := s.config()
+	p.Shields = shipStats.MaxShields
+	p.Damage = int(float64(shipStats.MaxDamage) * cfg.StartDamage)
+	p.Fuel = int(float64(shipStats.MaxFuel) * cfg.StartFuel)
+}
+
+// refitShip swaps p into a new ship in place, as when docked at a friendly
+// repair planet: the new ship starts with the spawn loadout, and carried
+// armies beyond its capacity are lost.
+func (s *Server) refitShip(p *game.Player, ship game.ShipType) {
+	shipStats := game.ShipData[ship]
+	p.Ship = ship
+	p.NextShipType = -1
+	s.spawnLoadout(p, shipStats)
+	p.WTemp = 0
+	p.ETemp = 0
+	p.Armies = min(p.Armies, shipStats.MaxArmies)
+	p.Repairing = false
+	p.RepairRequest = false
+	p.EngineOverheat = false
+	p.OverheatTimer = 0
+}
+
 // killPlayer handles all common state changes when a player is destroyed.
 // Must be called under gameState.Mu write lock.
 func (s *Server) killPlayer(target *game.Player, killerID int, whyDead int, actualDamage int) {
@@ -134,8 +216,7 @@ func (s *Server) killPlayer(target *game.Player, killerID int, whyDead int, actu
 	target.KilledBy = killerID
 	target.WhyDead = whyDead
 	target.Bombing = false
-	target.Beaming = false
-	target.BeamingUp = false
+	stopBeaming(target)
 	target.Orbiting = -1
 	target.LockType = "none"
 	target.LockTarget = -1
@@ -163,11 +244,189 @@ func (s *Server) killPlayer(target *game.Player, killerID int, whyDead int, actu
 		}
 	}
 
+	creditedID := -1
+	if killer != nil {
+		creditedID = killer.ID
+	}
+	s.recordKill(target.ID, creditedID, whyDead)
+	if killer != nil && killer != target && s.config().Ratings {
+		updateRatings(killer, target)
+		s.recordRatings(killer, target)
+	}
+
 	if killer != nil {
 		s.broadcastDeathMessage(target, killer)
 	}
 }
 
+// cloakBarsFire reports whether p's cloak stops it from firing: always,
+// unless Config.DecloakOnFire lets the shot go and drop the cloak instead
+// (see breakCloakToFire).
+func (s *Server) cloakBarsFire(p *game.Player) bool {
+	return p.Cloaked && !s.config().DecloakOnFire
+}
+
+// breakCloakToFire drops p's cloak as it fires under Config.DecloakOnFire
+// and keeps it from cloaking again for game.RecloakDelayFrames, leaving it
+// exposed.
+func (s *Server) breakCloakToFire(p *game.Player) {
+	if !p.Cloaked {
+		return
+	}
+	p.Cloaked = false
+	p.RecloakFrame = s.gameState.Frame + game.RecloakDelayFrames
+}
+
+// recloakBlocked reports whether p fired out of cloak too recently to cloak
+// again.
+func (s *Server) recloakBlocked(p *game.Player) bool {
+	return s.gameState.Frame < p.RecloakFrame
+}
+
+// facingBarsFire reports whether dir lies outside the arc around p's bow
+// that Config.FireArc allows torpedoes and phasers to be fired into.
+func (s *Server) facingBarsFire(p *game.Player, dir float64) bool {
+	arc := s.config().FireArc
+	return arc > 0 && arc < 360 && AngleDifference(p.Dir, dir) > arc*math.Pi/360
+}
+
+// plasmaFlight returns the speed (units per tick) and fuse (ticks) a plasma
+// fired by ship has under Config.PlasmaSpeedScale and PlasmaRangeScale.
+func (s *Server) plasmaFlight(ship game.ShipType) (float64, int) {
+	cfg := s.config()
+	return game.PlasmaFlight(ship, cfg.PlasmaSpeedScale, cfg.PlasmaRangeScale)
+}
+
+// maxPlasmaRange is game.MaxPlasmaRangeForShip under the configured plasma
+// speed and range scales.
+func (s *Server) maxPlasmaRange(ship game.ShipType) float64 {
+	cfg := s.config()
+	return game.ScaledPlasmaRangeForShip(ship, cfg.PlasmaSpeedScale, cfg.PlasmaRangeScale)
+}
+
+// effectivePlasmaRange is game.EffectivePlasmaRange under the configured
+// plasma speed and range scales.
+func (s *Server) effectivePlasmaRange(ship game.ShipType, safetyFactor float64) float64 {
+	cfg := s.config()
+	return game.ScaledEffectivePlasmaRange(ship, safetyFactor, cfg.PlasmaSpeedScale, cfg.PlasmaRangeScale)
+}
+
+// weaponsHeld reports whether p is barred from firing: during a ceasefire,
+// or while inside a repair planet's safe zone.
+func (s *Server) weaponsHeld(p *game.Player) bool {
+	return s.gameState.Ceasefire || s.inRepairSafeZone(p)
+}
+
+// inRepairSafeZone reports whether p is within the configured safe radius of
+// a repair planet. In owner-only mode the planet's owners may still fire
+// there (to drive off intruders), and independent planets have no zone.
+func (s *Server) inRepairSafeZone(p *game.Player) bool {
+	cfg := s.config()
+	if cfg.RepairSafeRadius <= 0 {
+		return false
+	}
+	for _, planet := range s.gameState.Planets {
+		if planet == nil || planet.Flags&game.PlanetRepair == 0 {
+			continue
+		}
+		if cfg.RepairSafeOwnerOnly && (planet.Owner == p.Team || planet.Owner == game.TeamNone) {
+			continue
+		}
+		if s.distance(p.X, p.Y, planet.X, planet.Y) <= cfg.RepairSafeRadius {
+			return true
+		}
+	}
+	return false
+}
+
+// applyDamage scales weapon damage by the configured damage scale and applies
+// it to shields first, then hull, letting through what the configured shield
+// absorption for kind doesn't soak up. Ships under spawn protection take
+// nothing, and nobody takes damage during a ceasefire.
+// Returns the total damage actually applied.
+func (s *Server) applyDamage(p *game.Player, damage int, kind game.DamageType) int {
+	return s.applyDamageAbsorb(p, damage, kind, s.config().ShieldAbsorption())
+}
+
+// applyDamageFrom is applyDamage for a weapon hit arriving from (srcX, srcY):
+// with directional shields configured, hits from astern get through more of
+// the shields than hits on the bow.
+func (s *Server) applyDamageFrom(p *game.Player, damage int, kind game.DamageType, srcX, srcY float64) int {
+	cfg := s.config()
+	srcX, srcY = s.nearestImage(p.X, p.Y, srcX, srcY)
+	return s.applyDamageAbsorb(p, damage, kind, cfg.ShieldAbsorption().Facing(p, srcX, srcY, cfg.ShieldRearFactor))
+}
+
+// applyDamageAbsorb does the work of applyDamage with the given shield
+// absorption.
+func (s *Server) applyDamageAbsorb(p *game.Player, damage int, kind game.DamageType, absorb game.ShieldAbsorption) int {
+	if s.gameState.Ceasefire || s.spawnProtected(p) {
+		return 0
+	}
+	if scale := s.config().DamageScale; scale != 1.0 {
+		damage = int(math.Round(float64(damage) * scale))
+	}
+	applied := game.ApplyDamageWithShields(p, damage, kind, absorb)
+	// Any hit interrupts repairs. This isn't a manual cancel, so auto-repair
+	// may ask again once no enemy is close.
+	if applied > 0 && (p.Repairing || p.RepairRequest) {
+		cancelRepair(p)
+		p.AutoRepairSet = false
+		p.OrbitRepairSet = false
+	}
+	return applied
+}
+
+// spawnProtected reports whether p is a freshly respawned ship still inside
+// its team's spawn zone. Established ships in the zone are not protected.
+func (s *Server) spawnProtected(p *game.Player) bool {
+	return p.SpawnProtectUntil > s.gameState.Frame && s.inSpawnZone(p)
+}
+
+// inSpawnZone reports whether p is within SpawnProtectRadius of its home.
+func (s *Server) inSpawnZone(p *game.Player) bool {
+	homeX, homeY := s.teamHome(p.Team)
+	return s.distance(p.X, p.Y, homeX, homeY) <= s.config().SpawnProtectRadius
+}
+
+// updateSpawnProtection ends p's spawn protection for good once it leaves
+// its spawn zone, so flying back in does not restore it.
+func (s *Server) updateSpawnProtection(p *game.Player) {
+	if p.SpawnProtectUntil > 0 && !s.inSpawnZone(p) {
+		p.SpawnProtectUntil = 0
+	}
+}
+
+// updateRatings moves rating points from victim to killer. Bots keep their
+// fixed rating, so only the human side of a kill is adjusted.
+func updateRatings(killer, victim *game.Player) {
+	gain := game.RatingGain(killer.Rating, victim.Rating)
+	if !killer.IsBot {
+		killer.Rating += gain
+	}
+	if !victim.IsBot {
+		victim.Rating -= gain
+	}
+}
+
+// recordKill appends a kill to the match kill feed, dropping the oldest entry
+// once the feed is full. Must be called under gameState.Mu write lock.
+func (s *Server) recordKill(victimID, killerID, reason int) {
+	victim := s.gameState.Players[victimID]
+	feed := append(s.gameState.KillFeed, game.KillEvent{
+		Frame:  s.gameState.Frame,
+		Victim: victimID,
+		Killer: killerID,
+		Reason: reason,
+		X:      victim.X,
+		Y:      victim.Y,
+	})
+	if len(feed) > game.MaxKillFeed {
+		feed = feed[len(feed)-game.MaxKillFeed:]
+	}
+	s.gameState.KillFeed = feed
+}
+
 // broadcastDeathMessage sends a death message to all players
 func (s *Server) broadcastDeathMessage(victim *game.Player, killer *game.Player) {
 	var msg string
@@ -258,15 +517,83 @@ type TeamCountData struct {
 	Ori   int
 }
 
+// teamHome returns the team's home location, honoring custom map overrides.
+func (s *Server) teamHome(team int) (x, y float64) {
+	hx, hy := s.config().Map.TeamHome(team)
+	return float64(hx), float64(hy)
+}
+
 // spawnPosition returns a random spawn point near the team's home planet,
 // offset by ±5000 in each axis and clamped to the galaxy.
 // Original uses: pl->pl_x + (random() % 10000) - 5000
-func spawnPosition(team int) (x, y float64) {
-	x = float64(game.TeamHomeX[team]) + float64(rand.Intn(10000)-5000)
-	y = float64(game.TeamHomeY[team]) + float64(rand.Intn(10000)-5000)
+func (s *Server) spawnPosition(team int) (x, y float64) {
+	homeX, homeY := s.teamHome(team)
+	x = homeX + float64(rand.Intn(10000)-5000)
+	y = homeY + float64(rand.Intn(10000)-5000)
+	return math.Max(0, math.Min(game.GalaxyWidth, x)), math.Max(0, math.Min(game.GalaxyHeight, y))
+}
+
+// wrapToGalaxy folds a position that has left the galaxy back in from the
+// opposite edge, as in a toroidal galaxy.
+func wrapToGalaxy(x, y float64) (float64, float64) {
+	x = math.Mod(x, game.GalaxyWidth)
+	if x < 0 {
+		x += game.GalaxyWidth
+	}
+	y = math.Mod(y, game.GalaxyHeight)
+	if y < 0 {
+		y += game.GalaxyHeight
+	}
+	return x, y
+}
+
+// confineToGalaxy brings a position pushed past the galaxy edge back inside:
+// wrapped around when Config.GalaxyEdge is EdgeWrap, otherwise clamped to the
+// edge.
+func (s *Server) confineToGalaxy(x, y float64) (float64, float64) {
+	if s.config().GalaxyEdge == EdgeWrap {
+		return wrapToGalaxy(x, y)
+	}
 	return math.Max(0, math.Min(game.GalaxyWidth, x)), math.Max(0, math.Min(game.GalaxyHeight, y))
 }
 
+// delta returns the offset from (x1, y1) to (x2, y2). When Config.GalaxyEdge
+// is EdgeWrap it goes the short way round, across the edge when that is
+// nearer, so weapon ranges, aim and collisions reach across the seam.
+func (s *Server) delta(x1, y1, x2, y2 float64) (float64, float64) {
+	if s.config().GalaxyEdge == EdgeWrap {
+		return wrapDelta(x2-x1, y2-y1)
+	}
+	return x2 - x1, y2 - y1
+}
+
+// wrapDelta folds an offset between two points in a toroidal galaxy into the
+// shortest one, at most half the galaxy along each axis.
+func wrapDelta(dx, dy float64) (float64, float64) {
+	return dx - game.GalaxyWidth*math.Round(dx/game.GalaxyWidth), dy - game.GalaxyHeight*math.Round(dy/game.GalaxyHeight)
+}
+
+// distance is game.Distance measured along delta.
+func (s *Server) distance(x1, y1, x2, y2 float64) float64 {
+	dx, dy := s.delta(x1, y1, x2, y2)
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// bearing returns the heading from (x1, y1) toward (x2, y2) along delta.
+func (s *Server) bearing(x1, y1, x2, y2 float64) float64 {
+	dx, dy := s.delta(x1, y1, x2, y2)
+	return math.Atan2(dy, dx)
+}
+
+// nearestImage returns the copy of (x, y) closest to (fromX, fromY): (x, y)
+// itself unless the galaxy wraps and the short way lies across the edge.
+// Geometry that knows nothing of the edge, such as InterceptDirection, can
+// then work on the result directly.
+func (s *Server) nearestImage(fromX, fromY, x, y float64) (float64, float64) {
+	dx, dy := s.delta(fromX, fromY, x, y)
+	return fromX + dx, fromY + dy
+}
+
 // computeTeamCounts calculates team counts from current game state.
 // Caller must hold gameState.Mu lock (read or write).
 func (s *Server) computeTeamCounts() TeamCountData {
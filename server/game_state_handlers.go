@@ -40,6 +40,18 @@ func (c *Client) handleLogin(data json.RawMessage) {
 		return
 	}
 
+	// Clients sent before versioning existed speak version 1
+	if loginData.Version == 0 {
+		loginData.Version = 1
+	}
+	if minVersion := c.server.config().MinProtocolVersion; loginData.Version < minVersion {
+		c.sendMsg(ServerMessage{
+			Type: MsgTypeError,
+			Data: fmt.Sprintf("Client protocol version %d is too old, this server needs version %d or newer. Please reload the page.", loginData.Version, minVersion),
+		})
+		return
+	}
+
 	// Validate team and ship type
 	if !validateTeam(loginData.Team) {
 		c.sendMsg(ServerMessage{
@@ -65,13 +77,30 @@ func (c *Client) handleLogin(data json.RawMessage) {
 		loginData.Name = fmt.Sprintf("Player%d", rand.Intn(1000))
 	}
 
+	// Survival mode is co-op: every human defends the starbase
+	if c.server.config().Survival && loginData.Team != SurvivalDefenders {
+		c.sendMsg(ServerMessage{
+			Type: MsgTypeError,
+			Data: fmt.Sprintf("Survival mode: join the %s to defend the starbase.", c.server.config().Map.TeamName(SurvivalDefenders)),
+		})
+		return
+	}
+
 	// Find a player slot
 	c.server.gameState.Mu.Lock()
 
+	// A player returning while a bot flies their ship takes it back as it is,
+	// whatever team and ship they asked for
+	if p := c.server.findTakenOverShip(loginData.Name); p != nil {
+		c.server.reclaimShip(p, c.ID)
+		c.completeLogin(p)
+		return
+	}
+
 	playerID := -1
 
-	// Check team balance
-	{
+	// Check team balance (survival mode puts every human on one team)
+	if !c.server.config().Survival {
 		// Count players per team (count all connected, non-free players including
 		// exploding/dead players who will respawn, to prevent imbalanced joins)
 		teamCounts := make(map[int]int)
@@ -151,16 +180,15 @@ func (c *Client) handleLogin(data json.RawMessage) {
 	}
 
 	if playerID == -1 {
-		c.sendMsg(ServerMessage{
-			Type: MsgTypeError,
-			Data: "Server full",
-		})
+		// Wait in line for a slot, watching the game meanwhile
 		c.server.gameState.Mu.Unlock()
+		c.server.enqueueLogin(c, data)
 		return
 	}
 
 	// Set up the player (use pointer to modify in place)
 	p := c.server.gameState.Players[playerID]
+	c.server.releaseBotName(playerID)
 
 	// Reset all player fields to prevent stale state inheritance
 	// Identity
@@ -170,7 +198,7 @@ func (c *Client) handleLogin(data json.RawMessage) {
 	p.Status = game.StatusAlive
 
 	// Set starting position near home planet with random offset (like original Netrek)
-	p.X, p.Y = spawnPosition(loginData.Team)
+	p.X, p.Y = c.server.spawnPosition(loginData.Team)
 
 	// Movement
 	p.Dir = 0
@@ -182,13 +210,17 @@ func (c *Client) handleLogin(data json.RawMessage) {
 
 	// Ship stats
 	shipStats := game.ShipData[loginData.Ship]
-	p.Shields = shipStats.MaxShields
-	p.Damage = 0
-	p.Fuel = shipStats.MaxFuel
+	c.server.spawnLoadout(p, shipStats)
 	p.Armies = 0
 	p.Kills = 0
 	p.KillsStreak = 0
 	p.Deaths = 0
+	p.Rating = 0
+	if c.server.ratings != nil {
+		p.Rating = c.server.ratings.rating(p.Name)
+	} else if c.server.config().Ratings {
+		p.Rating = game.InitialRating
+	}
 
 	// Weapons
 	p.WTemp = 0
@@ -199,15 +231,22 @@ func (c *Client) handleLogin(data json.RawMessage) {
 	// Flags
 	p.Shields_up = false
 	p.Cloaked = false
+	p.ECMActive = false
 	p.Repairing = false
 	p.RepairRequest = false
 	p.RepairCounter = 0
 	p.Bombing = false
-	p.Beaming = false
-	p.BeamingUp = false
+	stopBeaming(p)
 	p.EngineOverheat = false
 	p.Tractoring = -1
 	p.Pressoring = -1
+	p.Assist = false
+	p.AutoRepair = 0
+	p.AutoRepairSet = false
+	p.OrbitRepair = c.server.config().OrbitRepair
+	p.OrbitRepairSet = false
+	p.SpecialTimer = 0
+	p.SpawnProtectUntil = 0
 
 	// Lock-on
 	p.LockType = "none"
@@ -235,32 +274,45 @@ func (c *Client) handleLogin(data json.RawMessage) {
 
 	// Bot fields (ensure human player doesn't inherit bot state)
 	p.IsBot = false
+	p.Dummy = false
 	p.BotTarget = -1
 	p.BotTargetLockTime = 0
 	p.BotTargetValue = 0
 	p.BotPlanetApproachID = -1
 	p.BotDefenseTarget = -1
+	p.BotMemoryUntil = 0
 	p.BotGoalX = 0
 	p.BotGoalY = 0
 	p.BotCooldown = 0
 
+	p.BotTakeoverOf = ""
+
 	// Refit
 	p.NextShipType = -1
 
-	c.SetPlayerID(playerID)
+	c.completeLogin(p)
+}
+
+// completeLogin binds the client to its ship p and tells everyone. Called
+// with gameState.Mu held; releases it.
+func (c *Client) completeLogin(p *game.Player) {
+	c.SetPlayerID(p.ID)
 
 	// Send success response
 	c.sendMsg(ServerMessage{
 		Type: "login_success",
 		Data: map[string]interface{}{
-			"player_id": playerID,
-			"team":      loginData.Team,
-			"ship":      loginData.Ship,
+			"player_id":        p.ID,
+			"team":             p.Team,
+			"ship":             p.Ship,
+			"protocol_version": ProtocolVersion,
+			"tick_ms":          c.server.tickInterval().Milliseconds(),
+			"galaxy_wrap":      c.server.config().GalaxyEdge == EdgeWrap,
 		},
 	})
 
 	shipData := game.ShipData[p.Ship]
-	log.Printf("Player %s joined as %s on team %d", loginData.Name, shipData.Name, loginData.Team)
+	log.Printf("Player %s joined as %s on team %d", p.Name, shipData.Name, p.Team)
 
 	// Capture team counts before releasing lock to ensure consistency
 	teamCounts := c.server.computeTeamCounts()
@@ -306,11 +358,11 @@ func (c *Client) handleQuit(data json.RawMessage) {
 	p.LockTarget = -1
 	p.Shields_up = false
 	p.Cloaked = false
+	p.ECMActive = false
 	p.Repairing = false
 	p.RepairRequest = false
 	p.Bombing = false
-	p.Beaming = false
-	p.BeamingUp = false
+	stopBeaming(p)
 	p.Tractoring = -1
 	p.Pressoring = -1
 	p.Orbiting = -1
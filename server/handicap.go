@@ -0,0 +1,118 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// handicapRepairScale returns the repair speed multiplier team earns for
+// being outnumbered in humans: 1 + Config.HandicapRepair for each human the
+// team is short of the largest human team, once that gap reaches
+// Config.HandicapGap. Teams without humans, and every team while handicapping
+// is off, get 1. Caller must hold gameState.Mu.
+func (s *Server) handicapRepairScale(team int) float64 {
+	cfg := s.config()
+	if cfg.HandicapGap <= 0 || cfg.HandicapRepair <= 0 {
+		return 1
+	}
+
+	humans := make(map[int]int)
+	largest := 0
+	for _, p := range s.gameState.Players {
+		if p.Status == game.StatusFree || !p.Connected || p.IsBot || p.Dummy {
+			continue
+		}
+		humans[p.Team]++
+		largest = max(largest, humans[p.Team])
+	}
+
+	gap := largest - humans[team]
+	if humans[team] == 0 || gap < cfg.HandicapGap {
+		return 1
+	}
+	return 1 + cfg.HandicapRepair*float64(gap)
+}
+
+// TeamHandicap holds per-team multipliers for mixed-skill matches. They apply
+// to every ship on the team, bots included; 1 leaves a value unchanged.
+type TeamHandicap struct {
+	Damage float64 `json:"damage"` // Weapon damage the team's ships deal
+	Fuel   float64 `json:"fuel"`   // Fuel recharge rate
+	Repair float64 `json:"repair"` // Repair speed
+}
+
+// noTeamHandicap is the handicap of a team without one.
+var noTeamHandicap = TeamHandicap{Damage: 1, Fuel: 1, Repair: 1}
+
+// Allowed range for each TeamHandicap multiplier.
+const (
+	MinTeamHandicap = 0.25
+	MaxTeamHandicap = 4.0
+)
+
+// Valid reports whether every multiplier is within MinTeamHandicap..MaxTeamHandicap.
+func (h TeamHandicap) Valid() bool {
+	for _, v := range []float64{h.Damage, h.Fuel, h.Repair} {
+		if v < MinTeamHandicap || v > MaxTeamHandicap {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseTeamHandicap parses a -team-handicap value such as
+// "rom:damage=1.2,fuel=0.9"; multipliers left out stay at 1.
+func ParseTeamHandicap(spec string) (int, TeamHandicap, error) {
+	name, settings, found := strings.Cut(spec, ":")
+	team, ok := teamAlias[strings.ToLower(name)]
+	if !found || !ok {
+		return 0, TeamHandicap{}, fmt.Errorf("want team:setting=value,... with team fed, rom, kli or ori, got %q", spec)
+	}
+	h := noTeamHandicap
+	for _, setting := range strings.Split(settings, ",") {
+		key, value, _ := strings.Cut(setting, "=")
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, TeamHandicap{}, fmt.Errorf("bad multiplier in %q", setting)
+		}
+		switch key {
+		case "damage":
+			h.Damage = v
+		case "fuel":
+			h.Fuel = v
+		case "repair":
+			h.Repair = v
+		default:
+			return 0, TeamHandicap{}, fmt.Errorf("unknown handicap %q, want damage, fuel or repair", key)
+		}
+	}
+	if !h.Valid() {
+		return 0, TeamHandicap{}, fmt.Errorf("multipliers must be between %.2f and %.0f", MinTeamHandicap, MaxTeamHandicap)
+	}
+	return team, h, nil
+}
+
+// teamHandicap returns team's multipliers: those set through /api/handicap,
+// else Config.TeamHandicaps, else none. Caller must hold gameState.Mu.
+func (s *Server) teamHandicap(team int) TeamHandicap {
+	if h, ok := s.teamHandicaps[team]; ok {
+		return h
+	}
+	if h, ok := s.config().TeamHandicaps[team]; ok {
+		return h
+	}
+	return noTeamHandicap
+}
+
+// handicapDamage scales damage dealt by a ship on team by its handicap.
+// Caller must hold gameState.Mu.
+func (s *Server) handicapDamage(team, damage int) int {
+	if scale := s.teamHandicap(team).Damage; scale != 1 {
+		return int(math.Round(float64(damage) * scale))
+	}
+	return damage
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"encoding/json"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/lab1702/netrek-web/game"
@@ -103,6 +105,43 @@ func TestHandleLoginRejectsInvalidTeam(t *testing.T) {
 	}
 }
 
+func TestHandleLoginRejectsOldProtocolVersion(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MinProtocolVersion = 2
+	server := NewServerWithConfig(cfg)
+	client := &Client{
+		ID:     1,
+		server: server,
+		send:   make(chan ServerMessage, 64),
+	}
+	client.SetPlayerID(-1)
+
+	client.handleLogin(json.RawMessage(`{"name":"Test","team":1,"ship":2,"version":1}`))
+
+	if client.GetPlayerID() >= 0 {
+		t.Error("Expected login to be rejected for a too-old protocol version")
+	}
+	select {
+	case msg := <-client.send:
+		text, _ := msg.Data.(string)
+		if msg.Type != MsgTypeError || !strings.Contains(text, "version") {
+			t.Errorf("Expected a protocol version error, got %+v", msg)
+		}
+	default:
+		t.Error("Expected an error message to be sent")
+	}
+
+	// A new enough client gets in and learns the server's version
+	client.handleLogin(json.RawMessage(`{"name":"Test","team":1,"ship":2,"version":2}`))
+	if client.GetPlayerID() < 0 {
+		t.Fatal("Expected a version 2 client to log in")
+	}
+	msg := <-client.send
+	if data, _ := msg.Data.(map[string]interface{}); msg.Type != "login_success" || data["protocol_version"] != ProtocolVersion {
+		t.Errorf("Expected login_success carrying protocol_version %d, got %+v", ProtocolVersion, msg)
+	}
+}
+
 func TestHandleLoginRejectsDoubleLogin(t *testing.T) {
 	server := NewServer()
 	client := &Client{
@@ -204,6 +243,37 @@ func TestHandleMoveClampsNegativeSpeed(t *testing.T) {
 	}
 }
 
+func TestHandleMoveClampsOutOfRangeInput(t *testing.T) {
+	_, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
+	maxSpeed := float64(game.ShipData[game.ShipCruiser].MaxSpeed)
+
+	client.handleMove(json.RawMessage(`{"dir":20,"speed":99}`))
+	if p.DesSpeed != maxSpeed {
+		t.Errorf("Expected speed clamped to ship max %.0f, got %f", maxSpeed, p.DesSpeed)
+	}
+	if p.DesDir < 0 || p.DesDir >= 2*math.Pi {
+		t.Errorf("Expected direction normalized to [0, 2pi), got %f", p.DesDir)
+	}
+
+	// Damage lowers the cap below the ship's rated maximum
+	p.Damage = game.ShipData[game.ShipCruiser].MaxDamage / 2
+	client.handleMove(json.RawMessage(`{"dir":1,"speed":99}`))
+	if p.DesSpeed >= maxSpeed || p.DesSpeed != damagedMaxSpeed(p) {
+		t.Errorf("Expected speed clamped to damaged max %.2f, got %f", damagedMaxSpeed(p), p.DesSpeed)
+	}
+}
+
+func TestIsFiniteRejectsNaNAndInf(t *testing.T) {
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if isFinite(v) {
+			t.Errorf("isFinite(%v) = true, want false", v)
+		}
+	}
+	if !isFinite(1.5) {
+		t.Error("isFinite(1.5) = false, want true")
+	}
+}
+
 func TestHandleMoveIgnoresDeadPlayer(t *testing.T) {
 	_, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
 	p.Status = game.StatusExplode
@@ -260,6 +330,34 @@ func TestHandleFireTorpedoMaxReached(t *testing.T) {
 	}
 }
 
+func TestHandlePlasmaRespectsPerShipLimit(t *testing.T) {
+	orig := game.ShipData[game.ShipStarbase]
+	defer func() { game.ShipData[game.ShipStarbase] = orig }()
+	sb := orig
+	sb.MaxPlasma = 2
+	game.ShipData[game.ShipStarbase] = sb
+
+	fire := func(ship game.ShipType) int {
+		server, client, p := newTestClientAndPlayer(game.TeamFed, ship)
+		for i := 0; i < 3; i++ {
+			p.WTemp = 0
+			client.handlePlasma(json.RawMessage(`{"dir":1.0}`))
+		}
+		if p.NumPlasma != len(server.gameState.Plasmas) {
+			t.Errorf("%s: NumPlasma=%d does not match %d plasmas in flight",
+				game.ShipData[ship].Name, p.NumPlasma, len(server.gameState.Plasmas))
+		}
+		return len(server.gameState.Plasmas)
+	}
+
+	if got := fire(game.ShipStarbase); got != 2 {
+		t.Errorf("starbase with MaxPlasma=2: expected 2 plasmas in flight, got %d", got)
+	}
+	if got := fire(game.ShipCruiser); got != 1 {
+		t.Errorf("cruiser with default MaxPlasma: expected 1 plasma in flight, got %d", got)
+	}
+}
+
 func TestHandleFireTorpedoNoFuel(t *testing.T) {
 	server, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
 	p.Fuel = 0
@@ -284,6 +382,91 @@ func TestHandleFireWhileCloaked(t *testing.T) {
 	}
 }
 
+func TestDecloakOnFireDropsCloakAndFires(t *testing.T) {
+	server, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
+	server.cfg.DecloakOnFire = true
+	server.gameState.Frame = 100
+	p.Cloaked = true
+
+	client.handleFire(json.RawMessage(`{"dir":1.0}`))
+
+	if len(server.gameState.Torps) != 1 {
+		t.Fatalf("fired %d torpedoes out of cloak, want 1", len(server.gameState.Torps))
+	}
+	if p.Cloaked {
+		t.Error("ship still cloaked after firing in decloak-on-fire mode")
+	}
+
+	// The ship stays exposed for a moment before it can cloak again
+	client.handleCloak(nil)
+	if p.Cloaked {
+		t.Error("ship recloaked straight after firing")
+	}
+	server.gameState.Frame += game.RecloakDelayFrames
+	client.handleCloak(nil)
+	if !p.Cloaked {
+		t.Error("ship could not cloak once the recloak delay had passed")
+	}
+}
+
+// TestFireArcRejectsOffBoreShots verifies that with a fire arc set, torpedoes
+// and phasers aimed outside it are refused without spending fuel, shots
+// inside it go out, and a bot turns toward a shot it cannot yet take.
+func TestFireArcRejectsOffBoreShots(t *testing.T) {
+	server, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
+	server.cfg.FireArc = 90
+	p.Dir = 0
+	fuel := p.Fuel
+
+	client.handleFire(json.RawMessage(`{"dir":3.14}`))
+	client.handlePhaser(json.RawMessage(`{"dir":1.2,"target":-1}`))
+	if len(server.gameState.Torps) != 0 || p.Fuel != fuel {
+		t.Fatalf("off-bore shots went out: %d torpedoes, fuel %d of %d", len(server.gameState.Torps), p.Fuel, fuel)
+	}
+
+	client.handleFire(json.RawMessage(`{"dir":0.5}`))
+	if len(server.gameState.Torps) != 1 {
+		t.Errorf("fired %d torpedoes inside the arc, want 1", len(server.gameState.Torps))
+	}
+
+	p.IsBot = true
+	server.fireTorpedoSpreadDir(p, math.Pi/2, 1)
+	if len(server.gameState.Torps) != 1 {
+		t.Errorf("bot fired off-bore: %d torpedoes, want still 1", len(server.gameState.Torps))
+	}
+	if p.DesDir != math.Pi/2 {
+		t.Errorf("bot desired heading %.2f after an off-bore shot, want it turning to %.2f", p.DesDir, math.Pi/2)
+	}
+}
+
+func TestHandleFireInsideRepairSafeZone(t *testing.T) {
+	server, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
+	server.cfg.RepairSafeRadius = 5000
+
+	var repair *game.Planet
+	for _, planet := range server.gameState.Planets {
+		if planet.Flags&game.PlanetRepair != 0 {
+			repair = planet
+			break
+		}
+	}
+	p.X, p.Y = repair.X+2000, repair.Y
+
+	fireJSON := json.RawMessage(`{"dir":1.0}`)
+	client.handleFire(fireJSON)
+	if len(server.gameState.Torps) != 0 {
+		t.Error("Should not fire torpedo inside a repair planet safe zone")
+	}
+
+	// Owners may defend their own haven in owner-only mode
+	server.cfg.RepairSafeOwnerOnly = true
+	p.Team = repair.Owner
+	client.handleFire(fireJSON)
+	if len(server.gameState.Torps) != 1 {
+		t.Error("Owner should be able to fire inside its own repair planet safe zone")
+	}
+}
+
 func TestHandlePhaserDamagesTarget(t *testing.T) {
 	server, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
 	p.X = 50000
@@ -3,6 +3,7 @@ package server
 import (
 	"github.com/lab1702/netrek-web/game"
 	"html"
+	"math"
 	"strings"
 )
 
@@ -16,13 +17,22 @@ var shipAlias = map[string]int{
 	"SB": 5, "STARBASE": 5,
 }
 
+// teamAlias maps lowercase team abbreviations to team flags
+var teamAlias = map[string]int{
+	"fed": game.TeamFed,
+	"rom": game.TeamRom,
+	"kli": game.TeamKli,
+	"ori": game.TeamOri,
+}
+
 // Handler data structures
 
 // LoginData represents login request data
 type LoginData struct {
-	Name string        `json:"name"`
-	Team int           `json:"team"`
-	Ship game.ShipType `json:"ship"`
+	Name    string        `json:"name"`
+	Team    int           `json:"team"`
+	Ship    game.ShipType `json:"ship"`
+	Version int           `json:"version"` // Client protocol version (0 for clients that predate versioning)
 }
 
 // MoveData represents movement commands
@@ -49,7 +59,8 @@ type PlasmaData struct {
 
 // BeamData represents army beam request
 type BeamData struct {
-	Up bool `json:"up"` // true = beam up, false = beam down
+	Up    bool `json:"up"`              // true = beam up, false = beam down
+	Count int  `json:"count,omitempty"` // Armies to beam, 0 = beam until stopped
 }
 
 // MessageData represents a chat message
@@ -156,6 +167,14 @@ func (c *Client) getPlayer() *game.Player {
 	return p
 }
 
+// observing reports whether c watches the match rather than plays in it: it
+// has no player of its own, or its player is out of lives.
+// Caller must hold gameState lock (read or write).
+func (c *Client) observing() bool {
+	p := c.getPlayer()
+	return p == nil || p.Status == game.StatusFree || p.Status == game.StatusObserve
+}
+
 // sendMsg sends a message to this client's send channel without blocking;
 // the message is dropped if the channel is full.
 func (c *Client) sendMsg(msg ServerMessage) {
@@ -164,3 +183,20 @@ func (c *Client) sendMsg(msg ServerMessage) {
 	default:
 	}
 }
+
+// sendWarning sends a warning message to c alone.
+func (c *Client) sendWarning(text string) {
+	c.sendMsg(ServerMessage{
+		Type: MsgTypeMessage,
+		Data: map[string]interface{}{
+			"text": text,
+			"type": "warning",
+		},
+	})
+}
+
+// isFinite reports whether v is neither NaN nor infinite, for validating
+// numbers received from clients.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -1,9 +1,15 @@
 package server
 
 import (
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/lab1702/netrek-web/game"
 )
 
@@ -70,6 +76,131 @@ func TestRefitCommand(t *testing.T) {
 	server.gameState.Mu.RUnlock()
 }
 
+// Test that rotation refit mode cycles ships on each respawn and disables /refit
+func TestRefitRotationCyclesShips(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RefitMode = RefitRotation
+	server := NewServerWithConfig(cfg)
+
+	client := &Client{
+		ID:     1,
+		server: server,
+		send:   make(chan ServerMessage, 10),
+	}
+	client.SetPlayerID(0)
+
+	player := server.gameState.Players[0]
+	player.Status = game.StatusAlive
+	player.Ship = game.ShipScout
+	player.NextShipType = -1
+
+	// Manual refits are rejected in rotation mode
+	client.handleBotCommand("/refit BB")
+	if player.NextShipType != -1 {
+		t.Errorf("Expected /refit to be ignored in rotation mode, got NextShipType %d", player.NextShipType)
+	}
+
+	want := []game.ShipType{
+		game.ShipDestroyer, game.ShipCruiser, game.ShipBattleship,
+		game.ShipAssault, game.ShipScout, game.ShipDestroyer,
+	}
+	for i, ship := range want {
+		server.respawnPlayer(player)
+		if player.Ship != ship {
+			t.Fatalf("Respawn %d: expected %v, got %v", i+1, ship, player.Ship)
+		}
+	}
+}
+
+// TestStartFuelAndDamageApplyToSpawnedShips verifies the configured start
+// fuel and damage apply on every spawn path: respawn, bots, an immediate
+// refit while docked and the tournament start.
+func TestStartFuelAndDamageApplyToSpawnedShips(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.StartFuel = 0.5
+	cfg.StartDamage = 0.2
+	server := NewServerWithConfig(cfg)
+
+	player := server.gameState.Players[0]
+	player.Team = game.TeamFed
+	player.Ship = game.ShipCruiser
+	player.NextShipType = -1
+	player.Status = game.StatusDead
+	server.respawnPlayer(player)
+
+	stats := game.ShipData[game.ShipCruiser]
+	if player.Fuel != stats.MaxFuel/2 {
+		t.Errorf("respawned fuel = %d, want half of %d", player.Fuel, stats.MaxFuel)
+	}
+	if want := stats.MaxDamage / 5; player.Damage != want {
+		t.Errorf("respawned damage = %d, want %d", player.Damage, want)
+	}
+
+	if !server.AddBot(game.TeamRom, game.ShipBattleship) {
+		t.Fatal("AddBot failed")
+	}
+	for _, p := range server.gameState.Players {
+		if p.IsBot {
+			if want := game.ShipData[game.ShipBattleship].MaxFuel / 2; p.Fuel != want {
+				t.Errorf("bot fuel = %d, want %d", p.Fuel, want)
+			}
+		}
+	}
+
+	server.refitShip(player, game.ShipAssault)
+	stats = game.ShipData[game.ShipAssault]
+	if player.Fuel != stats.MaxFuel/2 || player.Damage != stats.MaxDamage/5 {
+		t.Errorf("refitted fuel %d damage %d, want %d and %d", player.Fuel, player.Damage, stats.MaxFuel/2, stats.MaxDamage/5)
+	}
+
+	player.Connected = true
+	player.Fuel = stats.MaxFuel
+	player.Damage = 0
+	server.startTournament()
+	if player.Fuel != stats.MaxFuel/2 || player.Damage != stats.MaxDamage/5 {
+		t.Errorf("tournament start fuel %d damage %d, want %d and %d", player.Fuel, player.Damage, stats.MaxFuel/2, stats.MaxDamage/5)
+	}
+}
+
+// Test that free refit mode swaps ships immediately while docked at a repair planet
+func TestRefitFreeWhileDocked(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RefitMode = RefitFree
+	server := NewServerWithConfig(cfg)
+
+	client := &Client{
+		ID:     1,
+		server: server,
+		send:   make(chan ServerMessage, 10),
+	}
+	client.SetPlayerID(0)
+
+	planet := server.gameState.Planets[0]
+	planet.Owner = game.TeamFed
+	planet.Flags |= game.PlanetRepair
+
+	player := server.gameState.Players[0]
+	player.Status = game.StatusAlive
+	player.Team = game.TeamFed
+	player.Ship = game.ShipScout
+	player.NextShipType = -1
+	player.Damage = 40
+	player.Orbiting = 0
+
+	client.handleBotCommand("/refit BB")
+	if player.Ship != game.ShipBattleship || player.Damage != 0 || player.NextShipType != -1 {
+		t.Errorf("Expected immediate refit to a repaired Battleship, got ship %v damage %d next %d",
+			player.Ship, player.Damage, player.NextShipType)
+	}
+
+	// Away from the planet the refit is queued as usual
+	player.Orbiting = -1
+	client.handleBotCommand("/refit CA")
+	if player.Ship != game.ShipBattleship || player.NextShipType != int(game.ShipCruiser) {
+		t.Errorf("Expected refit to be queued away from a planet, got ship %v next %d", player.Ship, player.NextShipType)
+	}
+}
+
 // Test /addbot command with ship aliases
 func TestAddbotCommandWithAliases(t *testing.T) {
 	// Create a test server
@@ -578,3 +709,138 @@ func TestRespawnStarbaseRefitLimitation(t *testing.T) {
 	}
 	server.gameState.Mu.RUnlock()
 }
+
+// TestWebSocketCompressionOptOut verifies that a client passing compress=0 at
+// upgrade gets a connection without per-message deflate, while the default
+// negotiates it.
+func TestWebSocketCompressionOptOut(t *testing.T) {
+	server := NewServer()
+	go server.Run()
+	defer server.Shutdown()
+
+	ts := httptest.NewServer(http.HandlerFunc(server.HandleWebSocket))
+	defer ts.Close()
+
+	dialer := websocket.Dialer{EnableCompression: true}
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	negotiated := func(query string) bool {
+		conn, resp, err := dialer.Dial(wsURL+query, nil)
+		if err != nil {
+			t.Fatalf("dial %q: %v", query, err)
+		}
+		defer conn.Close()
+		return strings.Contains(resp.Header.Get("Sec-Websocket-Extensions"), "permessage-deflate")
+	}
+
+	if !negotiated("") {
+		t.Error("default connection should negotiate compression")
+	}
+	if negotiated("?compress=0") {
+		t.Error("client requesting compress=0 should get an uncompressed connection")
+	}
+}
+
+// TestWebSocketRejectsConnectionsBeyondCap verifies that once MaxConnections
+// sockets are open the next upgrade is refused with a 503 before the
+// handshake, and that closing one frees its slot.
+func TestWebSocketRejectsConnectionsBeyondCap(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxConnections = 2
+	server := NewServerWithConfig(cfg)
+	go server.Run()
+	defer server.Shutdown()
+
+	ts := httptest.NewServer(http.HandlerFunc(server.HandleWebSocket))
+	defer ts.Close()
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	var conns []*websocket.Conn
+	for i := 0; i < cfg.MaxConnections; i++ {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		if err != nil {
+			t.Fatalf("connection %d within the cap: %v", i+1, err)
+		}
+		conns = append(conns, conn)
+	}
+
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err == nil {
+		conn.Close()
+		t.Fatal("connection beyond the cap was accepted")
+	}
+	if resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("response = %v, want HTTP 503", resp)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "Server full") {
+		t.Errorf("body = %q, want a server full message", body)
+	}
+
+	// The slot is released once the server notices the close
+	conns[0].Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for server.activeConns.Load() >= int32(cfg.MaxConnections) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	conn, _, err = websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("connection after one closed: %v", err)
+	}
+	conn.Close()
+	conns[1].Close()
+}
+
+func TestCustomTeamHomeUsedForRespawn(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Map = &game.MapConfig{Teams: map[string]game.TeamConfig{
+		"fed": {Name: "Alliance", HomeX: 50000, HomeY: 50000},
+	}}
+	server := NewServerWithConfig(cfg)
+
+	player := server.gameState.Players[0]
+	player.Team = game.TeamFed
+	player.Ship = game.ShipCruiser
+	player.NextShipType = -1
+
+	for i := 0; i < 20; i++ {
+		server.respawnPlayer(player)
+		if math.Abs(player.X-50000) > 5000 || math.Abs(player.Y-50000) > 5000 {
+			t.Fatalf("Respawned at (%.0f, %.0f), want within 5000 of custom home (50000, 50000)", player.X, player.Y)
+		}
+	}
+
+	if names := getTeamNamesFromFlag(cfg.Map, game.TeamFed|game.TeamRom); names[0] != "Alliance" || names[1] != "Romulan" {
+		t.Errorf("Team names = %v, want [Alliance Romulan]", names)
+	}
+}
+
+// TestAutoBalanceAnnouncesCustomTeamNames verifies that the auto-balance
+// report names teams as the custom map does.
+func TestAutoBalanceAnnouncesCustomTeamNames(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Map = &game.MapConfig{Teams: map[string]game.TeamConfig{
+		"rom": {Name: "Star Empire"},
+	}}
+	server := NewServerWithConfig(cfg)
+	server.broadcast = make(chan ServerMessage, 64)
+
+	human := server.gameState.Players[0]
+	human.Status = game.StatusAlive
+	human.Team = game.TeamFed
+	human.Connected = true
+
+	server.AutoBalanceBots()
+
+	for len(server.broadcast) > 0 {
+		msg := <-server.broadcast
+		data, _ := msg.Data.(map[string]interface{})
+		if text, _ := data["text"].(string); strings.HasPrefix(text, "Auto-balance: added") {
+			if !strings.Contains(text, "1 bot to Star Empire") {
+				t.Errorf("auto-balance report %q does not name the custom Romulan team", text)
+			}
+			return
+		}
+	}
+	t.Fatal("no auto-balance report broadcast")
+}
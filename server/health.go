@@ -0,0 +1,31 @@
+package server
+
+import (
+	"net/http"
+	"time"
+)
+
+// readyTimeout is how long the game loop may go without ticking before
+// /readyz reports the server as not ready.
+const readyTimeout = 2 * time.Second
+
+// HandleLivez reports that the process is up (GET /livez). It does not look
+// at the game loop; use /readyz for that.
+func (s *Server) HandleLivez(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+// HandleReadyz reports whether the game loop is still advancing (GET /readyz).
+// It returns 503 when the last tick is older than readyTimeout, which surfaces
+// a stalled or deadlocked loop to orchestration.
+func (s *Server) HandleReadyz(w http.ResponseWriter, r *http.Request) {
+	last := s.lastTick.Load()
+	if last == 0 || time.Since(time.Unix(0, last)) > readyTimeout {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("game loop stalled"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
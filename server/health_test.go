@@ -0,0 +1,39 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// TestReadyzFailsWhenGameLoopStalls verifies /readyz tracks game loop ticks
+// while /livez stays up regardless.
+func TestReadyzFailsWhenGameLoopStalls(t *testing.T) {
+	s := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 100)}
+
+	readyz := func() int {
+		rec := httptest.NewRecorder()
+		s.HandleReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return rec.Code
+	}
+
+	s.updateGame()
+	if code := readyz(); code != http.StatusOK {
+		t.Fatalf("readyz after a tick = %d, want 200", code)
+	}
+
+	// Simulate a loop that last ticked well past the timeout
+	s.lastTick.Store(time.Now().Add(-2 * readyTimeout).UnixNano())
+	if code := readyz(); code != http.StatusServiceUnavailable {
+		t.Errorf("readyz with stalled loop = %d, want 503", code)
+	}
+
+	rec := httptest.NewRecorder()
+	s.HandleLivez(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("livez = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+// maxHotSeats caps how many ships one hot seat connection may drive.
+const maxHotSeats = 8
+
+// seat returns the client driving hot seat slot of c's connection, creating
+// it on first use. Seats share c's ID, connection and send channel but log in
+// and play their own player slot, so a developer can fly several ships from
+// one browser. Returns nil when hot seat mode is off or slot is out of range.
+func (c *Client) seat(slot int) *Client {
+	if slot == 0 {
+		return c
+	}
+	if !c.server.config().HotSeat || slot < 0 || slot >= maxHotSeats {
+		return nil
+	}
+
+	c.seatsMu.Lock()
+	defer c.seatsMu.Unlock()
+	if s, ok := c.seats[slot]; ok {
+		return s
+	}
+	if c.seats == nil {
+		c.seats = make(map[int]*Client)
+	}
+	s := &Client{
+		ID:             c.ID,
+		conn:           c.conn,
+		send:           c.send,
+		server:         c.server,
+		botCmdCooldown: c.botCmdCooldown,
+	}
+	s.SetPlayerID(-1)
+	c.seats[slot] = s
+	return s
+}
+
+// seatPlayerIDs returns the player slot of c and of each of its hot seats,
+// captured once so a disconnect frees them all consistently.
+func (c *Client) seatPlayerIDs() []int {
+	ids := []int{c.GetPlayerID()}
+	c.seatsMu.Lock()
+	defer c.seatsMu.Unlock()
+	for _, s := range c.seats {
+		ids = append(ids, s.GetPlayerID())
+	}
+	return ids
+}
+
+// drives reports whether playerID is flown over c's connection, by c itself
+// or one of its hot seats, so messages addressed to that player reach it.
+func (c *Client) drives(playerID int) bool {
+	if c.GetPlayerID() == playerID {
+		return true
+	}
+	c.seatsMu.Lock()
+	defer c.seatsMu.Unlock()
+	for _, s := range c.seats {
+		if s.GetPlayerID() == playerID {
+			return true
+		}
+	}
+	return false
+}
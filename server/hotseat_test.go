@@ -0,0 +1,70 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// TestHotSeatSlotsActIndependently verifies that with hot seat mode on, one
+// client can log in two ships and steer each through its slot index.
+func TestHotSeatSlotsActIndependently(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.HotSeat = true
+	server := NewServerWithConfig(cfg)
+
+	client := &Client{ID: 1, server: server, send: make(chan ServerMessage, 64)}
+	client.SetPlayerID(-1)
+	server.clients[client.ID] = client
+
+	client.handleMessage(ClientMessage{Type: MsgTypeLogin, Data: json.RawMessage(`{"name":"Left","team":1,"ship":2}`)})
+	client.handleMessage(ClientMessage{Type: MsgTypeLogin, Slot: 1, Data: json.RawMessage(`{"name":"Right","team":2,"ship":2}`)})
+
+	first := client.GetPlayerID()
+	second := client.seat(1).GetPlayerID()
+	if first < 0 || second < 0 || first == second {
+		t.Fatalf("seats got slots %d and %d, want two distinct slots", first, second)
+	}
+
+	client.handleMessage(ClientMessage{Type: MsgTypeShields, Slot: 1, Data: json.RawMessage(`{}`)})
+	client.handleMessage(ClientMessage{Type: MsgTypeMove, Data: json.RawMessage(`{"dir":1.5,"speed":4}`)})
+
+	left, right := server.gameState.Players[first], server.gameState.Players[second]
+	if left.Shields_up || !right.Shields_up {
+		t.Errorf("shields up: seat 0 %v, seat 1 %v; want only seat 1", left.Shields_up, right.Shields_up)
+	}
+	if left.DesSpeed != 4 || right.DesSpeed != 0 {
+		t.Errorf("desired speed: seat 0 %v, seat 1 %v; want only seat 0 moving", left.DesSpeed, right.DesSpeed)
+	}
+	if !client.drives(second) {
+		t.Error("messages for seat 1's player would not reach the connection")
+	}
+
+	// Disconnecting frees every seat's ship
+	server.removeClient(client)
+	if left.Status != game.StatusFree || right.Status != game.StatusFree {
+		t.Errorf("after disconnect statuses are %d and %d, want both free", left.Status, right.Status)
+	}
+}
+
+// TestHotSeatRequiresFlag verifies that slot indexes are rejected unless hot
+// seat mode is enabled.
+func TestHotSeatRequiresFlag(t *testing.T) {
+	server := NewServer()
+	client := &Client{ID: 1, server: server, send: make(chan ServerMessage, 64)}
+	client.SetPlayerID(-1)
+
+	client.handleMessage(ClientMessage{Type: MsgTypeLogin, Slot: 1, Data: json.RawMessage(`{"name":"Extra","team":1,"ship":2}`)})
+	if client.seat(1) != nil {
+		t.Fatal("seat created with hot seat mode off")
+	}
+	if msg := <-client.send; msg.Type != MsgTypeError {
+		t.Errorf("got %q reply, want an error", msg.Type)
+	}
+	for _, p := range server.gameState.Players {
+		if p.Status != game.StatusFree {
+			t.Fatalf("slot %d taken by a rejected seat login", p.ID)
+		}
+	}
+}
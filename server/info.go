@@ -0,0 +1,39 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// HandleInfo returns the server's gameplay settings (GET /api/info) so
+// clients and tools can tell what kind of game they are joining.
+func (s *Server) HandleInfo(w http.ResponseWriter, r *http.Request) {
+	cfg := s.config()
+	teamNames := make(map[string]string)
+	for alias, team := range teamAlias {
+		teamNames[alias] = cfg.Map.TeamName(team)
+	}
+	info := map[string]interface{}{
+		"maxPlayers":      game.MaxPlayers,
+		"maxConnections":  cfg.MaxConnections,
+		"fps":             cfg.FPS,
+		"damageScale":     cfg.DamageScale,
+		"eventInterval":   cfg.EventInterval,
+		"armyMultiplier":  cfg.ArmyMultiplier,
+		"neutralStart":    cfg.NeutralStart,
+		"conquestPlanets": s.conquestPlanets(),
+		"customMap":       cfg.Map != nil,
+		"refitMode":       cfg.RefitMode,
+		"teamNames":       teamNames,
+		"freeForAll":      cfg.FreeForAll,
+		"galaxyEdge":      cfg.GalaxyEdge,
+		"fireArc":         cfg.FireArc,
+		"shipSpecials":    cfg.ShipSpecials,
+		"protocolVersion": ProtocolVersion,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(info)
+}
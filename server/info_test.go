@@ -0,0 +1,38 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// TestHandleInfoReportsDamageScale verifies /api/info surfaces the configured
+// damage scale.
+func TestHandleInfoReportsDamageScale(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DamageScale = 0.5
+	s := &Server{gameState: game.NewGameState(), cfg: &cfg}
+
+	rec := httptest.NewRecorder()
+	s.HandleInfo(rec, httptest.NewRequest(http.MethodGet, "/api/info", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	var body struct {
+		MaxPlayers  int     `json:"maxPlayers"`
+		DamageScale float64 `json:"damageScale"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if body.DamageScale != 0.5 {
+		t.Errorf("damageScale = %v, want 0.5", body.DamageScale)
+	}
+	if body.MaxPlayers != game.MaxPlayers {
+		t.Errorf("maxPlayers = %d, want %d", body.MaxPlayers, game.MaxPlayers)
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// matchTeam summarizes one team's standing in a match snapshot.
+type matchTeam struct {
+	Players int     `json:"players"`
+	Planets int     `json:"planets"`
+	Armies  int     `json:"armies"` // Armies on owned planets plus carried armies
+	Kills   float64 `json:"kills"`
+}
+
+// HandleMatch returns a complete snapshot of the current match for observers
+// and casters (GET /api/match): every active player with live stats, all
+// planets, per-team scores, the tournament timer and the recent kill feed.
+// It is served on request only and is separate from the per-tick stream.
+func (s *Server) HandleMatch(w http.ResponseWriter, r *http.Request) {
+	s.gameState.Mu.RLock()
+
+	gs := s.gameState
+	teams := map[string]*matchTeam{
+		"fed": {}, "rom": {}, "kli": {}, "ori": {},
+	}
+	teamKeys := map[int]string{
+		game.TeamFed: "fed",
+		game.TeamRom: "rom",
+		game.TeamKli: "kli",
+		game.TeamOri: "ori",
+	}
+
+	players := make([]*game.Player, 0, game.MaxPlayers)
+	for _, p := range gs.Players {
+		if p.Status == game.StatusFree {
+			continue
+		}
+		players = append(players, p)
+		if team, ok := teams[teamKeys[p.Team]]; ok {
+			team.Players++
+			team.Kills += p.Kills
+			team.Armies += p.Armies
+		}
+	}
+	for _, planet := range gs.Planets {
+		if planet == nil {
+			continue
+		}
+		if team, ok := teams[teamKeys[planet.Owner]]; ok {
+			team.Planets++
+			team.Armies += planet.Armies
+		}
+	}
+
+	snapshot := struct {
+		Frame    int64                 `json:"frame"`
+		TMode    bool                  `json:"tMode"`
+		TRemain  int                   `json:"tRemain"`
+		GameOver bool                  `json:"gameOver"`
+		Winner   int                   `json:"winner,omitempty"`
+		WinType  string                `json:"winType,omitempty"`
+		Event    string                `json:"event,omitempty"`
+		Teams    map[string]*matchTeam `json:"teams"`
+		Players  []*game.Player        `json:"players"`
+		Planets  []*game.Planet        `json:"planets"`
+		KillFeed []game.KillEvent      `json:"killFeed"`
+		Director directorFocus         `json:"director"`
+	}{
+		Frame:    gs.Frame,
+		TMode:    gs.T_mode,
+		TRemain:  gs.T_remain,
+		GameOver: gs.GameOver,
+		Winner:   gs.Winner,
+		WinType:  gs.WinType,
+		Event:    gs.ActiveEvent,
+		Teams:    teams,
+		Players:  players,
+		Planets:  gs.Planets[:],
+		KillFeed: gs.KillFeed,
+		Director: s.director,
+	}
+
+	// Marshal while holding the lock since the snapshot references live state
+	data, err := json.Marshal(snapshot)
+	s.gameState.Mu.RUnlock()
+
+	if err != nil {
+		log.Printf("Error marshaling match snapshot: %v", err)
+		http.Error(w, "Internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// TestHandleMatchIncludesAllTeamsAndPlayers verifies the match snapshot lists
+// every team, every active player and the recent kill feed.
+func TestHandleMatchIncludesAllTeamsAndPlayers(t *testing.T) {
+	s := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 100)}
+
+	teams := []int{game.TeamFed, game.TeamRom, game.TeamKli, game.TeamOri}
+	for i, team := range teams {
+		p := s.gameState.Players[i]
+		p.Status = game.StatusAlive
+		p.Team = team
+		p.Ship = game.ShipCruiser
+		p.Name = "Pilot"
+		p.Connected = true
+	}
+	s.gameState.Players[0].Kills = 2
+	s.killPlayer(s.gameState.Players[1], 0, game.KillTorp, 10)
+
+	rec := httptest.NewRecorder()
+	s.HandleMatch(rec, httptest.NewRequest(http.MethodGet, "/api/match", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	var body struct {
+		Teams map[string]struct {
+			Players int     `json:"players"`
+			Planets int     `json:"planets"`
+			Kills   float64 `json:"kills"`
+		} `json:"teams"`
+		Players []struct {
+			ID   int `json:"id"`
+			Team int `json:"team"`
+		} `json:"players"`
+		Planets  []json.RawMessage `json:"planets"`
+		KillFeed []game.KillEvent  `json:"killFeed"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	for _, key := range []string{"fed", "rom", "kli", "ori"} {
+		team, ok := body.Teams[key]
+		if !ok {
+			t.Errorf("team %q missing from snapshot", key)
+			continue
+		}
+		if team.Players != 1 || team.Planets != 10 {
+			t.Errorf("team %q: players=%d planets=%d, want 1 and 10", key, team.Players, team.Planets)
+		}
+	}
+	if body.Teams["fed"].Kills != 3 {
+		t.Errorf("fed kills = %v, want 3", body.Teams["fed"].Kills)
+	}
+	if len(body.Players) != len(teams) {
+		t.Fatalf("players = %d, want %d", len(body.Players), len(teams))
+	}
+	for i, p := range body.Players {
+		if p.ID != i || p.Team != teams[i] {
+			t.Errorf("player %d = %+v, want team %d", i, p, teams[i])
+		}
+	}
+	if len(body.Planets) != game.MaxPlanets {
+		t.Errorf("planets = %d, want %d", len(body.Planets), game.MaxPlanets)
+	}
+	if len(body.KillFeed) != 1 || body.KillFeed[0].Victim != 1 || body.KillFeed[0].Killer != 0 {
+		t.Errorf("kill feed = %+v, want one kill of player 1 by player 0", body.KillFeed)
+	}
+}
@@ -33,25 +33,28 @@ func TestHandleMessageRouting(t *testing.T) {
 	// All known message types with minimal valid JSON payloads.
 	// Handlers may reject these on validation, but the routing must not panic.
 	knownTypes := map[string]string{
-		MsgTypeLogin:    `{"name":"Test","team":1,"ship":2}`,
-		MsgTypeMove:     `{"direction":1.5,"speed":5}`,
-		MsgTypeFire:     `{}`,
-		MsgTypePhaser:   `{"direction":1.0,"target":-1}`,
-		MsgTypeShields:  `{"up":true}`,
-		MsgTypeOrbit:    `{}`,
-		MsgTypeRepair:   `{}`,
-		MsgTypeLock:     `{"type":"planet","target":0}`,
-		MsgTypeBeam:     `{"up":true}`,
-		MsgTypeBomb:     `{}`,
-		MsgTypeTractor:  `{"target":1}`,
-		MsgTypePressor:  `{"target":1}`,
-		MsgTypePlasma:   `{"direction":1.0}`,
-		MsgTypeDetonate: `{}`,
-		MsgTypeCloak:    `{}`,
-		MsgTypeMessage:  `{"text":"hello","to":"all"}`,
-		MsgTypeTeamMsg:  `{"text":"team hello"}`,
-		MsgTypePrivMsg:  `{"text":"private hello","target":1}`,
-		MsgTypeQuit:     `{}`,
+		MsgTypeLogin:     `{"name":"Test","team":1,"ship":2}`,
+		MsgTypeMove:      `{"direction":1.5,"speed":5}`,
+		MsgTypeFire:      `{}`,
+		MsgTypePhaser:    `{"direction":1.0,"target":-1}`,
+		MsgTypeShields:   `{"up":true}`,
+		MsgTypeOrbit:     `{}`,
+		MsgTypeRepair:    `{}`,
+		MsgTypeLock:      `{"type":"planet","target":0}`,
+		MsgTypeLockCycle: `{}`,
+		MsgTypeBeam:      `{"up":true}`,
+		MsgTypeBomb:      `{}`,
+		MsgTypeTractor:   `{"target":1}`,
+		MsgTypePressor:   `{"target":1}`,
+		MsgTypePlasma:    `{"direction":1.0}`,
+		MsgTypeDetonate:  `{}`,
+		MsgTypeCloak:     `{}`,
+		MsgTypeECM:       `{}`,
+		MsgTypeMessage:   `{"text":"hello","to":"all"}`,
+		MsgTypeTeamMsg:   `{"text":"team hello"}`,
+		MsgTypePrivMsg:   `{"text":"private hello","target":1}`,
+		MsgTypeObsMsg:    `{"text":"observer hello"}`,
+		MsgTypeQuit:      `{}`,
 	}
 
 	for msgType, payload := range knownTypes {
@@ -109,6 +112,7 @@ func TestHandleMessageAllTypesHaveConstants(t *testing.T) {
 		MsgTypeOrbit,
 		MsgTypeRepair,
 		MsgTypeLock,
+		MsgTypeLockCycle,
 		MsgTypeBeam,
 		MsgTypeBomb,
 		MsgTypeTractor,
@@ -116,9 +120,11 @@ func TestHandleMessageAllTypesHaveConstants(t *testing.T) {
 		MsgTypePlasma,
 		MsgTypeDetonate,
 		MsgTypeCloak,
+		MsgTypeECM,
 		MsgTypeMessage,
 		MsgTypeTeamMsg,
 		MsgTypePrivMsg,
+		MsgTypeObsMsg,
 		MsgTypeQuit,
 	}
 
@@ -138,9 +144,9 @@ func TestHandleMessageAllTypesHaveConstants(t *testing.T) {
 		seen[msgType] = true
 	}
 
-	// Verify count matches what we expect (19 client message types)
-	if len(expectedTypes) != 19 {
-		t.Errorf("Expected 19 client message types, got %d", len(expectedTypes))
+	// Verify count matches what we expect (22 client message types)
+	if len(expectedTypes) != 22 {
+		t.Errorf("Expected 22 client message types, got %d", len(expectedTypes))
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"sort"
 
 	"github.com/lab1702/netrek-web/game"
 )
@@ -21,9 +22,10 @@ func (c *Client) handleMove(data json.RawMessage) {
 		return
 	}
 
-	// Validate direction and speed
-	if math.IsNaN(moveData.Speed) || math.IsInf(moveData.Speed, 0) {
-		moveData.Speed = 0
+	// Reject physically meaningless input outright rather than letting it
+	// reach the physics as a heading or speed
+	if !isFinite(moveData.Dir) || !isFinite(moveData.Speed) {
+		return
 	}
 
 	c.server.gameState.Mu.Lock()
@@ -56,21 +58,11 @@ func (c *Client) handleMove(data json.RawMessage) {
 			// Send message about canceling repair request (non-blocking)
 			c.server.broadcastInfo(fmt.Sprintf("%s canceled repair request", formatPlayerName(p)))
 		}
-		p.Repairing = false
-	}
-
-	// Calculate max speed based on damage
-	shipStats := game.ShipData[p.Ship]
-	maxSpeed := float64(shipStats.MaxSpeed)
-	if p.Damage > 0 && shipStats.MaxDamage > 0 {
-		// Formula from original Netrek: maxspeed = (max + 2) - (max + 1) * (damage / maxdamage)
-		damageRatio := float64(p.Damage) / float64(shipStats.MaxDamage)
-		maxSpeed = float64(shipStats.MaxSpeed+2) - float64(shipStats.MaxSpeed+1)*damageRatio
-		maxSpeed = math.Max(1, maxSpeed) // Minimum speed of 1
+		cancelRepair(p)
 	}
 
 	// Clamp speed to damage-adjusted maximum
-	p.DesSpeed = math.Max(0, math.Min(moveData.Speed, maxSpeed))
+	p.DesSpeed = math.Max(0, math.Min(moveData.Speed, damagedMaxSpeed(p)))
 }
 
 // handleLock handles lock-on to players or planets
@@ -88,8 +80,8 @@ func (c *Client) handleLock(data json.RawMessage) {
 		return
 	}
 
-	// Validate lock target type - only allow "planet" or "none"
-	if lockData.Type != "planet" && lockData.Type != "none" {
+	// Validate lock target type
+	if lockData.Type != "planet" && lockData.Type != "player" && lockData.Type != "none" {
 		return
 	}
 
@@ -99,6 +91,11 @@ func (c *Client) handleLock(data json.RawMessage) {
 			return
 		}
 	}
+	if lockData.Type == "player" {
+		if lockData.Target < 0 || lockData.Target >= game.MaxPlayers {
+			return
+		}
+	}
 
 	c.server.gameState.Mu.Lock()
 	defer c.server.gameState.Mu.Unlock()
@@ -108,13 +105,24 @@ func (c *Client) handleLock(data json.RawMessage) {
 		return
 	}
 
+	// Check a ship lock before leaving orbit so a failed lock changes nothing
+	if lockData.Type == "player" {
+		target := c.server.gameState.Players[lockData.Target]
+		if target == p || target.Status != game.StatusAlive || (target.Cloaked && target.Team != p.Team) {
+			return
+		}
+		if c.server.jamsLock(p, target) {
+			c.sendMsg(ServerMessage{
+				Type: MsgTypeError,
+				Data: fmt.Sprintf("Lock failed: %s is jamming", formatPlayerName(target)),
+			})
+			return
+		}
+	}
+
 	// Break orbit when locking onto a new target (unless locking the planet we're orbiting)
 	if p.Orbiting >= 0 && (lockData.Type != "planet" || lockData.Target != p.Orbiting) {
-		p.Orbiting = -1
-		p.Bombing = false // Stop bombing when leaving orbit
-		p.Beaming = false // Stop beaming when leaving orbit
-		// Send message about breaking orbit (non-blocking)
-		c.server.broadcastInfo(fmt.Sprintf("%s has left orbit", formatPlayerName(p)))
+		c.server.leaveOrbitForLock(p)
 	}
 
 	// Validate target and set course
@@ -127,9 +135,13 @@ func (c *Client) handleLock(data json.RawMessage) {
 		p.LockTarget = lockData.Target
 
 		// Set desired course toward planet (ship will turn at normal rate)
-		dx := planet.X - p.X
-		dy := planet.Y - p.Y
+		dx, dy := c.server.delta(p.X, p.Y, planet.X, planet.Y)
 		p.DesDir = math.Atan2(dy, dx)
+	} else if lockData.Type == "player" {
+		target := c.server.gameState.Players[lockData.Target]
+		p.LockType = "player"
+		p.LockTarget = lockData.Target
+		p.DesDir = c.server.bearing(p.X, p.Y, target.X, target.Y)
 	} else if lockData.Type == "none" {
 		// Clear lock
 		p.LockType = "none"
@@ -137,6 +149,83 @@ func (c *Client) handleLock(data json.RawMessage) {
 	}
 }
 
+// handleLockCycle locks onto the nearest visible enemy, or, when already
+// locked on one, the next farther enemy (wrapping back to the nearest), so
+// keyboard players can step through targets without knowing their IDs. The
+// chosen target is sent back to the client (-1 when there is none).
+func (c *Client) handleLockCycle(data json.RawMessage) {
+	if !c.validPlayerID() {
+		return
+	}
+
+	c.server.gameState.Mu.Lock()
+	defer c.server.gameState.Mu.Unlock()
+
+	p := c.getAlivePlayer()
+	if p == nil {
+		return
+	}
+
+	target := c.server.nextLockCycleTarget(p)
+	if target == nil {
+		c.sendMsg(ServerMessage{Type: MsgTypeLockCycle, Data: map[string]interface{}{"target": -1}})
+		return
+	}
+
+	if p.Orbiting >= 0 {
+		c.server.leaveOrbitForLock(p)
+	}
+	p.LockType = "player"
+	p.LockTarget = target.ID
+	p.DesDir = c.server.bearing(p.X, p.Y, target.X, target.Y)
+
+	c.sendMsg(ServerMessage{
+		Type: MsgTypeLockCycle,
+		Data: map[string]interface{}{
+			"target": target.ID,
+			"name":   formatPlayerName(target),
+		},
+	})
+}
+
+// nextLockCycleTarget returns the enemy a lock cycle should move to: the
+// enemies p could lock onto (the same visible enemies findNearestEnemy
+// considers, less any jamming p) ordered nearest first, stepping one past
+// p's current player lock. Returns nil when there is nothing to lock.
+func (s *Server) nextLockCycleTarget(p *game.Player) *game.Player {
+	var enemies []*game.Player
+	for _, other := range s.gameState.Players {
+		if other.Status == game.StatusAlive && other.Team != p.Team && other.ID != p.ID && !other.Cloaked && !s.jamsLock(p, other) {
+			enemies = append(enemies, other)
+		}
+	}
+	if len(enemies) == 0 {
+		return nil
+	}
+	sort.SliceStable(enemies, func(i, j int) bool {
+		return s.distance(p.X, p.Y, enemies[i].X, enemies[i].Y) < s.distance(p.X, p.Y, enemies[j].X, enemies[j].Y)
+	})
+
+	if p.LockType == "player" {
+		for i, e := range enemies {
+			if e.ID == p.LockTarget {
+				return enemies[(i+1)%len(enemies)]
+			}
+		}
+	}
+	return enemies[0]
+}
+
+// leaveOrbitForLock takes p out of orbit to follow a new lock, stopping any
+// bombing or beaming.
+func (s *Server) leaveOrbitForLock(p *game.Player) {
+	p.Orbiting = -1
+	p.Bombing = false // Stop bombing when leaving orbit
+	stopBeaming(p)    // Stop beaming when leaving orbit
+	// Send message about breaking orbit (non-blocking)
+	s.broadcastInfo(fmt.Sprintf("%s has left orbit", formatPlayerName(p)))
+}
+
 // handleOrbit toggles orbit around nearest planet
 func (c *Client) handleOrbit(data json.RawMessage) {
 	if !c.validPlayerID() {
@@ -155,7 +244,12 @@ func (c *Client) handleOrbit(data json.RawMessage) {
 	if p.Orbiting >= 0 {
 		p.Orbiting = -1
 		p.Bombing = false // Stop bombing when breaking orbit
-		p.Beaming = false // Stop beaming when breaking orbit
+		stopBeaming(p)    // Stop beaming when breaking orbit
+		return
+	}
+
+	if p.Shields_up && c.server.config().ShieldsDownToOrbit {
+		c.sendWarning("Lower your shields to orbit")
 		return
 	}
 
@@ -171,7 +265,7 @@ func (c *Client) handleOrbit(data json.RawMessage) {
 
 	for i := 0; i < game.MaxPlanets; i++ {
 		planet := c.server.gameState.Planets[i]
-		dist := game.Distance(p.X, p.Y, planet.X, planet.Y)
+		dist := c.server.distance(p.X, p.Y, planet.X, planet.Y)
 		if dist <= closestDist {
 			closestDist = dist
 			closestPlanet = i
@@ -193,7 +287,7 @@ func (c *Client) handleOrbit(data json.RawMessage) {
 
 		// Calculate initial orbit position at correct radius
 		planet := c.server.gameState.Planets[closestPlanet]
-		angle := math.Atan2(p.Y-planet.Y, p.X-planet.X)
+		angle := c.server.bearing(planet.X, planet.Y, p.X, p.Y)
 		p.X = planet.X + float64(game.OrbitDist)*math.Cos(angle)
 		p.Y = planet.Y + float64(game.OrbitDist)*math.Sin(angle)
 
@@ -209,3 +303,9 @@ func (c *Client) handleOrbit(data json.RawMessage) {
 			p.Name, p.Team, planet.Name, oldInfo, planet.Info)
 	}
 }
+
+// jamsLock reports whether target's ECM keeps p from locking on. Jamming
+// only works against enemies.
+func (s *Server) jamsLock(p, target *game.Player) bool {
+	return target.ECMActive && (target.Team != p.Team || s.config().FreeForAll)
+}
@@ -23,8 +23,7 @@ func (s *Server) OrbitalVelocity(p *game.Player) (vx, vy float64, ok bool) {
 	}
 
 	// Calculate radius vector from planet to ship
-	dx := p.X - planet.X
-	dy := p.Y - planet.Y
+	dx, dy := s.delta(planet.X, planet.Y, p.X, p.Y)
 	radius := math.Sqrt(dx*dx + dy*dy)
 
 	// Avoid division by zero
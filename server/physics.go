@@ -8,6 +8,26 @@ import (
 	"github.com/lab1702/netrek-web/game"
 )
 
+// damagedMaxSpeed returns the highest speed the player's ship can currently
+// make. Formula from original Netrek:
+// maxspeed = (max + 2) - (max + 1) * (damage / maxdamage), never below 1.
+func damagedMaxSpeed(p *game.Player) float64 {
+	shipStats := game.ShipData[p.Ship]
+	maxSpeed := float64(shipStats.MaxSpeed)
+	if p.Damage > 0 && shipStats.MaxDamage > 0 {
+		damageRatio := float64(p.Damage) / float64(shipStats.MaxDamage)
+		maxSpeed = float64(shipStats.MaxSpeed+2) - float64(shipStats.MaxSpeed+1)*damageRatio
+		maxSpeed = math.Max(1, maxSpeed) // Minimum speed of 1
+	}
+	return maxSpeed
+}
+
+// isHeavyShip reports whether a ship's acceleration is small for its mass,
+// which makes it subject to the warp-up stall.
+func isHeavyShip(shipStats game.ShipStats) bool {
+	return float64(shipStats.AccInt)/float64(shipStats.Mass) < game.WarpUpAccelPerMass
+}
+
 // updatePlayerPhysics handles all movement, positioning, and physics for a single player
 func (s *Server) updatePlayerPhysics(p *game.Player, i int) {
 	if p.Status != game.StatusAlive {
@@ -24,6 +44,11 @@ func (s *Server) updatePlayerPhysics(p *game.Player, i int) {
 		if speed < 30 {
 			// Use bit shift: turnRate / (2^speed)
 			turnIncrement = shipStats.TurnRate >> uint(speed)
+			if scale := s.config().TurnRateScale; scale != 1.0 {
+				// Scale before dividing so slow-turning ships at speed don't
+				// lose the scale to the truncated shift
+				turnIncrement = int(float64(shipStats.TurnRate) * scale / float64(uint(1)<<uint(speed)))
+			}
 		} else {
 			// Very high speeds get minimal turning
 			turnIncrement = 0
@@ -67,13 +92,9 @@ func (s *Server) updatePlayerPhysics(p *game.Player, i int) {
 	{
 		// Calculate max speed based on damage
 		shipStats := game.ShipData[p.Ship]
-		maxSpeed := float64(shipStats.MaxSpeed)
-		if p.Damage > 0 {
-			// Formula from original Netrek: maxspeed = (max + 2) - (max + 1) * (damage / maxdamage)
-			damageRatio := float64(p.Damage) / float64(shipStats.MaxDamage)
-			maxSpeed = float64(shipStats.MaxSpeed+2) - float64(shipStats.MaxSpeed+1)*damageRatio
-			maxSpeed = math.Max(1, maxSpeed) // Minimum speed of 1
-		}
+		maxSpeed := damagedMaxSpeed(p)
+		// A ship left above its cap by fresh damage brakes at the configured rate
+		overDamageCap := p.Speed > maxSpeed
 
 		// Engine overheat limits actual speed to 1 (from original daemon.c).
 		// Cap maxSpeed only; do not overwrite DesSpeed, or the temporary penalty
@@ -93,7 +114,12 @@ func (s *Server) updatePlayerPhysics(p *game.Player, i int) {
 			if p.AccFrac < 0 {
 				p.AccFrac = 0
 			}
-			p.AccFrac += shipStats.AccInt
+			accInt := shipStats.AccInt
+			if s.config().WarpUp && p.Speed < game.WarpUpSpeed && isHeavyShip(shipStats) {
+				// Heavy ships stall while their engines spool up
+				accInt /= game.WarpUpPenalty
+			}
+			p.AccFrac += accInt
 			// Each FractionScale units of accumulator = 1 speed unit change
 			if p.AccFrac >= game.FractionScale {
 				speedInc := p.AccFrac / game.FractionScale
@@ -106,7 +132,11 @@ func (s *Server) updatePlayerPhysics(p *game.Player, i int) {
 			if p.AccFrac > 0 {
 				p.AccFrac = 0
 			}
-			p.AccFrac -= shipStats.DecInt
+			decInt := shipStats.DecInt
+			if overDamageCap {
+				decInt = int(float64(decInt) * s.config().DamageDecelScale)
+			}
+			p.AccFrac -= decInt
 			// Each FractionScale units of accumulator = 1 speed unit change
 			if p.AccFrac <= -game.FractionScale {
 				speedDec := (-p.AccFrac) / game.FractionScale
@@ -121,45 +151,75 @@ func (s *Server) updatePlayerPhysics(p *game.Player, i int) {
 
 	// Update position
 	if p.Speed > 0 {
-		// Convert speed to game units per tick
+		// Convert speed to game units per frame
 		// NOTE: Original Netrek uses WARP1=60, but we use 20 to maintain game balance
 		// This difference is compensated by scaling factors elsewhere
-		unitsPerTick := p.Speed * 20.0
-		p.X += unitsPerTick * math.Cos(p.Dir)
-		p.Y += unitsPerTick * math.Sin(p.Dir)
-
-		// Bounce off galaxy edges
-		bounced := false
-		if p.X < 0 {
-			p.X = 0
-			// Reverse X component of direction (bounce off left wall)
-			p.Dir = math.Pi - p.Dir
-			bounced = true
-		} else if p.X > game.GalaxyWidth {
-			p.X = game.GalaxyWidth
-			// Reverse X component of direction (bounce off right wall)
-			p.Dir = math.Pi - p.Dir
-			bounced = true
-		}
-		if p.Y < 0 {
-			p.Y = 0
-			// Reverse Y component of direction (bounce off top wall)
-			p.Dir = -p.Dir
-			bounced = true
-		} else if p.Y > game.GalaxyHeight {
-			p.Y = game.GalaxyHeight
-			// Reverse Y component of direction (bounce off bottom wall)
-			p.Dir = -p.Dir
-			bounced = true
+		unitsPerFrame := p.Speed * 20.0
+		// A frame's travel is spread over its ticks (see ticksPerFrame)
+		s.moveShip(p, unitsPerFrame/float64(s.ticksPerFrame()))
+	}
+}
+
+// moveShip carries p dist units along its heading, then applies the galaxy
+// edge rule.
+func (s *Server) moveShip(p *game.Player, dist float64) {
+	p.X += dist * math.Cos(p.Dir)
+	p.Y += dist * math.Sin(p.Dir)
+
+	switch s.config().GalaxyEdge {
+	case EdgeWrap:
+		// Toroidal galaxy: leave by one edge, reappear at the opposite one
+		// with heading and speed unchanged
+		p.X, p.Y = wrapToGalaxy(p.X, p.Y)
+		return
+	case EdgeKill:
+		// The edge is deadly: the ship is destroyed where it touched it
+		if p.X < 0 || p.X > game.GalaxyWidth || p.Y < 0 || p.Y > game.GalaxyHeight {
+			p.X = math.Max(0, math.Min(p.X, game.GalaxyWidth))
+			p.Y = math.Max(0, math.Min(p.Y, game.GalaxyHeight))
+			s.killPlayer(p, -1, game.KillEdge, 0)
+			s.tryBroadcast(ServerMessage{
+				Type: MsgTypeMessage,
+				Data: map[string]interface{}{
+					"text": fmt.Sprintf("%s strayed beyond the galaxy edge", formatPlayerName(p)),
+					"type": "kill",
+				},
+			})
 		}
-		if bounced {
-			// Normalize direction to [0, 2*PI]
-			p.Dir = math.Mod(p.Dir, 2*math.Pi)
-			if p.Dir < 0 {
-				p.Dir += 2 * math.Pi
-			}
-			p.DesDir = p.Dir // Update desired direction to match bounced direction
+		return
+	}
+
+	// Bounce off galaxy edges
+	bounced := false
+	if p.X < 0 {
+		p.X = 0
+		// Reverse X component of direction (bounce off left wall)
+		p.Dir = math.Pi - p.Dir
+		bounced = true
+	} else if p.X > game.GalaxyWidth {
+		p.X = game.GalaxyWidth
+		// Reverse X component of direction (bounce off right wall)
+		p.Dir = math.Pi - p.Dir
+		bounced = true
+	}
+	if p.Y < 0 {
+		p.Y = 0
+		// Reverse Y component of direction (bounce off top wall)
+		p.Dir = -p.Dir
+		bounced = true
+	} else if p.Y > game.GalaxyHeight {
+		p.Y = game.GalaxyHeight
+		// Reverse Y component of direction (bounce off bottom wall)
+		p.Dir = -p.Dir
+		bounced = true
+	}
+	if bounced {
+		// Normalize direction to [0, 2*PI]
+		p.Dir = math.Mod(p.Dir, 2*math.Pi)
+		if p.Dir < 0 {
+			p.Dir += 2 * math.Pi
 		}
+		p.DesDir = p.Dir // Update desired direction to match bounced direction
 	}
 }
 
@@ -211,9 +271,12 @@ func (s *Server) updatePlayerLockOn(p *game.Player) {
 			targetY = planet.Y
 			validTarget = true
 
-			// Auto-orbit when close to locked planet (same distance as manual orbit)
-			dist := game.Distance(p.X, p.Y, planet.X, planet.Y)
-			if dist < float64(game.EntOrbitDist) && p.Speed <= float64(game.ORBSPEED) {
+			// Auto-orbit when close to locked planet (same distance as manual
+			// orbit). Under the shields-down rule the ship holds at orbit
+			// speed until its shields drop.
+			dist := s.distance(p.X, p.Y, planet.X, planet.Y)
+			shieldsBlock := p.Shields_up && s.config().ShieldsDownToOrbit
+			if dist < float64(game.EntOrbitDist) && p.Speed <= float64(game.ORBSPEED) && !shieldsBlock {
 				// Close enough and slow enough to orbit
 				p.Orbiting = p.LockTarget
 				p.Speed = 0
@@ -243,12 +306,21 @@ func (s *Server) updatePlayerLockOn(p *game.Player) {
 				p.DesSpeed = math.Max(orbSpeed, math.Min(maxSpeed, p.DesSpeed))
 			}
 		}
+	} else if p.LockType == "player" {
+		// Ship locks drop when the target dies, cloaks or starts jamming
+		if p.LockTarget < game.MaxPlayers {
+			target := s.gameState.Players[p.LockTarget]
+			if target.Status == game.StatusAlive && !(target.Cloaked && target.Team != p.Team) && !s.jamsLock(p, target) {
+				targetX = target.X
+				targetY = target.Y
+				validTarget = true
+			}
+		}
 	}
 
 	if validTarget && p.Orbiting < 0 {
 		// Update desired course toward target (ship will turn at normal rate)
-		dx := targetX - p.X
-		dy := targetY - p.Y
+		dx, dy := s.delta(p.X, p.Y, targetX, targetY)
 		targetDir := math.Atan2(dy, dx)
 
 		// Calculate angle difference to target
@@ -306,7 +378,7 @@ func (s *Server) updateTractorBeams() {
 			if targetID >= 0 && targetID < game.MaxPlayers {
 				target := s.gameState.Players[targetID]
 				if target.Status == game.StatusAlive {
-					dist := game.Distance(p.X, p.Y, target.X, target.Y)
+					dist := s.distance(p.X, p.Y, target.X, target.Y)
 
 					// Get ship stats for range check
 					shipStats := game.ShipData[p.Ship]
@@ -321,8 +393,7 @@ func (s *Server) updateTractorBeams() {
 						targetStats := game.ShipData[target.Ship]
 
 						// Calculate normalized direction vector (cosTheta, sinTheta in original)
-						dx := target.X - p.X
-						dy := target.Y - p.Y
+						dx, dy := s.delta(p.X, p.Y, target.X, target.Y)
 						if dist == 0 {
 							dist = 1 // prevent divide by zero
 						}
@@ -347,17 +418,15 @@ func (s *Server) updateTractorBeams() {
 						target.X -= dir * cosTheta * halfforce / float64(targetStats.Mass)
 						target.Y -= dir * sinTheta * halfforce / float64(targetStats.Mass)
 
-						// Clamp positions to galaxy bounds
-						p.X = math.Max(0, math.Min(game.GalaxyWidth, p.X))
-						p.Y = math.Max(0, math.Min(game.GalaxyHeight, p.Y))
-						target.X = math.Max(0, math.Min(game.GalaxyWidth, target.X))
-						target.Y = math.Max(0, math.Min(game.GalaxyHeight, target.Y))
+						// Keep both ships inside the galaxy
+						p.X, p.Y = s.confineToGalaxy(p.X, p.Y)
+						target.X, target.Y = s.confineToGalaxy(target.X, target.Y)
 
 						// Break orbit immediately if target is orbiting (from original code)
 						if target.Orbiting >= 0 {
 							target.Orbiting = -1
 							target.Bombing = false // Stop bombing if forced out of orbit
-							target.Beaming = false // Stop beaming too
+							stopBeaming(target)    // Stop beaming too
 							// Send message about breaking orbit
 							s.broadcastInfo(fmt.Sprintf("%s was pulled out of orbit", formatPlayerName(target)))
 						}
@@ -414,8 +483,8 @@ func (s *Server) updateAlertLevels() {
 			}
 
 			// Calculate distance
-			dx := math.Abs(p.X - enemy.X)
-			dy := math.Abs(p.Y - enemy.Y)
+			dx, dy := s.delta(p.X, p.Y, enemy.X, enemy.Y)
+			dx, dy = math.Abs(dx), math.Abs(dy)
 
 			// Quick range check
 			if dx > game.AlertYellowRange || dy > game.AlertYellowRange {
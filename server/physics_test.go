@@ -122,6 +122,67 @@ func TestPlayerTurning(t *testing.T) {
 	}
 }
 
+// TestTurnRateScaleSpeedsUpTurning verifies that doubling the turn-rate
+// scale brings a ship onto its desired heading in fewer ticks.
+func TestTurnRateScaleSpeedsUpTurning(t *testing.T) {
+	ticksToTurn := func(scale float64) int {
+		cfg := DefaultConfig()
+		cfg.TurnRateScale = scale
+		gs := game.NewGameState()
+		server := &Server{gameState: gs, cfg: &cfg}
+
+		p := gs.Players[0]
+		p.Status = game.StatusAlive
+		p.Ship = game.ShipCruiser
+		p.Dir = 0
+		p.DesDir = math.Pi / 2
+		p.Speed = 4
+		p.DesSpeed = 4
+		p.X, p.Y = 50000, 50000
+
+		for tick := 1; tick <= 500; tick++ {
+			server.updatePlayerPhysics(p, 0)
+			if p.Dir == p.DesDir {
+				return tick
+			}
+		}
+		t.Fatalf("ship never finished its turn at scale %.1f", scale)
+		return 0
+	}
+
+	normal := ticksToTurn(1.0)
+	fast := ticksToTurn(2.0)
+	if fast >= normal {
+		t.Errorf("2x turn rate took %d ticks, want fewer than the %d at 1x", fast, normal)
+	}
+}
+
+// TestTurnRateScaleAppliedBeforeShift verifies that the turn-rate scale is
+// applied to the full turn rate, so a starbase at speed whose shifted rate
+// truncates to one unit still turns faster at a scale below two.
+func TestTurnRateScaleAppliedBeforeShift(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TurnRateScale = 1.9
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, cfg: &cfg}
+
+	p := gs.Players[0]
+	p.Status = game.StatusAlive
+	p.Ship = game.ShipStarbase
+	p.Dir = 0
+	p.DesDir = math.Pi / 2
+	p.Speed = 15
+	p.DesSpeed = 15
+	p.X, p.Y = 50000, 50000
+
+	server.updatePlayerPhysics(p, 0)
+
+	// 50000 * 1.9 / 2^15 = 2.9, where scaling the shifted rate of 1 gave 1
+	if p.SubDir != 2 {
+		t.Errorf("SubDir after one tick = %d, want 2", p.SubDir)
+	}
+}
+
 // TestSpeedAcceleration tests the acceleration mechanics with fractional accumulator
 func TestSpeedAcceleration(t *testing.T) {
 	tests := []struct {
@@ -208,6 +269,45 @@ func TestSpeedAcceleration(t *testing.T) {
 	}
 }
 
+// TestWarpUpSlowsHeavyShips tests that the optional warp-up stall makes a
+// battleship take longer to reach max warp while lighter ships are unaffected
+func TestWarpUpSlowsHeavyShips(t *testing.T) {
+	ticksToMax := func(ship game.ShipType, warpUp bool) int {
+		cfg := DefaultConfig()
+		cfg.WarpUp = warpUp
+		gs := game.NewGameState()
+		server := &Server{gameState: gs, cfg: &cfg}
+
+		p := gs.Players[0]
+		p.Status = game.StatusAlive
+		p.Ship = ship
+		p.X = 50000
+		p.Y = 50000
+		maxSpeed := float64(game.ShipData[ship].MaxSpeed)
+		p.DesSpeed = maxSpeed
+
+		for tick := 1; tick <= 1000; tick++ {
+			server.updatePlayerPhysics(p, 0)
+			if p.Speed >= maxSpeed {
+				return tick
+			}
+			p.X, p.Y = 50000, 50000 // Stay clear of the galaxy edges
+		}
+		t.Fatalf("%v never reached max speed", ship)
+		return 0
+	}
+
+	normal := ticksToMax(game.ShipBattleship, false)
+	stalled := ticksToMax(game.ShipBattleship, true)
+	if stalled <= normal {
+		t.Errorf("Battleship with warp-up took %d ticks to max warp, want more than %d", stalled, normal)
+	}
+
+	if normal, stalled := ticksToMax(game.ShipCruiser, false), ticksToMax(game.ShipCruiser, true); stalled != normal {
+		t.Errorf("Cruiser should be unaffected by warp-up: %d ticks vs %d", stalled, normal)
+	}
+}
+
 // TestSpeedWithDamage tests that damaged ships have reduced max speed
 func TestSpeedWithDamage(t *testing.T) {
 	gs := game.NewGameState()
@@ -249,6 +349,57 @@ func TestSpeedWithDamage(t *testing.T) {
 	}
 }
 
+// TestDamageDeceleratesToReducedCap verifies that a ship cruising at full
+// speed when damage lowers its cap brakes down to the new cap over the
+// following ticks, faster with a higher DamageDecelScale, and never below it.
+func TestDamageDeceleratesToReducedCap(t *testing.T) {
+	ticksToCap := func(scale float64) int {
+		cfg := DefaultConfig()
+		cfg.DamageDecelScale = scale
+		server := &Server{gameState: game.NewGameState(), cfg: &cfg}
+
+		p := server.gameState.Players[0]
+		p.Status = game.StatusAlive
+		p.Ship = game.ShipDestroyer
+		p.Speed = 10
+		p.DesSpeed = 10
+		p.X, p.Y = 50000, 50000
+
+		// Take 50% damage at top speed: cap drops to about (10+2) - (10+1)*0.5 = 6.5
+		p.Damage = game.ShipData[p.Ship].MaxDamage / 2
+		capSpeed := damagedMaxSpeed(p)
+		if math.Abs(capSpeed-6.5) > 0.1 {
+			t.Fatalf("damaged cap = %.2f, want about 6.5", capSpeed)
+		}
+
+		last := p.Speed
+		for tick := 1; tick <= 100; tick++ {
+			server.updatePlayerPhysics(p, 0)
+			if p.Speed > last {
+				t.Fatalf("scale %.1f: speed rose from %.1f to %.1f while over the cap", scale, last, p.Speed)
+			}
+			if p.Speed < capSpeed {
+				t.Fatalf("scale %.1f: speed %.1f dropped below the %.1f cap", scale, p.Speed, capSpeed)
+			}
+			last = p.Speed
+			if p.Speed == capSpeed {
+				return tick
+			}
+		}
+		t.Fatalf("scale %.1f: ship still at speed %.1f after 100 ticks, want %.1f", scale, p.Speed, capSpeed)
+		return 0
+	}
+
+	normal := ticksToCap(1.0)
+	hard := ticksToCap(3.0)
+	if normal < 2 {
+		t.Errorf("ship shed 3.5 warp in %d tick(s) at the normal rate", normal)
+	}
+	if hard >= normal {
+		t.Errorf("braking at scale 3 took %d ticks, not fewer than %d at scale 1", hard, normal)
+	}
+}
+
 // TestEngineOverheat tests that engine overheat limits actual speed to 1
 // without destroying the player's requested cruise speed, so the ship resumes
 // its desired speed once overheat clears.
@@ -471,6 +622,122 @@ func TestGalaxyEdgeBounce(t *testing.T) {
 	}
 }
 
+// TestGalaxyEdgeWrap tests that in wrap mode a ship crossing the right edge
+// reappears at the left with its heading and speed intact, and that
+// projectiles wrap the same way instead of expiring
+func TestGalaxyEdgeWrap(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.GalaxyEdge = EdgeWrap
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 10), cfg: &cfg}
+
+	p := gs.Players[0]
+	p.Status = game.StatusAlive
+	p.Ship = game.ShipDestroyer
+	p.Speed = 10
+	p.DesSpeed = 10
+	p.Dir = 0.1 // East, drifting slightly south
+	p.DesDir = 0.1
+	p.X = game.GalaxyWidth - 100
+	p.Y = 50000
+
+	server.updatePlayerPhysics(p, 0)
+
+	step := 10 * 20.0 // Warp 10 at 20 units per warp per tick
+	wantX := game.GalaxyWidth - 100 + step*math.Cos(0.1) - game.GalaxyWidth
+	if math.Abs(p.X-wantX) > 0.001 {
+		t.Errorf("X = %.1f after crossing the right edge, want %.1f near the left edge", p.X, wantX)
+	}
+	if wantY := 50000 + step*math.Sin(0.1); math.Abs(p.Y-wantY) > 0.001 {
+		t.Errorf("Y = %.1f, want %.1f", p.Y, wantY)
+	}
+	if p.Dir != 0.1 || p.Speed != 10 {
+		t.Errorf("dir %.4f and speed %.1f after wrapping, want 0.1000 and 10.0", p.Dir, p.Speed)
+	}
+
+	torp := &game.Torpedo{ID: 1, Owner: 1, X: 50000, Y: 50, Dir: -math.Pi / 2, Speed: 240, Fuse: 10, Status: game.TorpMove}
+	gs.Torps = []*game.Torpedo{torp}
+	gs.Players[1].NumTorps = 1
+	server.updateProjectiles()
+	if len(gs.Torps) != 1 || math.Abs(torp.Y-(game.GalaxyHeight-190)) > 0.001 {
+		t.Errorf("torpedo crossing the top edge: %d left at y=%.1f, want it at y=%.1f", len(gs.Torps), torp.Y, float64(game.GalaxyHeight-190))
+	}
+}
+
+// TestGalaxyEdgeWrapRangesReachAcross verifies that in wrap mode distances,
+// aim, phasers and torpedo hits all take the short way across the edge.
+func TestGalaxyEdgeWrapRangesReachAcross(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.GalaxyEdge = EdgeWrap
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 10), cfg: &cfg, playerGrid: NewSpatialGrid()}
+
+	shooter := gs.Players[0]
+	shooter.Status = game.StatusAlive
+	shooter.Team = game.TeamFed
+	shooter.Ship = game.ShipCruiser
+	shooter.X, shooter.Y = game.GalaxyWidth-500, 50000
+
+	target := gs.Players[1]
+	target.Status = game.StatusAlive
+	target.Team = game.TeamKli
+	target.Ship = game.ShipCruiser
+	target.X, target.Y = 300, 50000
+
+	if dist := server.distance(shooter.X, shooter.Y, target.X, target.Y); math.Abs(dist-800) > 0.001 {
+		t.Errorf("distance across the edge = %.1f, want 800", dist)
+	}
+	if dir := server.bearing(shooter.X, shooter.Y, target.X, target.Y); math.Abs(dir) > 0.001 {
+		t.Errorf("bearing across the edge = %.3f, want 0 (east, the short way)", dir)
+	}
+	if hit, dist, _ := server.phaserTargetInLine(shooter, 0, game.PhaserRange(game.ShipData[shooter.Ship])); hit != target || math.Abs(dist-800) > 0.001 {
+		t.Errorf("phaser east across the edge hit %v at %.1f, want the target at 800", hit, dist)
+	}
+
+	// A torpedo just short of the right edge hits the ship just past it
+	torp := &game.Torpedo{ID: 1, Owner: shooter.ID, Team: shooter.Team, X: game.GalaxyWidth - 30, Y: 50000, Dir: 0, Speed: 20, Fuse: 10, Damage: 10, Status: game.TorpMove}
+	gs.Torps = []*game.Torpedo{torp}
+	shooter.NumTorps = 1
+	server.updateProjectiles()
+	if torp.Status != game.TorpDet || target.Damage == 0 {
+		t.Errorf("torpedo beside the edge: status %d, target damage %d; want a hit across the edge", torp.Status, target.Damage)
+	}
+}
+
+// TestGalaxyEdgeKill verifies that under the kill edge rule a ship flying
+// out of the galaxy is destroyed at the edge, while one moving inside it is
+// untouched.
+func TestGalaxyEdgeKill(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.GalaxyEdge = EdgeKill
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 10), cfg: &cfg}
+
+	for i, x := range []float64{100, 5000} {
+		p := gs.Players[i]
+		p.Status = game.StatusAlive
+		p.Ship = game.ShipDestroyer
+		p.Speed = 10
+		p.DesSpeed = 10
+		p.Dir = math.Pi // West, toward the left edge
+		p.DesDir = math.Pi
+		p.X = x
+		p.Y = 50000
+		server.updatePlayerPhysics(p, i)
+	}
+
+	lost := gs.Players[0]
+	if lost.Status != game.StatusExplode || lost.WhyDead != game.KillEdge {
+		t.Errorf("ship crossing the edge: status %d, why dead %d; want exploding from KillEdge", lost.Status, lost.WhyDead)
+	}
+	if lost.X != 0 || lost.Y != 50000 {
+		t.Errorf("ship destroyed at (%.1f, %.1f), want it held at the edge (0, 50000)", lost.X, lost.Y)
+	}
+	if safe := gs.Players[1]; safe.Status != game.StatusAlive {
+		t.Errorf("ship inside the galaxy has status %d, want alive", safe.Status)
+	}
+}
+
 // TestPlayerOrbit tests orbital mechanics
 func TestPlayerOrbit(t *testing.T) {
 	gs := game.NewGameState()
@@ -643,6 +910,41 @@ func TestAutoOrbitOnLockOn(t *testing.T) {
 	}
 }
 
+// TestAutoOrbitWaitsForShieldsDown verifies that under the shields-down rule
+// a planet lock does not auto-orbit while shields are up, and does once they
+// drop.
+func TestAutoOrbitWaitsForShieldsDown(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ShieldsDownToOrbit = true
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 256), cfg: &cfg}
+
+	planet := gs.Planets[0]
+	p := gs.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipDestroyer
+	p.LockType = "planet"
+	p.LockTarget = 0
+	p.X = planet.X + float64(game.EntOrbitDist) - 100
+	p.Y = planet.Y
+	p.Speed = float64(game.ORBSPEED)
+	p.DesSpeed = float64(game.ORBSPEED)
+	p.Orbiting = -1
+	p.Shields_up = true
+
+	server.updatePlayerLockOn(p)
+	if p.Orbiting >= 0 || p.LockType != "planet" {
+		t.Fatalf("shields up: Orbiting=%d LockType=%s, want the lock kept and no orbit", p.Orbiting, p.LockType)
+	}
+
+	p.Shields_up = false
+	server.updatePlayerLockOn(p)
+	if p.Orbiting != 0 {
+		t.Errorf("shields down: Orbiting=%d, want auto-orbit of planet 0", p.Orbiting)
+	}
+}
+
 // TestLockOnSpeedAdjustment tests that ships slow down when approaching planet
 func TestLockOnSpeedAdjustment(t *testing.T) {
 	gs := game.NewGameState()
@@ -14,6 +14,44 @@ import (
 // the limit is enforced consistently regardless of how armies arrive.
 const maxPlanetArmies = 40
 
+// takeoverAlertFrames is the minimum gap between takeover alerts for the same
+// planet (15 seconds at 10 FPS).
+const takeoverAlertFrames = 150
+
+// initPlanets resets the planets to their startup layout. Planet flags come
+// from the configured map when it assigns them, otherwise from the random INL
+// distribution, and starting armies are scaled by the army multiplier. With
+// Config.NeutralStart every planet but the four home worlds starts neutral.
+func (s *Server) initPlanets() {
+	game.InitPlanets(s.gameState)
+	for _, planet := range s.gameState.Planets {
+		if planet != nil {
+			planet.Armies = min(planet.Armies*s.config().ArmyMultiplier, maxPlanetArmies)
+		}
+	}
+	if m := s.config().Map; m != nil && len(m.PlanetFlags) > 0 {
+		// Assignments were validated when the map was loaded
+		if err := game.ApplyPlanetFlags(s.gameState, m.PlanetFlags); err != nil {
+			log.Printf("Invalid map planet flags, using INL layout: %v", err)
+			game.InitINLPlanetFlags(s.gameState)
+		}
+	} else {
+		game.InitINLPlanetFlags(s.gameState)
+	}
+
+	// Neutralize after the flags are placed: map validation checks each team's
+	// starting planets for fuel and repair, which home worlds always provide.
+	// Neutral planets start empty so the first army landed takes them.
+	if s.config().NeutralStart {
+		for _, planet := range s.gameState.Planets {
+			if planet != nil && planet.Flags&game.PlanetHome == 0 {
+				planet.Owner = game.TeamNone
+				planet.Armies = 0
+			}
+		}
+	}
+}
+
 // updatePlanetInteractions handles all planet-related interactions for all players
 func (s *Server) updatePlanetInteractions() {
 	for i := 0; i < game.MaxPlayers; i++ {
@@ -53,6 +91,22 @@ func (s *Server) updateOrbitingPlayer(p *game.Player, playerIndex int) {
 	}
 	planet := s.gameState.Planets[p.Orbiting]
 
+	// Under the shields-down rule, raising shields breaks orbit
+	if p.Shields_up && s.config().ShieldsDownToOrbit {
+		p.Orbiting = -1
+		p.Bombing = false
+		stopBeaming(p)
+		s.tryBroadcast(ServerMessage{
+			Type: MsgTypeMessage,
+			Data: map[string]interface{}{
+				"text": fmt.Sprintf("Shields up: %s broke orbit of %s", formatPlayerName(p), planet.Name),
+				"type": "info",
+				"to":   playerIndex,
+			},
+		})
+		return
+	}
+
 	// Repair and refuel at friendly planets
 	if planet.Owner == p.Team && (planet.Flags&game.PlanetRepair) != 0 {
 		// Repair damage
@@ -75,11 +129,8 @@ func (s *Server) updateOrbitingPlayer(p *game.Player, playerIndex int) {
 	// This happens every 5 frames (2 times per second at 10 FPS) matching plfight()
 	if s.gameState.Frame%5 == 0 {
 		if planet.Owner != p.Team && planet.Owner != game.TeamNone && planet.Armies > 0 {
-			// Calculate damage: armies/10 + 2
-			damage := planet.Armies/10 + 2
-
 			// Apply damage to shields first, then hull
-			game.ApplyDamageWithShields(p, damage)
+			s.applyDamage(p, s.planetFireDamage(planet), game.DamageOther)
 
 			// Check if ship destroyed by planet
 			if p.Damage >= game.ShipData[p.Ship].MaxDamage {
@@ -88,7 +139,7 @@ func (s *Server) updateOrbitingPlayer(p *game.Player, playerIndex int) {
 				p.KilledBy = -1 // No player killer
 				p.WhyDead = game.KillPlanet
 				p.Bombing = false
-				p.Beaming = false
+				stopBeaming(p)
 				p.Orbiting = -1
 				// Clear lock-on when destroyed
 				p.LockType = "none"
@@ -107,6 +158,12 @@ func (s *Server) updateOrbitingPlayer(p *game.Player, playerIndex int) {
 		}
 	}
 
+	// An enemy carrier bombing or beaming at the planet is trying to take it
+	if p.Armies > 0 && (p.Bombing || (p.Beaming && !p.BeamingUp)) &&
+		planet.Owner != p.Team && planet.Owner != game.TeamNone {
+		s.alertTakeover(planet, p)
+	}
+
 	// Handle continuous bombing
 	if p.Bombing && planet.Owner != p.Team {
 		if planet.Armies > 0 {
@@ -114,10 +171,12 @@ func (s *Server) updateOrbitingPlayer(p *game.Player, playerIndex int) {
 			// plfight() is called every 0.5 seconds (2 times per second)
 			// 50% chance to bomb, then:
 			// 60% chance: 1 army, 20% chance: 2 armies, 20% chance: 3 armies
-			// This averages 1.6 armies per second
+			// This averages 1.6 armies per second, plus the ship's bomb bonus
 
-			// Only check bombing every 5 frames (2 times per second at 10 FPS)
-			if s.gameState.Frame%5 == 0 {
+			// Only check bombing every BombFrames frames (default 5: 2 times
+			// per second at 10 FPS), scaled by the ship's BombIntervalMult,
+			// for humans and bots alike
+			if s.gameState.Frame%int64(s.bombFrames(p)) == 0 {
 				// Random check (50% chance to bomb)
 				if rand.Float32() < 0.5 {
 					// Determine number of armies to bomb
@@ -131,77 +190,202 @@ func (s *Server) updateOrbitingPlayer(p *game.Player, playerIndex int) {
 						killed = 3
 					}
 
-					// Assault ships get +1 bonus (if we add assault ships later)
-					// if p.Ship == game.ShipAssault {
-					//     killed++
-					// }
+					// Ship-specific bonus (assault ships kill one extra army)
+					killed += game.ShipData[p.Ship].BombBonus
 
-					planet.Armies = max(0, planet.Armies-killed)
+					killed = min(killed, planet.Armies)
+					planet.Armies -= killed
+					if s.gameState.T_mode {
+						if stats, ok := s.gameState.TournamentStats[p.ID]; ok {
+							stats.ArmiesBombed += killed
+						}
+					}
 
 					// If planet has no armies left, it becomes neutral and stop bombing
 					if planet.Armies == 0 {
-						oldOwner := planet.Owner
-						planet.Owner = game.TeamNone
-						p.Bombing = false
-						// Send completion message
-						s.broadcastInfo(fmt.Sprintf("%s destroyed all armies on %s (now independent)", formatPlayerName(p), planet.Name))
-						// Debug log
-						log.Printf("Planet %s bombed to 0 armies, owner changed from %d to %d (TeamNone=%d)",
-							planet.Name, oldOwner, planet.Owner, game.TeamNone)
+						s.neutralizePlanet(planet, p)
 					}
 				}
 			}
 		} else {
-			// No armies left, stop bombing
+			// No armies left: an enemy-held planet still drops to
+			// independent, then bombing stops
+			if planet.Owner != game.TeamNone {
+				s.neutralizePlanet(planet, p)
+			}
 			p.Bombing = false
 		}
 	}
 
 	// Handle continuous beaming
 	if p.Beaming {
-		// Beam armies every 0.5 seconds (5 frames at 10 FPS)
-		if s.gameState.Frame%5 == 0 {
-			shipStats := game.ShipData[p.Ship]
-
-			if p.BeamingUp {
-				// Beam up mode - requires 2 kills since last death in classic Netrek
-				if planet.Owner == p.Team && planet.Armies > 1 && p.Armies < shipStats.MaxArmies && p.KillsStreak >= game.ArmyKillRequirement {
-					// Beam up 1 army at a time (leave at least 1 for defense)
-					p.Armies++
-					planet.Armies--
-				} else {
-					// Can't beam up anymore (no armies, full, or not enough kill streak), stop
-					p.Beaming = false
-					p.BeamingUp = false
-				}
-			} else {
-				// Beam down mode. Cap planet armies at maxPlanetArmies so
-				// beaming can't push a planet past the limit that natural
-				// repopulation already enforces.
-				if p.Armies > 0 && planet.Armies < maxPlanetArmies &&
-					(planet.Owner == p.Team || planet.Owner == game.TeamNone) {
-					// Beam down 1 army at a time
-					p.Armies--
-					planet.Armies++
-
-					// If beaming down to an independent planet, conquer it
-					if planet.Owner == game.TeamNone {
-						oldOwner := planet.Owner
-						planet.Owner = p.Team
-
-						log.Printf("Planet %s conquered by continuous beaming, owner changed from %d to %d",
-							planet.Name, oldOwner, planet.Owner)
-					}
-				} else {
-					// Can't beam down anymore, stop
-					p.Beaming = false
-					p.BeamingUp = false
-				}
+		// Beam every BeamFrames frames (default 5: every 0.5 seconds at
+		// 10 FPS), moving at most BeamArmies armies (default 1) per beam,
+		// for humans and bots alike
+		if s.gameState.Frame%int64(s.beamFrames(p)) == 0 {
+			for n := 0; n < s.config().BeamArmies && p.Beaming; n++ {
+				s.beamArmy(p, planet)
 			}
 		}
 	}
 }
 
+// beamArmy moves a single army between p and the planet it orbits in p's
+// beam direction, or stops the beam once no more armies can move.
+func (s *Server) beamArmy(p *game.Player, planet *game.Planet) {
+	shipStats := game.ShipData[p.Ship]
+
+	if p.BeamingUp {
+		// Beam up mode - requires 2 kills since last death in classic Netrek
+		if planet.Owner == p.Team && planet.Armies > 1 && p.Armies < shipStats.MaxArmies && p.KillsStreak >= game.ArmyKillRequirement {
+			// Beam up 1 army at a time (leave at least 1 for defense)
+			p.Armies++
+			planet.Armies--
+			countBeamedArmy(p)
+		} else {
+			// Can't beam up anymore (no armies, full, or not enough kill streak), stop
+			stopBeaming(p)
+		}
+		return
+	}
+
+	// Beam down mode. Cap planet armies at maxPlanetArmies so beaming can't
+	// push a planet past the limit that natural repopulation already enforces.
+	if p.Armies > 0 && planet.Armies < maxPlanetArmies &&
+		(planet.Owner == p.Team || planet.Owner == game.TeamNone) {
+		// Beam down 1 army at a time
+		p.Armies--
+		if planet.Owner == game.TeamNone && planet.Armies > 0 && s.config().CaptureNeedsArmies {
+			// Classic capture: each army landed kills one defender, so
+			// the planet only falls to more armies than it holds
+			planet.Armies--
+		} else {
+			planet.Armies++
+
+			// If beaming down to an independent planet, conquer it.
+			// Players are processed in slot order, so when several
+			// ships beam onto the same planet in one tick the first
+			// army to land flips ownership and earns the credit;
+			// later beamers see a friendly planet and just reinforce.
+			if planet.Owner == game.TeamNone {
+				s.capturePlanet(planet, p)
+			}
+		}
+		countBeamedArmy(p)
+	} else {
+		// Can't beam down anymore, stop
+		stopBeaming(p)
+	}
+}
+
+// bombFrames returns how many frames pass between p's bombing runs, scaled
+// by its ship's BombIntervalMult.
+func (s *Server) bombFrames(p *game.Player) int {
+	frames := s.config().BombFrames
+	if p.Ship == game.ShipStarbase {
+		frames = s.config().StarbaseBombFrames
+	}
+	if mult := game.ShipData[p.Ship].BombIntervalMult; mult > 0 {
+		frames = max(1, int(math.Round(float64(frames)*mult)))
+	}
+	return frames
+}
+
+// beamFrames returns how many frames pass between p's single-army beams.
+func (s *Server) beamFrames(p *game.Player) int {
+	if p.Ship == game.ShipStarbase {
+		return s.config().StarbaseBeamFrames
+	}
+	return s.config().BeamFrames
+}
+
+// countBeamedArmy counts one army against a limited beam request and stops
+// beaming once the requested number has moved. Continuous beams (BeamCount 0)
+// are unaffected.
+func countBeamedArmy(p *game.Player) {
+	if p.BeamCount <= 0 {
+		return
+	}
+	p.BeamCount--
+	if p.BeamCount == 0 {
+		stopBeaming(p)
+	}
+}
+
+// stopBeaming ends p's beaming along with its direction and any limited beam
+// request, so a count left over from one beam never cuts short the next.
+// Every path that stops a beam, human or bot, goes through here.
+func stopBeaming(p *game.Player) {
+	p.Beaming = false
+	p.BeamingUp = false
+	p.BeamCount = 0
+}
+
+// neutralizePlanet turns a planet bombed down to zero armies independent.
+// Bombing never captures: the bomber gets no credit and the planet only
+// changes hands when someone beams armies down onto it (see capturePlanet).
+// Must be called under gameState.Mu write lock.
+func (s *Server) neutralizePlanet(planet *game.Planet, p *game.Player) {
+	oldOwner := planet.Owner
+	planet.Owner = game.TeamNone
+	p.Bombing = false
+
+	s.broadcastInfo(fmt.Sprintf("%s destroyed all armies on %s (now independent)", formatPlayerName(p), planet.Name))
+
+	log.Printf("Planet %s bombed to 0 armies, owner changed from %d to %d (TeamNone=%d)",
+		planet.Name, oldOwner, planet.Owner, game.TeamNone)
+}
+
+// alertTakeover warns the planet's owners, privately, that attacker is trying
+// to capture it, at most once every takeoverAlertFrames per planet. Must be
+// called under gameState.Mu write lock.
+func (s *Server) alertTakeover(planet *game.Planet, attacker *game.Player) {
+	if last, ok := s.takeoverAlerts[planet.ID]; ok && s.gameState.Frame-last < takeoverAlertFrames {
+		return
+	}
+	if s.takeoverAlerts == nil {
+		s.takeoverAlerts = make(map[int]int64)
+	}
+	s.takeoverAlerts[planet.ID] = s.gameState.Frame
+
+	text := fmt.Sprintf("🚩 TAKEOVER: %s is carrying %d armies to %s (%d armies left)! Defend it!",
+		formatPlayerName(attacker), attacker.Armies, planet.Name, planet.Armies)
+	for _, defender := range s.gameState.Players {
+		if defender.Team != planet.Owner || defender.IsBot || !defender.Connected ||
+			defender.Status == game.StatusFree || defender.Status == game.StatusObserve {
+			continue
+		}
+		s.tryBroadcast(ServerMessage{
+			Type: MsgTypeMessage,
+			Data: map[string]interface{}{
+				"text": text,
+				"type": "warning",
+				"to":   defender.ID,
+			},
+		})
+	}
+}
+
+// capturePlanet transfers ownership of planet to the capturing player's team
+// and credits the capture to that player. It must be called exactly once per
+// ownership change, by the player whose beam-down crossed the threshold.
+// Must be called under gameState.Mu write lock.
+func (s *Server) capturePlanet(planet *game.Planet, p *game.Player) {
+	oldOwner := planet.Owner
+	planet.Owner = p.Team
+
+	if s.gameState.T_mode {
+		if stats, ok := s.gameState.TournamentStats[p.ID]; ok {
+			stats.PlanetsTaken++
+		}
+	}
+
+	s.broadcastInfo(fmt.Sprintf("%s captured %s", formatPlayerName(p), planet.Name))
+
+	log.Printf("Planet %s conquered by continuous beaming, owner changed from %d to %d",
+		planet.Name, oldOwner, planet.Owner)
+}
+
 // updatePlanetCombat handles planet-to-ship combat for non-orbiting ships
 func (s *Server) updatePlanetCombat(p *game.Player, playerIndex int) {
 	for _, planet := range s.gameState.Planets {
@@ -215,13 +399,10 @@ func (s *Server) updatePlanetCombat(p *game.Player, playerIndex int) {
 		}
 
 		// Check if within firing range
-		dist := game.Distance(p.X, p.Y, planet.X, planet.Y)
-		if dist <= game.PlanetFireDist {
-			// Calculate damage: armies/10 + 2
-			damage := planet.Armies/10 + 2
-
+		dist := s.distance(p.X, p.Y, planet.X, planet.Y)
+		if dist <= s.config().PlanetFireRange {
 			// Apply damage to shields first, then hull
-			game.ApplyDamageWithShields(p, damage)
+			s.applyDamage(p, s.planetFireDamage(planet), game.DamageOther)
 
 			// Check if ship destroyed by planet
 			if p.Damage >= game.ShipData[p.Ship].MaxDamage {
@@ -248,12 +429,26 @@ func (s *Server) updatePlanetCombat(p *game.Player, playerIndex int) {
 	}
 }
 
+// planetFireDamage returns the damage of one volley of planet's defensive
+// fire: armies/10 + 2, scaled by the configured planet fire scale.
+func (s *Server) planetFireDamage(planet *game.Planet) int {
+	return int(math.Round(float64(planet.Armies/10+2) * s.config().PlanetFireScale))
+}
+
 // updatePlanetArmies handles planet army repopulation
 func (s *Server) updatePlanetArmies() {
 	// Handle planet army repopulation
 	// AGRI planets generate 1 army every 5 seconds (50 frames at 10 FPS)
 	// Non-AGRI planets generate 1 army every 30 seconds (300 frames at 10 FPS)
 	// Only planets with owner (not neutral) can grow armies
+	// A double-growth event adds two armies per growth tick instead of one,
+	// and the army multiplier scales that again
+
+	growth := 1
+	if s.gameState.ActiveEvent == game.EventDoubleGrowth {
+		growth = 2
+	}
+	growth *= s.config().ArmyMultiplier
 
 	// Check AGRI planets every 5 seconds
 	if s.gameState.Frame%50 == 0 {
@@ -264,9 +459,7 @@ func (s *Server) updatePlanetArmies() {
 
 			// Check if planet is owned and has AGRI flag
 			if planet.Owner != game.TeamNone && (planet.Flags&game.PlanetAgri) != 0 {
-				if planet.Armies < maxPlanetArmies {
-					planet.Armies++
-				}
+				planet.Armies = min(planet.Armies+growth, max(planet.Armies, maxPlanetArmies))
 			}
 		}
 	}
@@ -280,9 +473,7 @@ func (s *Server) updatePlanetArmies() {
 
 			// Check if planet is owned and does NOT have AGRI flag
 			if planet.Owner != game.TeamNone && (planet.Flags&game.PlanetAgri) == 0 {
-				if planet.Armies < maxPlanetArmies {
-					planet.Armies++
-				}
+				planet.Armies = min(planet.Armies+growth, max(planet.Armies, maxPlanetArmies))
 			}
 		}
 	}
@@ -0,0 +1,750 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// TestSimultaneousBeamDownCreditsSingleCapture verifies that when two friendly
+// ships beam onto the same neutral planet in the same tick, exactly one of
+// them is credited with the capture and every beamed army is accounted for.
+func TestSimultaneousBeamDownCreditsSingleCapture(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+	gs.Frame = 5 // Frame%5==0 so continuous beaming runs
+	gs.T_mode = true
+
+	planet := gs.Planets[0]
+	planet.Owner = game.TeamNone
+	planet.Armies = 0
+
+	for i := 0; i < 2; i++ {
+		p := gs.Players[i]
+		p.Status = game.StatusAlive
+		p.Team = game.TeamFed
+		p.Ship = game.ShipAssault
+		p.Name = "Beamer"
+		p.Armies = 3
+		p.Orbiting = 0
+		p.X = planet.X
+		p.Y = planet.Y
+		p.Beaming = true
+		p.BeamingUp = false
+		gs.TournamentStats[i] = &game.TournamentPlayerStats{}
+	}
+
+	server.updatePlanetInteractions()
+
+	if planet.Owner != game.TeamFed {
+		t.Fatalf("planet owner = %d, want TeamFed", planet.Owner)
+	}
+	if planet.Armies != 2 {
+		t.Errorf("planet armies = %d, want 2 (one from each beamer)", planet.Armies)
+	}
+	if gs.Players[0].Armies != 2 || gs.Players[1].Armies != 2 {
+		t.Errorf("carried armies = %d/%d, want 2/2", gs.Players[0].Armies, gs.Players[1].Armies)
+	}
+	if got := gs.TournamentStats[0].PlanetsTaken; got != 1 {
+		t.Errorf("first beamer PlanetsTaken = %d, want 1", got)
+	}
+	if got := gs.TournamentStats[1].PlanetsTaken; got != 0 {
+		t.Errorf("second beamer PlanetsTaken = %d, want 0", got)
+	}
+
+	captures := 0
+	for len(server.broadcast) > 0 {
+		msg := <-server.broadcast
+		if data, ok := msg.Data.(map[string]interface{}); ok {
+			if text, _ := data["text"].(string); strings.Contains(text, "captured") {
+				captures++
+			}
+		}
+	}
+	if captures != 1 {
+		t.Errorf("capture announcements = %d, want 1", captures)
+	}
+
+	// A second tick must not credit another capture.
+	gs.Frame = 10
+	server.updatePlanetInteractions()
+	if gs.TournamentStats[0].PlanetsTaken+gs.TournamentStats[1].PlanetsTaken != 1 {
+		t.Errorf("total PlanetsTaken after second tick = %d, want 1",
+			gs.TournamentStats[0].PlanetsTaken+gs.TournamentStats[1].PlanetsTaken)
+	}
+	if planet.Armies != 4 {
+		t.Errorf("planet armies after second tick = %d, want 4", planet.Armies)
+	}
+}
+
+// TestDoubleGrowthEventDoublesArmyGrowth verifies that planets grow twice as
+// many armies per growth tick while a double-growth event is active.
+func TestDoubleGrowthEventDoublesArmyGrowth(t *testing.T) {
+	for _, tc := range []struct {
+		event string
+		want  int
+	}{
+		{game.EventNone, 11},
+		{game.EventDoubleGrowth, 12},
+	} {
+		gs := game.NewGameState()
+		server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+		gs.Frame = 50 // AGRI growth tick
+		gs.ActiveEvent = tc.event
+
+		planet := gs.Planets[0]
+		planet.Owner = game.TeamFed
+		planet.Flags |= game.PlanetAgri
+		planet.Armies = 10
+
+		server.updatePlanetArmies()
+
+		if planet.Armies != tc.want {
+			t.Errorf("event %q: planet armies = %d, want %d", tc.event, planet.Armies, tc.want)
+		}
+	}
+}
+
+// TestArmyMultiplierDoublesStartingArmies verifies that a double-armies game
+// starts every planet with twice the usual armies and grows them twice as
+// fast, and that larger multipliers stop at the planet army cap.
+func TestArmyMultiplierDoublesStartingArmies(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ArmyMultiplier = 2
+	server := NewServerWithConfig(cfg)
+	gs := server.gameState
+
+	for _, planet := range gs.Planets {
+		if planet.Armies != 34 {
+			t.Fatalf("%s starts with %d armies, want 34", planet.Name, planet.Armies)
+		}
+	}
+
+	planet := gs.Planets[0]
+	planet.Flags |= game.PlanetAgri
+	planet.Armies = 10
+	gs.Frame = 50 // AGRI growth tick
+	server.updatePlanetArmies()
+	if planet.Armies != 12 {
+		t.Errorf("planet armies after a growth tick = %d, want 12", planet.Armies)
+	}
+
+	// Larger multipliers stop at the planet army cap
+	cfg.ArmyMultiplier = 3
+	server = NewServerWithConfig(cfg)
+	for _, planet := range server.gameState.Planets {
+		if planet.Armies != maxPlanetArmies {
+			t.Fatalf("%s starts with %d armies under a 3x multiplier, want the cap of %d", planet.Name, planet.Armies, maxPlanetArmies)
+		}
+	}
+}
+
+// TestNeutralStartOwnsOnlyHomeWorlds verifies that a neutral-zone game
+// starts with each team owning just its home world, that capturing a
+// neutral planet does not hand over a garrison, and that players still
+// respawn at home.
+func TestNeutralStartOwnsOnlyHomeWorlds(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.NeutralStart = true
+	server := NewServerWithConfig(cfg)
+	gs := server.gameState
+
+	homes := 0
+	for _, planet := range gs.Planets {
+		isHome := planet.Flags&game.PlanetHome != 0
+		if isHome {
+			homes++
+		}
+		if isHome && planet.Owner == game.TeamNone {
+			t.Errorf("home world %s starts neutral", planet.Name)
+		}
+		if !isHome && planet.Owner != game.TeamNone {
+			t.Errorf("%s starts owned by team %d, want neutral", planet.Name, planet.Owner)
+		}
+	}
+	if homes != 4 {
+		t.Fatalf("found %d home worlds, want 4", homes)
+	}
+
+	neutral := gs.Planets[1]
+	carrier := gs.Players[1]
+	carrier.Status = game.StatusAlive
+	carrier.Team = game.TeamFed
+	carrier.Ship = game.ShipAssault
+	carrier.Armies = 1
+	carrier.Orbiting = neutral.ID
+	carrier.X, carrier.Y = neutral.X, neutral.Y
+	carrier.Beaming = true
+	gs.Frame = 5 // A beam tick, clear of army growth
+	server.updatePlanetInteractions()
+	if neutral.Owner != game.TeamFed || neutral.Armies != 1 {
+		t.Errorf("after landing one army %s is owned by %d with %d armies, want Fed with 1",
+			neutral.Name, neutral.Owner, neutral.Armies)
+	}
+
+	p := gs.Players[0]
+	p.Team = game.TeamKli
+	p.Ship = game.ShipCruiser
+	p.Status = game.StatusDead
+	server.respawnPlayer(p)
+	homeX, homeY := server.teamHome(game.TeamKli)
+	if p.Status != game.StatusAlive || game.Distance(p.X, p.Y, homeX, homeY) > 10000 {
+		t.Errorf("respawned at (%.0f, %.0f) with status %d, want alive near home", p.X, p.Y, p.Status)
+	}
+}
+
+// TestGameEventSchedulerStartsAndEnds verifies that the scheduler starts an
+// event on the configured interval and clears it once its duration elapses.
+func TestGameEventSchedulerStartsAndEnds(t *testing.T) {
+	gs := game.NewGameState()
+	cfg := DefaultConfig()
+	cfg.EventInterval = 30
+	cfg.EventDuration = 10
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+	gs.Frame = int64(30 * game.FPS)
+	server.updateGameEvents()
+	if gs.ActiveEvent == game.EventNone {
+		t.Fatal("expected an event to start on the interval frame")
+	}
+	if want := gs.Frame + int64(10*game.FPS); gs.EventEndFrame != want {
+		t.Errorf("EventEndFrame = %d, want %d", gs.EventEndFrame, want)
+	}
+
+	gs.Frame = gs.EventEndFrame
+	server.updateGameEvents()
+	if gs.ActiveEvent != game.EventNone {
+		t.Errorf("event %q still active after its duration", gs.ActiveEvent)
+	}
+}
+
+// TestAssaultShipBombsFasterThanScout verifies that the ship-specific bomb
+// bonus and interval let an assault ship remove more armies than a scout over
+// the same number of ticks.
+func TestAssaultShipBombsFasterThanScout(t *testing.T) {
+	const ticks = 2000 // 400 bombing checks, enough to swamp the randomness
+
+	bombed := func(ship game.ShipType) int {
+		gs := game.NewGameState()
+		server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+		// An independent planet so it doesn't shoot back at the bomber
+		planet := gs.Planets[0]
+		planet.Owner = game.TeamNone
+		planet.Armies = 100000
+
+		p := gs.Players[0]
+		p.Status = game.StatusAlive
+		p.Team = game.TeamFed
+		p.Ship = ship
+		p.Orbiting = 0
+		p.X = planet.X
+		p.Y = planet.Y
+		p.Bombing = true
+
+		for frame := 1; frame <= ticks; frame++ {
+			gs.Frame = int64(frame)
+			server.updateOrbitingPlayer(p, 0)
+		}
+		return 100000 - planet.Armies
+	}
+
+	scout := bombed(game.ShipScout)
+	assault := bombed(game.ShipAssault)
+	if assault <= scout {
+		t.Errorf("assault ship bombed %d armies, scout %d; assault should bomb more", assault, scout)
+	}
+}
+
+// TestBombIntervalScalesPerShip verifies that BombIntervalMult shortens the
+// assault ship's interval between bombing runs while other ships keep the
+// configured BombFrames.
+func TestBombIntervalScalesPerShip(t *testing.T) {
+	server := &Server{gameState: game.NewGameState()}
+	cfg := server.config()
+
+	scout := &game.Player{Ship: game.ShipScout}
+	if got := server.bombFrames(scout); got != cfg.BombFrames {
+		t.Errorf("scout bombs every %d frames, want %d", got, cfg.BombFrames)
+	}
+	assault := &game.Player{Ship: game.ShipAssault}
+	if got := server.bombFrames(assault); got >= cfg.BombFrames {
+		t.Errorf("assault ship bombs every %d frames, want fewer than %d", got, cfg.BombFrames)
+	}
+}
+
+// TestStarbaseBombsAtSlowerRate verifies that a starbase only bombs on its own
+// configured frames, kills fewer armies than a cruiser over the same time, and
+// has what it kills credited in the tournament stats.
+func TestStarbaseBombsAtSlowerRate(t *testing.T) {
+	const ticks = 4000 // 800 cruiser checks, 400 starbase checks
+
+	cfg := DefaultConfig()
+	cfg.BombFrames = 5
+	cfg.StarbaseBombFrames = 10
+
+	bombed := func(ship game.ShipType) (int, *game.TournamentPlayerStats) {
+		gs := game.NewGameState()
+		gs.T_mode = true
+		server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+		// An independent planet so it doesn't shoot back at the bomber
+		planet := gs.Planets[0]
+		planet.Owner = game.TeamNone
+		planet.Armies = 100000
+
+		p := gs.Players[0]
+		p.Status = game.StatusAlive
+		p.Team = game.TeamFed
+		p.Ship = ship
+		p.Orbiting = 0
+		p.X = planet.X
+		p.Y = planet.Y
+		p.Bombing = true
+		stats := &game.TournamentPlayerStats{}
+		gs.TournamentStats[p.ID] = stats
+
+		for frame := 1; frame <= ticks; frame++ {
+			gs.Frame = int64(frame)
+			before := planet.Armies
+			server.updateOrbitingPlayer(p, 0)
+			if planet.Armies != before && frame%server.bombFrames(p) != 0 {
+				t.Fatalf("%v bombed on frame %d, want only every %d frames", ship, frame, server.bombFrames(p))
+			}
+		}
+		return 100000 - planet.Armies, stats
+	}
+
+	cruiser, _ := bombed(game.ShipCruiser)
+	starbase, stats := bombed(game.ShipStarbase)
+	// Half as many checks should kill about half as many armies
+	if starbase*3 >= cruiser*2 {
+		t.Errorf("starbase bombed %d armies, cruiser %d; starbase should bomb about half as many", starbase, cruiser)
+	}
+	if stats.ArmiesBombed != starbase {
+		t.Errorf("ArmiesBombed = %d, want %d", stats.ArmiesBombed, starbase)
+	}
+}
+
+func TestBombingToZeroLeavesPlanetNeutral(t *testing.T) {
+	gs := game.NewGameState()
+	gs.T_mode = true
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+	planet := gs.Planets[0]
+	planet.Owner = game.TeamRom
+	planet.Armies = 1
+
+	p := gs.Players[0]
+	p.ID = 0
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipAssault // Bomb bonus guarantees a hit clears 1 army
+	p.Orbiting = 0
+	p.X = planet.X
+	p.Y = planet.Y
+	p.Bombing = true
+	p.Shields_up = true
+	gs.TournamentStats[p.ID] = &game.TournamentPlayerStats{}
+
+	for frame := 5; planet.Armies > 0 && frame <= 500; frame += 5 {
+		gs.Frame = int64(frame)
+		server.updateOrbitingPlayer(p, 0)
+	}
+
+	if planet.Armies != 0 {
+		t.Fatalf("planet still has %d armies after bombing", planet.Armies)
+	}
+	if planet.Owner != game.TeamNone {
+		t.Errorf("bombed-out planet owner = %d, want independent (%d)", planet.Owner, game.TeamNone)
+	}
+	if p.Bombing {
+		t.Error("bombing should stop once the planet is independent")
+	}
+	if taken := gs.TournamentStats[p.ID].PlanetsTaken; taken != 0 {
+		t.Errorf("bomber credited with %d planets, want 0", taken)
+	}
+}
+
+func TestBombingEmptyEnemyPlanetNeutralizesIt(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+	planet := gs.Planets[0]
+	planet.Owner = game.TeamRom
+	planet.Armies = 0
+
+	p := gs.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipCruiser
+	p.Orbiting = 0
+	p.X = planet.X
+	p.Y = planet.Y
+	p.Bombing = true
+
+	gs.Frame = 5
+	server.updateOrbitingPlayer(p, 0)
+
+	if planet.Owner != game.TeamNone {
+		t.Errorf("empty enemy planet owner = %d, want independent (%d)", planet.Owner, game.TeamNone)
+	}
+}
+
+// TestBeamDownCountTransfersExactArmies verifies that a counted beam-down
+// drops exactly the requested armies and keeps the rest aboard, and that the
+// count is limited by what the ship carries.
+func TestBeamDownCountTransfersExactArmies(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+	client := &Client{ID: 1, server: server, send: make(chan ServerMessage, 10)}
+	client.SetPlayerID(0)
+
+	planet := gs.Planets[0]
+	planet.Owner = game.TeamNone
+	planet.Armies = 0
+
+	p := gs.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipAssault
+	p.Orbiting = 0
+	p.X, p.Y = planet.X, planet.Y
+	p.Armies = 6
+
+	beam := func() {
+		for frame := 5; p.Beaming && frame <= 500; frame += 5 {
+			gs.Frame = int64(frame)
+			server.updateOrbitingPlayer(p, 0)
+		}
+	}
+
+	client.startBeam(false, 4)
+	beam()
+	if planet.Armies != 4 || p.Armies != 2 {
+		t.Fatalf("after beaming 4: planet %d, ship %d armies; want 4 and 2", planet.Armies, p.Armies)
+	}
+	if planet.Owner != game.TeamFed {
+		t.Errorf("planet owner = %d, want captured by Fed", planet.Owner)
+	}
+
+	// Asking for more than is carried drops only what is aboard
+	client.startBeam(false, 10)
+	beam()
+	if planet.Armies != 6 || p.Armies != 0 {
+		t.Errorf("after beaming 10 of 2: planet %d, ship %d armies; want 6 and 0", planet.Armies, p.Armies)
+	}
+}
+
+// TestBeamCountClearedWhenBeamStops verifies that a limited beam request
+// interrupted by leaving orbit leaves no count behind to cut short a later
+// beam, such as a bot's after it takes over the ship.
+func TestBeamCountClearedWhenBeamStops(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+	client := &Client{ID: 1, server: server, send: make(chan ServerMessage, 10)}
+	client.SetPlayerID(0)
+
+	planet := gs.Planets[0]
+	planet.Owner = game.TeamFed
+	planet.Armies = 4
+
+	p := gs.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipAssault
+	p.Orbiting = 0
+	p.X, p.Y = planet.X, planet.Y
+	p.Armies = 6
+
+	client.startBeam(false, 4)
+	gs.Frame = 5
+	server.updateOrbitingPlayer(p, 0)
+	if p.BeamCount != 3 {
+		t.Fatalf("after one army of four, BeamCount = %d, want 3", p.BeamCount)
+	}
+
+	server.leaveOrbitForLock(p)
+	if p.Beaming || p.BeamCount != 0 {
+		t.Errorf("after leaving orbit Beaming = %v, BeamCount = %d; want false and 0", p.Beaming, p.BeamCount)
+	}
+}
+
+// TestBeamCountSetOnlyWhenBeamStarts verifies that a limited request that
+// cannot start a beam leaves no count behind, and that a beam-down onto an
+// unowned planet is limited only by the armies aboard.
+func TestBeamCountSetOnlyWhenBeamStarts(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+	client := &Client{ID: 1, server: server, send: make(chan ServerMessage, 10)}
+	client.SetPlayerID(0)
+
+	planet := gs.Planets[0]
+	planet.Owner = game.TeamFed
+	planet.Armies = 10
+
+	p := gs.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipAssault
+	p.Orbiting = 0
+	p.X, p.Y = planet.X, planet.Y
+	p.Armies = 6
+	p.KillsStreak = 0
+
+	// Without the kills to pick up armies the beam never starts
+	client.startBeam(true, 3)
+	if p.Beaming || p.BeamCount != 0 {
+		t.Errorf("refused beam-up left Beaming = %v, BeamCount = %d; want false and 0", p.Beaming, p.BeamCount)
+	}
+
+	planet.Owner = game.TeamNone
+	planet.Armies = maxPlanetArmies - 2
+	client.startBeam(false, 4)
+	if !p.Beaming || p.BeamCount != 4 {
+		t.Errorf("beam-down onto unowned planet: Beaming = %v, BeamCount = %d; want true and 4", p.Beaming, p.BeamCount)
+	}
+}
+
+// TestBotAndHumanBeamAtSameRate verifies that a bot and a human beaming up
+// side by side move armies at the same configured rate.
+func TestBotAndHumanBeamAtSameRate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BeamFrames = 3
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+	client := &Client{ID: 1, server: server, send: make(chan ServerMessage, 10)}
+	client.SetPlayerID(0)
+
+	for _, planet := range gs.Planets {
+		planet.Owner = game.TeamNone
+		planet.Armies = 0
+	}
+	setup := func(id int, planet *game.Planet) *game.Player {
+		planet.Owner = game.TeamFed
+		planet.Armies = 30
+		p := gs.Players[id]
+		p.Status = game.StatusAlive
+		p.Team = game.TeamFed
+		p.Ship = game.ShipAssault
+		p.Fuel = game.ShipData[game.ShipAssault].MaxFuel
+		p.Orbiting = planet.ID
+		p.X, p.Y = planet.X, planet.Y
+		p.KillsStreak = game.ArmyKillRequirement
+		p.Tractoring = -1
+		p.Pressoring = -1
+		p.BotTarget = -1
+		p.BotDefenseTarget = -1
+		p.BotPlanetApproachID = planet.ID
+		return p
+	}
+	human := setup(0, gs.Planets[0])
+	bot := setup(1, gs.Planets[1])
+	bot.IsBot = true
+	bot.Connected = true
+
+	// Bots run their planet-taking logic in tournament mode
+	gs.T_mode = true
+	client.startBeam(true, 0)
+	server.updateBotHard(bot)
+	if !bot.Beaming || !bot.BeamingUp {
+		t.Fatal("bot at a friendly army planet with kills should start beaming up")
+	}
+
+	for frame := 1; frame <= 30; frame++ {
+		gs.Frame = int64(frame)
+		server.updateOrbitingPlayer(human, 0)
+		server.updateOrbitingPlayer(bot, 1)
+	}
+	if human.Armies != 10 || bot.Armies != 10 {
+		t.Errorf("after 30 frames at one army per 3: human %d, bot %d armies; want 10 each", human.Armies, bot.Armies)
+	}
+	if want := BotBeamBatch * cfg.BeamFrames; bot.BotCooldown != want {
+		t.Errorf("bot beam cooldown = %d, want %d to match the beam rate", bot.BotCooldown, want)
+	}
+}
+
+// TestBeamArmiesLimitsCaptureRate verifies that beaming onto an independent
+// planet moves at most BeamArmies armies per beam tick, so a capture with
+// several armies takes several ticks.
+func TestBeamArmiesLimitsCaptureRate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BeamFrames = 1
+	cfg.BeamArmies = 2
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+	planet := gs.Planets[0]
+	planet.Owner = game.TeamNone
+	planet.Armies = 0
+
+	p := gs.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipAssault
+	p.Armies = 5
+	p.Orbiting = 0
+	p.X, p.Y = planet.X, planet.Y
+	p.Beaming = true
+
+	for tick, want := range []int{2, 4, 5} {
+		gs.Frame = int64(tick + 1)
+		server.updateOrbitingPlayer(p, 0)
+		if planet.Armies != want {
+			t.Fatalf("after tick %d planet has %d armies, want %d", tick+1, planet.Armies, want)
+		}
+	}
+	if planet.Owner != game.TeamFed || p.Armies != 0 {
+		t.Errorf("owner = %d, ship armies = %d; want Fed and 0", planet.Owner, p.Armies)
+	}
+}
+
+// TestCaptureNeedsArmiesOutnumberDefenders verifies the classic capture rule:
+// beaming fewer armies than an independent planet holds only whittles its
+// armies down, while an army landing on an empty planet takes it.
+func TestCaptureNeedsArmiesOutnumberDefenders(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BeamFrames = 1
+	cfg.CaptureNeedsArmies = true
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+	planet := gs.Planets[0]
+	planet.Owner = game.TeamNone
+	planet.Armies = 5
+
+	p := gs.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipAssault
+	p.Armies = 3
+	p.Orbiting = 0
+	p.X, p.Y = planet.X, planet.Y
+	p.Beaming = true
+
+	for tick := 1; tick <= 4; tick++ {
+		gs.Frame = int64(tick)
+		server.updateOrbitingPlayer(p, 0)
+	}
+	if planet.Owner != game.TeamNone || planet.Armies != 2 || p.Armies != 0 {
+		t.Fatalf("after beaming 3 armies onto 5: owner %d, planet armies %d, ship armies %d; want independent, 2 and 0",
+			planet.Owner, planet.Armies, p.Armies)
+	}
+
+	// Bombed out, the same planet falls to a single army
+	planet.Armies = 0
+	p.Armies = 1
+	p.Beaming = true
+	gs.Frame++
+	server.updateOrbitingPlayer(p, 0)
+	if planet.Owner != game.TeamFed || planet.Armies != 1 {
+		t.Errorf("beaming onto an empty planet: owner %d, armies %d; want Fed and 1", planet.Owner, planet.Armies)
+	}
+}
+
+// TestBeamDownOnFriendlyPlanetAlertsOwners verifies that an enemy carrier
+// beaming at a planet privately warns the planet's team, not anyone else, and
+// that repeated attempts on the same planet are throttled.
+func TestBeamDownOnFriendlyPlanetAlertsOwners(t *testing.T) {
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100)}
+
+	planet := gs.Planets[0]
+	planet.Owner = game.TeamFed
+	planet.Armies = 3
+
+	for i, team := range []int{game.TeamFed, game.TeamRom} {
+		p := gs.Players[i]
+		p.Status = game.StatusAlive
+		p.Connected = true
+		p.Team = team
+		p.Ship = game.ShipCruiser
+	}
+
+	attacker := gs.Players[2]
+	attacker.Status = game.StatusAlive
+	attacker.Team = game.TeamKli
+	attacker.Ship = game.ShipAssault
+	attacker.Orbiting = 0
+	attacker.X, attacker.Y = planet.X, planet.Y
+	attacker.Armies = 5
+	attacker.Beaming = true
+
+	alerts := func() []int {
+		var to []int
+		for len(server.broadcast) > 0 {
+			msg := <-server.broadcast
+			data, ok := msg.Data.(map[string]interface{})
+			if ok && strings.Contains(data["text"].(string), "TAKEOVER") {
+				if !strings.Contains(data["text"].(string), planet.Name) {
+					t.Errorf("alert %q does not name %s", data["text"], planet.Name)
+				}
+				to = append(to, data["to"].(int))
+			}
+		}
+		return to
+	}
+
+	gs.Frame = 1
+	server.updatePlanetInteractions()
+	if to := alerts(); len(to) != 1 || to[0] != 0 {
+		t.Fatalf("takeover alert sent to %v, want only the Federation player [0]", to)
+	}
+
+	attacker.Beaming = true
+	gs.Frame = 2
+	server.updatePlanetInteractions()
+	if to := alerts(); len(to) != 0 {
+		t.Errorf("repeat attempt alerted %v within the throttle window", to)
+	}
+
+	attacker.Beaming = true
+	gs.Frame = 2 + takeoverAlertFrames
+	server.updatePlanetInteractions()
+	if to := alerts(); len(to) != 1 {
+		t.Errorf("attempt after the throttle window alerted %v, want one alert", to)
+	}
+}
+
+// TestShieldsDownToOrbitBlocksBombing verifies that under the shields-down
+// rule a ship in orbit cannot start bombing with shields up, can once they
+// drop, and breaks orbit if it raises them again.
+func TestShieldsDownToOrbitBlocksBombing(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ShieldsDownToOrbit = true
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+	client := &Client{ID: 1, server: server, send: make(chan ServerMessage, 10)}
+	client.SetPlayerID(0)
+
+	planet := gs.Planets[0]
+	planet.Owner = game.TeamRom
+	planet.Armies = 10
+
+	p := gs.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipCruiser
+	p.Orbiting = planet.ID
+	p.X, p.Y = planet.X, planet.Y
+	p.Shields_up = true
+
+	client.handleBomb(nil)
+	if p.Bombing {
+		t.Fatal("ship with shields up started bombing")
+	}
+
+	p.Shields_up = false
+	client.handleBomb(nil)
+	if !p.Bombing {
+		t.Fatal("ship with shields down could not start bombing")
+	}
+
+	p.Shields_up = true
+	gs.Frame = 1
+	server.updateOrbitingPlayer(p, 0)
+	if p.Orbiting >= 0 || p.Bombing {
+		t.Errorf("raising shields left Orbiting = %d, Bombing = %v; want orbit broken", p.Orbiting, p.Bombing)
+	}
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// HandlePossess lets an admin fly a bot for a demonstration (POST
+// /api/possess). player is the admin's own player slot, which identifies
+// the game connection that takes over the bot:
+//
+//	{"player": 3, "bot": 7}
+//
+// The admin hands the bot back with /release in game.
+func (s *Server) HandlePossess(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Player int `json:"player"`
+		Bot    int `json:"bot"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Bot < 0 || req.Bot >= game.MaxPlayers {
+		http.Error(w, "Invalid bot id", http.StatusBadRequest)
+		return
+	}
+
+	var admin *Client
+	s.mu.RLock()
+	for _, client := range s.clients {
+		if req.Player >= 0 && client.GetPlayerID() == req.Player {
+			admin = client
+			break
+		}
+	}
+	s.mu.RUnlock()
+	if admin == nil {
+		http.Error(w, "No connection is flying that player", http.StatusNotFound)
+		return
+	}
+
+	if err := admin.possessBot(req.Bot); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"player": req.Bot})
+}
+
+// possessBot lets an admin fly a live bot for demonstrations: the bot's slot
+// becomes a human-controlled player owned by c, so UpdateBots skips it and
+// c's inputs drive it, until the admin releases it (or disconnects). The
+// admin's own slot is left as it was and restored on release. Callers must
+// have checked the admin token.
+func (c *Client) possessBot(botID int) error {
+	s := c.server
+	s.gameState.Mu.Lock()
+	if c.possessing {
+		s.gameState.Mu.Unlock()
+		return errors.New("already flying a bot; /release it first")
+	}
+	bot := s.gameState.Players[botID]
+	if !bot.IsBot || bot.Dummy || bot.Status != game.StatusAlive {
+		s.gameState.Mu.Unlock()
+		return fmt.Errorf("player %d is not a live bot", botID)
+	}
+	c.possessing = true
+	c.possessReturn = c.GetPlayerID()
+	bot.IsBot = false
+	bot.OwnerClientID = c.ID
+	bot.BotCooldown = 0
+	c.SetPlayerID(botID)
+	name := formatPlayerName(bot)
+	s.gameState.Mu.Unlock()
+
+	c.sendMsg(ServerMessage{Type: MsgTypeControl, Data: map[string]interface{}{"player_id": botID}})
+	s.broadcastInfo(fmt.Sprintf("🎮 An administrator has taken control of %s", name))
+	return nil
+}
+
+// releaseBot hands the bot c possessed back to the AI and returns c to its
+// own slot.
+func (c *Client) releaseBot() {
+	s := c.server
+	restored, ok := s.endPossession(c)
+	if !ok {
+		c.sendWarning("You are not flying a bot")
+		return
+	}
+	c.sendMsg(ServerMessage{Type: MsgTypeControl, Data: map[string]interface{}{"player_id": restored}})
+	s.broadcastInfo("🎮 The administrator has handed the bot back to the AI")
+}
+
+// endPossession reverts a bot possessed by c to bot control and points c
+// back at its own slot, returning that slot. ok is false if c was not
+// possessing a bot. Acquires gameState.Mu internally.
+func (s *Server) endPossession(c *Client) (restored int, ok bool) {
+	s.gameState.Mu.Lock()
+	defer s.gameState.Mu.Unlock()
+	if !c.possessing {
+		return -1, false
+	}
+
+	if id := c.GetPlayerID(); id >= 0 && id < game.MaxPlayers {
+		bot := s.gameState.Players[id]
+		if bot.OwnerClientID == c.ID && bot.Status != game.StatusFree {
+			bot.IsBot = true
+			bot.OwnerClientID = -1
+			bot.BotTarget = -1
+			bot.BotTargetLockTime = 0
+			bot.BotPlanetApproachID = -1
+			bot.BotDefenseTarget = -1
+			bot.BotCooldown = 0
+		}
+	}
+	c.possessing = false
+	c.SetPlayerID(c.possessReturn)
+	return c.possessReturn, true
+}
@@ -28,6 +28,14 @@ func (s *Server) updateTorpedoes() {
 		})
 }
 
+// galaxyTorpsFull reports whether the galaxy-wide torpedo cap leaves no room
+// for another torpedo. Refusing new fire keeps the collision loops bounded
+// under heavy bot counts without cutting short torps already in flight.
+func (s *Server) galaxyTorpsFull() bool {
+	limit := s.config().MaxGalaxyTorps
+	return limit > 0 && len(s.gameState.Torps) >= limit
+}
+
 // updatePlasmas handles plasma movement, collision detection, and cleanup
 func (s *Server) updatePlasmas() {
 	s.gameState.Plasmas = s.updateProjectileList(s.gameState.Plasmas, game.PlasmaExplosionDist, game.KillPlasma,
@@ -44,6 +52,7 @@ func (s *Server) updatePlasmas() {
 // in explosion distance, kill reason, and which per-player counter to
 // decrement (decCount floors at 0 to handle post-death expiry).
 func (s *Server) updateProjectileList(list []*game.Torpedo, explDist float64, killType int, decCount func(*game.Player)) []*game.Torpedo {
+	plasma := killType == game.KillPlasma
 	writeIdx := 0
 	for _, t := range list {
 		decOwner := func() {
@@ -57,34 +66,44 @@ func (s *Server) updateProjectileList(list []*game.Torpedo, explDist float64, ki
 		// If projectile is already exploding, remove it this frame
 		if t.Status == game.TorpDet {
 			decOwner()
+			s.recordProjectileEnd(projectileDetonated, t, plasma)
 			continue
 		}
 
 		// Move projectile before decrementing fuse so projectiles travel
 		// the full number of ticks their fuse allows (fixes off-by-one
 		// where fuse was decremented before movement, causing projectiles
-		// to travel one tick short of their configured range).
-		t.X += t.Speed * math.Cos(t.Dir)
-		t.Y += t.Speed * math.Sin(t.Dir)
+		// to travel one tick short of their configured range). Speed is per
+		// frame; the ticks before this one covered the rest of it.
+		frac := 1 / float64(s.ticksPerFrame())
+		t.X += t.Speed * frac * math.Cos(t.Dir)
+		t.Y += t.Speed * frac * math.Sin(t.Dir)
 
 		// Decrement fuse every tick (now running at 10 ticks/sec)
 		t.Fuse--
 		if t.Fuse <= 0 {
 			// Projectile expired
 			decOwner()
+			s.recordProjectileEnd(projectileExpired, t, plasma)
 			continue
 		}
 
-		// Check if projectile went out of bounds - remove it
+		// Check if projectile went out of bounds - wrap it around in a
+		// toroidal galaxy, otherwise remove it
 		if t.X < 0 || t.X > game.GalaxyWidth || t.Y < 0 || t.Y > game.GalaxyHeight {
-			decOwner()
-			continue
+			if s.config().GalaxyEdge == EdgeWrap {
+				t.X, t.Y = wrapToGalaxy(t.X, t.Y)
+			} else {
+				decOwner()
+				s.recordProjectileEnd(projectileExpired, t, plasma)
+				continue
+			}
 		}
 
 		// Check for hits using spatial grid for O(1) average lookup (falls back to O(n) if grid unavailable)
 		var nearbyPlayers []int
 		if s.playerGrid != nil {
-			nearbyPlayers = s.playerGrid.GetNearby(t.X, t.Y)
+			nearbyPlayers = s.playerGrid.GetNearby(t.X, t.Y, s.config().GalaxyEdge == EdgeWrap)
 		} else {
 			// Fallback: check all players (O(n) per projectile)
 			for i := 0; i < game.MaxPlayers; i++ {
@@ -100,14 +119,14 @@ func (s *Server) updateProjectileList(list []*game.Torpedo, explDist float64, ki
 				continue
 			}
 			// Prevent friendly fire - check if target is on same team as projectile owner
-			if t.Owner >= 0 && t.Owner < game.MaxPlayers {
+			if !s.config().FreeForAll && t.Owner >= 0 && t.Owner < game.MaxPlayers {
 				owner := s.gameState.Players[t.Owner]
 				if owner != nil && p.Team == owner.Team {
 					continue
 				}
 			}
 
-			if game.Distance(t.X, t.Y, p.X, p.Y) <= explDist {
+			if s.distance(t.X, t.Y, p.X, p.Y) <= explDist {
 				// Hit! Mark as exploding - it will be removed next frame
 				s.handleProjectileHit(t, p, killType)
 				t.Status = game.TorpDet
@@ -122,9 +141,58 @@ func (s *Server) updateProjectileList(list []*game.Torpedo, explDist float64, ki
 	return list[:writeIdx]
 }
 
+// projectileTeam returns the team a projectile fired by p carries. In
+// free-for-all mode projectiles are neutral, so they are drawn in the
+// independent color and treated as hostile by everyone; the Owner field still
+// attributes hits and kills to the shooter.
+func (s *Server) projectileTeam(p *game.Player) int {
+	if s.config().FreeForAll {
+		return game.TeamNone
+	}
+	return p.Team
+}
+
+// How a projectile left play: a fizzle when the fuse runs out or it leaves
+// the galaxy, a detonation when it hit or was detonated
+const (
+	projectileExpired   = "expire"
+	projectileDetonated = "detonate"
+)
+
+// projectileEnd records a torpedo or plasma leaving play and where, so
+// clients can draw a fizzle for an expiry and a blast for a detonation
+// instead of inferring it from the projectile disappearing.
+type projectileEnd struct {
+	Kind   string  `json:"kind"`
+	ID     int     `json:"id"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Team   int     `json:"team"`
+	Plasma bool    `json:"plasma"`
+}
+
+// recordProjectileEnd queues a projectile end event for this tick's state
+// update. Caller must hold gameState.Mu.
+func (s *Server) recordProjectileEnd(kind string, t *game.Torpedo, plasma bool) {
+	s.projectileEnds = append(s.projectileEnds, projectileEnd{
+		Kind:   kind,
+		ID:     t.ID,
+		X:      t.X,
+		Y:      t.Y,
+		Team:   t.Team,
+		Plasma: plasma,
+	})
+}
+
 // handleProjectileHit processes a torpedo or plasma hit on a player
 func (s *Server) handleProjectileHit(t *game.Torpedo, target *game.Player, killType int) {
-	actualDamage := game.ApplyDamageWithShields(target, t.Damage)
+	kind := game.DamageTorp
+	if killType == game.KillPlasma {
+		kind = game.DamagePlasma
+	}
+	damage := s.handicapDamage(s.gameState.Players[t.Owner].Team, t.Damage)
+	// The projectile arrived from where it was a tick ago
+	actualDamage := s.applyDamageFrom(target, damage, kind, t.X-math.Cos(t.Dir)*t.Speed, t.Y-math.Sin(t.Dir)*t.Speed)
 	if target.Damage >= game.ShipData[target.Ship].MaxDamage {
 		s.killPlayer(target, t.Owner, killType, actualDamage)
 	} else if s.gameState.T_mode {
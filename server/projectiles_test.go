@@ -0,0 +1,158 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// updateProjectileEnds decodes the projectile end events carried by the
+// state update queued on the broadcast channel, failing if any other
+// message was sent alongside it.
+func updateProjectileEnds(t *testing.T, s *Server) []projectileEnd {
+	t.Helper()
+	s.sendGameState()
+	if len(s.broadcast) != 1 {
+		t.Fatalf("broadcast queued %d messages, want only the state update", len(s.broadcast))
+	}
+	msg := <-s.broadcast
+	var update struct {
+		Ends []projectileEnd `json:"projectileEnds"`
+	}
+	if err := json.Unmarshal(msg.Data.(json.RawMessage), &update); err != nil {
+		t.Fatalf("decoding update: %v", err)
+	}
+	return update.Ends
+}
+
+// TestProjectileEndEvents verifies that a torpedo whose fuse runs out is
+// reported as expired at its final position in that tick's state update,
+// and one that hits a ship as detonated instead. Plasmas are flagged as such.
+func TestProjectileEndEvents(t *testing.T) {
+	t.Run("fuse expiry", func(t *testing.T) {
+		server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 100)}
+		server.gameState.Torps = []*game.Torpedo{{
+			ID: 7, Owner: 0, X: 20000, Y: 20000, Speed: 100, Fuse: 1,
+			Status: game.TorpMove, Team: game.TeamFed,
+		}}
+
+		server.updateProjectiles()
+
+		ends := updateProjectileEnds(t, server)
+		want := projectileEnd{Kind: projectileExpired, ID: 7, X: 20100, Y: 20000, Team: game.TeamFed}
+		if len(ends) != 1 || ends[0] != want {
+			t.Fatalf("ends = %+v, want [%+v]", ends, want)
+		}
+	})
+
+	t.Run("hit", func(t *testing.T) {
+		server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 100)}
+		shooter := server.gameState.Players[0]
+		shooter.Status = game.StatusAlive
+		shooter.Team = game.TeamFed
+		shooter.NumPlasma = 1
+		target := server.gameState.Players[1]
+		target.Status = game.StatusAlive
+		target.Team = game.TeamRom
+		target.Ship = game.ShipCruiser
+		target.X = 20100
+		target.Y = 20000
+
+		server.gameState.Plasmas = []*game.Plasma{{
+			ID: 3, Owner: 0, X: 20000, Y: 20000, Speed: 100, Fuse: 30, Damage: 10,
+			Status: game.TorpMove, Team: game.TeamFed,
+		}}
+
+		// Hit this frame, removed (and reported) on the next
+		server.updateProjectiles()
+		for len(server.broadcast) > 0 {
+			<-server.broadcast
+		}
+		server.updateProjectiles()
+
+		ends := updateProjectileEnds(t, server)
+		if len(ends) != 1 || ends[0].Kind != projectileDetonated || !ends[0].Plasma {
+			t.Fatalf("ends = %+v, want a single plasma %s", ends, projectileDetonated)
+		}
+	})
+}
+
+// TestFreeForAllTorpedoHitsTeammate verifies that in free-for-all mode a
+// torpedo damages and kills a same-team player, crediting the shooter.
+func TestFreeForAllTorpedoHitsTeammate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FreeForAll = true
+	server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+	shooter := server.gameState.Players[0]
+	shooter.Status = game.StatusAlive
+	shooter.Team = game.TeamFed
+	shooter.Ship = game.ShipCruiser
+	shooter.NumTorps = 1
+	teammate := server.gameState.Players[1]
+	teammate.Status = game.StatusAlive
+	teammate.Team = game.TeamFed
+	teammate.Ship = game.ShipCruiser
+	teammate.X = 20100
+	teammate.Y = 20000
+	teammate.Damage = game.ShipData[game.ShipCruiser].MaxDamage - 10
+
+	if team := server.projectileTeam(shooter); team != game.TeamNone {
+		t.Fatalf("projectile team = %d, want neutral", team)
+	}
+	server.gameState.Torps = []*game.Torpedo{{
+		ID: 1, Owner: shooter.ID, X: 20000, Y: 20000, Speed: 100, Fuse: 30, Damage: 40,
+		Status: game.TorpMove, Team: server.projectileTeam(shooter),
+	}}
+
+	server.updateProjectiles()
+
+	if teammate.Status != game.StatusExplode {
+		t.Fatalf("teammate status = %d, want exploding (damage %d)", teammate.Status, teammate.Damage)
+	}
+	if teammate.KilledBy != shooter.ID {
+		t.Errorf("KilledBy = %d, want shooter %d", teammate.KilledBy, shooter.ID)
+	}
+	if shooter.Kills != 1 {
+		t.Errorf("shooter kills = %v, want 1", shooter.Kills)
+	}
+}
+
+// TestGalaxyTorpCapRefusesFire verifies that once the galaxy holds
+// MaxGalaxyTorps live torpedoes, neither bots nor humans can add more.
+func TestGalaxyTorpCapRefusesFire(t *testing.T) {
+	const limit = 20
+	cfg := DefaultConfig()
+	cfg.MaxGalaxyTorps = limit
+	gs := game.NewGameState()
+	server := &Server{gameState: gs, broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+	// Ten bots each loose a full spread, far more than the cap allows
+	for i := 1; i <= 10; i++ {
+		bot := gs.Players[i]
+		bot.Status = game.StatusAlive
+		bot.IsBot = true
+		bot.Team = game.TeamRom
+		bot.Ship = game.ShipCruiser
+		bot.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+		bot.X, bot.Y = float64(10000*i), 50000
+		server.fireTorpedoSpreadDir(bot, 0, game.MaxTorps)
+	}
+	if len(gs.Torps) != limit {
+		t.Fatalf("bots left %d torpedoes in flight, want the cap of %d", len(gs.Torps), limit)
+	}
+
+	human := gs.Players[0]
+	human.Status = game.StatusAlive
+	human.Team = game.TeamFed
+	human.Ship = game.ShipCruiser
+	human.Fuel = game.ShipData[game.ShipCruiser].MaxFuel
+	client := &Client{ID: 1, server: server, send: make(chan ServerMessage, 10)}
+	client.SetPlayerID(0)
+
+	client.handleFire([]byte(`{"dir": 0}`))
+	if len(gs.Torps) != limit || human.NumTorps != 0 {
+		t.Errorf("human fired into a full galaxy: %d torpedoes, %d owned, want %d and 0", len(gs.Torps), human.NumTorps, limit)
+	}
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// queuedLogin is a login that arrived while every player slot was taken.
+type queuedLogin struct {
+	client *Client
+	data   json.RawMessage
+}
+
+// enqueueLogin parks c's login until a player slot frees up and tells the
+// client its place in line. A client that logs in again while queued keeps
+// its place with the new login data.
+func (s *Server) enqueueLogin(c *Client, data json.RawMessage) {
+	s.queueMu.Lock()
+	pos := -1
+	for i, q := range s.loginQueue {
+		if q.client == c {
+			q.data = data
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		s.loginQueue = append(s.loginQueue, &queuedLogin{client: c, data: data})
+		pos = len(s.loginQueue) - 1
+	}
+	s.queueMu.Unlock()
+
+	c.sendMsg(queuePositionMsg(pos + 1))
+}
+
+// dequeueLogin drops c from the login queue, e.g. when it disconnects.
+func (s *Server) dequeueLogin(c *Client) {
+	s.queueMu.Lock()
+	defer s.queueMu.Unlock()
+	for i, q := range s.loginQueue {
+		if q.client == c {
+			s.loginQueue = append(s.loginQueue[:i], s.loginQueue[i+1:]...)
+			return
+		}
+	}
+}
+
+// promoteQueued logs queued clients into player slots as they free up, in
+// queue order, then tells everyone still waiting their new position. Called
+// from the game loop with no locks held.
+func (s *Server) promoteQueued() {
+	// Holding s.mu keeps removeClient from closing a client's send channel
+	// while we log it in
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	promoted := false
+	for s.hasFreeSlot() {
+		s.queueMu.Lock()
+		if len(s.loginQueue) == 0 {
+			s.queueMu.Unlock()
+			break
+		}
+		q := s.loginQueue[0]
+		s.loginQueue = s.loginQueue[1:]
+		s.queueMu.Unlock()
+
+		if _, ok := s.clients[q.client.ID]; !ok {
+			continue
+		}
+		// handleLogin re-queues the client if the slot was taken meanwhile
+		q.client.handleLogin(q.data)
+		promoted = true
+	}
+	if !promoted {
+		return
+	}
+
+	s.queueMu.Lock()
+	defer s.queueMu.Unlock()
+	for i, q := range s.loginQueue {
+		q.client.sendMsg(queuePositionMsg(i + 1))
+	}
+}
+
+// hasFreeSlot reports whether a player slot is open for a new login.
+func (s *Server) hasFreeSlot() bool {
+	s.gameState.Mu.RLock()
+	defer s.gameState.Mu.RUnlock()
+	for _, p := range s.gameState.Players {
+		if p.Status == game.StatusFree {
+			return true
+		}
+	}
+	return false
+}
+
+// queuePositionMsg tells a queued client where it stands.
+func queuePositionMsg(pos int) ServerMessage {
+	return ServerMessage{
+		Type: MsgTypeMessage,
+		Data: map[string]interface{}{
+			"text": fmt.Sprintf("Server full, you are in queue position %d", pos),
+			"type": "info",
+		},
+	}
+}
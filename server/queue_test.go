@@ -0,0 +1,56 @@
+package server
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// TestFullServerQueuesAndPromotesLogin verifies that a login arriving while
+// every slot is taken waits in the queue and is logged in once a slot opens.
+func TestFullServerQueuesAndPromotesLogin(t *testing.T) {
+	server := NewServer()
+	teams := []int{game.TeamFed, game.TeamRom, game.TeamKli, game.TeamOri}
+	for i, p := range server.gameState.Players {
+		p.Status = game.StatusAlive
+		p.Team = teams[i%len(teams)]
+		p.Connected = true
+		p.OwnerClientID = 100 + i
+	}
+
+	client := &Client{ID: 1, server: server, send: make(chan ServerMessage, 64)}
+	client.SetPlayerID(-1)
+	server.clients[client.ID] = client
+
+	client.handleLogin(json.RawMessage(`{"name":"Waiting","team":1,"ship":2}`))
+	if pid := client.GetPlayerID(); pid != -1 {
+		t.Fatalf("login on a full server got slot %d, want to be queued", pid)
+	}
+	msg := <-client.send
+	if text, _ := msg.Data.(map[string]interface{})["text"].(string); !strings.Contains(text, "queue position 1") {
+		t.Fatalf("queued client got %+v, want its queue position", msg)
+	}
+
+	// Nothing changes while the server stays full
+	server.promoteQueued()
+	if client.GetPlayerID() != -1 {
+		t.Fatal("client promoted with no free slot")
+	}
+
+	// A Federation player leaves
+	server.gameState.Players[4].Status = game.StatusFree
+	server.gameState.Players[4].Connected = false
+	server.promoteQueued()
+
+	if pid := client.GetPlayerID(); pid != 4 {
+		t.Fatalf("queued client has slot %d after a slot opened, want 4", pid)
+	}
+	if p := server.gameState.Players[4]; p.Name != "Waiting" || p.Status != game.StatusAlive {
+		t.Errorf("promoted slot holds %q with status %d", p.Name, p.Status)
+	}
+	if len(server.loginQueue) != 0 {
+		t.Errorf("queue still holds %d logins", len(server.loginQueue))
+	}
+}
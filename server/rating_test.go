@@ -0,0 +1,107 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// TestRatingGainHigherForStrongerVictim verifies that killing a higher-rated
+// player earns more rating than killing a lower-rated one, and that bots keep
+// their fixed rating.
+func TestRatingGainHigherForStrongerVictim(t *testing.T) {
+	gainFromKill := func(victimRating float64, victimIsBot bool) (gain, victimAfter float64) {
+		cfg := DefaultConfig()
+		cfg.Ratings = true
+		s := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+		killer := s.gameState.Players[0]
+		killer.Status = game.StatusAlive
+		killer.Team = game.TeamFed
+		killer.Rating = game.InitialRating
+
+		victim := s.gameState.Players[1]
+		victim.Status = game.StatusAlive
+		victim.Team = game.TeamRom
+		victim.Rating = victimRating
+		victim.IsBot = victimIsBot
+
+		s.killPlayer(victim, killer.ID, game.KillTorp, 10)
+		return killer.Rating - game.InitialRating, victim.Rating
+	}
+
+	strong, strongAfter := gainFromKill(1800, false)
+	weak, _ := gainFromKill(1200, false)
+	if strong <= weak {
+		t.Errorf("gain for killing 1800-rated player = %.2f, want more than %.2f for 1200-rated", strong, weak)
+	}
+	if strongAfter != 1800-strong {
+		t.Errorf("victim rating = %.2f, want %.2f", strongAfter, 1800-strong)
+	}
+
+	if _, botAfter := gainFromKill(game.BotRating, true); botAfter != game.BotRating {
+		t.Errorf("bot rating changed to %.2f, want fixed %.0f", botAfter, game.BotRating)
+	}
+}
+
+// TestRatingsPersistAcrossRestart verifies that ratings recorded at a kill
+// are saved to the ratings file, loaded by a fresh store, and listed best
+// first on the leaderboard.
+func TestRatingsPersistAcrossRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratings.json")
+	cfg := DefaultConfig()
+	cfg.Ratings = true
+	cfg.RatingsFile = path
+	s := NewServerWithConfig(cfg)
+
+	killer := s.gameState.Players[0]
+	killer.Status = game.StatusAlive
+	killer.Team = game.TeamFed
+	killer.Name = "ace"
+	killer.Rating = game.InitialRating
+
+	victim := s.gameState.Players[1]
+	victim.Status = game.StatusAlive
+	victim.Team = game.TeamRom
+	victim.Name = "rookie"
+	victim.Rating = game.InitialRating
+
+	s.killPlayer(victim, killer.ID, game.KillTorp, 10)
+	if err := s.ratings.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	restarted := newRatingStore(path)
+	if got := restarted.rating("ace"); got != killer.Rating {
+		t.Errorf("reloaded rating for ace = %.2f, want %.2f", got, killer.Rating)
+	}
+	if got := restarted.rating("newcomer"); got != game.InitialRating {
+		t.Errorf("rating for unknown player = %.2f, want %.0f", got, game.InitialRating)
+	}
+
+	rec := httptest.NewRecorder()
+	s.ratings = restarted
+	s.HandleLeaderboard(rec, httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil))
+	var board []leaderboardEntry
+	if err := json.NewDecoder(rec.Body).Decode(&board); err != nil {
+		t.Fatalf("decode leaderboard: %v", err)
+	}
+	if len(board) != 2 || board[0].Name != "ace" || board[1].Name != "rookie" {
+		t.Errorf("leaderboard = %+v, want ace then rookie", board)
+	}
+}
+
+// TestLeaderboardDisabledWithoutRatings verifies that the leaderboard answers
+// 404 when ratings are off.
+func TestLeaderboardDisabledWithoutRatings(t *testing.T) {
+	s := NewServerWithConfig(DefaultConfig())
+	rec := httptest.NewRecorder()
+	s.HandleLeaderboard(rec, httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,171 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// leaderboardSize is how many players GET /api/leaderboard lists.
+const leaderboardSize = 20
+
+// ratingStore keeps each human player's rating by name so it survives a
+// logout, and saves the table to Config.RatingsFile so it survives a restart.
+type ratingStore struct {
+	mu      sync.Mutex
+	path    string             // File the table is saved to ("" keeps it in memory)
+	ratings map[string]float64 // Rating by player name
+	dirty   chan struct{}      // Signals the saver goroutine that the table changed
+}
+
+// leaderboardEntry is one row of GET /api/leaderboard.
+type leaderboardEntry struct {
+	Name   string  `json:"name"`
+	Rating float64 `json:"rating"`
+}
+
+// newRatingStore loads the rating table from path, if set. A missing file
+// starts an empty table; an unreadable one is logged and left untouched by
+// keeping the table in memory only.
+func newRatingStore(path string) *ratingStore {
+	r := &ratingStore{
+		path:    path,
+		ratings: make(map[string]float64),
+		dirty:   make(chan struct{}, 1),
+	}
+	if path == "" {
+		return r
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r
+	}
+	if err == nil {
+		err = json.Unmarshal(data, &r.ratings)
+	}
+	if err != nil {
+		log.Printf("Ratings: cannot load %s, ratings will not be saved: %v", path, err)
+		r.path = ""
+		r.ratings = make(map[string]float64)
+		return r
+	}
+	log.Printf("Ratings: loaded %d players from %s", len(r.ratings), path)
+	return r
+}
+
+// rating returns name's stored rating, or InitialRating for a new player.
+func (r *ratingStore) rating(name string) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rating, ok := r.ratings[name]; ok {
+		return rating
+	}
+	return game.InitialRating
+}
+
+// record stores name's rating and wakes the saver without blocking.
+func (r *ratingStore) record(name string, rating float64) {
+	r.mu.Lock()
+	r.ratings[name] = rating
+	r.mu.Unlock()
+	select {
+	case r.dirty <- struct{}{}:
+	default:
+		// A save is already pending and will pick this change up
+	}
+}
+
+// save writes the table to a temporary file and renames it into place, so
+// a crash mid-write never leaves a truncated table behind.
+func (r *ratingStore) save() error {
+	if r.path == "" {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.ratings, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), ".ratings-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), r.path)
+}
+
+// top returns up to n players, best rating first, ties by name.
+func (r *ratingStore) top(n int) []leaderboardEntry {
+	r.mu.Lock()
+	entries := make([]leaderboardEntry, 0, len(r.ratings))
+	for name, rating := range r.ratings {
+		entries = append(entries, leaderboardEntry{Name: name, Rating: rating})
+	}
+	r.mu.Unlock()
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Rating != entries[j].Rating {
+			return entries[i].Rating > entries[j].Rating
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries
+}
+
+// saveRatings writes the rating table each time it changes until the server
+// shuts down. Runs in its own goroutine so file I/O never stalls the game
+// loop.
+func (s *Server) saveRatings() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ratings.dirty:
+			if err := s.ratings.save(); err != nil {
+				log.Printf("Ratings: cannot save %s: %v", s.ratings.path, err)
+			}
+		}
+	}
+}
+
+// recordRatings stores the ratings of the human players among ps.
+func (s *Server) recordRatings(ps ...*game.Player) {
+	if s.ratings == nil {
+		return
+	}
+	for _, p := range ps {
+		if !p.IsBot {
+			s.ratings.record(p.Name, p.Rating)
+		}
+	}
+}
+
+// HandleLeaderboard lists the best-rated players (GET /api/leaderboard),
+// including those who are offline. Answers 404 when ratings are disabled.
+func (s *Server) HandleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if s.ratings == nil {
+		http.Error(w, "Ratings are disabled", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.ratings.top(leaderboardSize))
+}
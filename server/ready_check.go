@@ -0,0 +1,105 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// setReady records whether the client is ready for a ready-checked match,
+// broadcasts the roster, and starts the match once Config.ReadyQuorum of the
+// connected players are ready. Acquires s.mu and gameState.Mu internally.
+func (c *Client) setReady(ready bool) {
+	s := c.server
+	if !s.config().ReadyCheck {
+		c.sendMsg(ServerMessage{
+			Type: MsgTypeMessage,
+			Data: map[string]interface{}{
+				"text": "This server does not use a ready check; tournament mode starts at 4v4",
+				"type": "warning",
+			},
+		})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gameState.Mu.Lock()
+	defer s.gameState.Mu.Unlock()
+
+	if s.gameState.T_mode {
+		c.sendMsg(ServerMessage{
+			Type: MsgTypeMessage,
+			Data: map[string]interface{}{
+				"text": "The match is already under way",
+				"type": "warning",
+			},
+		})
+		return
+	}
+
+	if s.ready == nil {
+		s.ready = make(map[int]bool)
+	}
+	if ready {
+		s.ready[c.ID] = true
+	} else {
+		delete(s.ready, c.ID)
+	}
+	s.checkReadyQuorum()
+}
+
+// checkReadyQuorum broadcasts the ready roster and starts the match once
+// Config.ReadyQuorum of the connected players are ready. Caller must hold
+// s.mu and gameState.Mu.
+func (s *Server) checkReadyQuorum() {
+	readyNames, waitingNames := s.readyRoster()
+	total := len(readyNames) + len(waitingNames)
+	needed := readyNeeded(total, s.config().ReadyQuorum)
+	text := fmt.Sprintf("Ready %d/%d (%d needed)", len(readyNames), total, needed)
+	if len(waitingNames) > 0 {
+		text += " - waiting on: " + strings.Join(waitingNames, ", ")
+	}
+	s.broadcastInfo(text)
+
+	if total > 0 && len(readyNames) >= needed {
+		s.startReadyMatch()
+	}
+}
+
+// readyRoster splits the connected human players into those whose client has
+// sent /ready and those still waiting, by formatted name. Observers and
+// lobby clients are not counted. Caller must hold s.mu and gameState.Mu.
+func (s *Server) readyRoster() (readyNames, waitingNames []string) {
+	for _, client := range s.clients {
+		p := client.getPlayer()
+		if p == nil || p.IsBot || !p.Connected ||
+			p.Status == game.StatusFree || p.Status == game.StatusObserve {
+			continue
+		}
+		if s.ready[client.ID] {
+			readyNames = append(readyNames, formatPlayerName(p))
+		} else {
+			waitingNames = append(waitingNames, formatPlayerName(p))
+		}
+	}
+	return readyNames, waitingNames
+}
+
+// readyNeeded returns how many of total players must be ready to meet quorum.
+func readyNeeded(total int, quorum float64) int {
+	return max(1, int(math.Ceil(float64(total)*quorum-1e-9)))
+}
+
+// startReadyMatch begins a ready-checked match: tournament mode is forced on
+// so it holds regardless of population, the galaxy is reset and the clock
+// restarted, and readiness is cleared for the next match. Caller must hold
+// s.mu and gameState.Mu.
+func (s *Server) startReadyMatch() {
+	s.ready = nil
+	s.gameState.T_forced = true
+	s.startTournament()
+	s.broadcastInfo("⚔️ PLAYERS READY! Tournament mode active. Galaxy reset - 30 minute time limit. Fight for victory!")
+}
@@ -38,7 +38,7 @@ func (c *Client) handleRepair(data json.RawMessage) {
 		p.Shields_up = false // Lower shields
 		// Cancel any locks, beaming, bombing
 		p.Bombing = false
-		p.Beaming = false
+		stopBeaming(p)
 		p.Tractoring = -1
 		p.Pressoring = -1
 	} else if p.RepairRequest {
@@ -48,10 +48,100 @@ func (c *Client) handleRepair(data json.RawMessage) {
 		c.server.broadcastInfo(fmt.Sprintf("%s canceled repair request", formatPlayerName(p)))
 	} else {
 		// Exit repair mode
-		p.Repairing = false
+		cancelRepair(p)
 	}
 }
 
+// handleAutoRepair sets the damage percentage at which the server automatically
+// requests repairs for the player. A threshold of 0 disables auto-repair.
+func (c *Client) handleAutoRepair(data json.RawMessage) {
+	if !c.validPlayerID() {
+		return
+	}
+
+	var repairData struct {
+		Threshold int `json:"threshold"` // Damage percent (0 = off)
+	}
+	if err := json.Unmarshal(data, &repairData); err != nil {
+		log.Printf("Error unmarshaling auto-repair data: %v", err)
+		return
+	}
+	if repairData.Threshold < 0 || repairData.Threshold > 100 {
+		return
+	}
+	c.setAutoRepair(repairData.Threshold)
+}
+
+// setAutoRepair sets the player's auto-repair damage threshold (0 = off).
+// It reports whether the player had a ship to set it on.
+func (c *Client) setAutoRepair(threshold int) bool {
+	c.server.gameState.Mu.Lock()
+	defer c.server.gameState.Mu.Unlock()
+
+	p := c.getAlivePlayer()
+	if p == nil {
+		return false
+	}
+
+	p.AutoRepair = threshold
+	p.AutoRepairSet = false
+	return true
+}
+
+// handleOrbitRepair toggles orbit auto-repair, which puts the player into
+// repair mode whenever they sit idle in orbit of a friendly planet.
+func (c *Client) handleOrbitRepair(data json.RawMessage) {
+	if !c.validPlayerID() {
+		return
+	}
+
+	c.server.gameState.Mu.Lock()
+	defer c.server.gameState.Mu.Unlock()
+
+	p := c.getAlivePlayer()
+	if p == nil {
+		return
+	}
+
+	p.OrbitRepair = !p.OrbitRepair
+	p.OrbitRepairSet = false
+}
+
+// handleSpecial triggers the ship's special ability, if it has one
+func (c *Client) handleSpecial(data json.RawMessage) {
+	if !c.validPlayerID() {
+		return
+	}
+
+	c.server.gameState.Mu.Lock()
+	p := c.getAlivePlayer()
+	if p == nil {
+		c.server.gameState.Mu.Unlock()
+		return
+	}
+	reason := c.server.useSpecial(p)
+	name := game.SpecialNames[game.ShipData[p.Ship].Special]
+	c.server.gameState.Mu.Unlock()
+
+	if reason != "" {
+		c.sendMsg(ServerMessage{
+			Type: MsgTypeMessage,
+			Data: map[string]interface{}{
+				"text": reason,
+				"type": "warning",
+			},
+		})
+		return
+	}
+	c.sendMsg(ServerMessage{
+		Type: MsgTypeMessage,
+		Data: map[string]interface{}{
+			"text": name + " activated.",
+			"type": "info",
+		},
+	})
+}
+
 // handleBeam handles army beaming
 func (c *Client) handleBeam(data json.RawMessage) {
 	if !c.validPlayerID() {
@@ -64,6 +154,14 @@ func (c *Client) handleBeam(data json.RawMessage) {
 		return
 	}
 
+	c.startBeam(beamData.Up, beamData.Count)
+}
+
+// startBeam toggles army beaming up or down for the client's player. A
+// positive count limits the transfer to that many armies (clamped to what the
+// ship and planet allow); 0 beams until stopped. Repeating a counted request
+// replaces the count instead of stopping.
+func (c *Client) startBeam(up bool, count int) {
 	c.server.gameState.Mu.Lock()
 	defer c.server.gameState.Mu.Unlock()
 
@@ -75,12 +173,35 @@ func (c *Client) handleBeam(data json.RawMessage) {
 	planet := c.server.gameState.Planets[p.Orbiting]
 	shipStats := game.ShipData[p.Ship]
 
-	if beamData.Up {
+	if !p.Beaming && p.Shields_up && c.server.config().ShieldsDownToOrbit {
+		c.sendWarning("Lower your shields to beam armies")
+		return
+	}
+
+	if count > 0 {
+		switch {
+		case up:
+			count = min(count, shipStats.MaxArmies-p.Armies, planet.Armies-1)
+		case planet.Owner == p.Team:
+			count = min(count, p.Armies, maxPlanetArmies-planet.Armies)
+		default:
+			// Armies beamed onto an unowned planet fight its defenders first
+			count = min(count, p.Armies)
+		}
+		if count <= 0 {
+			return
+		}
+		if p.Beaming && p.BeamingUp == up {
+			p.BeamCount = count
+			return
+		}
+	}
+
+	if up {
 		// Toggle beam up mode or turn it off if already beaming up
 		if p.Beaming && p.BeamingUp {
 			// Already beaming up, turn it off
-			p.Beaming = false
-			p.BeamingUp = false
+			stopBeaming(p)
 		} else {
 			// Start beaming up (only if planet has armies and is friendly)
 			// Must leave at least 1 army on the planet
@@ -89,6 +210,7 @@ func (c *Client) handleBeam(data json.RawMessage) {
 				if p.KillsStreak >= game.ArmyKillRequirement {
 					p.Beaming = true
 					p.BeamingUp = true
+					p.BeamCount = max(count, 0)
 				} else {
 					// Send message about needing kills
 					errorMsg := ServerMessage{
@@ -110,13 +232,13 @@ func (c *Client) handleBeam(data json.RawMessage) {
 		// Toggle beam down mode or turn it off if already beaming down
 		if p.Beaming && !p.BeamingUp {
 			// Already beaming down, turn it off
-			p.Beaming = false
-			p.BeamingUp = false
+			stopBeaming(p)
 		} else {
 			// Start beaming down (only if we have armies and planet is friendly or independent)
 			if p.Armies > 0 && (planet.Owner == p.Team || planet.Owner == game.TeamNone) {
 				p.Beaming = true
 				p.BeamingUp = false
+				p.BeamCount = max(count, 0)
 			}
 		}
 	}
@@ -138,10 +260,16 @@ func (c *Client) handleBomb(data json.RawMessage) {
 
 	planet := c.server.gameState.Planets[p.Orbiting]
 
+	if !p.Bombing && p.Shields_up && c.server.config().ShieldsDownToOrbit {
+		c.sendWarning("Lower your shields to bomb")
+		return
+	}
+
 	// Can only bomb enemy or independent planets
 	if planet.Owner != p.Team {
-		// Don't start bombing if planet has no armies
-		if !p.Bombing && planet.Armies == 0 {
+		// Don't start bombing an independent planet with no armies. An
+		// enemy planet at zero armies can still be bombed independent.
+		if !p.Bombing && planet.Armies == 0 && planet.Owner == game.TeamNone {
 			return
 		}
 		// Toggle bombing state
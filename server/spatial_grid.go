@@ -73,14 +73,43 @@ func (g *SpatialGrid) Insert(playerID int, x, y float64) {
 }
 
 // GetNearby returns player IDs that might be within range of the given position.
-// The caller must still perform exact distance checks.
-func (g *SpatialGrid) GetNearby(x, y float64) []int {
+// The caller must still perform exact distance checks. In a wrapping galaxy
+// players just across the opposite edge are included as well.
+func (g *SpatialGrid) GetNearby(x, y float64, wrap bool) []int {
+	result := g.appendNearby(nil, x, y)
+	if !wrap {
+		return result
+	}
+
+	// Look again from the position's images beyond any edge within a cell
+	xs, ys := []float64{x}, []float64{y}
+	if x < g.cellSize {
+		xs = append(xs, x+game.GalaxyWidth)
+	} else if x > game.GalaxyWidth-g.cellSize {
+		xs = append(xs, x-game.GalaxyWidth)
+	}
+	if y < g.cellSize {
+		ys = append(ys, y+game.GalaxyHeight)
+	} else if y > game.GalaxyHeight-g.cellSize {
+		ys = append(ys, y-game.GalaxyHeight)
+	}
+	for _, ix := range xs {
+		for _, iy := range ys {
+			if ix != x || iy != y {
+				result = g.appendNearby(result, ix, iy)
+			}
+		}
+	}
+	return result
+}
+
+// appendNearby appends the players in the cell containing (x, y) and its 8
+// neighbors to result, skipping cells outside the galaxy.
+func (g *SpatialGrid) appendNearby(result []int, x, y float64) []int {
 	col := int(x / g.cellSize)
 	row := int(y / g.cellSize)
 
 	// Collect players from the current cell and all 8 adjacent cells
-	var result []int
-
 	for dr := -1; dr <= 1; dr++ {
 		for dc := -1; dc <= 1; dc++ {
 			c := col + dc
@@ -210,3 +210,64 @@ func TestStarbaseFiresAtMovingEnemyBehind(t *testing.T) {
 		t.Logf("SUCCESS: Starbase successfully fired at enemy behind it")
 	}
 }
+
+func TestStarbaseDetectRangeConfigDelaysEngagement(t *testing.T) {
+	newSetup := func(cfg Config) (*Server, *game.Player, *game.Player) {
+		gs := game.NewGameState()
+		server := &Server{
+			gameState: gs,
+			broadcast: make(chan ServerMessage, 100),
+			cfg:       &cfg,
+		}
+		// No owned planets, so planet defense can't preempt the combat check
+		for _, planet := range gs.Planets {
+			planet.Owner = game.TeamNone
+		}
+
+		starbase := gs.Players[0]
+		starbase.Status = game.StatusAlive
+		starbase.Team = game.TeamFed
+		starbase.Ship = game.ShipStarbase
+		starbase.IsBot = true
+		starbase.X = 50000
+		starbase.Y = 50000
+		starbase.Fuel = 30000
+		starbase.Orbiting = -1
+
+		enemy := gs.Players[1]
+		enemy.Status = game.StatusAlive
+		enemy.Team = game.TeamRom
+		enemy.Ship = game.ShipCruiser
+		enemy.X = 60000 // 10000 units away
+		enemy.Y = 50000
+		return server, starbase, enemy
+	}
+
+	// engaged reports whether the starbase entered defensive combat, which
+	// holds position with shields up and re-evaluates quickly.
+	engaged := func(sb *game.Player) bool {
+		return sb.Shields_up && sb.DesSpeed == 0 && sb.BotCooldown < 15
+	}
+
+	server, starbase, _ := newSetup(DefaultConfig())
+	server.updateStarbaseBot(starbase)
+	if !engaged(starbase) {
+		t.Fatalf("default detect range: expected starbase to engage enemy at 10000 (cooldown=%d)", starbase.BotCooldown)
+	}
+
+	cfg := DefaultConfig()
+	cfg.StarbaseEnemyDetectRange = 8000
+	server, starbase, enemy := newSetup(cfg)
+	server.updateStarbaseBot(starbase)
+	if engaged(starbase) {
+		t.Fatal("reduced detect range: starbase should not engage an enemy at 10000")
+	}
+
+	// Once the enemy closes inside the reduced range, the starbase engages
+	enemy.X = 57000
+	starbase.BotCooldown = 0
+	server.updateStarbaseBot(starbase)
+	if !engaged(starbase) {
+		t.Errorf("reduced detect range: expected starbase to engage enemy at 7000 (cooldown=%d)", starbase.BotCooldown)
+	}
+}
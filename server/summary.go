@@ -0,0 +1,90 @@
+package server
+
+import (
+	"sort"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// matchPlayerSummary is one player's line in the end-of-match summary.
+type matchPlayerSummary struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	Team         int           `json:"team"`
+	Ship         game.ShipType `json:"ship"`
+	IsBot        bool          `json:"isBot"`
+	Kills        float64       `json:"kills"`
+	Deaths       int           `json:"deaths"`
+	Planets      int           `json:"planets"` // Planets taken (tournament stats)
+	ArmiesBombed int           `json:"armiesBombed"`
+	DamageDealt  int           `json:"damageDealt"`
+	DamageTaken  int           `json:"damageTaken"`
+}
+
+// matchSummary is broadcast once when a match ends.
+type matchSummary struct {
+	Winner   int                  `json:"winner"`
+	WinType  string               `json:"winType"`
+	Duration int                  `json:"duration"` // Seconds since the match started
+	MVP      int                  `json:"mvp"`      // Player ID, or -1 when nobody played
+	Players  []matchPlayerSummary `json:"players"`
+}
+
+// buildMatchSummary collects the final standings from the live player stats
+// and the tournament stats. Players are listed best first: most kills, then
+// most planets taken, then most damage dealt, then lowest slot; the first is
+// the MVP. Caller must hold gameState.Mu.
+func (s *Server) buildMatchSummary() matchSummary {
+	gs := s.gameState
+	summary := matchSummary{
+		Winner:  gs.Winner,
+		WinType: gs.WinType,
+		MVP:     -1,
+		Players: []matchPlayerSummary{},
+	}
+
+	// A tournament is timed from T_start; otherwise the match began at the
+	// last reset, when the frame counter went back to zero
+	frames := gs.Frame
+	if gs.T_mode {
+		frames -= gs.T_start
+	}
+	summary.Duration = int(frames / game.FPS)
+
+	for _, p := range gs.Players {
+		if p.Status == game.StatusFree || p.Team == game.TeamNone {
+			continue
+		}
+		line := matchPlayerSummary{
+			ID:     p.ID,
+			Name:   p.Name,
+			Team:   p.Team,
+			Ship:   p.Ship,
+			IsBot:  p.IsBot,
+			Kills:  p.Kills,
+			Deaths: p.Deaths,
+		}
+		if stats, ok := gs.TournamentStats[p.ID]; ok {
+			line.Planets = stats.PlanetsTaken
+			line.ArmiesBombed = stats.ArmiesBombed
+			line.DamageDealt = stats.DamageDealt
+			line.DamageTaken = stats.DamageTaken
+		}
+		summary.Players = append(summary.Players, line)
+	}
+
+	sort.SliceStable(summary.Players, func(i, j int) bool {
+		a, b := summary.Players[i], summary.Players[j]
+		if a.Kills != b.Kills {
+			return a.Kills > b.Kills
+		}
+		if a.Planets != b.Planets {
+			return a.Planets > b.Planets
+		}
+		return a.DamageDealt > b.DamageDealt
+	})
+	if len(summary.Players) > 0 {
+		summary.MVP = summary.Players[0].ID
+	}
+	return summary
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// Teams in survival mode: humans and their bots defend a starbase against
+// waves of bots from the opposite corner of the galaxy.
+const (
+	SurvivalDefenders = game.TeamFed
+	SurvivalAttackers = game.TeamKli
+)
+
+// SurvivalFirstWave is the number of ships in the first attack wave; each
+// later wave brings one more.
+const SurvivalFirstWave = 2
+
+// SurvivalWaveRetry is how many seconds a wave waits for a free player slot
+// when every slot is taken at launch time.
+const SurvivalWaveRetry = 5
+
+// survivalTiers lists the ships attack waves are drawn from, two waves per
+// tier, so later waves bring heavier ships.
+var survivalTiers = [][]game.ShipType{
+	{game.ShipScout, game.ShipDestroyer},
+	{game.ShipDestroyer, game.ShipCruiser},
+	{game.ShipCruiser, game.ShipBattleship},
+	{game.ShipBattleship, game.ShipCruiser, game.ShipBattleship},
+}
+
+// survivalWave returns the ships and bot caution profile for attack wave
+// number wave (counting from 1). Waves grow by one ship each and turn
+// aggressive from the third.
+func survivalWave(wave int) ([]game.ShipType, string) {
+	tier := survivalTiers[min((wave-1)/2, len(survivalTiers)-1)]
+	ships := make([]game.ShipType, SurvivalFirstWave+wave-1)
+	for i := range ships {
+		ships[i] = tier[i%len(tier)]
+	}
+	caution := CautionBalanced
+	if wave >= 3 {
+		caution = CautionAggressive
+	}
+	return ships, caution
+}
+
+// launchSurvivalWaves runs survival mode from the game loop: once a human has
+// joined the defenders it launches the starbase, then sends a larger attack
+// wave every Config.SurvivalWaveInterval seconds. Bots are added through
+// AddBot, so this must be called without gameState.Mu held.
+func (s *Server) launchSurvivalWaves() {
+	gs := s.gameState
+	gs.Mu.RLock()
+	defenders := 0
+	for _, p := range gs.Players {
+		if p.Team == SurvivalDefenders && p.Connected && !p.IsBot && p.Status != game.StatusFree {
+			defenders++
+		}
+	}
+	needBase := gs.SurvivalBase < 0
+	due := gs.Frame >= gs.SurvivalNextWave
+	wave := gs.SurvivalWave + 1
+	over := gs.GameOver
+	gs.Mu.RUnlock()
+
+	if over || defenders == 0 {
+		return
+	}
+
+	if needBase {
+		s.launchSurvivalBase()
+		return
+	}
+	if !due {
+		return
+	}
+
+	ships, caution := survivalWave(wave)
+	launched := 0
+	for _, ship := range ships {
+		if !s.AddBotWithCaution(SurvivalAttackers, ship, caution) {
+			break // Out of player slots
+		}
+		launched++
+	}
+
+	gs.Mu.Lock()
+	if launched == 0 {
+		// No free slots: hold the wave until earlier attackers are cleared
+		gs.SurvivalNextWave = gs.Frame + int64(SurvivalWaveRetry*game.FPS)
+		gs.Mu.Unlock()
+		return
+	}
+	gs.SurvivalWave = wave
+	gs.SurvivalNextWave = gs.Frame + int64(s.config().SurvivalWaveInterval*game.FPS)
+	gs.Mu.Unlock()
+
+	s.broadcastInfo(fmt.Sprintf("🚨 WAVE %d: %d %s ships inbound for the starbase!",
+		wave, launched, s.config().Map.TeamName(SurvivalAttackers)))
+}
+
+// launchSurvivalBase adds the defenders' starbase (or adopts one a human
+// already flies) and schedules the first attack wave.
+func (s *Server) launchSurvivalBase() {
+	s.AddBot(SurvivalDefenders, game.ShipStarbase) // Fails if the team already has one
+
+	gs := s.gameState
+	gs.Mu.Lock()
+	defer gs.Mu.Unlock()
+	for _, p := range gs.Players {
+		if p.Team == SurvivalDefenders && p.Ship == game.ShipStarbase && p.Status == game.StatusAlive {
+			gs.SurvivalBase = p.ID
+			gs.SurvivalBaseHull = game.ShipData[p.Ship].MaxDamage - p.Damage
+			gs.SurvivalNextWave = gs.Frame + int64(s.config().SurvivalWaveInterval*game.FPS)
+			s.broadcastInfo(fmt.Sprintf("🛡️ SURVIVAL: defend the starbase %s! The first wave arrives in %d seconds.",
+				p.Name, s.config().SurvivalWaveInterval))
+			return
+		}
+	}
+}
+
+// checkSurvival tracks the defended starbase's hull and ends the game once it
+// is destroyed, with the attackers as the winners. It replaces the usual
+// victory conditions in survival mode. Caller must hold gameState.Mu.
+func (s *Server) checkSurvival() {
+	gs := s.gameState
+	if gs.GameOver || gs.SurvivalBase < 0 {
+		return
+	}
+
+	base := gs.Players[gs.SurvivalBase]
+	if base.Status == game.StatusAlive && base.Ship == game.ShipStarbase && base.Team == SurvivalDefenders {
+		gs.SurvivalBaseHull = game.ShipData[base.Ship].MaxDamage - base.Damage
+		return
+	}
+
+	gs.SurvivalBaseHull = 0
+	gs.GameOver = true
+	gs.Winner = SurvivalAttackers
+	gs.WinType = "survival"
+	s.announceVictory()
+}
+
+// survivalAttacker reports whether p is an attack wave bot, which fights
+// until destroyed and then frees its slot for later waves.
+func (s *Server) survivalAttacker(p *game.Player) bool {
+	return s.config().Survival && p.IsBot && p.Team == SurvivalAttackers
+}
+
+// survivalBaseFor returns the starbase p should attack: the defended base
+// while it is alive, for bots on the attacking team in survival mode.
+// Returns nil otherwise.
+func (s *Server) survivalBaseFor(p *game.Player) *game.Player {
+	gs := s.gameState
+	if !s.config().Survival || p.Team != SurvivalAttackers || gs.SurvivalBase < 0 {
+		return nil
+	}
+	base := gs.Players[gs.SurvivalBase]
+	if base.Status != game.StatusAlive || base.Team == p.Team {
+		return nil
+	}
+	return base
+}
+
+// assaultBase sends an attacking bot at the defended starbase, fighting only
+// defenders that get within SurvivalEscortRange on the way.
+func (s *Server) assaultBase(p *game.Player, base *game.Player) {
+	var escort *game.Player
+	escortDist := SurvivalEscortRange
+	for _, other := range s.gameState.Players {
+		if other == base || other.Status != game.StatusAlive || other.Team == p.Team || other.Cloaked {
+			continue
+		}
+		if dist := s.distance(p.X, p.Y, other.X, other.Y); dist < escortDist {
+			escortDist = dist
+			escort = other
+		}
+	}
+	if escort != nil {
+		p.BotTarget = escort.ID
+		s.engageCombat(p, escort, escortDist)
+		return
+	}
+
+	p.BotTarget = base.ID
+	dist := s.distance(p.X, p.Y, base.X, base.Y)
+	if dist < SurvivalEngageRange {
+		s.engageCombat(p, base, dist)
+		return
+	}
+
+	p.Orbiting = -1
+	p.Bombing = false
+	stopBeaming(p)
+	s.applySafeNavigation(p, s.bearing(p.X, p.Y, base.X, base.Y), float64(game.ShipData[p.Ship].MaxSpeed))
+}
+
+// resetSurvival clears survival progress for a new game. Caller must hold
+// gameState.Mu.
+func (s *Server) resetSurvival() {
+	s.gameState.SurvivalWave = 0
+	s.gameState.SurvivalBase = -1
+	s.gameState.SurvivalBaseHull = 0
+	s.gameState.SurvivalNextWave = 0
+}
+
+// survivalStatus is the survival progress sent with each game state update.
+type survivalStatus struct {
+	Wave        int `json:"wave"`
+	BaseHull    int `json:"baseHull"`
+	BaseMaxHull int `json:"baseMaxHull"`
+	NextWave    int `json:"nextWave"` // Seconds until the next wave
+}
+
+// survivalProgress reports survival progress for clients, or nil outside
+// survival mode and before the starbase launches. Caller must hold
+// gameState.Mu.
+func (s *Server) survivalProgress() *survivalStatus {
+	gs := s.gameState
+	if !s.config().Survival || gs.SurvivalBase < 0 {
+		return nil
+	}
+	return &survivalStatus{
+		Wave:        gs.SurvivalWave,
+		BaseHull:    gs.SurvivalBaseHull,
+		BaseMaxHull: game.ShipData[game.ShipStarbase].MaxDamage,
+		NextWave:    int(max(0, gs.SurvivalNextWave-gs.Frame) / game.FPS),
+	}
+}
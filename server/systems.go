@@ -22,6 +22,28 @@ func (s *Server) updateShipSystems() {
 
 // updatePlayerSystems handles fuel, heat, repair, and other systems for a single player
 func (s *Server) updatePlayerSystems(p *game.Player, playerIndex int) {
+	// Dogfight assist: manage a human's shields from the bot threat assessment
+	if p.Assist && !p.IsBot {
+		s.assessAndActivateShields(p)
+	}
+
+	// Auto-repair: request repairs for a human once damage passes their threshold
+	if p.AutoRepair > 0 && !p.IsBot {
+		s.updateAutoRepair(p, playerIndex)
+	}
+
+	// Orbit repair: start repairs for a human idling at a friendly planet
+	if p.OrbitRepair && !p.IsBot {
+		s.updateOrbitRepair(p)
+	}
+
+	if p.SpecialTimer > 0 {
+		p.SpecialTimer--
+	}
+
+	// Spawn protection ends for good once the ship leaves its spawn zone
+	s.updateSpawnProtection(p)
+
 	// Check if ship has slowed down to 0 for repair request
 	if p.RepairRequest && p.Speed == 0 && p.Orbiting < 0 {
 		// Transition from repair request to actual repair
@@ -45,8 +67,7 @@ func (s *Server) updatePlayerSystems(p *game.Player, playerIndex int) {
 		fuelUsage = int(p.Speed) * 2
 		if p.Cloaked {
 			// Use ship-specific cloak cost
-			cloakShipStats := game.ShipData[p.Ship]
-			fuelUsage += cloakShipStats.CloakCost
+			fuelUsage += s.cloakCost(p)
 		}
 		// Charge for shields (from original Netrek daemon.c)
 		if p.Shields_up {
@@ -57,11 +78,16 @@ func (s *Server) updatePlayerSystems(p *game.Player, playerIndex int) {
 		// p_etemp += j->p_speed
 		p.ETemp += int(p.Speed)
 	}
+	// ECM jams in orbit too
+	if p.ECMActive {
+		fuelUsage += game.ECMFuelCost
+	}
 	p.Fuel = int(math.Max(0, float64(p.Fuel-fuelUsage)))
 
-	// Decloak if out of fuel
-	if p.Cloaked && p.Fuel == 0 {
+	// Decloak and stop jamming if out of fuel
+	if p.Fuel == 0 {
 		p.Cloaked = false
+		p.ECMActive = false
 	}
 
 	// Cap ETemp at a reasonable maximum (150% of overheat threshold)
@@ -71,13 +97,21 @@ func (s *Server) updatePlayerSystems(p *game.Player, playerIndex int) {
 
 	// Recharge fuel using ship-specific rate (every tick)
 	shipStats := game.ShipData[p.Ship]
+	fuelRecharge := shipStats.FuelRecharge
+	if scale := s.teamHandicap(p.Team).Fuel; scale != 1 {
+		fuelRecharge = int(math.Round(float64(fuelRecharge) * scale))
+	}
 	if p.Fuel < shipStats.MaxFuel {
-		p.Fuel = min(p.Fuel+shipStats.FuelRecharge, shipStats.MaxFuel)
+		p.Fuel = min(p.Fuel+fuelRecharge, shipStats.MaxFuel)
 	}
 
 	// Cool weapons and engines using ship-specific rates
 	if p.WTemp > 0 {
-		p.WTemp -= shipStats.WpnCool
+		wpnCool := shipStats.WpnCool
+		if s.gameState.ActiveEvent == game.EventRapidFire {
+			wpnCool *= 2
+		}
+		p.WTemp -= wpnCool
 		if p.WTemp < 0 {
 			p.WTemp = 0
 		}
@@ -130,7 +164,7 @@ func (s *Server) updatePlayerSystems(p *game.Player, playerIndex int) {
 		if planet.Owner == p.Team && (planet.Flags&game.PlanetFuel) != 0 {
 			// Extra recharge at fuel planets (applied every 10 ticks)
 			if s.gameState.TickCount%10 == 0 {
-				p.Fuel = min(p.Fuel+shipStats.FuelRecharge, shipStats.MaxFuel)
+				p.Fuel = min(p.Fuel+fuelRecharge, shipStats.MaxFuel)
 			}
 		}
 	}
@@ -158,6 +192,10 @@ func (s *Server) updatePlayerSystems(p *game.Player, playerIndex int) {
 					}
 				}
 			}
+			// Outnumbered and handicapped teams repair faster
+			if scale := s.config().RepairRateScale * s.handicapRepairScale(p.Team) * s.teamHandicap(p.Team).Repair; scale != 1.0 {
+				repairInterval = max(int(math.Round(float64(repairInterval)/scale)), 1)
+			}
 
 			// Apply repairs when counter reaches interval
 			if p.RepairCounter >= repairInterval {
@@ -189,14 +227,137 @@ func (s *Server) updatePlayerSystems(p *game.Player, playerIndex int) {
 				})
 			} else {
 				// Add small fuel consumption for repairs
-				p.Fuel = max(p.Fuel-1, 0)
+				p.Fuel = max(p.Fuel-s.config().RepairFuelCost, 0)
 			}
 		} else {
 			// Cancel repair mode and repair request if moving while not orbiting
-			p.Repairing = false
-			p.RepairRequest = false
-			p.RepairCounter = 0
+			cancelRepair(p)
 		}
 	}
 
 }
+
+// updateOrbitRepair puts a human ship idling in orbit of a friendly planet
+// into repair mode, as bots do, when it has damage or shield to restore and no
+// enemy is within RepairSafetyDistance. Bombing or beaming counts as busy. A
+// repair the player cancels by hand is not restarted until they leave orbit,
+// take a hit, or come back to full strength.
+func (s *Server) updateOrbitRepair(p *game.Player) {
+	if p.Orbiting < 0 || p.Orbiting >= game.MaxPlanets ||
+		s.gameState.Planets[p.Orbiting] == nil || s.gameState.Planets[p.Orbiting].Owner != p.Team {
+		p.OrbitRepairSet = false
+		return
+	}
+	shipStats := game.ShipData[p.Ship]
+	if p.Damage == 0 && p.Shields >= shipStats.MaxShields {
+		p.OrbitRepairSet = false
+		return
+	}
+	if p.OrbitRepairSet || p.Repairing || p.Bombing || p.Beaming {
+		return
+	}
+	if enemy := s.findNearestEnemy(p); enemy != nil && s.distance(p.X, p.Y, enemy.X, enemy.Y) < RepairSafetyDistance {
+		return
+	}
+
+	p.OrbitRepairSet = true
+	p.Repairing = true
+	p.RepairRequest = false
+	p.Shields_up = false
+	p.Tractoring = -1
+	p.Pressoring = -1
+}
+
+// cancelRepair takes p out of repair mode and drops any pending repair
+// request. Every path that interrupts repairs, human or bot, goes through
+// here so the repair counter never carries over into the next repair.
+func cancelRepair(p *game.Player) {
+	p.Repairing = false
+	p.RepairRequest = false
+	p.RepairCounter = 0
+}
+
+// cloakCost returns p's per-tick cloak fuel cost after the configured scale.
+func (s *Server) cloakCost(p *game.Player) int {
+	return int(math.Round(float64(game.ShipData[p.Ship].CloakCost) * s.config().CloakCostScale))
+}
+
+// updateAutoRepair requests repairs for a human player whose damage exceeds
+// their auto-repair threshold while no enemy is within RepairSafetyDistance,
+// and abandons an automatic repair once an enemy closes in, mirroring the bot
+// repair logic. A repair the player cancels by hand is not requested again
+// until damage drops back under the threshold.
+func (s *Server) updateAutoRepair(p *game.Player, playerIndex int) {
+	shipStats := game.ShipData[p.Ship]
+
+	enemyDist := MaxSearchDistance
+	if enemy := s.findNearestEnemy(p); enemy != nil {
+		enemyDist = s.distance(p.X, p.Y, enemy.X, enemy.Y)
+	}
+	threatened := enemyDist < RepairSafetyDistance
+
+	if p.Repairing || p.RepairRequest {
+		if p.AutoRepairSet && threatened {
+			cancelRepair(p)
+			p.AutoRepairSet = false
+			s.tryBroadcast(ServerMessage{
+				Type: MsgTypeMessage,
+				Data: map[string]interface{}{
+					"text": "Enemy approaching - auto-repair cancelled",
+					"type": "warning",
+					"to":   playerIndex,
+				},
+			})
+		}
+		return
+	}
+
+	if p.Damage*100 <= shipStats.MaxDamage*p.AutoRepair {
+		p.AutoRepairSet = false
+		return
+	}
+	if p.AutoRepairSet || threatened {
+		return
+	}
+
+	p.AutoRepairSet = true
+	p.DesSpeed = 0
+	if p.Speed > 0 && p.Orbiting < 0 {
+		// Slow down first; updatePlayerSystems starts repairs once stopped
+		p.RepairRequest = true
+		s.broadcastInfo(fmt.Sprintf("%s is slowing to repair", formatPlayerName(p)))
+		return
+	}
+
+	p.Repairing = true
+	p.Shields_up = false
+	p.Bombing = false
+	stopBeaming(p)
+	p.Tractoring = -1
+	p.Pressoring = -1
+}
+
+// useSpecial triggers the special ability of p's ship. It returns why the
+// ability could not be used, or "" on success.
+func (s *Server) useSpecial(p *game.Player) string {
+	shipStats := game.ShipData[p.Ship]
+	switch {
+	case !s.config().ShipSpecials:
+		return "Ship specials are disabled on this server."
+	case shipStats.Special == game.SpecialNone:
+		return "Your ship has no special ability."
+	case p.SpecialTimer > 0:
+		return fmt.Sprintf("%s recharging (%d seconds).", game.SpecialNames[shipStats.Special], (p.SpecialTimer+game.FPS-1)/game.FPS)
+	case p.Fuel < shipStats.SpecialFuel:
+		return "Not enough fuel."
+	}
+
+	switch shipStats.Special {
+	case game.SpecialShieldBoost:
+		p.Shields = shipStats.MaxShields
+		p.Shields_up = true
+	}
+	p.Fuel -= shipStats.SpecialFuel
+	p.SpecialTimer = shipStats.SpecialCooldown
+	return ""
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/lab1702/netrek-web/game"
@@ -45,3 +47,460 @@ func TestRepairStartMessageIsPrivate(t *testing.T) {
 		t.Fatal("expected a repair-start broadcast message")
 	}
 }
+
+func TestDogfightAssistManagesShields(t *testing.T) {
+	for _, assist := range []bool{true, false} {
+		server, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
+		server.gameState.Frame = 1
+		p.X = 50000
+		p.Y = 50000
+		p.Shields_up = false
+
+		if assist {
+			client.handleAssist(json.RawMessage(`{}`))
+			if !p.Assist {
+				t.Fatal("assist toggle did not enable assist")
+			}
+		}
+
+		// Enemy torpedo closing in on the player
+		server.gameState.Torps = []*game.Torpedo{{
+			Owner:  1,
+			Team:   game.TeamRom,
+			Status: game.TorpMove,
+			X:      50800,
+			Y:      50000,
+			Dir:    math.Pi,
+			Speed:  240,
+			Fuse:   20,
+		}}
+
+		server.updateShipSystems()
+
+		if assist && !p.Shields_up {
+			t.Error("assist on: shields should raise automatically when a torpedo threatens")
+		}
+		if !assist && p.Shields_up {
+			t.Error("assist off: shields should stay under manual control")
+		}
+	}
+}
+
+// TestAutoRepairEntersAndCancelsOnThreat verifies that a player with
+// auto-repair enabled starts repairing once damage passes the threshold with
+// no enemies around, and abandons the repair when an enemy closes in.
+func TestAutoRepairEntersAndCancelsOnThreat(t *testing.T) {
+	server, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
+	server.gameState.Frame = 1
+	p.X = 50000
+	p.Y = 50000
+	p.Speed = 0
+	p.DesSpeed = 0
+	p.Orbiting = -1
+
+	client.handleAutoRepair(json.RawMessage(`{"threshold":50}`))
+	if p.AutoRepair != 50 {
+		t.Fatalf("AutoRepair = %d, want 50", p.AutoRepair)
+	}
+
+	// Below the threshold nothing happens
+	p.Damage = game.ShipData[p.Ship].MaxDamage / 4
+	server.updateShipSystems()
+	if p.Repairing || p.RepairRequest {
+		t.Fatal("auto-repair triggered below the damage threshold")
+	}
+
+	p.Damage = game.ShipData[p.Ship].MaxDamage * 3 / 4
+	server.updateShipSystems()
+	if !p.Repairing {
+		t.Fatal("auto-repair did not start with heavy damage and no threats")
+	}
+
+	enemy := server.gameState.Players[1]
+	enemy.Status = game.StatusAlive
+	enemy.Team = game.TeamRom
+	enemy.Ship = game.ShipDestroyer
+	enemy.X = p.X + 5000
+	enemy.Y = p.Y
+
+	server.updateShipSystems()
+	if p.Repairing || p.RepairRequest {
+		t.Error("auto-repair should be cancelled when an enemy approaches")
+	}
+}
+
+// TestAutoRepairOnlyWhenConfigured verifies that a heavily damaged player
+// who never set a threshold is left alone, and that /autorepair sets and
+// clears the threshold.
+func TestAutoRepairOnlyWhenConfigured(t *testing.T) {
+	server, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
+	p.X = 50000
+	p.Y = 50000
+	p.Orbiting = -1
+	p.Damage = game.ShipData[p.Ship].MaxDamage * 3 / 4
+
+	server.updateShipSystems()
+	if p.Repairing || p.RepairRequest {
+		t.Fatal("repairs started for a player who never configured auto-repair")
+	}
+
+	client.handleBotCommand("/autorepair 60%")
+	if p.AutoRepair != 60 {
+		t.Errorf("after /autorepair 60%%, AutoRepair = %d, want 60", p.AutoRepair)
+	}
+	client.handleBotCommand("/autorepair 150")
+	if p.AutoRepair != 60 {
+		t.Errorf("out-of-range /autorepair changed AutoRepair to %d", p.AutoRepair)
+	}
+	client.handleBotCommand("/autorepair off")
+	if p.AutoRepair != 0 {
+		t.Errorf("after /autorepair off, AutoRepair = %d, want 0", p.AutoRepair)
+	}
+}
+
+// TestOrbitRepairRecoversDamage verifies a human ship idling in orbit of a
+// friendly planet with orbit repair on goes into repair mode by itself and
+// recovers damage, while one with it off stays damaged.
+func TestOrbitRepairRecoversDamage(t *testing.T) {
+	for _, on := range []bool{false, true} {
+		server, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
+		planet := server.gameState.Planets[0]
+		planet.Owner = game.TeamFed
+		p.X, p.Y = planet.X, planet.Y
+		p.Orbiting = planet.ID
+		p.Speed = 0
+		p.Shields_up = true
+		p.Damage = 40
+		if on {
+			client.handleOrbitRepair(nil)
+		}
+
+		for frame := 0; frame < 100; frame++ {
+			server.gameState.Frame++
+			server.updateShipSystems()
+		}
+
+		if on && (p.Damage >= 40 || p.Shields_up) {
+			t.Errorf("with orbit repair on, damage = %d (shields up %v), want repairs below 40 with shields down", p.Damage, p.Shields_up)
+		}
+		if !on && (p.Damage != 40 || p.Repairing) {
+			t.Errorf("with orbit repair off, damage = %d (repairing %v), want 40 and no repair", p.Damage, p.Repairing)
+		}
+	}
+}
+
+// TestOrbitRepairWithoutPlanet verifies orbit repair leaves a ship alone
+// when the planet it is orbiting is missing rather than panicking.
+func TestOrbitRepairWithoutPlanet(t *testing.T) {
+	server, _, p := newTestClientAndPlayer(game.TeamFed, game.ShipCruiser)
+	p.Orbiting = 0
+	p.Damage = 40
+	p.OrbitRepairSet = true
+	server.gameState.Planets[0] = nil
+
+	server.updateOrbitRepair(p)
+
+	if p.Repairing || p.OrbitRepairSet {
+		t.Errorf("repairing=%v orbitRepairSet=%v, want neither without a planet", p.Repairing, p.OrbitRepairSet)
+	}
+}
+
+// TestShieldOverchargeSpecial verifies the battleship special ability is off
+// unless enabled, restores shields at a fuel cost and cannot be reused until
+// its cooldown has run out, and that ships without a special are refused.
+func TestShieldOverchargeSpecial(t *testing.T) {
+	server, client, p := newTestClientAndPlayer(game.TeamFed, game.ShipBattleship)
+	stats := game.ShipData[game.ShipBattleship]
+	p.Shields = 10
+	p.Shields_up = false
+
+	// Off by default: the ability is refused
+	client.handleSpecial(json.RawMessage(`{}`))
+	if p.Shields != 10 || p.SpecialTimer != 0 {
+		t.Fatalf("special used while disabled: shields = %d, timer = %d", p.Shields, p.SpecialTimer)
+	}
+
+	server.cfg.ShipSpecials = true
+	client.handleSpecial(json.RawMessage(`{}`))
+	if p.Shields != stats.MaxShields || !p.Shields_up {
+		t.Fatalf("shields = %d (up=%v), want %d and raised", p.Shields, p.Shields_up, stats.MaxShields)
+	}
+	if p.Fuel != stats.MaxFuel-stats.SpecialFuel {
+		t.Errorf("fuel = %d, want %d", p.Fuel, stats.MaxFuel-stats.SpecialFuel)
+	}
+	if p.SpecialTimer != stats.SpecialCooldown {
+		t.Errorf("special timer = %d, want %d", p.SpecialTimer, stats.SpecialCooldown)
+	}
+
+	// Still cooling down: a second use is refused
+	p.Shields = 10
+	client.handleSpecial(json.RawMessage(`{}`))
+	if p.Shields != 10 {
+		t.Errorf("special reused during cooldown: shields = %d", p.Shields)
+	}
+
+	for i := 0; i < stats.SpecialCooldown; i++ {
+		server.updatePlayerSystems(p, 0)
+	}
+	if p.SpecialTimer != 0 {
+		t.Fatalf("special timer = %d after cooldown, want 0", p.SpecialTimer)
+	}
+	p.Fuel = stats.MaxFuel
+	client.handleSpecial(json.RawMessage(`{}`))
+	if p.Shields != stats.MaxShields {
+		t.Errorf("special not usable after cooldown: shields = %d", p.Shields)
+	}
+
+	// Ships without a special ability are refused
+	scout := server.gameState.Players[0]
+	scout.Ship = game.ShipScout
+	scout.Shields = 5
+	if reason := server.useSpecial(scout); reason == "" || scout.Shields != 5 {
+		t.Errorf("scout special: reason %q, shields %d; want refusal", reason, scout.Shields)
+	}
+}
+
+// TestCloakCostScaleDoublesFuelDrain verifies that a cloak cost scale of 2
+// drains a cloaked ship's fuel twice as fast.
+func TestCloakCostScaleDoublesFuelDrain(t *testing.T) {
+	drain := func(scale float64) int {
+		cfg := DefaultConfig()
+		cfg.CloakCostScale = scale
+		server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 10), cfg: &cfg}
+
+		p := server.gameState.Players[0]
+		p.Status = game.StatusAlive
+		p.Ship = game.ShipCruiser
+		p.Orbiting = -1
+		p.Cloaked = true
+		p.Fuel = 5000
+
+		const ticks = 10
+		for i := 0; i < ticks; i++ {
+			server.updatePlayerSystems(p, 0)
+		}
+		// Add back the normal recharge so only the cloak drain remains
+		return 5000 - p.Fuel + ticks*game.ShipData[p.Ship].FuelRecharge
+	}
+
+	base := drain(1.0)
+	doubled := drain(2.0)
+	if base != 10*game.ShipData[game.ShipCruiser].CloakCost {
+		t.Fatalf("cloak drain at scale 1 = %d, want %d", base, 10*game.ShipData[game.ShipCruiser].CloakCost)
+	}
+	if doubled != 2*base {
+		t.Errorf("cloak drain at scale 2 = %d, want %d", doubled, 2*base)
+	}
+}
+
+// TestECMBlocksAndDropsLocks verifies that a ship lock onto a jamming enemy is
+// refused, and that an established lock drops once the target starts jamming.
+func TestECMBlocksAndDropsLocks(t *testing.T) {
+	server := NewServer()
+	client := &Client{ID: 1, server: server, send: make(chan ServerMessage, 10)}
+	client.SetPlayerID(0)
+
+	p := server.gameState.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipCruiser
+	p.Orbiting = -1
+	p.LockType = "none"
+	p.LockTarget = -1
+
+	target := server.gameState.Players[1]
+	target.Status = game.StatusAlive
+	target.Team = game.TeamRom
+	target.Ship = game.ShipCruiser
+	target.X, target.Y = p.X+5000, p.Y
+	target.Fuel = 5000
+
+	lock := json.RawMessage(`{"type":"player","target":1}`)
+
+	target.ECMActive = true
+	client.handleLock(lock)
+	if p.LockType != "none" {
+		t.Fatalf("locked onto a jamming target (lock %q %d)", p.LockType, p.LockTarget)
+	}
+	if msg := <-client.send; msg.Type != MsgTypeError {
+		t.Errorf("failed lock sent %q, want an error", msg.Type)
+	}
+
+	target.ECMActive = false
+	client.handleLock(lock)
+	if p.LockType != "player" || p.LockTarget != 1 {
+		t.Fatalf("lock on a quiet target = %q %d, want player 1", p.LockType, p.LockTarget)
+	}
+	server.updatePlayerLockOn(p)
+	if p.LockType != "player" {
+		t.Fatal("lock dropped while the target was not jamming")
+	}
+
+	// Switching ECM on breaks the existing lock
+	client2 := &Client{ID: 2, server: server, send: make(chan ServerMessage, 10)}
+	client2.SetPlayerID(1)
+	client2.handleECM(nil)
+	if !target.ECMActive {
+		t.Fatal("ECM did not switch on")
+	}
+	server.updatePlayerLockOn(p)
+	if p.LockType != "none" || p.LockTarget != -1 {
+		t.Errorf("lock still %q %d after the target started jamming", p.LockType, p.LockTarget)
+	}
+
+	// Jamming burns fuel on top of normal use until the tank runs dry
+	target.Fuel = game.ECMFuelCost
+	server.updatePlayerSystems(target, 1)
+	if target.ECMActive {
+		t.Error("ECM still on with an empty tank")
+	}
+}
+
+// TestLockCycleStepsThroughEnemiesByDistance verifies that repeated lock
+// cycles lock progressively farther enemies, skip teammates and cloakers,
+// report each choice to the client, and wrap back to the nearest.
+func TestLockCycleStepsThroughEnemiesByDistance(t *testing.T) {
+	server := NewServer()
+	client := &Client{ID: 1, server: server, send: make(chan ServerMessage, 10)}
+	client.SetPlayerID(0)
+
+	p := server.gameState.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipCruiser
+	p.X, p.Y = 50000, 50000
+	p.Orbiting = -1
+
+	place := func(id, team int, dist float64) *game.Player {
+		other := server.gameState.Players[id]
+		other.Status = game.StatusAlive
+		other.Team = team
+		other.Ship = game.ShipCruiser
+		other.X, other.Y = p.X+dist, p.Y
+		return other
+	}
+	place(1, game.TeamRom, 9000)
+	place(2, game.TeamKli, 3000)
+	place(3, game.TeamRom, 6000)
+	place(4, game.TeamFed, 1000)                // Teammate
+	place(5, game.TeamRom, 2000).Cloaked = true // Cloaked enemy
+
+	for _, want := range []int{2, 3, 1, 2} {
+		client.handleLockCycle(nil)
+		if p.LockType != "player" || p.LockTarget != want {
+			t.Fatalf("lock = %q %d, want player %d", p.LockType, p.LockTarget, want)
+		}
+		msg := <-client.send
+		data, _ := msg.Data.(map[string]interface{})
+		if msg.Type != MsgTypeLockCycle || data["target"] != want {
+			t.Errorf("reply %q %v, want %q naming target %d", msg.Type, msg.Data, MsgTypeLockCycle, want)
+		}
+	}
+}
+
+// TestDamageInterruptsRepair verifies that a hit ends repair mode and resets
+// the repair counter, so repairs don't quietly continue under fire.
+func TestDamageInterruptsRepair(t *testing.T) {
+	server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 10)}
+
+	p := server.gameState.Players[0]
+	p.Status = game.StatusAlive
+	p.Ship = game.ShipCruiser
+	p.Orbiting = -1
+	p.Damage = 50
+	p.Repairing = true
+	p.RepairCounter = 4
+
+	server.applyDamage(p, 10, game.DamageTorp)
+	if p.Repairing || p.RepairRequest || p.RepairCounter != 0 {
+		t.Fatalf("after a hit: repairing=%v request=%v counter=%d, want repairs cancelled",
+			p.Repairing, p.RepairRequest, p.RepairCounter)
+	}
+
+	// A pending request is dropped too
+	p.RepairRequest = true
+	server.applyDamage(p, 10, game.DamagePhaser)
+	if p.RepairRequest {
+		t.Error("repair request survived a hit")
+	}
+}
+
+// TestRepairRateScaleAndFuelCost verifies that the configured repair rate
+// shortens the interval between repair steps and that repairing burns the
+// configured fuel each tick.
+func TestRepairRateScaleAndFuelCost(t *testing.T) {
+	repaired := func(scale float64, fuelCost int) (int, int) {
+		cfg := DefaultConfig()
+		cfg.RepairRateScale = scale
+		cfg.RepairFuelCost = fuelCost
+		server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 10), cfg: &cfg}
+
+		p := server.gameState.Players[0]
+		p.Status = game.StatusAlive
+		p.Ship = game.ShipCruiser
+		p.Orbiting = -1
+		p.Shields = game.ShipData[p.Ship].MaxShields
+		p.Damage = 80
+		p.Fuel = 5000
+		p.Repairing = true
+
+		const ticks = 40
+		for i := 0; i < ticks; i++ {
+			server.updatePlayerSystems(p, 0)
+		}
+		// Add back the normal recharge (capped at full) so only repair use remains
+		fuelUsed := 5000 - p.Fuel + ticks*game.ShipData[p.Ship].FuelRecharge
+		return 80 - p.Damage, fuelUsed
+	}
+
+	baseRepair, baseFuel := repaired(1.0, 1)
+	fastRepair, costlyFuel := repaired(2.0, 5)
+	if fastRepair != 2*baseRepair {
+		t.Errorf("repaired %d hull at scale 2, want %d (twice scale 1)", fastRepair, 2*baseRepair)
+	}
+	if costlyFuel != 5*baseFuel {
+		t.Errorf("repair burned %d fuel at cost 5, want %d", costlyFuel, 5*baseFuel)
+	}
+}
+
+// TestHandicapSpeedsRepairsForOutnumberedTeam verifies a two-human team
+// facing a five-human team earns the configured repair buff for each human
+// it is short, and repairs faster than the larger team for it.
+func TestHandicapSpeedsRepairsForOutnumberedTeam(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.HandicapGap = 2
+	cfg.HandicapRepair = 0.2
+	server := &Server{gameState: game.NewGameState(), broadcast: make(chan ServerMessage, 100), cfg: &cfg}
+
+	for i := 0; i < 7; i++ {
+		p := server.gameState.Players[i]
+		p.Status = game.StatusAlive
+		p.Connected = true
+		p.Team = game.TeamRom
+		if i < 2 {
+			p.Team = game.TeamFed
+		}
+		p.Ship = game.ShipCruiser
+		p.Orbiting = -1
+		p.Shields = game.ShipData[p.Ship].MaxShields
+		p.Fuel = 5000
+		p.Damage = 80
+		p.Repairing = true
+	}
+
+	if got := server.handicapRepairScale(game.TeamFed); math.Abs(got-1.6) > 1e-9 {
+		t.Errorf("repair scale for the team three humans short = %.2f, want 1.60", got)
+	}
+	if got := server.handicapRepairScale(game.TeamRom); got != 1 {
+		t.Errorf("repair scale for the larger team = %.2f, want 1", got)
+	}
+
+	fed, rom := server.gameState.Players[0], server.gameState.Players[2]
+	for i := 0; i < 40; i++ {
+		server.updatePlayerSystems(fed, fed.ID)
+		server.updatePlayerSystems(rom, rom.ID)
+	}
+	if 80-fed.Damage <= 80-rom.Damage {
+		t.Errorf("handicapped ship repaired %d hull, no more than the larger team's %d", 80-fed.Damage, 80-rom.Damage)
+	}
+}
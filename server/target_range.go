@@ -0,0 +1,71 @@
+package server
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// Practice target range layout: a horizontal row of dummies centered on the
+// galaxy, so weapon tests always start from the same geometry.
+const (
+	TargetRangeSpacing = 2000.0                // Distance between neighbouring dummies
+	TargetRangeY       = game.GalaxyHeight / 2 // Row height
+	MaxTargetDummies   = 8                     // Largest row the range command will build
+)
+
+// targetRangePosition returns the position of dummy i in a row of count.
+func targetRangePosition(i, count int) (float64, float64) {
+	startX := game.GalaxyWidth/2 - float64(count-1)*TargetRangeSpacing/2
+	return startX + float64(i)*TargetRangeSpacing, TargetRangeY
+}
+
+// AddTargetRange spawns up to count stationary target dummies for team in a
+// row at fixed coordinates and returns their player IDs. Dummies have no AI,
+// are ignored by team balancing, and are removed when destroyed.
+func (s *Server) AddTargetRange(team, count int) []int {
+	count = max(0, min(count, MaxTargetDummies))
+
+	s.gameState.Mu.Lock()
+	defer s.gameState.Mu.Unlock()
+
+	ids := make([]int, 0, count)
+	for i := 0; i < game.MaxPlayers && len(ids) < count; i++ {
+		p := s.gameState.Players[i]
+		if p.Status != game.StatusFree || p.Connected {
+			continue
+		}
+
+		shipStats := game.ShipData[game.ShipCruiser]
+		p.Name = fmt.Sprintf("[DUMMY] %d", len(ids)+1)
+		p.Team = team
+		p.Ship = game.ShipCruiser
+		p.Status = game.StatusAlive
+		p.NextShipType = -1
+		p.Connected = true
+		p.IsBot = true
+		p.Dummy = true
+		p.X, p.Y = targetRangePosition(len(ids), count)
+		p.Dir = math.Pi / 2
+		p.DesDir = p.Dir
+		p.Speed = 0
+		p.DesSpeed = 0
+		p.Shields = shipStats.MaxShields
+		p.Shields_up = false
+		p.Damage = 0
+		p.Fuel = shipStats.MaxFuel
+		p.Armies = 0
+		p.Cloaked = false
+		p.ECMActive = false
+		p.Orbiting = -1
+		p.Tractoring = -1
+		p.Pressoring = -1
+		p.LockType = "none"
+		p.LockTarget = -1
+		p.NumTorps = 0
+		p.NumPlasma = 0
+		ids = append(ids, i)
+	}
+	return ids
+}
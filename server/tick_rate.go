@@ -0,0 +1,51 @@
+package server
+
+import (
+	"math"
+	"time"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// Game rules advance in frames, game.FPS of them per second: fuses, timers,
+// fuel and army rates and the bots all count frames. Config.FPS can run the
+// game loop faster than that for smoother updates. The extra ticks fall
+// between frames and only carry ships and projectiles along their courses,
+// so every speed still covers the same distance per second.
+
+// ticksPerFrame returns how many game loop ticks make up one game frame.
+func (s *Server) ticksPerFrame() int {
+	return max(1, s.config().FPS/game.FPS)
+}
+
+// tickInterval returns the game loop's tick period.
+func (s *Server) tickInterval() time.Duration {
+	return game.UpdateInterval / time.Duration(s.ticksPerFrame())
+}
+
+// advanceMotion moves every free-flying ship and live projectile through one
+// tick's share of its per-frame travel. Orbits, collisions and fuses wait
+// for the next frame. Caller must hold gameState.Mu.
+func (s *Server) advanceMotion() {
+	frac := 1 / float64(s.ticksPerFrame())
+	wrap := s.config().GalaxyEdge == EdgeWrap
+
+	for _, p := range s.gameState.Players {
+		if p.Status == game.StatusAlive && p.Orbiting < 0 && p.Speed > 0 {
+			s.moveShip(p, p.Speed*20*frac)
+		}
+	}
+
+	for _, list := range [][]*game.Torpedo{s.gameState.Torps, s.gameState.Plasmas} {
+		for _, t := range list {
+			if t.Status != game.TorpMove {
+				continue
+			}
+			t.X += t.Speed * frac * math.Cos(t.Dir)
+			t.Y += t.Speed * frac * math.Sin(t.Dir)
+			if wrap {
+				t.X, t.Y = wrapToGalaxy(t.X, t.Y)
+			}
+		}
+	}
+}
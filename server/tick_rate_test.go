@@ -0,0 +1,58 @@
+package server
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lab1702/netrek-web/game"
+)
+
+// TestTorpedoDistancePerSecondIndependentOfFPS verifies that a torpedo
+// covers the same distance in one second at 20 FPS as at 10 FPS, moving half
+// a frame's worth on each of the extra ticks.
+func TestTorpedoDistancePerSecondIndependentOfFPS(t *testing.T) {
+	type sample struct{ firstTick, oneSecond float64 }
+	fly := func(fps int) sample {
+		cfg := DefaultConfig()
+		cfg.FPS = fps
+		server := NewServerWithConfig(cfg)
+		server.broadcast = make(chan ServerMessage, 100)
+
+		// A connected human keeps the galaxy from resetting
+		shooter := server.gameState.Players[0]
+		shooter.Status = game.StatusAlive
+		shooter.Connected = true
+		shooter.Team = game.TeamFed
+		shooter.Ship = game.ShipCruiser
+		shooter.X, shooter.Y = 10000, 10000
+		shooter.NumTorps = 1
+
+		torp := &game.Torpedo{ID: 1, Owner: 0, X: 20000, Y: 50000, Dir: 0, Speed: 240, Fuse: 100, Status: game.TorpMove, Team: game.TeamFed}
+		server.gameState.Torps = []*game.Torpedo{torp}
+
+		var s sample
+		for tick := 1; tick <= fps; tick++ {
+			server.updateGame()
+			if tick == 1 {
+				s.firstTick = torp.X - 20000
+			}
+		}
+		s.oneSecond = torp.X - 20000
+		if frames := server.gameState.Frame; frames != int64(game.FPS) {
+			t.Errorf("%d FPS: %d game frames in one second, want %d", fps, frames, game.FPS)
+		}
+		return s
+	}
+
+	base := fly(10)
+	fast := fly(20)
+	if base.oneSecond != 2400 {
+		t.Fatalf("10 FPS torpedo flew %.1f in one second, want 2400", base.oneSecond)
+	}
+	if math.Abs(fast.oneSecond-base.oneSecond) > 0.001 {
+		t.Errorf("20 FPS torpedo flew %.1f in one second, want %.1f as at 10 FPS", fast.oneSecond, base.oneSecond)
+	}
+	if math.Abs(fast.firstTick-base.firstTick/2) > 0.001 {
+		t.Errorf("20 FPS first tick moved %.1f, want half of %.1f", fast.firstTick, base.firstTick)
+	}
+}
@@ -31,6 +31,18 @@ func (s *Server) checkTournamentMode() {
 
 	wasInTMode := s.gameState.T_mode
 	shouldBeInTMode := teamsWithEnough >= 2
+	if s.gameState.T_forced {
+		// An admin override holds the current mode regardless of population
+		shouldBeInTMode = wasInTMode
+	} else if s.config().ReadyCheck {
+		// Ready-checked matches start only through the /ready quorum
+		shouldBeInTMode = false
+	}
+	if s.config().Survival {
+		// Survival is co-op against attack waves and ends only through
+		// checkSurvival; a galaxy reset or tournament timeout would break it
+		shouldBeInTMode = false
+	}
 
 	if !wasInTMode && shouldBeInTMode {
 		// Entering tournament mode - announce BEFORE resetting so players understand the teleport
@@ -42,110 +54,12 @@ func (s *Server) checkTournamentMode() {
 			},
 		})
 
-		s.gameState.T_mode = true
-		s.gameState.T_start = s.gameState.Frame
-		s.gameState.T_remain = 1800 // 30 minutes in seconds
-
-		// Reset galaxy to ensure fair start
-		// Re-initialize planets to startup state
-		game.InitPlanets(s.gameState)
-		game.InitINLPlanetFlags(s.gameState)
-
-		// Reset planet info - teams only know about their own planets at start
-		for _, planet := range s.gameState.Planets {
-			if planet != nil {
-				// Each team only has info on planets they own
-				if planet.Owner != game.TeamNone {
-					planet.Info = planet.Owner
-				} else {
-					// Neutral planets are unknown to everyone
-					planet.Info = 0
-				}
-			}
-		}
-
-		// Clear all torpedoes and plasmas for clean start
-		s.gameState.Torps = make([]*game.Torpedo, 0)
-		s.gameState.Plasmas = make([]*game.Plasma, 0)
-
-		// Reset all active players to spawn positions
-		for i := range s.gameState.Players {
-			p := s.gameState.Players[i]
-			if p.Status == game.StatusAlive && p.Connected {
-				// Initialize tournament stats
-				s.gameState.TournamentStats[p.ID] = &game.TournamentPlayerStats{}
-
-				// Reset ship state
-				shipStats := game.ShipData[p.Ship]
-				p.Shields = shipStats.MaxShields
-				p.Damage = 0
-				p.Fuel = shipStats.MaxFuel
-				p.WTemp = 0
-				p.ETemp = 0
-				p.Speed = 0
-				p.DesSpeed = 0
-				p.SubDir = 0  // Reset fractional turn accumulator
-				p.AccFrac = 0 // Reset fractional acceleration accumulator
-
-				// Reset kills and deaths for fair tournament start
-				p.Kills = 0
-				p.KillsStreak = 0
-				p.Deaths = 0
-				p.Shields_up = false
-				p.Cloaked = false
-				p.Tractoring = -1
-				p.Pressoring = -1
-				p.Orbiting = -1
-				p.Bombing = false
-				p.Beaming = false
-				p.BeamingUp = false
-				p.Repairing = false
-				p.RepairRequest = false
-				p.RepairCounter = 0
-				p.EngineOverheat = false
-				p.OverheatTimer = 0
-				p.Armies = 0 // Clear any armies being carried
-				p.NumTorps = 0
-				p.NumPlasma = 0
-
-				// Reset lock-on
-				p.LockType = "none"
-				p.LockTarget = -1
-
-				// Reset death tracking (in case they were exploding)
-				p.ExplodeTimer = 0
-				p.KilledBy = -1
-				p.WhyDead = game.KillNone
-
-				// Reset position to near home world (random offset prevents
-				// ships spawning on top of each other)
-				p.X, p.Y = spawnPosition(p.Team)
-
-				// Random starting direction
-				p.Dir = rand.Float64() * 2 * math.Pi
-				p.DesDir = p.Dir
-
-				// Reset alert level
-				p.AlertLevel = "green"
-
-				// Clear bot-specific state
-				if p.IsBot {
-					p.BotTarget = -1
-					p.BotCooldown = 0
-					p.BotGoalX = 0
-					p.BotGoalY = 0
-				}
-			}
-		}
+		s.startTournament()
 
 		// Announce T-mode is now active
 		s.broadcastInfo("⚔️ TOURNAMENT MODE ACTIVE! 30 minute time limit. Fight for victory!")
 	} else if wasInTMode && !shouldBeInTMode {
-		// Leaving tournament mode
-		s.gameState.T_mode = false
-
-		// Announce T-mode end
-		s.broadcastInfo("Tournament mode deactivated - not enough players")
+		s.stopTournament("Tournament mode deactivated - not enough players")
 	}
 
 	// Ensure every active participant has a tournament stats entry. Entries are
@@ -225,3 +139,112 @@ func (s *Server) checkTournamentMode() {
 		}
 	}
 }
+
+// stopTournament leaves tournament mode, clearing its clock, and announces
+// why. The galaxy is left as it is.
+func (s *Server) stopTournament(reason string) {
+	s.gameState.T_mode = false
+	s.gameState.T_remain = 0
+	s.broadcastInfo(reason)
+}
+
+// startTournament enters tournament mode: the galaxy is reset, projectiles
+// cleared, and every active player is restored and sent home.
+func (s *Server) startTournament() {
+	s.gameState.T_mode = true
+	s.gameState.T_start = s.gameState.Frame
+	s.gameState.T_remain = 1800 // 30 minutes in seconds
+
+	// Reset galaxy to ensure fair start
+	// Re-initialize planets to startup state
+	s.initPlanets()
+
+	// Reset planet info - teams only know about their own planets at start
+	for _, planet := range s.gameState.Planets {
+		if planet != nil {
+			// Each team only has info on planets they own
+			if planet.Owner != game.TeamNone {
+				planet.Info = planet.Owner
+			} else {
+				// Neutral planets are unknown to everyone
+				planet.Info = 0
+			}
+		}
+	}
+
+	// Clear all torpedoes and plasmas for clean start
+	s.gameState.Torps = make([]*game.Torpedo, 0)
+	s.gameState.Plasmas = make([]*game.Plasma, 0)
+
+	// Reset all active players to spawn positions
+	for i := range s.gameState.Players {
+		p := s.gameState.Players[i]
+		// Players knocked out by the lives limit return for the new match
+		if p.Status == game.StatusObserve && p.Connected {
+			s.respawnPlayer(p)
+		}
+		if p.Status == game.StatusAlive && p.Connected {
+			// Initialize tournament stats
+			s.gameState.TournamentStats[p.ID] = &game.TournamentPlayerStats{}
+
+			// Reset ship state
+			s.spawnLoadout(p, game.ShipData[p.Ship])
+			p.WTemp = 0
+			p.ETemp = 0
+			p.Speed = 0
+			p.DesSpeed = 0
+			p.SubDir = 0  // Reset fractional turn accumulator
+			p.AccFrac = 0 // Reset fractional acceleration accumulator
+
+			// Reset kills and deaths for fair tournament start
+			p.Kills = 0
+			p.KillsStreak = 0
+			p.Deaths = 0
+			p.Shields_up = false
+			p.Cloaked = false
+			p.ECMActive = false
+			p.Tractoring = -1
+			p.Pressoring = -1
+			p.Orbiting = -1
+			p.Bombing = false
+			stopBeaming(p)
+			p.Repairing = false
+			p.RepairRequest = false
+			p.RepairCounter = 0
+			p.EngineOverheat = false
+			p.OverheatTimer = 0
+			p.Armies = 0 // Clear any armies being carried
+			p.NumTorps = 0
+			p.NumPlasma = 0
+
+			// Reset lock-on
+			p.LockType = "none"
+			p.LockTarget = -1
+
+			// Reset death tracking (in case they were exploding)
+			p.ExplodeTimer = 0
+			p.KilledBy = -1
+			p.WhyDead = game.KillNone
+
+			// Reset position to near home world (random offset prevents
+			// ships spawning on top of each other)
+			p.X, p.Y = s.spawnPosition(p.Team)
+
+			// Random starting direction
+			p.Dir = rand.Float64() * 2 * math.Pi
+			p.DesDir = p.Dir
+
+			// Reset alert level
+			p.AlertLevel = "green"
+
+			// Clear bot-specific state
+			if p.IsBot {
+				p.BotTarget = -1
+				p.BotCooldown = 0
+				p.BotGoalX = 0
+				p.BotGoalY = 0
+				p.BotMemoryUntil = 0
+			}
+		}
+	}
+}
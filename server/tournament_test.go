@@ -582,3 +582,100 @@ func TestTournamentTimeoutThreeWayTie(t *testing.T) {
 		t.Error("Expected victory broadcast message")
 	}
 }
+
+// TestReadyCheckStartsMatchAtQuorum verifies that with -ready-check the match
+// waits for every connected player's /ready, then starts in forced tournament
+// mode on a freshly reset galaxy.
+func TestReadyCheckStartsMatchAtQuorum(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ReadyCheck = true
+	server := NewServerWithConfig(cfg)
+	server.broadcast = make(chan ServerMessage, 64)
+
+	var clients []*Client
+	for i, team := range []int{game.TeamFed, game.TeamRom} {
+		client := &Client{ID: i + 1, server: server, send: make(chan ServerMessage, 16)}
+		client.SetPlayerID(i)
+		server.clients[client.ID] = client
+		clients = append(clients, client)
+
+		p := server.gameState.Players[i]
+		p.Status = game.StatusAlive
+		p.Team = team
+		p.Ship = game.ShipCruiser
+		p.Name = "Player"
+		p.Connected = true
+		p.OwnerClientID = client.ID
+		p.Damage = 50
+		p.Kills = 3
+	}
+
+	home := server.gameState.Planets[0]
+	homeOwner := home.Owner
+	home.Owner = game.TeamOri
+	server.gameState.Frame = 5000
+
+	clients[0].handleBotCommand("/ready")
+	server.checkTournamentMode()
+	if server.gameState.T_mode {
+		t.Fatal("match started with only 1 of 2 players ready")
+	}
+	if home.Owner != game.TeamOri {
+		t.Fatal("galaxy was reset before the ready quorum was met")
+	}
+
+	clients[1].handleBotCommand("/ready")
+	gs := server.gameState
+	if !gs.T_mode || !gs.T_forced {
+		t.Fatalf("T_mode=%v T_forced=%v after all players were ready, want both true", gs.T_mode, gs.T_forced)
+	}
+	if gs.T_start != 5000 || gs.T_remain != 1800 {
+		t.Errorf("tournament clock T_start=%d T_remain=%d, want 5000 and 1800", gs.T_start, gs.T_remain)
+	}
+	if owner := gs.Planets[0].Owner; owner != homeOwner {
+		t.Errorf("planet 0 owner = %d after the match start, want its starting owner %d", owner, homeOwner)
+	}
+	for i := 0; i < 2; i++ {
+		if p := gs.Players[i]; p.Damage != 0 || p.Kills != 0 {
+			t.Errorf("player %d damage=%v kills=%v, want a fresh start", i, p.Damage, p.Kills)
+		}
+	}
+	if len(server.ready) != 0 {
+		t.Error("readiness should be cleared once the match starts")
+	}
+}
+
+// TestReadyQuorumRecheckedOnDisconnect verifies that when a player who had
+// not sent /ready disconnects, the ready players left start the match.
+func TestReadyQuorumRecheckedOnDisconnect(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ReadyCheck = true
+	server := NewServerWithConfig(cfg)
+	server.broadcast = make(chan ServerMessage, 64)
+
+	var clients []*Client
+	for i, team := range []int{game.TeamFed, game.TeamRom} {
+		client := &Client{ID: i + 1, server: server, send: make(chan ServerMessage, 16)}
+		client.SetPlayerID(i)
+		server.clients[client.ID] = client
+		clients = append(clients, client)
+
+		p := server.gameState.Players[i]
+		p.Status = game.StatusAlive
+		p.Team = team
+		p.Ship = game.ShipCruiser
+		p.Name = "Player"
+		p.Connected = true
+		p.OwnerClientID = client.ID
+	}
+
+	clients[0].handleBotCommand("/ready")
+	if server.gameState.T_mode {
+		t.Fatal("match started with only 1 of 2 players ready")
+	}
+
+	server.removeClient(clients[1])
+	if !server.gameState.T_mode || !server.gameState.T_forced {
+		t.Error("the match should start once the only player not ready has left")
+	}
+}
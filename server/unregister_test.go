@@ -1,7 +1,9 @@
 package server
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/lab1702/netrek-web/game"
 )
@@ -70,3 +72,276 @@ func TestFreeDisconnectedSlotOwnership(t *testing.T) {
 		}
 	})
 }
+
+// TestBackfillOnDisconnect verifies that with the backfill policy enabled a
+// departing human is replaced by a bot on the same team, and that with the
+// default policy the slot is simply left open.
+func TestBackfillOnDisconnect(t *testing.T) {
+	for _, backfill := range []bool{true, false} {
+		cfg := DefaultConfig()
+		cfg.BackfillOnDisconnect = backfill
+		s := NewServerWithConfig(cfg)
+		go s.Run()
+
+		s.gameState.Mu.Lock()
+		human := s.gameState.Players[0]
+		human.Status = game.StatusAlive
+		human.Team = game.TeamFed
+		human.Name = "Alice"
+		human.Connected = true
+		human.OwnerClientID = 42
+		s.gameState.Mu.Unlock()
+		if !s.AddBot(game.TeamRom, game.ShipCruiser) {
+			t.Fatal("failed to add opposing bot")
+		}
+
+		client := &Client{ID: 42, send: make(chan ServerMessage, 256), server: s}
+		client.SetPlayerID(0)
+		s.register <- client
+		s.unregister <- client
+
+		fedBots := func() int {
+			s.gameState.Mu.RLock()
+			defer s.gameState.Mu.RUnlock()
+			n := 0
+			for _, p := range s.gameState.Players {
+				if p.IsBot && p.Connected && p.Team == game.TeamFed && p.Status != game.StatusFree {
+					n++
+				}
+			}
+			return n
+		}
+
+		// Run handles events in order, so once another register is accepted
+		// the unregister (and any backfill) has been fully processed
+		s.register <- &Client{ID: 43, send: make(chan ServerMessage, 1), server: s}
+
+		want := 0
+		if backfill {
+			want = 1
+		}
+		if got := fedBots(); got != want {
+			t.Errorf("backfill=%v: Federation bots after disconnect = %d, want %d", backfill, got, want)
+		}
+		s.Shutdown()
+	}
+}
+
+// TestBotTakeoverOnDisconnect verifies that with the takeover policy enabled a
+// disconnecting human's ship stays in play under bot control.
+func TestBotTakeoverOnDisconnect(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BotTakeoverOnDisconnect = true
+	s := NewServerWithConfig(cfg)
+	go s.Run()
+	defer s.Shutdown()
+
+	s.gameState.Mu.Lock()
+	p := s.gameState.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Ship = game.ShipDestroyer
+	p.Name = "Alice"
+	p.Connected = true
+	p.OwnerClientID = 42
+	p.Armies = 3
+	s.gameState.Mu.Unlock()
+
+	client := &Client{ID: 42, send: make(chan ServerMessage, 256), server: s}
+	client.SetPlayerID(0)
+	s.register <- client
+	s.unregister <- client
+	// Run handles events in order, so this register completes the unregister
+	s.register <- &Client{ID: 43, send: make(chan ServerMessage, 1), server: s}
+
+	s.gameState.Mu.RLock()
+	defer s.gameState.Mu.RUnlock()
+	if !p.IsBot || !p.Connected || p.Status != game.StatusAlive {
+		t.Fatalf("slot should hold an active bot, got IsBot=%v Connected=%v Status=%v", p.IsBot, p.Connected, p.Status)
+	}
+	if p.OwnerClientID != -1 || p.BotTarget != -1 {
+		t.Errorf("bot fields not initialized: OwnerClientID=%d BotTarget=%d", p.OwnerClientID, p.BotTarget)
+	}
+	if p.Team != game.TeamFed || p.Ship != game.ShipDestroyer || p.Armies != 3 {
+		t.Error("the bot should inherit the ship as it was left")
+	}
+}
+
+// TestReconnectReclaimsTakenOverShip verifies a player who reconnects under
+// the same name gets back the ship a bot took over, armies and all, and that
+// the bot holds the player's name in the bot name pool until then.
+func TestReconnectReclaimsTakenOverShip(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BotTakeoverOnDisconnect = true
+	s := NewServerWithConfig(cfg)
+	go s.Run()
+	defer s.Shutdown()
+
+	s.gameState.Mu.Lock()
+	p := s.gameState.Players[3]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamRom
+	p.Ship = game.ShipCruiser
+	p.Name = "Alice"
+	p.Connected = true
+	p.OwnerClientID = 42
+	p.Armies = 4
+	s.gameState.Mu.Unlock()
+
+	client := &Client{ID: 42, send: make(chan ServerMessage, 256), server: s}
+	client.SetPlayerID(3)
+	s.register <- client
+	s.unregister <- client
+	// Run handles events in order, so this register completes the unregister
+	s.register <- &Client{ID: 43, send: make(chan ServerMessage, 1), server: s}
+
+	s.gameState.Mu.Lock()
+	if !p.IsBot || p.Name != "[BOT] Alice" {
+		s.gameState.Mu.Unlock()
+		t.Fatalf("a bot should fly Alice's ship, got IsBot=%v Name=%q", p.IsBot, p.Name)
+	}
+	if s.botNames[3] != "Alice" {
+		t.Errorf("the takeover bot should hold its name in the pool, got %q", s.botNames[3])
+	}
+	s.gameState.Mu.Unlock()
+
+	back := &Client{ID: 44, send: make(chan ServerMessage, 256), server: s}
+	back.SetPlayerID(-1)
+	back.handleLogin(json.RawMessage(`{"name":"Alice","team":1,"ship":2}`))
+
+	if back.GetPlayerID() != 3 {
+		t.Fatalf("Alice should get slot 3 back, got %d", back.GetPlayerID())
+	}
+	s.gameState.Mu.RLock()
+	defer s.gameState.Mu.RUnlock()
+	if p.IsBot || p.Name != "Alice" || p.OwnerClientID != 44 {
+		t.Errorf("the ship should be Alice's again, got IsBot=%v Name=%q Owner=%d", p.IsBot, p.Name, p.OwnerClientID)
+	}
+	if p.Team != game.TeamRom || p.Ship != game.ShipCruiser || p.Armies != 4 {
+		t.Error("the ship should come back as the bot left it")
+	}
+	if _, held := s.botNames[3]; held {
+		t.Error("the reclaimed slot should release its bot name")
+	}
+}
+
+// TestAutoBalanceWaitsBeforeRemovingBots verifies that scheduled auto-balance
+// leaves balancing bots in place through a brief human disconnect and only
+// trims them once the team has stayed over strength for the remove delay.
+func TestAutoBalanceWaitsBeforeRemovingBots(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AutoBalance = true
+	s := NewServerWithConfig(cfg)
+
+	for i, team := range []int{game.TeamFed, game.TeamFed, game.TeamRom} {
+		p := s.gameState.Players[i]
+		p.Status = game.StatusAlive
+		p.Team = team
+		p.Connected = true
+	}
+	romBots := func() int {
+		n := 0
+		for _, p := range s.gameState.Players {
+			if p.IsBot && p.Status != game.StatusFree && p.Team == game.TeamRom {
+				n++
+			}
+		}
+		return n
+	}
+
+	start := time.Now()
+	s.scheduledAutoBalance(start)
+	if got := romBots(); got != 1 {
+		t.Fatalf("Romulan bots after balancing = %d, want 1", got)
+	}
+
+	// A Federation player drops out and comes back within the delay
+	leaver := s.gameState.Players[1]
+	leaver.Status = game.StatusFree
+	leaver.Connected = false
+	s.scheduledAutoBalance(start.Add(5 * time.Second))
+	s.scheduledAutoBalance(start.Add(10 * time.Second))
+	if got := romBots(); got != 1 {
+		t.Fatalf("Romulan bots right after a disconnect = %d, want 1", got)
+	}
+	leaver.Status = game.StatusAlive
+	leaver.Connected = true
+	s.scheduledAutoBalance(start.Add(15 * time.Second))
+	s.scheduledAutoBalance(start.Add(50 * time.Second))
+	if got := romBots(); got != 1 {
+		t.Fatalf("Romulan bots after the player returned = %d, want 1", got)
+	}
+
+	// Gone for good: the surplus bot goes once the delay has passed
+	leaver.Status = game.StatusFree
+	leaver.Connected = false
+	s.scheduledAutoBalance(start.Add(55 * time.Second))
+	s.scheduledAutoBalance(start.Add(80 * time.Second))
+	if got := romBots(); got != 1 {
+		t.Fatalf("Romulan bots before the remove delay = %d, want 1", got)
+	}
+	s.scheduledAutoBalance(start.Add(90 * time.Second))
+	if got := romBots(); got != 0 {
+		t.Errorf("Romulan bots after the remove delay = %d, want 0", got)
+	}
+}
+
+// TestChronicallySlowClientIsDisconnected verifies that a client whose send
+// buffer stays full for maxConsecutiveDrops broadcasts is unregistered and
+// has its player slot freed.
+func TestChronicallySlowClientIsDisconnected(t *testing.T) {
+	s := NewServer()
+	go s.Run()
+	defer s.Shutdown()
+
+	s.gameState.Mu.Lock()
+	p := s.gameState.Players[0]
+	p.Status = game.StatusAlive
+	p.Team = game.TeamFed
+	p.Name = "Slowpoke"
+	p.Connected = true
+	p.OwnerClientID = 42
+	s.gameState.Mu.Unlock()
+
+	// A send buffer that is already full and never drained
+	client := &Client{ID: 42, send: make(chan ServerMessage, 1), server: s}
+	client.SetPlayerID(0)
+	client.send <- ServerMessage{Type: MsgTypeUpdate}
+	s.register <- client
+
+	registered := func() bool {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		_, ok := s.clients[42]
+		return ok
+	}
+
+	// Half the threshold (the game loop's own updates also count as drops)
+	for i := 0; i < maxConsecutiveDrops/2; i++ {
+		s.broadcast <- ServerMessage{Type: MsgTypeUpdate}
+	}
+	// Wait for the queued broadcasts to be handed out
+	deadline := time.Now().Add(2 * time.Second)
+	for len(s.broadcast) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !registered() {
+		t.Fatal("client should stay connected until the drop threshold is reached")
+	}
+
+	for i := 0; i < maxConsecutiveDrops/2; i++ {
+		s.broadcast <- ServerMessage{Type: MsgTypeUpdate}
+	}
+	for registered() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if registered() {
+		t.Fatal("chronically slow client should have been unregistered")
+	}
+
+	s.gameState.Mu.RLock()
+	defer s.gameState.Mu.RUnlock()
+	if p.Status != game.StatusFree {
+		t.Error("slow client's player slot should be freed")
+	}
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 	"log"
+	"math"
 	"math/bits"
 	"strings"
 	"sync/atomic"
@@ -62,6 +63,12 @@ func (s *Server) checkVictoryConditions() {
 		}
 	}
 
+	// Survival mode ends only when the defended starbase falls
+	if s.config().Survival {
+		s.checkSurvival()
+		return
+	}
+
 	// Check for genocide (all players of other teams eliminated)
 	// But require that multiple teams were playing (had players at some point)
 	totalPlayers := 0
@@ -91,7 +98,7 @@ func (s *Server) checkVictoryConditions() {
 		if p.Team <= 0 {
 			continue
 		}
-		willRespawn := p.Connected && (p.Status == game.StatusDead ||
+		willRespawn := p.Connected && !s.outOfLives(p) && (p.Status == game.StatusDead ||
 			(p.Status == game.StatusExplode && p.WhyDead != game.KillQuit))
 		if p.Status == game.StatusAlive || willRespawn {
 			inPlayFlags |= p.Team
@@ -114,8 +121,9 @@ func (s *Server) checkVictoryConditions() {
 	// - Game has been running for a bit
 	// - Only one team remains in play (alive or respawning), and it is the team
 	//   that currently has alive players
-	// - At least 2 total players currently
-	if numTeamsPlayed >= 2 && totalPlayers >= 2 && s.gameState.Frame > 100 && teamsInPlay == 1 && teamsAlive == 1 && lastTeamAlive > 0 {
+	// - At least 2 total players currently, unless the lives limit has
+	//   knocked the rest out
+	if numTeamsPlayed >= 2 && (totalPlayers >= 2 || s.config().Lives > 0) && s.gameState.Frame > 100 && teamsInPlay == 1 && teamsAlive == 1 && lastTeamAlive > 0 {
 		// Genocide victory
 		s.gameState.GameOver = true
 		s.gameState.Winner = lastTeamAlive
@@ -124,18 +132,30 @@ func (s *Server) checkVictoryConditions() {
 		return
 	}
 
-	// Check for conquest (one team owns all planets)
-	// Also require multiple players for conquest victory
+	// Check for conquest (one team owns Config.ConquestFraction of the planets)
+	// Also require multiple players for conquest victory. At a fraction of
+	// one half two teams can both reach the goal; neither wins until one
+	// pulls ahead.
 	if totalPlayers >= 2 && s.gameState.Frame > 100 {
+		goal := s.conquestPlanets()
+		conqueror := -1
 		for i, count := range s.gameState.TeamPlanets {
-			if count == game.MaxPlanets {
-				// Conquest victory
-				s.gameState.GameOver = true
-				s.gameState.Winner = teamIndexToFlag(i)
-				s.gameState.WinType = "conquest"
-				s.announceVictory()
-				return
+			if count < goal {
+				continue
+			}
+			if conqueror >= 0 {
+				conqueror = -1
+				break
 			}
+			conqueror = i
+		}
+		if conqueror >= 0 {
+			// Conquest victory
+			s.gameState.GameOver = true
+			s.gameState.Winner = teamIndexToFlag(conqueror)
+			s.gameState.WinType = "conquest"
+			s.announceVictory()
+			return
 		}
 	}
 
@@ -186,20 +206,20 @@ func (s *Server) checkVictoryConditions() {
 	}
 }
 
-// getTeamNamesFromFlag converts a combined team flag to a slice of team names
-func getTeamNamesFromFlag(teamFlag int) []string {
+// conquestPlanets returns how many planets a team must own for a conquest
+// victory under Config.ConquestFraction.
+func (s *Server) conquestPlanets() int {
+	return int(math.Ceil(s.config().ConquestFraction*game.MaxPlanets - 1e-9))
+}
+
+// getTeamNamesFromFlag converts a combined team flag to a slice of team names,
+// using any custom names from the map config (nil uses the defaults)
+func getTeamNamesFromFlag(m *game.MapConfig, teamFlag int) []string {
 	var names []string
-	if teamFlag&game.TeamFed != 0 {
-		names = append(names, "Federation")
-	}
-	if teamFlag&game.TeamRom != 0 {
-		names = append(names, "Romulan")
-	}
-	if teamFlag&game.TeamKli != 0 {
-		names = append(names, "Klingon")
-	}
-	if teamFlag&game.TeamOri != 0 {
-		names = append(names, "Orion")
+	for _, team := range []int{game.TeamFed, game.TeamRom, game.TeamKli, game.TeamOri} {
+		if teamFlag&team != 0 {
+			names = append(names, m.TeamName(team))
+		}
 	}
 	return names
 }
@@ -219,7 +239,7 @@ func formatTeamNames(names []string) string {
 
 // announceVictory sends victory message to all clients
 func (s *Server) announceVictory() {
-	teamNames := getTeamNamesFromFlag(s.gameState.Winner)
+	teamNames := getTeamNamesFromFlag(s.config().Map, s.gameState.Winner)
 	teamNameStr := formatTeamNames(teamNames)
 
 	var message string
@@ -230,10 +250,14 @@ func (s *Server) announceVictory() {
 			message = fmt.Sprintf("🎉 GENOCIDE! %s team has eliminated all enemies! Victory!", teamNameStr)
 		}
 	} else if s.gameState.WinType == "conquest" {
+		captured := "all planets"
+		if goal := s.conquestPlanets(); goal < game.MaxPlanets {
+			captured = fmt.Sprintf("%d of %d planets", goal, game.MaxPlanets)
+		}
 		if len(teamNames) > 1 {
-			message = fmt.Sprintf("🎉 CONQUEST! %s teams have captured all planets! Shared victory!", teamNameStr)
+			message = fmt.Sprintf("🎉 CONQUEST! %s teams have captured %s! Shared victory!", teamNameStr, captured)
 		} else {
-			message = fmt.Sprintf("🎉 CONQUEST! %s team has captured all planets! Victory!", teamNameStr)
+			message = fmt.Sprintf("🎉 CONQUEST! %s team has captured %s! Victory!", teamNameStr, captured)
 		}
 	} else if s.gameState.WinType == "domination" {
 		if len(teamNames) > 1 {
@@ -241,6 +265,8 @@ func (s *Server) announceVictory() {
 		} else {
 			message = fmt.Sprintf("🏆 DOMINATION! %s team controls all owned planets and enemies have no armies! Victory!", teamNameStr)
 		}
+	} else if s.gameState.WinType == "survival" {
+		message = fmt.Sprintf("💥 BASE DESTROYED! %s overran the starbase after %d waves. Game over!", teamNameStr, s.gameState.SurvivalWave)
 	} else if s.gameState.WinType == "timeout" {
 		if len(teamNames) > 1 {
 			message = fmt.Sprintf("⏱️ TIME LIMIT! %s teams share victory by controlling the most planets!", teamNameStr)
@@ -263,6 +289,9 @@ func (s *Server) announceVictory() {
 	default:
 		log.Printf("Warning: victory broadcast dropped (channel full)")
 	}
+	summary := s.buildMatchSummary()
+	s.tryBroadcast(ServerMessage{Type: MsgTypeMatchSummary, Data: summary})
+	s.postGameOverWebhook(summary)
 
 	// Schedule game reset after 10 seconds, respecting server shutdown.
 	// Guard with atomic bool to prevent concurrent reset goroutines.
@@ -296,6 +325,12 @@ func (s *Server) resetGame() {
 	// Reset game state in-place (do not replace the pointer)
 	s.gameState.Mu.Lock()
 
+	// Possessed bots go with every other slot
+	for _, client := range s.clients {
+		client.possessing = false
+		client.possessReturn = -1
+	}
+
 	// Reset all player slots
 	for i := 0; i < game.MaxPlayers; i++ {
 		p := s.gameState.Players[i]
@@ -315,8 +350,7 @@ func (s *Server) resetGame() {
 	}
 
 	// Re-initialize planets
-	game.InitPlanets(s.gameState)
-	game.InitINLPlanetFlags(s.gameState)
+	s.initPlanets()
 
 	// Reset game-level state
 	s.gameState.Frame = 0
@@ -324,14 +358,23 @@ func (s *Server) resetGame() {
 	s.gameState.T_mode = false
 	s.gameState.T_start = 0
 	s.gameState.T_remain = 0
+	s.gameState.T_forced = false
+	s.gameState.Ceasefire = false
 	s.gameState.GameOver = false
 	s.gameState.Winner = 0
 	s.gameState.WinType = ""
+	s.gameState.ActiveEvent = game.EventNone
+	s.gameState.EventEndFrame = 0
+	s.gameState.KillFeed = nil
 	s.gameState.Torps = make([]*game.Torpedo, 0)
 	s.gameState.Plasmas = make([]*game.Plasma, 0)
 	s.nextTorpID = 0
 	s.nextPlasmaID = 0
 	s.gameState.TournamentStats = make(map[int]*game.TournamentPlayerStats)
+	s.gameState.TeamOrders = make(map[int]game.TeamOrder)
+	s.gameState.BotSquads = make(map[int]string)
+	s.resetSurvival()
+	s.takeoverAlerts = nil
 	for i := range s.gameState.TeamPlayers {
 		s.gameState.TeamPlayers[i] = 0
 		s.gameState.TeamPlanets[i] = 0
@@ -1,6 +1,10 @@
 package server
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -410,7 +414,7 @@ func TestGetTeamNamesFromFlag(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := getTeamNamesFromFlag(tt.flag)
+			result := getTeamNamesFromFlag(nil, tt.flag)
 			if len(result) != len(tt.expected) {
 				t.Errorf("Expected %d team names, got %d", len(tt.expected), len(result))
 				return
@@ -496,3 +500,384 @@ func TestAnnounceVictoryMultiTeamTimeout(t *testing.T) {
 		t.Error("Expected victory broadcast message")
 	}
 }
+
+// TestConquestBroadcastsMatchSummary verifies that a conquest victory is
+// followed by a match summary naming the winner and the MVP.
+func TestConquestBroadcastsMatchSummary(t *testing.T) {
+	server := NewServer()
+	server.broadcast = make(chan ServerMessage, 10)
+	gs := server.gameState
+	gs.Frame = 3000 // Five minutes at 10 FPS
+
+	for _, planet := range gs.Planets {
+		planet.Owner = game.TeamFed
+	}
+	for i, kills := range []float64{1, 4, 2} {
+		p := gs.Players[i]
+		p.Status = game.StatusAlive
+		p.Team = game.TeamFed
+		p.Name = "Fed"
+		p.Kills = kills
+	}
+	rom := gs.Players[3]
+	rom.Status = game.StatusAlive
+	rom.Team = game.TeamRom
+	rom.Name = "Rom"
+	rom.Deaths = 7
+
+	server.checkVictoryConditions()
+	if !gs.GameOver || gs.WinType != "conquest" {
+		t.Fatalf("GameOver = %v, WinType = %q; want a conquest", gs.GameOver, gs.WinType)
+	}
+
+	for len(server.broadcast) > 0 {
+		msg := <-server.broadcast
+		if msg.Type != MsgTypeMatchSummary {
+			continue
+		}
+		summary := msg.Data.(matchSummary)
+		if summary.Winner != game.TeamFed || summary.WinType != "conquest" {
+			t.Errorf("summary winner = %d (%s), want Fed (conquest)", summary.Winner, summary.WinType)
+		}
+		if summary.MVP != 1 {
+			t.Errorf("MVP = %d, want player 1 with the most kills", summary.MVP)
+		}
+		if summary.Duration != 300 {
+			t.Errorf("duration = %d seconds, want 300", summary.Duration)
+		}
+		if len(summary.Players) != 4 || summary.Players[3].ID != rom.ID || summary.Players[3].Deaths != 7 {
+			t.Errorf("players = %+v, want all four ranked with Rom last", summary.Players)
+		}
+		return
+	}
+	t.Fatal("no match summary broadcast")
+}
+
+// TestConquestFractionSetsPlanetGoal verifies that a team holding half the
+// galaxy wins by conquest at a 50% threshold but not yet at 60%, and that two
+// teams holding half the galaxy each leave the game running.
+func TestConquestFractionSetsPlanetGoal(t *testing.T) {
+	for _, tc := range []struct {
+		fraction   float64
+		fedPlanets int
+		romPlanets int
+		wantWin    bool
+	}{
+		{0.5, 20, 20, false},
+		{0.5, 20, 10, true},
+		{0.6, 20, 10, false},
+		{0.6, 24, 10, true},
+	} {
+		cfg := DefaultConfig()
+		cfg.ConquestFraction = tc.fraction
+		server := NewServerWithConfig(cfg)
+		server.broadcast = make(chan ServerMessage, 10)
+		gs := server.gameState
+		gs.Frame = 3000
+
+		// The rest of the galaxy is neutral
+		for i, planet := range gs.Planets {
+			switch {
+			case i < tc.fedPlanets:
+				planet.Owner = game.TeamFed
+			case i < tc.fedPlanets+tc.romPlanets:
+				planet.Owner = game.TeamRom
+			default:
+				planet.Owner = game.TeamNone
+			}
+		}
+		for i, team := range []int{game.TeamFed, game.TeamRom} {
+			p := gs.Players[i]
+			p.Status = game.StatusAlive
+			p.Team = team
+		}
+
+		server.checkVictoryConditions()
+		if won := gs.GameOver && gs.WinType == "conquest" && gs.Winner == game.TeamFed; won != tc.wantWin {
+			t.Errorf("fraction %.1f with %d/%d planets: GameOver = %v, WinType = %q, Winner = %d; want Fed conquest %v",
+				tc.fraction, tc.fedPlanets, tc.romPlanets, gs.GameOver, gs.WinType, gs.Winner, tc.wantWin)
+		}
+		if tc.fedPlanets == tc.romPlanets && gs.GameOver {
+			t.Errorf("fraction %.1f: a %d/%d tie ended the game with winner %d", tc.fraction, tc.fedPlanets, tc.romPlanets, gs.Winner)
+		}
+	}
+}
+
+// TestConquestPostsGameOverWebhook verifies that the game-over transition
+// posts the match result to the configured webhook.
+func TestConquestPostsGameOverWebhook(t *testing.T) {
+	received := make(chan gameOverWebhook, 1)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload gameOverWebhook
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("webhook got %s with Content-Type %q, want a JSON POST", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("webhook body: %v", err)
+		}
+		received <- payload
+	}))
+	defer hook.Close()
+
+	cfg := DefaultConfig()
+	cfg.WebhookURL = hook.URL
+	server := NewServerWithConfig(cfg)
+	server.broadcast = make(chan ServerMessage, 10)
+	gs := server.gameState
+	gs.Frame = 1200 // Two minutes at 10 FPS
+
+	for _, planet := range gs.Planets {
+		planet.Owner = game.TeamFed
+	}
+	for i, kills := range []float64{1, 5, 3, 2} {
+		p := gs.Players[i]
+		p.Status = game.StatusAlive
+		p.Team = game.TeamFed
+		p.Kills = kills
+	}
+	rom := gs.Players[4]
+	rom.Status = game.StatusAlive
+	rom.Team = game.TeamRom
+
+	server.checkVictoryConditions()
+
+	select {
+	case payload := <-received:
+		if payload.Event != "game_over" || payload.Winner != game.TeamFed || payload.WinnerName != "Federation" || payload.WinType != "conquest" {
+			t.Errorf("payload = %+v, want a Federation conquest game_over", payload)
+		}
+		if payload.Duration != 120 {
+			t.Errorf("duration = %d seconds, want 120", payload.Duration)
+		}
+		if len(payload.TopPlayers) != webhookTopPlayers {
+			t.Fatalf("got %d top players, want %d", len(payload.TopPlayers), webhookTopPlayers)
+		}
+		for i, id := range []int{1, 2, 3} {
+			if payload.TopPlayers[i].ID != id {
+				t.Errorf("top player %d = %d, want %d", i, payload.TopPlayers[i].ID, id)
+			}
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook was not called")
+	}
+}
+
+// TestLivesLimitMovesPlayerToObserver verifies that a player who reaches the
+// death cap observes instead of respawning, and that knocking out a team's
+// last player ends the match.
+func TestLivesLimitMovesPlayerToObserver(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Lives = 2
+	server := NewServerWithConfig(cfg)
+	server.broadcast = make(chan ServerMessage, 100)
+	gs := server.gameState
+	gs.Frame = 200
+
+	setup := func(id, team, deaths, status int) *game.Player {
+		p := gs.Players[id]
+		p.Status = status
+		p.Team = team
+		p.Ship = game.ShipCruiser
+		p.Connected = true
+		p.Deaths = deaths
+		return p
+	}
+	fed := setup(0, game.TeamFed, 0, game.StatusAlive)
+	out := setup(1, game.TeamRom, 2, game.StatusDead)
+	spare := setup(2, game.TeamRom, 1, game.StatusDead)
+
+	server.updateGame()
+	if out.Status != game.StatusObserve {
+		t.Fatalf("player at the death cap has status %d, want observe", out.Status)
+	}
+	if spare.Status != game.StatusAlive {
+		t.Fatalf("player with a life left has status %d, want alive", spare.Status)
+	}
+	if left := server.livesLeft(); left[out.ID] != 0 || left[spare.ID] != 1 || left[fed.ID] != 2 {
+		t.Errorf("livesLeft = %v, want 0, 1 and 2 for players 1, 2 and 0", left)
+	}
+
+	// The observer stays out on later ticks
+	server.updateGame()
+	if out.Status != game.StatusObserve {
+		t.Errorf("observer respawned with status %d", out.Status)
+	}
+
+	// Losing the last Romulan life leaves the Federation alone in the match
+	spare.Status = game.StatusDead
+	spare.Deaths = 2
+	server.updateGame()
+	server.checkVictoryConditions()
+	if !gs.GameOver || gs.Winner != game.TeamFed || gs.WinType != "genocide" {
+		t.Errorf("GameOver = %v, winner %d (%s); want a Federation genocide", gs.GameOver, gs.Winner, gs.WinType)
+	}
+}
+
+// TestSurvivalEndsWhenBaseDestroyed verifies that survival mode launches the
+// defenders' starbase and attack waves aimed at it, and that the game ends
+// only once the starbase is destroyed.
+func TestSurvivalEndsWhenBaseDestroyed(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Survival = true
+	server := NewServerWithConfig(cfg)
+	server.broadcast = make(chan ServerMessage, 64)
+	gs := server.gameState
+	gs.Frame = 200
+
+	human := gs.Players[0]
+	human.Status = game.StatusAlive
+	human.Team = SurvivalDefenders
+	human.Ship = game.ShipCruiser
+	human.Connected = true
+	human.X, human.Y = 20000, 80000
+
+	server.launchSurvivalWaves()
+	if gs.SurvivalBase < 0 {
+		t.Fatal("the defenders' starbase should launch once a human joins")
+	}
+	base := gs.Players[gs.SurvivalBase]
+	if base.Ship != game.ShipStarbase || base.Team != SurvivalDefenders || !base.IsBot {
+		t.Fatalf("survival base is %v on team %d, want a defending starbase bot", base.Ship, base.Team)
+	}
+
+	gs.Frame = gs.SurvivalNextWave
+	server.launchSurvivalWaves()
+	var attackers []*game.Player
+	for _, p := range gs.Players {
+		if p.Status == game.StatusAlive && p.Team == SurvivalAttackers {
+			attackers = append(attackers, p)
+		}
+	}
+	if gs.SurvivalWave != 1 || len(attackers) != SurvivalFirstWave {
+		t.Fatalf("wave %d launched %d attackers, want wave 1 with %d", gs.SurvivalWave, len(attackers), SurvivalFirstWave)
+	}
+	server.updateBotHard(attackers[0])
+	if attackers[0].BotTarget != base.ID {
+		t.Errorf("attacker targets player %d, want the starbase %d", attackers[0].BotTarget, base.ID)
+	}
+
+	// Wiping out the attackers is not a victory in survival mode
+	for _, p := range attackers {
+		p.Status = game.StatusFree
+		p.Connected = false
+	}
+	server.checkVictoryConditions()
+	if gs.GameOver {
+		t.Fatalf("game ended (%s) while the starbase is alive", gs.WinType)
+	}
+
+	base.Status = game.StatusExplode
+	server.checkVictoryConditions()
+	if !gs.GameOver || gs.WinType != "survival" || gs.Winner != SurvivalAttackers {
+		t.Fatalf("GameOver=%v WinType=%q Winner=%d after the base fell, want a survival loss to the attackers",
+			gs.GameOver, gs.WinType, gs.Winner)
+	}
+	if gs.SurvivalBaseHull != 0 {
+		t.Errorf("base hull = %d after destruction, want 0", gs.SurvivalBaseHull)
+	}
+}
+
+// TestSurvivalSkipsTournamentAndBalance verifies that a survival game with
+// four ships on each side never enters tournament mode, and that /balance
+// adds no bots to the other teams.
+func TestSurvivalSkipsTournamentAndBalance(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Survival = true
+	server := NewServerWithConfig(cfg)
+	server.broadcast = make(chan ServerMessage, 64)
+	gs := server.gameState
+
+	for i := 0; i < 8; i++ {
+		p := gs.Players[i]
+		p.Status = game.StatusAlive
+		p.Connected = true
+		p.Ship = game.ShipCruiser
+		p.Team = SurvivalDefenders
+		if i >= 4 {
+			p.Team = SurvivalAttackers
+			p.IsBot = true
+		}
+	}
+
+	server.checkTournamentMode()
+	if gs.T_mode {
+		t.Fatal("survival game entered tournament mode at 4v4")
+	}
+
+	server.AutoBalanceBots()
+	for _, p := range gs.Players[8:] {
+		if p.Status != game.StatusFree {
+			t.Fatalf("/balance added a bot to team %d in survival mode", p.Team)
+		}
+	}
+}
+
+// TestSurvivalDefendersClearWave verifies that destroyed attackers leave
+// instead of respawning, and that a wave waits for free slots rather than
+// launching empty.
+func TestSurvivalDefendersClearWave(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Survival = true
+	server := NewServerWithConfig(cfg)
+	server.broadcast = make(chan ServerMessage, 256)
+	gs := server.gameState
+	gs.Frame = 200
+
+	human := gs.Players[0]
+	human.Status = game.StatusAlive
+	human.Team = SurvivalDefenders
+	human.Ship = game.ShipCruiser
+	human.Connected = true
+	human.X, human.Y = 20000, 80000
+
+	server.launchSurvivalWaves()
+	gs.Frame = gs.SurvivalNextWave
+	server.launchSurvivalWaves()
+	var attackers []*game.Player
+	for _, p := range gs.Players {
+		if p.Status == game.StatusAlive && p.Team == SurvivalAttackers {
+			attackers = append(attackers, p)
+		}
+	}
+	if len(attackers) != SurvivalFirstWave {
+		t.Fatalf("wave 1 launched %d attackers, want %d", len(attackers), SurvivalFirstWave)
+	}
+
+	// The defenders destroy the whole wave
+	for _, p := range attackers {
+		server.killPlayer(p, human.ID, game.KillTorp, 0)
+	}
+	for i := 0; i < 2*game.ExplodeTimerFrames*server.ticksPerFrame(); i++ {
+		server.updateGame()
+	}
+	for _, p := range attackers {
+		if p.Status != game.StatusFree || p.IsBot {
+			t.Errorf("destroyed attacker %d has status %d, want its slot freed", p.ID, p.Status)
+		}
+	}
+
+	// With every slot taken the next wave is held, not announced empty
+	for _, p := range gs.Players {
+		if p.Status == game.StatusFree {
+			p.Status = game.StatusAlive
+			p.Team = SurvivalDefenders
+			p.Connected = true
+		}
+	}
+	for len(server.broadcast) > 0 {
+		<-server.broadcast
+	}
+	gs.Frame = gs.SurvivalNextWave
+	server.launchSurvivalWaves()
+	if gs.SurvivalWave != 1 {
+		t.Errorf("wave counter = %d with no free slots, want it held at 1", gs.SurvivalWave)
+	}
+	if gs.SurvivalNextWave <= gs.Frame {
+		t.Error("a held wave should be rescheduled")
+	}
+	for len(server.broadcast) > 0 {
+		if msg := <-server.broadcast; strings.Contains(fmt.Sprint(msg.Data), "WAVE") {
+			t.Errorf("announced %v with no free slots", msg.Data)
+		}
+	}
+}
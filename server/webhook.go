@@ -0,0 +1,71 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+// webhookTopPlayers is how many of the best players the game-over webhook
+// lists.
+const webhookTopPlayers = 3
+
+// webhookTimeout bounds each outbound webhook request.
+const webhookTimeout = 5 * time.Second
+
+// gameOverWebhook is the JSON body posted to Config.WebhookURL when a match
+// ends.
+type gameOverWebhook struct {
+	Event      string               `json:"event"` // Always "game_over"
+	Winner     int                  `json:"winner"`
+	WinnerName string               `json:"winnerName"`
+	WinType    string               `json:"winType"`
+	Duration   int                  `json:"duration"` // Seconds
+	TopPlayers []matchPlayerSummary `json:"topPlayers"`
+}
+
+// buildGameOverWebhook assembles the webhook body from the match summary,
+// keeping its best-first player order.
+func (s *Server) buildGameOverWebhook(summary matchSummary) gameOverWebhook {
+	top := summary.Players
+	if len(top) > webhookTopPlayers {
+		top = top[:webhookTopPlayers]
+	}
+	return gameOverWebhook{
+		Event:      "game_over",
+		Winner:     summary.Winner,
+		WinnerName: formatTeamNames(getTeamNamesFromFlag(s.config().Map, summary.Winner)),
+		WinType:    summary.WinType,
+		Duration:   summary.Duration,
+		TopPlayers: top,
+	}
+}
+
+// postGameOverWebhook posts the match result to Config.WebhookURL, if set.
+// The request runs in its own goroutine so a slow endpoint never stalls the
+// game loop; failures are only logged.
+func (s *Server) postGameOverWebhook(summary matchSummary) {
+	url := s.config().WebhookURL
+	if url == "" {
+		return
+	}
+	body, err := json.Marshal(s.buildGameOverWebhook(summary))
+	if err != nil {
+		log.Printf("Webhook: failed to encode payload: %v", err)
+		return
+	}
+	go func() {
+		client := &http.Client{Timeout: webhookTimeout}
+		resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Printf("Webhook: post failed: %v", err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			log.Printf("Webhook: %s answered %s", url, resp.Status)
+		}
+	}()
+}
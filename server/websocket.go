@@ -20,9 +20,15 @@ const (
 	wsReadTimeout  = 60 * time.Second // Read deadline for client messages
 	wsPingInterval = 54 * time.Second // Ping interval (must be less than wsReadTimeout)
 
-	// Maximum concurrent WebSocket connections to prevent memory exhaustion.
-	// Each connection spawns 2 goroutines and a 256-entry channel buffer.
+	// Default maximum concurrent WebSocket connections to prevent memory
+	// exhaustion. Each connection spawns 2 goroutines and a 256-entry
+	// channel buffer.
 	maxConnections = 128
+
+	// Consecutive broadcasts a client may miss because its send buffer is
+	// full before it is disconnected so it can reconnect with fresh state
+	// (about 10 seconds of game updates).
+	maxConsecutiveDrops = 100
 )
 
 // isValidOrigin checks if the origin is allowed to connect
@@ -69,36 +75,81 @@ var upgrader = websocket.Upgrader{
 	EnableCompression: true, // Enable per-message deflate compression
 }
 
+// plainUpgrader never negotiates compression, for LAN clients where the CPU
+// cost of deflate outweighs the bandwidth saved.
+var plainUpgrader = websocket.Upgrader{
+	CheckOrigin:       isValidOrigin,
+	EnableCompression: false,
+}
+
+// upgraderFor picks the upgrader for a connection: the server default from
+// Config.WSCompression, overridden by a "compress" query parameter
+// (compress=0 opts out, compress=1 opts in).
+func (s *Server) upgraderFor(r *http.Request) *websocket.Upgrader {
+	compress := s.config().WSCompression
+	switch strings.ToLower(r.URL.Query().Get("compress")) {
+	case "0", "false", "off", "no":
+		compress = false
+	case "1", "true", "on", "yes":
+		compress = true
+	}
+	if compress {
+		return &upgrader
+	}
+	return &plainUpgrader
+}
+
+// ProtocolVersion is the client/server protocol version this server speaks,
+// sent back in login_success. Bump it whenever messages change in a way old
+// clients can't handle, and raise Config.MinProtocolVersion to turn those
+// clients away at login.
+const ProtocolVersion = 1
+
 // Message types
 const (
-	MsgTypeLogin      = "login"
-	MsgTypeMove       = "move"
-	MsgTypeFire       = "fire"
-	MsgTypePhaser     = "phaser"
-	MsgTypeShields    = "shields"
-	MsgTypeOrbit      = "orbit"
-	MsgTypeRepair     = "repair"
-	MsgTypeLock       = "lock"
-	MsgTypeBeam       = "beam"
-	MsgTypeBomb       = "bomb"
-	MsgTypeCloak      = "cloak"
-	MsgTypeTractor    = "tractor"
-	MsgTypePressor    = "pressor"
-	MsgTypePlasma     = "plasma"
-	MsgTypeDetonate   = "detonate"
-	MsgTypeMessage    = "message"
-	MsgTypeTeamMsg    = "teammsg"
-	MsgTypePrivMsg    = "privmsg"
-	MsgTypeQuit       = "quit"
-	MsgTypeUpdate     = "update"
-	MsgTypeError      = "error"
-	MsgTypeTeamUpdate = "team_update"
+	MsgTypeLogin       = "login"
+	MsgTypeMove        = "move"
+	MsgTypeFire        = "fire"
+	MsgTypePhaser      = "phaser"
+	MsgTypeShields     = "shields"
+	MsgTypeOrbit       = "orbit"
+	MsgTypeRepair      = "repair"
+	MsgTypeLock        = "lock"
+	MsgTypeBeam        = "beam"
+	MsgTypeBomb        = "bomb"
+	MsgTypeCloak       = "cloak"
+	MsgTypeECM         = "ecm"
+	MsgTypeTractor     = "tractor"
+	MsgTypePressor     = "pressor"
+	MsgTypePlasma      = "plasma"
+	MsgTypeDetonate    = "detonate"
+	MsgTypeMessage     = "message"
+	MsgTypeTeamMsg     = "teammsg"
+	MsgTypePrivMsg     = "privmsg"
+	MsgTypeObsMsg      = "obsmsg" // Observer-only chat, never delivered to players in the match
+	MsgTypeQuit        = "quit"
+	MsgTypeUpdate      = "update"
+	MsgTypeError       = "error"
+	MsgTypeTeamUpdate  = "team_update"
+	MsgTypeAssist      = "assist"
+	MsgTypeAutoRepair  = "autorepair"
+	MsgTypeOrbitRepair = "orbitrepair"
+	MsgTypeSpecial     = "special"
+	MsgTypeLockCycle   = "lock_cycle" // Lock the next enemy by distance; the reply names it
+
+	// End-of-match standings, sent once when a victory is declared
+	MsgTypeMatchSummary = "match_summary"
+
+	// The player slot a connection now flies, sent when an admin possesses
+	// or releases a bot
+	MsgTypeControl = "control"
 )
 
 // ClientMessage represents a message from client to server
 type ClientMessage struct {
 	Type string          `json:"type"`
 	Data json.RawMessage `json:"data"`
+	Slot int             `json:"slot,omitempty"` // Hot seat index; 0 is the connection's own seat
 }
 
 // ServerMessage represents a message from server to client
@@ -135,10 +186,19 @@ type Client struct {
 	conn     *websocket.Conn
 	send     chan ServerMessage
 	server   *Server
+	dropped  int // Consecutive broadcasts skipped because send was full (Run goroutine only)
 
 	// Rate limiting for destructive bot commands
 	lastBotCmd     time.Time // Last /fillbots or /clearbots execution
 	botCmdCooldown time.Duration
+
+	// Extra hot seats driven over this connection, by slot index
+	seatsMu sync.Mutex
+	seats   map[int]*Client
+
+	// Bot possessed by an admin (see possessBot), guarded by gameState.Mu
+	possessing    bool
+	possessReturn int // Player slot the client flew before possessing
 }
 
 // GetPlayerID returns the player ID atomically
@@ -175,11 +235,30 @@ type Server struct {
 	cachedIsolationFrame     int64                // Frame when isolation cache was last computed
 	cachedPlanetThreats      map[int]planetThreat // Per-planet threat cache (bot-independent, shared per team)
 	cachedPlanetThreatsFrame int64                // Frame when planet-threat cache was last computed
+	cfg                      *Config              // Operator-tunable settings (nil means DefaultConfig)
+	lastTick                 atomic.Int64         // Unix nanoseconds of the last game loop tick (for /readyz)
+	subTick                  int                  // Ticks since the last game frame (guarded by gameState.Mu)
+	balanceSurplusSince      map[int]time.Time    // When each over-strength team first had surplus bots (game loop only)
+	queueMu                  sync.Mutex           // Guards loginQueue
+	loginQueue               []*queuedLogin       // Logins waiting for a free player slot, oldest first
+	director                 directorFocus        // Director camera target for observers (guarded by gameState.Mu)
+	ready                    map[int]bool         // Client IDs that sent /ready for a ready-checked match (guarded by mu)
+	botNames                 map[int]string       // Pool name held by each bot slot (guarded by gameState.Mu)
+	teamHandicaps            map[int]TeamHandicap // Per-team handicaps set through /api/handicap (guarded by gameState.Mu)
+	takeoverAlerts           map[int]int64        // Frame of each planet's last takeover alert (guarded by gameState.Mu)
+	projectileEnds           []projectileEnd      // Projectiles that left play this tick, sent with the update (guarded by gameState.Mu)
+	ratings                  *ratingStore         // Human ratings by name, kept across logins and restarts (nil when ratings are disabled)
 }
 
-// NewServer creates a new game server
+// NewServer creates a new game server with the default configuration
 func NewServer() *Server {
-	return &Server{
+	return NewServerWithConfig(DefaultConfig())
+}
+
+// NewServerWithConfig creates a new game server using the given configuration
+func NewServerWithConfig(cfg Config) *Server {
+	s := &Server{
+		cfg:         &cfg,
 		clients:     make(map[int]*Client),
 		register:    make(chan *Client),
 		unregister:  make(chan *Client),
@@ -189,6 +268,11 @@ func NewServer() *Server {
 		done:        make(chan struct{}),
 		playerGrid:  NewSpatialGrid(),
 	}
+	if cfg.Ratings {
+		s.ratings = newRatingStore(cfg.RatingsFile)
+	}
+	s.initPlanets()
+	return s
 }
 
 // Shutdown signals the server to stop background goroutines
@@ -221,10 +305,93 @@ func (s *Server) freeDisconnectedSlot(clientID, playerID int) bool {
 	return true
 }
 
+// takeOverDisconnectedSlot hands a disconnecting human's ship to a bot, keeping
+// its position, damage, armies and score so the team isn't suddenly a ship
+// down. The same ownership guard as freeDisconnectedSlot applies. Returns true
+// if the slot was converted. Acquires the gameState lock internally.
+func (s *Server) takeOverDisconnectedSlot(clientID, playerID int) bool {
+	if playerID < 0 || playerID >= game.MaxPlayers {
+		return false
+	}
+	s.gameState.Mu.Lock()
+	defer s.gameState.Mu.Unlock()
+	p := s.gameState.Players[playerID]
+	if p.IsBot || p.OwnerClientID != clientID || p.Status == game.StatusFree {
+		return false
+	}
+	log.Printf("Bot taking over ship of disconnected player %s", p.Name)
+	// The player's own name holds the bot name, so the pool never hands it
+	// to another bot, and reclaimShip can give the ship back by name
+	if s.botNames == nil {
+		s.botNames = make(map[int]string)
+	}
+	s.botNames[playerID] = p.Name
+	p.BotTakeoverOf = p.Name
+	p.Name = "[BOT] " + p.Name
+	p.OwnerClientID = -1
+	p.LastUpdate = time.Time{}
+
+	p.IsBot = true
+	p.Dummy = false
+	p.BotTarget = -1
+	p.BotTargetLockTime = 0
+	p.BotTargetValue = 0
+	p.BotPlanetApproachID = -1
+	p.BotDefenseTarget = -1
+	p.BotMemoryUntil = 0
+	p.BotGoalX = 0
+	p.BotGoalY = 0
+	p.BotCooldown = 0
+	p.BotCaution = ""
+
+	// Drop human-only helpers and any pending manual orders
+	p.Assist = false
+	p.AutoRepair = 0
+	p.AutoRepairSet = false
+	p.OrbitRepair = false
+	p.OrbitRepairSet = false
+	p.LockType = "none"
+	p.LockTarget = -1
+	return true
+}
+
+// findTakenOverShip returns the ship a bot took over from the disconnected
+// player called name, or nil. Caller must hold gameState.Mu.
+func (s *Server) findTakenOverShip(name string) *game.Player {
+	for _, p := range s.gameState.Players {
+		if p.IsBot && p.Status != game.StatusFree && p.BotTakeoverOf == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// reclaimShip hands a ship taken over by a bot back to its returning player
+// on client clientID, as the bot left it. Caller must hold gameState.Mu.
+func (s *Server) reclaimShip(p *game.Player, clientID int) {
+	log.Printf("Player %s reclaimed their ship from a bot", p.BotTakeoverOf)
+	s.releaseBotName(p.ID)
+	p.Name = p.BotTakeoverOf
+	p.BotTakeoverOf = ""
+	p.IsBot = false
+	p.Connected = true
+	p.LastUpdate = time.Now()
+	p.OwnerClientID = clientID
+	p.BotTarget = -1
+	p.BotTargetLockTime = 0
+	p.BotPlanetApproachID = -1
+	p.BotDefenseTarget = -1
+	p.BotMemoryUntil = 0
+	p.BotCooldown = 0
+}
+
 // Run starts the server main loop
 func (s *Server) Run() {
 	// Start game loop
 	go s.gameLoop()
+	if s.ratings != nil {
+		go s.saveRatings()
+	}
 
 	// Handle client events
 	for {
@@ -238,25 +405,7 @@ func (s *Server) Run() {
 			log.Printf("Client %d connected", client.ID)
 
 		case client := <-s.unregister:
-			needBroadcast := false
-			// Capture playerID once to avoid race between multiple GetPlayerID() calls
-			playerID := client.GetPlayerID()
-			s.mu.Lock()
-			if _, ok := s.clients[client.ID]; ok {
-				delete(s.clients, client.ID)
-				close(client.send)
-				s.activeConns.Add(-1) // Release the connection slot
-
-				// Immediately free the player slot on disconnect, but only if
-				// this client still owns it.
-				needBroadcast = s.freeDisconnectedSlot(client.ID, playerID)
-			}
-			s.mu.Unlock()
-			// Broadcast updated team counts after releasing s.mu to avoid deadlock
-			if needBroadcast {
-				s.broadcastTeamCounts()
-			}
-			log.Printf("Client %d disconnected", client.ID)
+			s.removeClient(client)
 
 		case message := <-s.broadcast:
 			s.mu.RLock()
@@ -273,23 +422,94 @@ func (s *Server) Run() {
 					}
 				}
 			}
+			var slowClients []*Client
 			for _, client := range s.clients {
-				if targetPlayerID >= 0 && client.GetPlayerID() != targetPlayerID {
+				if targetPlayerID >= 0 && !client.drives(targetPlayerID) {
 					continue // Skip clients that are not the intended recipient
 				}
 				select {
 				case client.send <- message:
 					// Successfully sent
+					client.dropped = 0
 				default:
 					// Client send channel is full, skip this message
 					log.Printf("Warning: Client %d send buffer full, skipping broadcast", client.ID)
+					client.dropped++
+					if client.dropped >= maxConsecutiveDrops {
+						slowClients = append(slowClients, client)
+					}
 				}
 			}
 			s.mu.RUnlock()
+
+			// Disconnect clients that have fallen hopelessly behind rather
+			// than leave them silently desynced
+			for _, client := range slowClients {
+				log.Printf("Client %d missed %d consecutive broadcasts, disconnecting", client.ID, client.dropped)
+				if client.conn != nil {
+					_ = client.conn.WriteControl(websocket.CloseMessage,
+						websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "Connection too slow to keep up, please reconnect"),
+						time.Now().Add(100*time.Millisecond))
+				}
+				s.removeClient(client)
+			}
 		}
 	}
 }
 
+// removeClient drops a disconnected client, releases its connection slot and
+// frees the player slot it owned. Safe to call more than once for the same
+// client. Must only be called from the Run goroutine.
+func (s *Server) removeClient(client *Client) {
+	var freedTeams []int
+	// Hand a possessed bot back first so the admin's own slot is freed below
+	s.endPossession(client)
+	// Capture player IDs once to avoid race between multiple GetPlayerID() calls
+	playerIDs := client.seatPlayerIDs()
+	s.mu.Lock()
+	if _, ok := s.clients[client.ID]; ok {
+		delete(s.clients, client.ID)
+		close(client.send)
+		s.activeConns.Add(-1) // Release the connection slot
+		s.dequeueLogin(client)
+		delete(s.ready, client.ID)
+
+		// Immediately free the player slots on disconnect (or hand them to
+		// bots), but only those this client still owns.
+		for _, playerID := range playerIDs {
+			if s.config().BotTakeoverOnDisconnect && s.takeOverDisconnectedSlot(client.ID, playerID) {
+				continue
+			}
+			if s.freeDisconnectedSlot(client.ID, playerID) {
+				s.gameState.Mu.RLock()
+				freedTeams = append(freedTeams, s.gameState.Players[playerID].Team)
+				s.gameState.Mu.RUnlock()
+			}
+		}
+
+		// Those left may now make up the ready quorum on their own
+		if s.config().ReadyCheck && len(s.ready) > 0 {
+			s.gameState.Mu.Lock()
+			if !s.gameState.T_mode {
+				s.checkReadyQuorum()
+			}
+			s.gameState.Mu.Unlock()
+		}
+	}
+	s.mu.Unlock()
+	// Optionally backfill the departed players' teams with bots
+	if s.config().BackfillOnDisconnect {
+		for _, team := range freedTeams {
+			s.backfillTeam(team)
+		}
+	}
+	// Broadcast updated team counts after releasing s.mu to avoid deadlock
+	if len(freedTeams) > 0 {
+		s.broadcastTeamCounts()
+	}
+	log.Printf("Client %d disconnected", client.ID)
+}
+
 // pendingPlayerMsg is a message to send to a specific player after locks are released
 type pendingPlayerMsg struct {
 	playerID int
@@ -298,21 +518,30 @@ type pendingPlayerMsg struct {
 
 // gameLoop runs the main game simulation
 func (s *Server) gameLoop() {
-	ticker := time.NewTicker(game.UpdateInterval)
+	ticker := time.NewTicker(s.tickInterval())
 	defer ticker.Stop()
+	balanceTicker := time.NewTicker(autoBalanceInterval)
+	defer balanceTicker.Stop()
 
 	for {
 		select {
 		case <-s.done:
 			return
+		case now := <-balanceTicker.C:
+			if s.config().AutoBalance {
+				s.scheduledAutoBalance(now)
+			}
 		case <-ticker.C:
 			pending := s.updateGame()
+			if s.config().Survival {
+				s.launchSurvivalWaves()
+			}
 			// Send buffered per-player messages after game state lock is released
 			if len(pending) > 0 {
 				s.mu.RLock()
 				for _, pm := range pending {
 					for _, client := range s.clients {
-						if client.GetPlayerID() == pm.playerID {
+						if client.drives(pm.playerID) {
 							select {
 							case client.send <- pm.msg:
 							default:
@@ -324,6 +553,7 @@ func (s *Server) gameLoop() {
 				s.mu.RUnlock()
 			}
 			s.sendGameState()
+			s.promoteQueued()
 		}
 	}
 }
@@ -335,6 +565,18 @@ func (s *Server) updateGame() []pendingPlayerMsg {
 
 	var pendingMsgs []pendingPlayerMsg
 
+	s.lastTick.Store(time.Now().UnixNano())
+
+	// The previous tick's update already carried its projectile end events
+	s.projectileEnds = s.projectileEnds[:0]
+
+	// Ticks between game frames only carry ships and projectiles along
+	s.subTick = (s.subTick + 1) % s.ticksPerFrame()
+	if s.subTick != 0 {
+		s.advanceMotion()
+		return nil
+	}
+
 	s.gameState.Frame++
 	s.gameState.TickCount++
 
@@ -364,6 +606,7 @@ func (s *Server) updateGame() []pendingPlayerMsg {
 				p.Status = game.StatusFree
 				p.Name = ""
 				p.IsBot = false
+				p.Dummy = false
 				p.Connected = false
 				botCount++
 			}
@@ -386,17 +629,23 @@ func (s *Server) updateGame() []pendingPlayerMsg {
 		// Only reset if we haven't already reset (transition from players to no players)
 		if !s.galaxyReset {
 			// Re-initialize planets to startup state
-			game.InitPlanets(s.gameState)
-			game.InitINLPlanetFlags(s.gameState)
+			s.initPlanets()
 
 			// Reset game state
 			s.gameState.Frame = 0
 			s.gameState.T_mode = false
 			s.gameState.T_start = 0
 			s.gameState.T_remain = 0
+			s.gameState.T_forced = false
+			s.gameState.Ceasefire = false
 			s.gameState.GameOver = false
 			s.gameState.Winner = 0
 			s.gameState.WinType = ""
+			s.gameState.ActiveEvent = game.EventNone
+			s.gameState.EventEndFrame = 0
+			s.gameState.KillFeed = nil
+			s.resetSurvival()
+			s.takeoverAlerts = nil
 
 			// Clear tournament stats
 			s.gameState.TournamentStats = make(map[int]*game.TournamentPlayerStats)
@@ -426,7 +675,8 @@ func (s *Server) updateGame() []pendingPlayerMsg {
 			// On the first frame of explosion, deal damage to nearby ships
 			if p.ExplodeTimer == game.ExplodeTimerFrames && p.WhyDead != game.KillQuit {
 				// Calculate explosion damage to nearby ships
-				explosionDamage := game.GetShipExplosionDamage(p.Ship)
+				explosionDamage := game.ShipData[p.Ship].ExplosionDamage
+				fullDist, maxDist := s.config().ExplosionFullDist, s.config().ExplosionMaxDist
 
 				// Check all other players for explosion damage
 				for j := 0; j < game.MaxPlayers; j++ {
@@ -440,20 +690,20 @@ func (s *Server) updateGame() []pendingPlayerMsg {
 					}
 
 					// Calculate distance to explosion
-					dist := game.Distance(p.X, p.Y, target.X, target.Y)
+					dist := s.distance(p.X, p.Y, target.X, target.Y)
 
 					// Apply damage based on distance
 					var damage int
-					if dist <= game.ShipExplosionDist {
+					if dist <= fullDist {
 						// Full damage within close range
 						damage = explosionDamage
-					} else if dist < game.ShipExplosionMaxDist {
+					} else if dist < maxDist {
 						// Reduced damage with linear falloff
-						damage = int(float64(explosionDamage) * (game.ShipExplosionMaxDist - dist) / game.ShipExplosionRange)
+						damage = int(float64(explosionDamage) * (maxDist - dist) / (maxDist - fullDist))
 					}
 
 					if damage > 0 {
-						actualDamage := game.ApplyDamageWithShields(target, damage)
+						actualDamage := s.applyDamage(target, damage, game.DamageOther)
 						if target.Damage >= game.ShipData[target.Ship].MaxDamage {
 							s.killPlayer(target, i, game.KillExplosion, actualDamage)
 						}
@@ -463,8 +713,23 @@ func (s *Server) updateGame() []pendingPlayerMsg {
 
 			p.ExplodeTimer--
 			if p.ExplodeTimer <= 0 {
-				// Check if this was a self-destruct quit
-				if p.WhyDead == game.KillQuit {
+				// Practice dummies are removed once destroyed
+				if p.Dummy {
+					p.Status = game.StatusFree
+					p.Name = ""
+					p.Connected = false
+					p.IsBot = false
+					p.Dummy = false
+					p.WhyDead = game.KillNone
+				} else if s.survivalAttacker(p) {
+					// Destroyed attackers leave, so each wave can be cleared
+					s.releaseBotName(p.ID)
+					p.Status = game.StatusFree
+					p.Name = ""
+					p.Connected = false
+					p.IsBot = false
+					p.WhyDead = game.KillNone
+				} else if p.WhyDead == game.KillQuit {
 					// Player quit via self-destruct, free the slot
 					p.Status = game.StatusFree
 					p.Name = ""
@@ -485,6 +750,22 @@ func (s *Server) updateGame() []pendingPlayerMsg {
 
 		// Handle dead state - respawn
 		if p.Status == game.StatusDead && p.Connected {
+			// A player who has used up every life watches the rest of the match
+			if s.outOfLives(p) {
+				p.Status = game.StatusObserve
+				pendingMsgs = append(pendingMsgs, pendingPlayerMsg{
+					playerID: p.ID,
+					msg: ServerMessage{
+						Type: MsgTypeMessage,
+						Data: map[string]interface{}{
+							"text": "Out of lives - you are observing until the match ends",
+							"type": "error",
+						},
+					},
+				})
+				continue
+			}
+
 			// Check if team owns planets during t-mode
 			if s.gameState.T_mode {
 				teamPlanetCount := 0
@@ -544,6 +825,7 @@ func (s *Server) updateGame() []pendingPlayerMsg {
 	}
 
 	// Update game systems using extracted modules
+	s.updateGameEvents()         // Start/end timed rule-changing events
 	s.updateShipSystems()        // Fuel, heat, repair, cloak for all players
 	s.updatePlanetInteractions() // Planet interactions, orbital mechanics, bombing/beaming
 	s.updateProjectiles()        // Torpedo and plasma movement/collision
@@ -567,12 +849,17 @@ func (s *Server) updateGame() []pendingPlayerMsg {
 		s.updatePlayerLockOn(p)
 	}
 
-	// Update bot AI
+	// Update bot AI, starting with each team's commander and squads
+	s.updateCommanders()
+	s.updateSquads()
 	s.UpdateBots()
 	// Apply buffered target suggestions after all bots have been processed,
 	// so processing order does not affect targeting decisions.
 	s.ApplyPendingTargetSuggestions()
 
+	// Point the director camera at the busiest fight
+	s.updateDirector()
+
 	// Check tournament mode
 	s.checkTournamentMode()
 
@@ -589,27 +876,41 @@ func (s *Server) sendGameState() {
 	// Marshal game state to JSON while holding the lock to prevent races.
 	// Use pointers from GameState arrays directly to avoid copying large structs.
 	update := struct {
-		Frame    int64           `json:"frame"`
-		Players  []*game.Player  `json:"players"`
-		Planets  []*game.Planet  `json:"planets"`
-		Torps    []*game.Torpedo `json:"torps"`
-		Plasmas  []*game.Plasma  `json:"plasmas"`
-		GameOver bool            `json:"gameOver"`
-		Winner   int             `json:"winner,omitempty"`
-		WinType  string          `json:"winType,omitempty"`
-		TMode    bool            `json:"tMode"`
-		TRemain  int             `json:"tRemain,omitempty"`
+		Frame     int64           `json:"frame"`
+		Players   []*game.Player  `json:"players"`
+		Planets   []*game.Planet  `json:"planets"`
+		Torps     []*game.Torpedo `json:"torps"`
+		Plasmas   []*game.Plasma  `json:"plasmas"`
+		GameOver  bool            `json:"gameOver"`
+		Winner    int             `json:"winner,omitempty"`
+		WinType   string          `json:"winType,omitempty"`
+		TMode     bool            `json:"tMode"`
+		TRemain   int             `json:"tRemain,omitempty"`
+		Event     string          `json:"event,omitempty"`
+		Ceasefire bool            `json:"ceasefire,omitempty"`
+		Director  directorFocus   `json:"director"`
+		LivesLeft map[int]int     `json:"livesLeft,omitempty"` // Player ID -> respawns left, when lives are limited
+		Survival  *survivalStatus `json:"survival,omitempty"`
+		Conquest  int             `json:"conquestPlanets"` // Planets a team must own for a conquest victory
+		Ends      []projectileEnd `json:"projectileEnds,omitempty"`
 	}{
-		Frame:    s.gameState.Frame,
-		Players:  s.gameState.Players[:],
-		Planets:  s.gameState.Planets[:],
-		Torps:    s.gameState.Torps,
-		Plasmas:  s.gameState.Plasmas,
-		GameOver: s.gameState.GameOver,
-		Winner:   s.gameState.Winner,
-		WinType:  s.gameState.WinType,
-		TMode:    s.gameState.T_mode,
-		TRemain:  s.gameState.T_remain,
+		Frame:     s.gameState.Frame,
+		Players:   s.gameState.Players[:],
+		Planets:   s.gameState.Planets[:],
+		Torps:     s.gameState.Torps,
+		Plasmas:   s.gameState.Plasmas,
+		GameOver:  s.gameState.GameOver,
+		Winner:    s.gameState.Winner,
+		WinType:   s.gameState.WinType,
+		TMode:     s.gameState.T_mode,
+		TRemain:   s.gameState.T_remain,
+		Event:     s.gameState.ActiveEvent,
+		Ceasefire: s.gameState.Ceasefire,
+		Director:  s.director,
+		LivesLeft: s.livesLeft(),
+		Survival:  s.survivalProgress(),
+		Conquest:  s.conquestPlanets(),
+		Ends:      s.projectileEnds,
 	}
 
 	data, err := json.Marshal(update)
@@ -657,14 +958,14 @@ func (s *Server) HandleTeamStats(w http.ResponseWriter, r *http.Request) {
 func (s *Server) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	// Atomically reserve a connection slot before upgrading to prevent
 	// the TOCTOU race where multiple concurrent requests could all pass
-	// a count check and exceed maxConnections.
-	if s.activeConns.Add(1) > int32(maxConnections) {
+	// a count check and exceed the connection limit.
+	if s.activeConns.Add(1) > int32(s.config().MaxConnections) {
 		s.activeConns.Add(-1) // Release the slot we just took
 		http.Error(w, "Server full", http.StatusServiceUnavailable)
 		return
 	}
 
-	conn, err := upgrader.Upgrade(w, r, nil)
+	conn, err := s.upgraderFor(r).Upgrade(w, r, nil)
 	if err != nil {
 		s.activeConns.Add(-1) // Release slot on upgrade failure
 		log.Printf("WebSocket upgrade error: %v", err)
@@ -774,6 +1075,20 @@ func (c *Client) handleMessage(msg ClientMessage) {
 		}
 	}()
 
+	if msg.Slot != 0 {
+		seat := c.seat(msg.Slot)
+		if seat == nil {
+			c.sendMsg(ServerMessage{
+				Type: MsgTypeError,
+				Data: "Invalid slot",
+			})
+			return
+		}
+		msg.Slot = 0
+		seat.handleMessage(msg)
+		return
+	}
+
 	switch msg.Type {
 	case MsgTypeLogin:
 		c.handleLogin(msg.Data)
@@ -791,6 +1106,8 @@ func (c *Client) handleMessage(msg ClientMessage) {
 		c.handleRepair(msg.Data)
 	case MsgTypeLock:
 		c.handleLock(msg.Data)
+	case MsgTypeLockCycle:
+		c.handleLockCycle(msg.Data)
 	case MsgTypeBeam:
 		c.handleBeam(msg.Data)
 	case MsgTypeBomb:
@@ -805,12 +1122,24 @@ func (c *Client) handleMessage(msg ClientMessage) {
 		c.handleDetonate(msg.Data)
 	case MsgTypeCloak:
 		c.handleCloak(msg.Data)
+	case MsgTypeECM:
+		c.handleECM(msg.Data)
+	case MsgTypeAssist:
+		c.handleAssist(msg.Data)
+	case MsgTypeAutoRepair:
+		c.handleAutoRepair(msg.Data)
+	case MsgTypeOrbitRepair:
+		c.handleOrbitRepair(msg.Data)
+	case MsgTypeSpecial:
+		c.handleSpecial(msg.Data)
 	case MsgTypeMessage:
 		c.handleChatMessage(msg.Data)
 	case MsgTypeTeamMsg:
 		c.handleTeamMessage(msg.Data)
 	case MsgTypePrivMsg:
 		c.handlePrivateMessage(msg.Data)
+	case MsgTypeObsMsg:
+		c.handleObserverMessage(msg.Data)
 	case MsgTypeQuit:
 		c.handleQuit(msg.Data)
 	default:
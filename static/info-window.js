@@ -145,6 +145,7 @@ class InfoWindow {
         let status = [];
         if (player.shields_up) status.push('Shields');
         if (player.cloaked) status.push('Cloak');
+        if (player.ecm) status.push('ECM');
         if (player.wtemp > 50) status.push('W-Temp');
         if (player.etemp > 50) status.push('E-Temp');
         if (player.armies > 0) status.push(`${escapeHtml(player.armies)} armies`);
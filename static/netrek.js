@@ -30,6 +30,9 @@ function getBasePath() {
     return basePath.replace(/\/+$/, '');
 }
 
+// Protocol version sent at login; must match the server's ProtocolVersion
+const PROTOCOL_VERSION = 1;
+
 // Visual constants for galactic map
 const GALACTIC_DIM_ALPHA = 0.5;        // Alpha level for dimmed ships
 const GALACTIC_NEUTRAL_GRAY = '#888';  // Neutral gray for cloaked enemies
@@ -73,6 +76,7 @@ function updatePlanetCounter() {
     if (dashboardEls.kliPlanets) dashboardEls.kliPlanets.textContent = counts[4];
     if (dashboardEls.oriPlanets) dashboardEls.oriPlanets.textContent = counts[8];
     if (dashboardEls.indPlanets) dashboardEls.indPlanets.textContent = counts[0];
+    if (dashboardEls.goalPlanets && gameState.conquestPlanets) dashboardEls.goalPlanets.textContent = gameState.conquestPlanets;
 }
 
 // Cached DOM references for updateTeamDisplay (avoids repeated queries)
@@ -198,9 +202,34 @@ function updateTeamStats() {
         });
 }
 
+// Fetch and display the best-rated players; the list stays hidden when the
+// server has ratings disabled
+function updateLeaderboard() {
+    const basePath = getBasePath();
+    fetch(`${basePath}/api/leaderboard`)
+        .then(response => {
+            if (!response.ok) throw new Error(`HTTP ${response.status}`);
+            return response.json();
+        })
+        .then(entries => {
+            const list = document.getElementById('leaderboardList');
+            list.replaceChildren();
+            for (const entry of entries) {
+                const item = document.createElement('li');
+                item.textContent = `${entry.name} ${Math.round(entry.rating)}`;
+                list.appendChild(item);
+            }
+            document.getElementById('leaderboard').style.display = entries.length > 0 ? 'block' : 'none';
+        })
+        .catch(() => {
+            document.getElementById('leaderboard').style.display = 'none';
+        });
+}
+
 // Update team stats on page load and periodically
 window.addEventListener('DOMContentLoaded', () => {
     updateTeamStats();
+    updateLeaderboard();
     // Update every 5 seconds while on login screen
     const statsInterval = setInterval(() => {
         if (document.getElementById('login').style.display !== 'none') {
@@ -220,9 +249,13 @@ let gameState = {
     torps: [],
     plasmas: [],
     phasers: [], // Active phaser beams
+    fizzles: [], // Recently expired projectiles (fuse ran out without a hit)
+    director: null, // Server's director camera focus, followed while observing
     frame: 0,
     lastUpdate: 0,
     updateInterval: 0,
+    tickMs: 100, // Server tick period from login_success (100ms at the default 10 FPS)
+    galaxyWrap: false, // Whether the galaxy edges wrap around (-galaxy-edge wrap), from login_success
     quitRequested: false // Track if player has requested to quit
 };
 
@@ -270,7 +303,7 @@ const StatusOutfit = 1;
 const StatusAlive = 2;
 const StatusExplode = 3;
 const StatusDead = 4;
-const StatusObserve = 6;
+const StatusObserve = 5;
 
 // UI state tracking
 let uiState = {
@@ -412,6 +445,7 @@ async function init() {
         kliPlanets: document.getElementById('kli-planets'),
         oriPlanets: document.getElementById('ori-planets'),
         indPlanets: document.getElementById('ind-planets'),
+        goalPlanets: document.getElementById('goal-planets'),
         // Cached refs for hot-path DOM lookups (keypress, every tick)
         messageInput: document.getElementById('message-input'),
         helpWindow: document.getElementById('help-window'),
@@ -459,14 +493,15 @@ async function init() {
         });
     }
     
-    // Start render loop using requestAnimationFrame with 10 FPS target
+    // Start render loop using requestAnimationFrame, targeting the server's
+    // update rate (10 FPS unless the server runs with -fps)
     // This stops rendering in background tabs (saving CPU/battery) and syncs with vsync
     if (renderIntervalId !== null) {
         cancelAnimationFrame(renderIntervalId);
     }
     lastRenderTime = 0;
     function renderLoop(timestamp) {
-        if (timestamp - lastRenderTime >= 95) { // ~10 FPS with slight tolerance
+        if (timestamp - lastRenderTime >= gameState.tickMs - 5) { // Update rate with slight tolerance
             lastRenderTime = timestamp;
             render();
         }
@@ -703,6 +738,12 @@ function handleKeyPress(key) {
     }
 
     const player = gameState.players[gameState.myPlayerID];
+    // Observers (no ship of their own, or out of lives) can only chat
+    // among themselves
+    if ((!player || player.status === 0 || player.status === 5) && key.toLowerCase() === 'a') {
+        showMessageInput('observer');
+        return;
+    }
     if (!player || player.status !== 2) return;
 
     // Speed control - numbers set speed
@@ -753,6 +794,18 @@ function handleKeyPress(key) {
         case 'c':
             sendMessage({ type: 'cloak', data: {} });
             break;
+        case 'j':
+            // Toggle ECM jamming (blocks enemy locks, costs fuel)
+            sendMessage({ type: 'ecm', data: {} });
+            break;
+        case 'g':
+            // Toggle dogfight assist (server-managed shields)
+            sendMessage({ type: 'assist', data: {} });
+            break;
+        case 'e':
+            // Ship special ability (e.g. battleship shield overcharge)
+            sendMessage({ type: 'special', data: {} });
+            break;
         case 'd':
             sendMessage({ type: 'detonate', data: {} });
             break;
@@ -810,8 +863,14 @@ function handleKeyPress(key) {
             }
             break;
         case 'o':
-            // Orbit planet
-            sendMessage({ type: 'orbit', data: {} });
+            if (key === 'O') {
+                // Shift+O toggles repairing automatically while idle at a friendly planet
+                sendMessage({ type: 'orbitrepair', data: {} });
+                addMessage(player.orbitRepair ? 'Orbit repair off' : 'Orbit repair on', 'info', null, null, 'messages-server');
+            } else {
+                // Orbit planet
+                sendMessage({ type: 'orbit', data: {} });
+            }
             break;
         case 'i':
         case 'I':
@@ -824,7 +883,12 @@ function handleKeyPress(key) {
             sendMessage({ type: 'repair', data: {} });
             break;
         case 'l':
-            // Lock on to nearest planet to mouse cursor (no player locking)
+            // Shift+L cycles the lock through enemies, nearest first
+            if (key === 'L') {
+                sendMessage({ type: 'lock_cycle', data: {} });
+                break;
+            }
+            // Lock on to nearest planet to mouse cursor
             if (gameState.myPlayerID >= 0) {
                 const myPlayer = gameState.players[gameState.myPlayerID];
                 if (myPlayer) {
@@ -976,7 +1040,7 @@ function reOutfit() {
     // Send outfit message to rejoin with new selection
     sendMessage({
         type: 'login', // Server expects 'login' type for both initial and rejoin
-        data: { name: name, team: team, ship: ship }
+        data: { name: name, team: team, ship: ship, version: PROTOCOL_VERSION }
     });
 }
 
@@ -1041,7 +1105,11 @@ function openWebSocket(name, team, ship) {
     // Connect to WebSocket
     const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
     const basePath = getBasePath();
-    const wsPath = `${basePath}/ws`;
+    // Forward ?compress=0/1 from the page URL so LAN players can opt out of
+    // WebSocket compression
+    const compress = new URLSearchParams(window.location.search).get('compress');
+    const wsQuery = compress !== null ? `?compress=${encodeURIComponent(compress)}` : '';
+    const wsPath = `${basePath}/ws${wsQuery}`;
     ws = new WebSocket(`${protocol}//${window.location.host}${wsPath}`);
 
     ws.onopen = () => {
@@ -1056,6 +1124,7 @@ function openWebSocket(name, team, ship) {
         gameState.torps = [];
         gameState.plasmas = [];
         gameState.phasers = [];
+        gameState.fizzles = [];
         prevState.players = [];
         prevState.torps = [];
         prevState.plasmas = [];
@@ -1065,7 +1134,7 @@ function openWebSocket(name, team, ship) {
 
         sendMessage({
             type: 'login',
-            data: { name: name, team: team, ship: ship }
+            data: { name: name, team: team, ship: ship, version: PROTOCOL_VERSION }
         });
     };
 
@@ -1117,8 +1186,15 @@ function handleServerMessage(msg) {
     switch(msg.type) {
         case 'login_success':
             gameState.myPlayerID = msg.data.player_id;
+            gameState.tickMs = msg.data.tick_ms || 100;
+            gameState.galaxyWrap = !!msg.data.galaxy_wrap;
             addMessage(`Joined as player ${msg.data.player_id}`, 'info', null, null, 'messages-server');
             break;
+
+        case 'control':
+            // An admin possessed or released a bot: follow the new slot
+            gameState.myPlayerID = msg.data.player_id;
+            break;
             
         case 'update':
             // Store previous positions for interpolation (only fields needed)
@@ -1151,6 +1227,19 @@ function handleServerMessage(msg) {
             }
             
             gameState.frame = msg.data.frame;
+
+            // Fizzle where a projectile ran out of fuel. Detonations are
+            // already drawn from the exploding status in the update.
+            for (const end of msg.data.projectileEnds || []) {
+                if (end.kind === 'expire') {
+                    gameState.fizzles.push({
+                        x: end.x,
+                        y: end.y,
+                        plasma: end.plasma,
+                        life: 5 // Frames to display
+                    });
+                }
+            }
             gameState.players = Array.isArray(msg.data.players) ? msg.data.players : [];
             gameState.planets = Array.isArray(msg.data.planets) ? msg.data.planets : [];
             gameState.torps = Array.isArray(msg.data.torps) ? msg.data.torps : [];
@@ -1160,6 +1249,11 @@ function handleServerMessage(msg) {
             gameState.winType = msg.data.winType;
             gameState.tMode = !!msg.data.tMode;
             gameState.tRemain = msg.data.tRemain;
+            gameState.ceasefire = !!msg.data.ceasefire;
+            gameState.survival = msg.data.survival || null;
+            gameState.conquestPlanets = msg.data.conquestPlanets;
+            gameState.livesLeft = msg.data.livesLeft || null;
+            gameState.director = msg.data.director || null;
 
             // Update planet counter
             updatePlanetCounter();
@@ -1214,7 +1308,7 @@ function handleServerMessage(msg) {
 
             // Route messages based on type: player chat vs server events
             // Player-to-player chat messages (typed by users)
-            const chatTypes = ['all', 'team', 'private'];
+            const chatTypes = ['all', 'team', 'private', 'observer'];
             const isPlayerChat = chatTypes.includes(msgType);
             const targetPanel = isPlayerChat ? 'messages-player' : 'messages-server';
             
@@ -1236,6 +1330,18 @@ function handleServerMessage(msg) {
             });
             break;
             
+        case 'match_summary':
+            showMatchSummary(msg.data);
+            break;
+
+        case 'lock_cycle':
+            if (msg.data.target >= 0) {
+                addMessage(`Locked on ${msg.data.name}`, 'info', null, null, 'messages-server');
+            } else {
+                addMessage('No enemy to lock on', 'warning', null, null, 'messages-server');
+            }
+            break;
+
         case 'error':
             addMessage(msg.data, 'warning', null, null, 'messages-server');
             break;
@@ -1259,7 +1365,7 @@ function getInterpolatedPosition(current, previous, entityId) {
     
     const now = Date.now();
     const timeSinceUpdate = now - gameState.lastUpdate;
-    const expectedInterval = 100; // 10 FPS = 100ms per frame
+    const expectedInterval = gameState.tickMs; // 100ms per update at 10 FPS
     const t = Math.min(timeSinceUpdate / expectedInterval, 1);
     
     // Find previous position
@@ -1315,13 +1421,22 @@ function render() {
     // requestAnimationFrame loop continues in renderLoop()
 }
 
-// decayPhasers ages phaser beams and drops expired ones. It is called on the
-// render paths that skip the main draw loop (outfit/victory screens, no local
-// player) so the phaser list can't grow without bound while the server keeps
-// pushing beam messages — which would leak memory and produce a burst of stale
+// renderLifeStep is how much of a phaser's or fizzle's life, counted in
+// 100ms frames, one render takes. Renders follow the server tick, so a faster
+// server ages them in smaller steps and they stay on screen just as long.
+function renderLifeStep() {
+    return gameState.tickMs / 100;
+}
+
+// decayPhasers ages phaser beams and projectile fizzles and drops expired ones.
+// It is called on the render paths that skip the main draw loop (outfit/victory
+// screens, no local player) so the phaser list can't grow without bound while
+// the server keeps pushing beam messages — which would leak memory and produce a burst of stale
 // beams the moment normal rendering resumes.
 function decayPhasers() {
-    gameState.phasers = gameState.phasers.filter(p => { p.life--; return p.life > 0; });
+    const step = renderLifeStep();
+    gameState.phasers = gameState.phasers.filter(p => { p.life -= step; return p.life > 0; });
+    gameState.fizzles = gameState.fizzles.filter(f => { f.life -= step; return f.life > 0; });
 }
 
 function renderTactical() {
@@ -1415,14 +1530,8 @@ function renderTactical() {
         return; // Don't render game elements during victory screen
     }
 
-    // Don't render if we don't have a valid player
-    if (gameState.myPlayerID < 0) {
-        decayPhasers();
-        return;
-    }
-
-    // Get my player
-    const myPlayer = gameState.players[gameState.myPlayerID];
+    // Center on my ship, or on the director camera while observing
+    const myPlayer = tacticalViewpoint();
     if (!myPlayer) {
         decayPhasers();
         return;
@@ -1432,7 +1541,7 @@ function renderTactical() {
     const centerY = height / 2;
     const scale = TACTICAL_SCALE;
     
-    // Draw galaxy edges if visible
+    // Draw galaxy edges if visible; a wrapping galaxy has none
     ctx.save();
     ctx.strokeStyle = '#ff0000';
     ctx.lineWidth = 4;
@@ -1447,29 +1556,31 @@ function renderTactical() {
     const bottomEdge = (100000 - myPlayer.y) * scale + centerY;
     
     // Draw edges if they're visible - check with some margin
-    if (leftEdge >= -10 && leftEdge <= width + 10) {
-        ctx.beginPath();
-        ctx.moveTo(leftEdge, 0);
-        ctx.lineTo(leftEdge, height);
-        ctx.stroke();
-    }
-    if (rightEdge >= -10 && rightEdge <= width + 10) {
-        ctx.beginPath();
-        ctx.moveTo(rightEdge, 0);
-        ctx.lineTo(rightEdge, height);
-        ctx.stroke();
-    }
-    if (topEdge >= -10 && topEdge <= height + 10) {
-        ctx.beginPath();
-        ctx.moveTo(0, topEdge);
-        ctx.lineTo(width, topEdge);
-        ctx.stroke();
-    }
-    if (bottomEdge >= -10 && bottomEdge <= height + 10) {
-        ctx.beginPath();
-        ctx.moveTo(0, bottomEdge);
-        ctx.lineTo(width, bottomEdge);
-        ctx.stroke();
+    if (!gameState.galaxyWrap) {
+        if (leftEdge >= -10 && leftEdge <= width + 10) {
+            ctx.beginPath();
+            ctx.moveTo(leftEdge, 0);
+            ctx.lineTo(leftEdge, height);
+            ctx.stroke();
+        }
+        if (rightEdge >= -10 && rightEdge <= width + 10) {
+            ctx.beginPath();
+            ctx.moveTo(rightEdge, 0);
+            ctx.lineTo(rightEdge, height);
+            ctx.stroke();
+        }
+        if (topEdge >= -10 && topEdge <= height + 10) {
+            ctx.beginPath();
+            ctx.moveTo(0, topEdge);
+            ctx.lineTo(width, topEdge);
+            ctx.stroke();
+        }
+        if (bottomEdge >= -10 && bottomEdge <= height + 10) {
+            ctx.beginPath();
+            ctx.moveTo(0, bottomEdge);
+            ctx.lineTo(width, bottomEdge);
+            ctx.stroke();
+        }
     }
     
     ctx.restore();
@@ -1478,8 +1589,8 @@ function renderTactical() {
     for (const planet of gameState.planets) {
         if (!planet) continue;
         
-        const dx = (planet.x - myPlayer.x) * scale;
-        const dy = (planet.y - myPlayer.y) * scale;
+        const dx = wrapOffset(planet.x - myPlayer.x) * scale;
+        const dy = wrapOffset(planet.y - myPlayer.y) * scale;
         const screenX = centerX + dx;
         const screenY = centerY + dy;
         
@@ -1505,20 +1616,20 @@ function renderTactical() {
 
         ctx.save();
         
-        const fromX = centerX + (fromPlayer.x - myPlayer.x) * scale;
-        const fromY = centerY + (fromPlayer.y - myPlayer.y) * scale;
+        const fromX = centerX + wrapOffset(fromPlayer.x - myPlayer.x) * scale;
+        const fromY = centerY + wrapOffset(fromPlayer.y - myPlayer.y) * scale;
         let toX, toY;
         
         if (phaser.target >= 0) {
             // Phaser hit a player target
             const toPlayer = gameState.players[phaser.target];
             if (!toPlayer) return false;
-            toX = centerX + (toPlayer.x - myPlayer.x) * scale;
-            toY = centerY + (toPlayer.y - myPlayer.y) * scale;
+            toX = centerX + wrapOffset(toPlayer.x - myPlayer.x) * scale;
+            toY = centerY + wrapOffset(toPlayer.y - myPlayer.y) * scale;
         } else if (phaser.to === -2) {
             // Phaser hit a plasma torpedo (special code -2)
-            toX = centerX + (phaser.x - myPlayer.x) * scale;
-            toY = centerY + (phaser.y - myPlayer.y) * scale;
+            toX = centerX + wrapOffset(phaser.x - myPlayer.x) * scale;
+            toY = centerY + wrapOffset(phaser.y - myPlayer.y) * scale;
         } else {
             // Phaser missed - draw in direction fired
             const phaserRange = (phaser.range || 5000) * scale; // Use ship-specific phaser range in screen pixels
@@ -1579,7 +1690,7 @@ function renderTactical() {
         }
         
         ctx.restore();
-        phaser.life--;
+        phaser.life -= renderLifeStep();
         return true;
     });
 
@@ -1587,8 +1698,8 @@ function renderTactical() {
     for (const torp of gameState.torps) {
         if (!torp) continue;
         
-        const dx = (torp.x - myPlayer.x) * scale;
-        const dy = (torp.y - myPlayer.y) * scale;
+        const dx = wrapOffset(torp.x - myPlayer.x) * scale;
+        const dy = wrapOffset(torp.y - myPlayer.y) * scale;
         const screenX = centerX + dx;
         const screenY = centerY + dy;
         
@@ -1609,8 +1720,8 @@ function renderTactical() {
     for (const plasma of gameState.plasmas) {
         if (!plasma) continue;
         
-        const dx = (plasma.x - myPlayer.x) * scale;
-        const dy = (plasma.y - myPlayer.y) * scale;
+        const dx = wrapOffset(plasma.x - myPlayer.x) * scale;
+        const dy = wrapOffset(plasma.y - myPlayer.y) * scale;
         const screenX = centerX + dx;
         const screenY = centerY + dy;
         
@@ -1627,6 +1738,21 @@ function renderTactical() {
         }
     }
     
+    // Draw fizzles for expired projectiles as a small fading ring
+    gameState.fizzles = gameState.fizzles.filter(fizzle => {
+        const screenX = centerX + wrapOffset(fizzle.x - myPlayer.x) * scale;
+        const screenY = centerY + wrapOffset(fizzle.y - myPlayer.y) * scale;
+        ctx.save();
+        ctx.strokeStyle = '#888';
+        ctx.globalAlpha = fizzle.life / 5;
+        ctx.beginPath();
+        ctx.arc(screenX, screenY, fizzle.plasma ? 6 : 3, 0, Math.PI * 2);
+        ctx.stroke();
+        ctx.restore();
+        fizzle.life -= renderLifeStep();
+        return fizzle.life > 0;
+    });
+
     // Draw tractor (blue) and pressor (orange) beams
     const beamStyles = [
         { field: 'tractoring', color: '#00f', dash: [10, 5] },
@@ -1648,8 +1774,8 @@ function renderTactical() {
             ctx.lineWidth = 2;
             ctx.setLineDash(beam.dash);
             ctx.beginPath();
-            ctx.moveTo(centerX + (player.x - myPlayer.x) * scale, centerY + (player.y - myPlayer.y) * scale);
-            ctx.lineTo(centerX + (target.x - myPlayer.x) * scale, centerY + (target.y - myPlayer.y) * scale);
+            ctx.moveTo(centerX + wrapOffset(player.x - myPlayer.x) * scale, centerY + wrapOffset(player.y - myPlayer.y) * scale);
+            ctx.lineTo(centerX + wrapOffset(target.x - myPlayer.x) * scale, centerY + wrapOffset(target.y - myPlayer.y) * scale);
             ctx.stroke();
             ctx.restore();
         }
@@ -1665,8 +1791,8 @@ function renderTactical() {
         
         // Handle explosion animation (status 3)
         if (player.status === 3) {
-            const dx = (player.x - myPlayer.x) * scale;
-            const dy = (player.y - myPlayer.y) * scale;
+            const dx = wrapOffset(player.x - myPlayer.x) * scale;
+            const dy = wrapOffset(player.y - myPlayer.y) * scale;
             const screenX = centerX + dx;
             const screenY = centerY + dy;
 
@@ -1755,8 +1881,8 @@ function renderTactical() {
             continue;
         }
         
-        const dx = (player.x - myPlayer.x) * scale;
-        const dy = (player.y - myPlayer.y) * scale;
+        const dx = wrapOffset(player.x - myPlayer.x) * scale;
+        const dy = wrapOffset(player.y - myPlayer.y) * scale;
         const screenX = centerX + dx;
         const screenY = centerY + dy;
         
@@ -1893,6 +2019,40 @@ function centroid(objs, useMedian, scale) {
     return [one(objs.map(o => o.x)), one(objs.map(o => o.y))];
 }
 
+// Observers without a ship of their own watch the busiest fight: the
+// tactical view follows the server's director focus, easing toward it so a
+// switch between fights pans rather than jumps
+const spectatorCamera = {x: 0, y: 0, team: 0, following: false};
+
+// wrapOffset folds an offset along one galaxy axis into the shortest one when
+// the galaxy wraps, so ships just across an edge are drawn beside us
+function wrapOffset(d) {
+    if (!gameState.galaxyWrap) return d;
+    return d - 100000 * Math.round(d / 100000);
+}
+
+// tacticalViewpoint returns what the tactical view is centered on: my ship,
+// the spectator camera while observing, or null when there is nothing to show
+function tacticalViewpoint() {
+    const myPlayer = gameState.myPlayerID >= 0 ? gameState.players[gameState.myPlayerID] : null;
+    if (myPlayer && myPlayer.status !== StatusFree && myPlayer.status !== StatusObserve) {
+        spectatorCamera.following = false;
+        return myPlayer;
+    }
+    const focus = gameState.director;
+    if (!focus || !focus.active) {
+        return null;
+    }
+    if (!spectatorCamera.following) {
+        spectatorCamera.x = focus.x;
+        spectatorCamera.y = focus.y;
+        spectatorCamera.following = true;
+    }
+    spectatorCamera.x += (focus.x - spectatorCamera.x) * 0.2;
+    spectatorCamera.y += (focus.y - spectatorCamera.y) * 0.2;
+    return spectatorCamera;
+}
+
 function renderGalactic() {
     const ctx = canvases.galacticCtx;
     const width = canvases.galactic.width;
@@ -2087,6 +2247,10 @@ function updateDashboard() {
     const deaths = player.deaths || 0;
     if (dashboardEls.kdaStats) {
         dashboardEls.kdaStats.textContent = `${killStreak} / ${kills} / ${deaths}`;
+        // Lives left when the server limits them
+        if (gameState.livesLeft && gameState.livesLeft[player.id] !== undefined) {
+            dashboardEls.kdaStats.textContent += ` (${gameState.livesLeft[player.id]} left)`;
+        }
         // Color based on kill streak
         if (killStreak >= 5) {
             dashboardEls.kdaStats.style.color = '#ff0'; // Yellow for high streak
@@ -2139,6 +2303,11 @@ function updateDashboard() {
             if (target) {
                 statusText = `Lock: ${target.name}`;
             }
+        } else if (player.lockType === 'player' && player.lockTarget >= 0) {
+            const target = gameState.players[player.lockTarget];
+            if (target) {
+                statusText = `Lock: ${target.name}`;
+            }
         } else if (player.engineOverheat) {
             statusText = 'ENGINES OVERHEATED!';
             dashboardEls.status.style.color = '#f00'; // Make it red
@@ -2147,6 +2316,26 @@ function updateDashboard() {
         } else if (player.cloaked) {
             statusText = 'Cloaked';
         }
+        if (player.assist) {
+            statusText += (statusText ? ' ' : '') + '[ASSIST]';
+        }
+        if (player.autoRepair) {
+            statusText += (statusText ? ' ' : '') + `[AUTO-REPAIR ${player.autoRepair}%]`;
+        }
+        if (player.orbitRepair) {
+            statusText += (statusText ? ' ' : '') + '[ORBIT-REPAIR]';
+        }
+        if (player.specialTimer > 0) {
+            statusText += (statusText ? ' ' : '') + `[SPECIAL ${Math.ceil(player.specialTimer / 10)}s]`;
+        }
+        if (gameState.ceasefire) {
+            statusText += (statusText ? ' ' : '') + '[CEASEFIRE]';
+        }
+        if (gameState.survival) {
+            const sv = gameState.survival;
+            const hull = Math.round(100 * sv.baseHull / sv.baseMaxHull);
+            statusText += (statusText ? ' ' : '') + `[WAVE ${sv.wave} BASE ${hull}% NEXT ${sv.nextWave}s]`;
+        }
         dashboardEls.status.textContent = statusText;
     }
 
@@ -2206,7 +2395,7 @@ function updatePlayerList() {
     for (let i = 0; i < gameState.players.length; i++) {
         const p = gameState.players[i];
         if (p && p.status !== 0 && p.status !== 1) {
-            sig += `${i}:${p.team}:${p.status}:${p.ship}:${p.name}:${Math.floor(p.killsStreak||0)}:${Math.floor(p.kills||0)}:${p.deaths||0};`;
+            sig += `${i}:${p.team}:${p.status}:${p.ship}:${p.name}:${Math.floor(p.killsStreak||0)}:${Math.floor(p.kills||0)}:${p.deaths||0}:${Math.round(p.rating||0)};`;
         }
     }
     if (sig === lastPlayerListSignature) return;
@@ -2223,7 +2412,8 @@ function updatePlayerList() {
     headerLeft.appendChild(headerIdLabel);
     headerLeft.appendChild(document.createTextNode('\u00a0PLAYERS'));
     const headerRight = document.createElement('span');
-    headerRight.textContent = 'KS/K/D/KD';
+    const showRatings = gameState.players.some(p => p && p.rating);
+    headerRight.textContent = showRatings ? 'KS/K/D/KD/RTG' : 'KS/K/D/KD';
     header.appendChild(headerLeft);
     header.appendChild(headerRight);
 
@@ -2281,6 +2471,9 @@ function updatePlayerList() {
         const statsSpan = document.createElement('span');
         statsSpan.style.fontSize = '9px';
         statsSpan.textContent = `${Math.floor(killsStreak)} / ${Math.floor(kills)} / ${deaths} / ${kd}`;
+        if (showRatings) {
+            statsSpan.textContent += ` / ${Math.round(player.rating || 0)}`;
+        }
 
         entry.appendChild(nameSpan);
         entry.appendChild(statsSpan);
@@ -2302,6 +2495,8 @@ function showMessageInput(mode, initialText = '') {
         prompt.textContent = 'Team message:';
     } else if (mode === 'all') {
         prompt.textContent = 'All message:';
+    } else if (mode === 'observer') {
+        prompt.textContent = 'Observer message:';
     } else if (mode.startsWith('private:')) {
         const targetId = parseInt(mode.split(':')[1], 10);
         const target = gameState.players[targetId];
@@ -2359,8 +2554,8 @@ function showInfoWindow() {
             // Don't allow targeting cloaked enemies
             if (player.cloaked && player.team !== myPlayer.team) continue;
             
-            const dx = (player.x - myPlayer.x) * scale;
-            const dy = (player.y - myPlayer.y) * scale;
+            const dx = wrapOffset(player.x - myPlayer.x) * scale;
+            const dy = wrapOffset(player.y - myPlayer.y) * scale;
             const screenX = centerX + dx;
             const screenY = centerY + dy;
             
@@ -2378,8 +2573,8 @@ function showInfoWindow() {
         for (const planet of gameState.planets) {
             if (!planet) continue;
             
-            const dx = (planet.x - myPlayer.x) * scale;
-            const dy = (planet.y - myPlayer.y) * scale;
+            const dx = wrapOffset(planet.x - myPlayer.x) * scale;
+            const dy = wrapOffset(planet.y - myPlayer.y) * scale;
             const screenX = centerX + dx;
             const screenY = centerY + dy;
             
@@ -2474,6 +2669,8 @@ function sendChatMessage() {
         sendMessage({ type: 'teammsg', data: { text } });
     } else if (messageMode === 'all') {
         sendMessage({ type: 'message', data: { text } });
+    } else if (messageMode === 'observer') {
+        sendMessage({ type: 'obsmsg', data: { text } });
     } else if (messageMode.startsWith('private:')) {
         const targetId = parseInt(messageMode.split(':')[1], 10);
         if (!isNaN(targetId)) {
@@ -2494,7 +2691,7 @@ function addMessage(text, type = '', fromPlayer = null, teamId = null, targetPan
     } else {
         // Fallback logic for backward compatibility (this should rarely be used now)
         // Note: The new routing logic in handleServerMessage should always specify targetPanel
-        const playerChatTypes = ['all', 'team', 'private', 'privmsg'];
+        const playerChatTypes = ['all', 'team', 'private', 'privmsg', 'observer'];
         if (playerChatTypes.includes(type)) {
             panelId = 'messages-player';
         }
@@ -2507,7 +2704,7 @@ function addMessage(text, type = '', fromPlayer = null, teamId = null, targetPan
         const fallback = document.getElementById('messages');
         if (fallback) {
             const div = document.createElement('div');
-            const VALID_FALLBACK_TYPES = ['all', 'team', 'private', 'kill', 'warning', 'error', 'info', 'victory'];
+            const VALID_FALLBACK_TYPES = ['all', 'team', 'private', 'observer', 'kill', 'warning', 'error', 'info', 'victory'];
             const safeFallbackType = VALID_FALLBACK_TYPES.includes(type) ? type : '';
             div.className = `message ${safeFallbackType}`;
             div.textContent = `[${new Date().toLocaleTimeString()}] ${text}`;
@@ -2528,7 +2725,7 @@ function addMessage(text, type = '', fromPlayer = null, teamId = null, targetPan
     }
 
     const div = document.createElement('div');
-    const VALID_MSG_TYPES = ['all', 'team', 'private', 'kill', 'warning', 'error', 'info', 'victory'];
+    const VALID_MSG_TYPES = ['all', 'team', 'private', 'observer', 'kill', 'warning', 'error', 'info', 'victory'];
     const safeType = VALID_MSG_TYPES.includes(type) ? type : '';
     div.className = `message ${safeType}`;
     div.textContent = `[${new Date().toLocaleTimeString()}] ${text}`;
@@ -2559,6 +2756,19 @@ function addMessage(text, type = '', fromPlayer = null, teamId = null, targetPan
     }
 }
 
+// Print the end-of-match standings (best player first) to the server panel
+function showMatchSummary(summary) {
+    if (!summary || !Array.isArray(summary.players)) return;
+    const minutes = Math.floor(summary.duration / 60);
+    const seconds = String(summary.duration % 60).padStart(2, '0');
+    addMessage(`Match over after ${minutes}:${seconds}`, 'victory', null, null, 'messages-server');
+    for (const p of summary.players) {
+        const mvp = p.id === summary.mvp ? ' ★ MVP' : '';
+        addMessage(`${getTeamName(p.team)} ${p.name}: ${p.kills.toFixed(2)} kills, ${p.deaths} deaths, ` +
+            `${p.planets} planets, ${p.damageDealt} damage${mvp}`, 'info', null, null, 'messages-server');
+    }
+}
+
 // Helper functions
 function getTeamName(team) {
     switch(team) {